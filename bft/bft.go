@@ -43,7 +43,12 @@ type BFT struct {
 	round      atomic.Uint64 // atomic mirror of View.Round for external readers
 	deadlineMs atomic.Int64  // atomic proposal vote mode deadline in unix milliseconds for external readers
 
-	PhaseTimer *time.Timer // ensures the node waits for a configured duration (Round x phaseTimeout) to allow for full voter participation
+	Clock      lib.Clock // the source of time: the wall clock, unless the consensus is simulated
+	PhaseTimer lib.Timer // ensures the node waits for a configured duration (Round x phaseTimeout) to allow for full voter participation
+
+	HighWaterMark *HighWaterMarkStore // double-sign protection: the highest view signed, consulted before every consensus signature
+	Timeouts      *PhaseTimeouts      // the observed +2/3 latency of each phase, driving the phase timeouts if adaptive
+	WAL           *WAL                // the write-ahead log of the consensus messages and lock of this height, replayed after a restart
 
 	PublicKey    []byte             // self consensus public key
 	PrivateKey   crypto.PrivateKeyI // self consensus private key
@@ -62,6 +67,17 @@ func New(c lib.Config, valKey crypto.PrivateKeyI, rootHeight, height uint64, con
 		vdfTargetTime := time.Duration(float64(c.BlockTimeMS())*BlockTimeToVDFTargetCoefficient) * time.Millisecond
 		vdf = lib.NewVDFService(vdfTargetTime, l)
 	}
+	// load the double-sign protection database and the consensus write-ahead log
+	hwm, wal := NewHighWaterMarkStoreInMemory(), NewWALInMemory()
+	if !c.InMemory {
+		var err lib.ErrorI
+		if hwm, err = NewHighWaterMarkStoreFromFile(c.DataDirPath); err != nil {
+			return nil, err
+		}
+		if wal, err = NewWALFromFile(c.DataDirPath); err != nil {
+			return nil, err
+		}
+	}
 	b := &BFT{
 		View: &lib.View{
 			Height:     height,
@@ -83,7 +99,11 @@ func New(c lib.Config, valKey crypto.PrivateKeyI, rootHeight, height uint64, con
 		Controller:        con,
 		ResetBFT:          make(chan ResetBFT, 100),
 		syncing:           con.Syncing(),
-		PhaseTimer:        lib.NewTimer(),
+		Clock:             lib.WallClock{},
+		PhaseTimer:        lib.NewWallTimer(),
+		HighWaterMark:     hwm,
+		Timeouts:          NewPhaseTimeouts(),
+		WAL:               wal,
 		VDFService:        vdf,
 		Metrics:           m,
 		HighVDF:           new(crypto.VDF),
@@ -133,54 +153,64 @@ func (b *BFT) Start() {
 		select {
 		// EXECUTE PHASE
 		// - This triggers when the phase's sleep time has expired, indicating that all expected messages for this phase should have already been received
-		case <-b.PhaseTimer.C:
-			func() {
-				startTime := time.Now()
-				b.Controller.Lock()
-				defer b.Controller.Unlock()
-				// Update BFT metrics
-				defer b.Metrics.UpdateBFTMetrics(b.Height, b.RootHeight, b.LoadRootChainId(b.Height), b.Round, b.Phase, startTime)
-				// handle the phase
-				b.HandlePhase()
-			}()
+		case <-b.PhaseTimer.C():
+			b.HandlePhaseTimer()
 
 		// RESET BFT
 		// - This triggers when receiving a new Commit Block (QC) from either root-chainId (a) or the Target-ChainId (b)
 		case resetBFT := <-b.ResetBFT:
-			var processTime time.Duration
-			func() {
-				b.Controller.Lock()
-				defer b.Controller.Unlock()
-				// calculate time since
-				since := time.Since(resetBFT.StartTime)
-				// allow if 'since' is less than 1 block old
-				if int(since.Milliseconds()) < b.Config.BlockTimeMS() {
-					b.log.Infof("Using included timestamp to calculate process time: %s", resetBFT.StartTime.Format(time.StampMilli))
-					processTime = since
-				}
-				// if is a root-chain update reset back to round 0 but maintain locks to prevent 'fork attacks'
-				// else increment the height and don't maintain locks
-				if !resetBFT.IsRootChainUpdate {
-					b.log.Info("Reset BFT (NEW_HEIGHT)")
-					b.NewHeight(false)
-					b.SetWaitTimers(time.Duration(b.Config.NewHeightTimeoutMs)*time.Millisecond, processTime)
-					b.BFTStartTime = time.Now()
-					b.deadlineMs.Store(b.BFTStartTime.Add(time.Duration(b.Config.BlockTimeMS()*3) * time.Millisecond).UnixMilli())
-				} else {
-					b.log.Info("Reset BFT (NEW_COMMITTEE)")
-					//if b.LoadIsOwnRoot() {
-					b.NewHeight(true)
-					//} else if b.Round != 0 {
-					//	b.NewHeight(true)
-					// set the wait timers to start consensus
-					b.SetWaitTimers(time.Duration(b.Config.NewHeightTimeoutMs)*time.Millisecond, processTime)
-					//}
-				}
-			}()
+			b.HandleResetBFT(resetBFT)
 		}
 	}
 }
 
+// HandlePhaseTimer() executes the current phase once its phase timer expired
+func (b *BFT) HandlePhaseTimer() {
+	startTime := b.Clock.Now()
+	b.Controller.Lock()
+	defer b.Controller.Unlock()
+	// Update BFT metrics
+	defer b.Metrics.UpdateBFTMetrics(b.Height, b.RootHeight, b.LoadRootChainId(b.Height), b.Round, b.Phase, startTime)
+	// handle the phase
+	b.HandlePhase()
+}
+
+// HandleResetBFT() resets the consensus for a new height (or a new committee) once a block (or a root chain update) is received
+func (b *BFT) HandleResetBFT(resetBFT ResetBFT) {
+	var processTime time.Duration
+	func() {
+		b.Controller.Lock()
+		defer b.Controller.Unlock()
+		// calculate time since
+		since := b.Clock.Now().Sub(resetBFT.StartTime)
+		// allow if 'since' is less than 1 block old
+		if int(since.Milliseconds()) < b.Config.BlockTimeMS() {
+			b.log.Infof("Using included timestamp to calculate process time: %s", resetBFT.StartTime.Format(time.StampMilli))
+			processTime = since
+		}
+		// if is a root-chain update reset back to round 0 but maintain locks to prevent 'fork attacks'
+		// else increment the height and don't maintain locks
+		if !resetBFT.IsRootChainUpdate {
+			b.log.Info("Reset BFT (NEW_HEIGHT)")
+			b.NewHeight(false)
+			b.SetWaitTimers(time.Duration(b.Config.NewHeightTimeoutMs)*time.Millisecond, processTime)
+			b.BFTStartTime = b.Clock.Now()
+			b.deadlineMs.Store(b.BFTStartTime.Add(time.Duration(b.Config.BlockTimeMS()*3) * time.Millisecond).UnixMilli())
+		} else {
+			b.log.Info("Reset BFT (NEW_COMMITTEE)")
+			//if b.LoadIsOwnRoot() {
+			b.NewHeight(true)
+			//} else if b.Round != 0 {
+			//	b.NewHeight(true)
+			// set the wait timers to start consensus
+			b.SetWaitTimers(time.Duration(b.Config.NewHeightTimeoutMs)*time.Millisecond, processTime)
+			//}
+		}
+	}()
+	// restore the consensus state of this height after a restart (if any)
+	b.ReplayWAL()
+}
+
 // HandlePhase() is the main BFT Phase stepping loop
 func (b *BFT) HandlePhase() {
 	stopTimers := func() { b.PhaseTimer.Stop() }
@@ -197,7 +227,7 @@ func (b *BFT) HandlePhase() {
 		return
 	}
 	// measure process time to have the most accurate timer timeouts
-	startTime := time.Now()
+	startTime := b.Clock.Now()
 	switch b.Phase {
 	case Election:
 		b.StartElectionPhase()
@@ -219,7 +249,7 @@ func (b *BFT) HandlePhase() {
 		b.Pacemaker()
 	}
 	// after each phase, set the timers for the next phase
-	b.SetTimerForNextPhase(time.Since(startTime))
+	b.SetTimerForNextPhase(b.Clock.Now().Sub(startTime))
 }
 
 // StartElectionPhase() begins the ElectionPhase after the CommitProcess (normal) or Pacemaker (previous Round failure) timeouts
@@ -470,6 +500,8 @@ func (b *BFT) StartPrecommitVotePhase() {
 	b.HighQC.Block = b.Block
 	b.HighQC.Results = b.Results
 	b.log.Infof("🔒 Locked on proposal %s", lib.BytesToTruncatedString(b.HighQC.BlockHash))
+	// persist the lock before voting, so it can still be proven after a restart
+	b.walLock()
 	// send vote to the proposer
 	b.SendToProposer(&Message{
 		Qc: &QC{ // NOTE: Replicas use the QC to communicate important information so that it's aggregable by the Leader
@@ -509,7 +541,7 @@ func (b *BFT) StartCommitPhase() {
 			ProposerKey: b.ProposerKey,
 			Signature:   as,
 		},
-		Timestamp: uint64(time.Now().Add(b.WaitTime(Commit, b.Round)).UnixMicro()),
+		Timestamp: uint64(b.Clock.Now().Add(b.WaitTime(Commit, b.Round)).UnixMicro()),
 	})
 }
 
@@ -537,15 +569,10 @@ func (b *BFT) StartCommitProcessPhase() {
 	b.ByzantineEvidence = &ByzantineEvidence{
 		DSE: b.GetLocalDSE(),
 	}
-	// non-blocking
-	go func() {
-		// send the block to self for committing
-		b.SelfSendBlock(msg.Qc, msg.Timestamp)
-		// wait to allow for CommitProcess to finish
-		<-time.After(time.Duration(b.Config.CommitTimeoutMS) * time.Millisecond)
-		// gossip committed block message to peers
-		b.GossipBlock(msg.Qc, b.PublicKey, msg.Timestamp)
-	}()
+	// send the block to self for committing (non-blocking)
+	b.Clock.AfterFunc(0, func() { b.SelfSendBlock(msg.Qc, msg.Timestamp) })
+	// gossip committed block message to peers, after waiting to allow for CommitProcess to finish
+	b.Clock.AfterFunc(time.Duration(b.Config.CommitTimeoutMS)*time.Millisecond, func() { b.GossipBlock(msg.Qc, b.PublicKey, msg.Timestamp) })
 }
 
 // RoundInterrupt() begins the ROUND-INTERRUPT phase after any phase errors
@@ -691,6 +718,9 @@ func (b *BFT) NewHeight(keepLocks ...bool) {
 	b.PacemakerMessages = make(PacemakerMessages)
 	// initialize Round 0
 	b.NewRound(true)
+	// stop measuring the previous height and load the adaptive timeout bounds
+	b.Timeouts.stopWait()
+	b.RefreshPhaseTimeoutBounds()
 	// set phase to Election
 	b.Phase = Election
 	b.phase.Store(int32(b.Phase))
@@ -705,6 +735,8 @@ func (b *BFT) NewHeight(keepLocks ...bool) {
 		b.HighQC = nil
 		b.RCBuildHeight = 0
 	}
+	// start the write-ahead log of this height
+	b.truncateWAL()
 }
 
 // SafeNode is the codified Hotstuff SafeNodePredicate:
@@ -736,6 +768,8 @@ func (b *BFT) SafeNode(msg *Message) lib.ErrorI {
 // SetTimerForNextPhase() calculates the wait time for a specific phase/Round, resets the Phase wait timer
 func (b *BFT) SetTimerForNextPhase(processTime time.Duration) {
 	waitTime := b.WaitTime(b.Phase, b.Round)
+	// measure the time it takes for the messages of this phase to reach +2/3
+	b.Timeouts.startWait(b.Phase, b.Clock.Now().Add(-processTime))
 	switch b.Phase {
 	default:
 		b.Phase++
@@ -751,20 +785,9 @@ func (b *BFT) SetTimerForNextPhase(processTime time.Duration) {
 // WaitTime() returns the wait time (wait and receive consensus messages) for a specific Phase.Round
 func (b *BFT) WaitTime(phase Phase, round uint64) (waitTime time.Duration) {
 	switch phase {
-	case Election:
-		waitTime = b.waitTime(b.Config.ElectionTimeoutMS, round)
-	case ElectionVote:
-		waitTime = b.waitTime(b.Config.ElectionVoteTimeoutMS, round)
-	case Propose:
-		waitTime = b.waitTime(b.Config.ProposeTimeoutMS, round)
-	case ProposeVote:
-		waitTime = b.waitTime(b.Config.ProposeVoteTimeoutMS, round)
-	case Precommit:
-		waitTime = b.waitTime(b.Config.PrecommitTimeoutMS, round)
-	case PrecommitVote:
-		waitTime = b.waitTime(b.Config.PrecommitVoteTimeoutMS, round)
-	case Commit:
-		waitTime = b.waitTime(b.Config.CommitTimeoutMS, round)
+	case Election, ElectionVote, Propose, ProposeVote, Precommit, PrecommitVote, Commit:
+		// the configured (or adapted) timeout of the phase
+		waitTime = b.waitTime(b.PhaseTimeoutMS(phase), round)
 	case CommitProcess:
 		// arbitrarily sleep for 1 minute -- the BFT should be reset by an inbound block
 		waitTime = b.waitTime(60000, round)
@@ -831,7 +854,7 @@ func (b *BFT) SetWaitTimers(phaseWaitTime, processTime time.Duration) {
 	phaseWaitTime = subtract(phaseWaitTime, processTime)
 	b.log.Debugf("Setting consensus timer: %.2f sec", phaseWaitTime.Seconds())
 	// set Phase timers to go off in their respective timeouts
-	lib.ResetTimer(b.PhaseTimer, phaseWaitTime)
+	b.PhaseTimer.Reset(phaseWaitTime)
 }
 
 // SelfIsPropose() returns true if this node is the Leader
@@ -1005,6 +1028,8 @@ type (
 		IsValidDoubleSigner(rootChainId, rootHeight uint64, address []byte) bool
 		// LoadMaxBlockSize() loads the chain enforced maximum block size for valid blocks
 		LoadMaxBlockSize() int
+		// LoadPhaseTimeoutBounds() loads the chain enforced bounds of adaptive phase timeouts
+		LoadPhaseTimeoutBounds() (minMS, maxMS int)
 	}
 )
 
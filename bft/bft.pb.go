@@ -276,6 +276,98 @@ func (x *DoubleSignEvidences) GetDeDuplicator() map[string]bool {
 	return nil
 }
 
+// wal_entry is a single record of the consensus write-ahead log, allowing a restarted node to restore the consensus
+// state of its height: the messages it received and sent and the proposal it's locked on
+type WALEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// height: the height the entry belongs to
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// received: a validated consensus message the node received (from a peer or from itself)
+	Received *Message `protobuf:"bytes,2,opt,name=received,proto3" json:"received,omitempty"`
+	// partial_qc: the received proposer message is a partial QC, stored as potential byzantine evidence
+	PartialQc bool `protobuf:"varint,3,opt,name=partial_qc,json=partialQc,proto3" json:"partialQC"` // @gotags: json:"partialQC"
+	// sent: a consensus message the node signed and sent
+	Sent *Message `protobuf:"bytes,4,opt,name=sent,proto3" json:"sent,omitempty"`
+	// lock: the quorum certificate (with the block and results) the node locked on
+	Lock *lib.QuorumCertificate `protobuf:"bytes,5,opt,name=lock,proto3" json:"lock,omitempty"`
+	// rc_build_height: the root height the locked proposal was built at
+	RcBuildHeight uint64 `protobuf:"varint,6,opt,name=rc_build_height,json=rcBuildHeight,proto3" json:"rcBuildHeight"` // @gotags: json:"rcBuildHeight"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WALEntry) Reset() {
+	*x = WALEntry{}
+	mi := &file_bft_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WALEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WALEntry) ProtoMessage() {}
+
+func (x *WALEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_bft_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WALEntry.ProtoReflect.Descriptor instead.
+func (*WALEntry) Descriptor() ([]byte, []int) {
+	return file_bft_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WALEntry) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *WALEntry) GetReceived() *Message {
+	if x != nil {
+		return x.Received
+	}
+	return nil
+}
+
+func (x *WALEntry) GetPartialQc() bool {
+	if x != nil {
+		return x.PartialQc
+	}
+	return false
+}
+
+func (x *WALEntry) GetSent() *Message {
+	if x != nil {
+		return x.Sent
+	}
+	return nil
+}
+
+func (x *WALEntry) GetLock() *lib.QuorumCertificate {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
+func (x *WALEntry) GetRcBuildHeight() uint64 {
+	if x != nil {
+		return x.RcBuildHeight
+	}
+	return 0
+}
+
 var File_bft_proto protoreflect.FileDescriptor
 
 const file_bft_proto_rawDesc = "" +
@@ -300,7 +392,15 @@ const file_bft_proto_rawDesc = "" +
 	"\fDeDuplicator\x18\x02 \x03(\v2,.types.DoubleSignEvidences.DeDuplicatorEntryR\fDeDuplicator\x1a?\n" +
 	"\x11DeDuplicatorEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01B&Z$github.com/canopy-network/canopy/bftb\x06proto3"
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"\xe7\x01\n" +
+	"\bWALEntry\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x12*\n" +
+	"\breceived\x18\x02 \x01(\v2\x0e.types.MessageR\breceived\x12\x1d\n" +
+	"\n" +
+	"partial_qc\x18\x03 \x01(\bR\tpartialQc\x12\"\n" +
+	"\x04sent\x18\x04 \x01(\v2\x0e.types.MessageR\x04sent\x12,\n" +
+	"\x04lock\x18\x05 \x01(\v2\x18.types.QuorumCertificateR\x04lock\x12&\n" +
+	"\x0frc_build_height\x18\x06 \x01(\x04R\rrcBuildHeightB&Z$github.com/canopy-network/canopy/bftb\x06proto3"
 
 var (
 	file_bft_proto_rawDescOnce sync.Once
@@ -314,34 +414,38 @@ func file_bft_proto_rawDescGZIP() []byte {
 	return file_bft_proto_rawDescData
 }
 
-var file_bft_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_bft_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_bft_proto_goTypes = []any{
 	(*Message)(nil),               // 0: types.Message
 	(*DoubleSignEvidence)(nil),    // 1: types.DoubleSignEvidence
 	(*DoubleSignEvidences)(nil),   // 2: types.DoubleSignEvidences
-	nil,                           // 3: types.DoubleSignEvidences.DeDuplicatorEntry
-	(*lib.View)(nil),              // 4: types.View
-	(*lib.Signature)(nil),         // 5: types.Signature
-	(*lib.QuorumCertificate)(nil), // 6: types.QuorumCertificate
-	(*crypto.VDF)(nil),            // 7: types.VDF
+	(*WALEntry)(nil),              // 3: types.WALEntry
+	nil,                           // 4: types.DoubleSignEvidences.DeDuplicatorEntry
+	(*lib.View)(nil),              // 5: types.View
+	(*lib.Signature)(nil),         // 6: types.Signature
+	(*lib.QuorumCertificate)(nil), // 7: types.QuorumCertificate
+	(*crypto.VDF)(nil),            // 8: types.VDF
 }
 var file_bft_proto_depIdxs = []int32{
-	4,  // 0: types.Message.header:type_name -> types.View
-	5,  // 1: types.Message.vrf:type_name -> types.Signature
-	6,  // 2: types.Message.qc:type_name -> types.QuorumCertificate
-	6,  // 3: types.Message.high_qc:type_name -> types.QuorumCertificate
+	5,  // 0: types.Message.header:type_name -> types.View
+	6,  // 1: types.Message.vrf:type_name -> types.Signature
+	7,  // 2: types.Message.qc:type_name -> types.QuorumCertificate
+	7,  // 3: types.Message.high_qc:type_name -> types.QuorumCertificate
 	1,  // 4: types.Message.last_double_sign_evidence:type_name -> types.DoubleSignEvidence
-	7,  // 5: types.Message.vdf:type_name -> types.VDF
-	5,  // 6: types.Message.signature:type_name -> types.Signature
-	6,  // 7: types.DoubleSignEvidence.vote_a:type_name -> types.QuorumCertificate
-	6,  // 8: types.DoubleSignEvidence.vote_b:type_name -> types.QuorumCertificate
+	8,  // 5: types.Message.vdf:type_name -> types.VDF
+	6,  // 6: types.Message.signature:type_name -> types.Signature
+	7,  // 7: types.DoubleSignEvidence.vote_a:type_name -> types.QuorumCertificate
+	7,  // 8: types.DoubleSignEvidence.vote_b:type_name -> types.QuorumCertificate
 	1,  // 9: types.DoubleSignEvidences.Evidence:type_name -> types.DoubleSignEvidence
-	3,  // 10: types.DoubleSignEvidences.DeDuplicator:type_name -> types.DoubleSignEvidences.DeDuplicatorEntry
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	4,  // 10: types.DoubleSignEvidences.DeDuplicator:type_name -> types.DoubleSignEvidences.DeDuplicatorEntry
+	0,  // 11: types.WALEntry.received:type_name -> types.Message
+	0,  // 12: types.WALEntry.sent:type_name -> types.Message
+	7,  // 13: types.WALEntry.lock:type_name -> types.QuorumCertificate
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_bft_proto_init() }
@@ -355,7 +459,7 @@ func file_bft_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bft_proto_rawDesc), len(file_bft_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
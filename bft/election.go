@@ -123,7 +123,7 @@ func VRF(lastNProposers [][]byte, rootHeight, height, round uint64, privateKey c
 	// sign it with the Private Key
 	return &lib.Signature{
 		PublicKey: privateKey.PublicKey().Bytes(),
-		Signature: privateKey.Sign(vrfIn), // BLS signatures provide non-malleability and uniqueness making them a good candidate for a Practical VRF
+		Signature: crypto.SignFor(privateKey, crypto.SignVRFSeed, vrfIn), // BLS signatures provide non-malleability and uniqueness making them a good candidate for a Practical VRF
 	}
 }
 
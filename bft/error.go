@@ -62,3 +62,19 @@ func ErrFailedSafeNodePredicate() lib.ErrorI {
 func ErrAggregateSignature(err error) lib.ErrorI {
 	return lib.NewError(lib.CodeAggregateSignature, lib.ConsensusModule, fmt.Sprintf("aggregateSignature() failed with err: %s", err.Error()))
 }
+
+func ErrDoubleSignProtection(mark, view *lib.View) lib.ErrorI {
+	return lib.NewError(lib.CodeDoubleSignProtection, lib.ConsensusModule, fmt.Sprintf("refusing to sign %s: conflicts with the signing high-water mark %s", view.ToString(), mark.ToString()))
+}
+
+func ErrHighWaterMark(err error) lib.ErrorI {
+	return lib.NewError(lib.CodeHighWaterMark, lib.ConsensusModule, fmt.Sprintf("signing high-water mark failed with err: %s", err.Error()))
+}
+
+func ErrWAL(err error) lib.ErrorI {
+	return lib.NewError(lib.CodeWAL, lib.ConsensusModule, fmt.Sprintf("consensus write-ahead log failed with err: %s", err.Error()))
+}
+
+func ErrInvalidConsensusSignBytes() lib.ErrorI {
+	return lib.NewError(lib.CodeInvalidConsensusSignBytes, lib.ConsensusModule, "not the sign bytes of a consensus message of this network and chain")
+}
@@ -0,0 +1,224 @@
+package bft
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	"google.golang.org/protobuf/proto"
+)
+
+/*
+	Double-sign protection: the node persists the highest view its validator key signed a consensus message for
+
+	- every vote, proposal and pacemaker message is checked against the mark before it's signed
+	- a message for a lower view is refused, as is a different message for the same view
+	- the mark is written to disk (atomically) before the message is signed, so a crash can't lose it
+	- operators move the mark with the validator key during a failover (see 'canopy high-water-mark')
+	- a remote signer keeps its own mark, so it refuses a conflicting message even from a node with a stale one
+*/
+
+// HighWaterMarkFilePath is the file path for the signing high-water mark in the 'data directory'
+const HighWaterMarkFilePath = "signing_high_water_mark.json"
+
+// SendToReplicas() checks the message against the high-water mark before the controller signs and sends it to the replicas, then records it in the write-ahead log
+func (b *BFT) SendToReplicas(replicas lib.ValidatorSet, msg *Message) {
+	if b.checkHighWaterMark(msg) {
+		b.Controller.SendToReplicas(replicas, msg)
+		b.walSent(msg)
+	}
+}
+
+// SendToProposer() checks the message against the high-water mark before the controller signs and sends it to the proposer, then records it in the write-ahead log
+func (b *BFT) SendToProposer(msg *Message) {
+	if b.checkHighWaterMark(msg) {
+		b.Controller.SendToProposer(msg)
+		b.walSent(msg)
+	}
+}
+
+// checkHighWaterMark() returns true if the message is safe to sign, raising the mark if needed
+func (b *BFT) checkHighWaterMark(msg *Message) bool {
+	view := msg.view()
+	if view == nil {
+		b.log.Errorf("Refusing to sign a consensus message without a view")
+		return false
+	}
+	// NOTE: the sign bytes are taken from a clone, as marshalling populates the (cached) size of the message
+	if err := b.HighWaterMark.CheckAndSet(view, proto.Clone(msg).(*Message).SignBytes()); err != nil {
+		b.log.Error(err.Error())
+		return false
+	}
+	return true
+}
+
+// HighWaterMark is the highest consensus view the validator key signed a message for
+type HighWaterMark struct {
+	View          *lib.View    `json:"view"`          // the highest (rH, H, R, P) signed
+	SignBytesHash lib.HexBytes `json:"signBytesHash"` // the hash of the sign bytes of the message signed at that view
+}
+
+// HighWaterMarkStore is the double-sign protection database of the validator
+type HighWaterMarkStore struct {
+	path string         // the file the mark is persisted to (empty if in memory)
+	mark *HighWaterMark // the current mark (nil if nothing was signed)
+	mux  sync.Mutex     // thread safety
+}
+
+// NewHighWaterMarkStoreInMemory() creates a new store that isn't persisted, only for testing
+func NewHighWaterMarkStoreInMemory() *HighWaterMarkStore { return &HighWaterMarkStore{} }
+
+// NewHighWaterMarkStoreFromFile() loads the store from the data directory (empty if the file doesn't exist)
+func NewHighWaterMarkStoreFromFile(dataDirPath string) (*HighWaterMarkStore, lib.ErrorI) {
+	s := &HighWaterMarkStore{path: filepath.Join(dataDirPath, HighWaterMarkFilePath)}
+	bz, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, ErrHighWaterMark(err)
+	}
+	// NOTE: a corrupt file is an error rather than an empty mark, as signing without the mark isn't safe
+	mark := new(HighWaterMark)
+	if e := lib.UnmarshalJSON(bz, mark); e != nil {
+		return nil, e
+	}
+	if mark.View == nil {
+		return nil, ErrHighWaterMark(errors.New("missing view"))
+	}
+	s.mark = mark
+	return s, nil
+}
+
+// CheckAndSet() refuses to sign a message that conflicts with the mark, and raises the mark before signing a higher view
+func (s *HighWaterMarkStore) CheckAndSet(view *lib.View, signBytes []byte) lib.ErrorI {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	hash := crypto.Hash(signBytes)
+	if s.mark != nil {
+		// a lower view may conflict with a message signed before
+		if view.Less(s.mark.View) {
+			return ErrDoubleSignProtection(s.mark.View, view)
+		}
+		// the same view may only be re-signed with the exact same message
+		if view.Equals(s.mark.View) {
+			if !bytes.Equal(hash, s.mark.SignBytesHash) {
+				return ErrDoubleSignProtection(s.mark.View, view)
+			}
+			return nil
+		}
+	}
+	return s.set(&HighWaterMark{View: view.Copy(), SignBytesHash: hash})
+}
+
+// NewRemoteSignPolicy() returns the consensus signing policy of a remote signer: it only signs the exact sign bytes of a consensus
+// message of the network and chain, and only if the message doesn't conflict with the signer's own high-water mark
+// bytes that don't decode as a consensus message at all are left to the signer's other checks
+func NewRemoteSignPolicy(hwm *HighWaterMarkStore, networkId, chainId uint64) func(signBytes []byte) (consensus bool, err lib.ErrorI) {
+	return func(signBytes []byte) (consensus bool, err lib.ErrorI) {
+		view := signBytesView(signBytes)
+		if view == nil {
+			return false, ErrInvalidConsensusSignBytes()
+		}
+		if view.NetworkId != networkId || view.ChainId != chainId {
+			return true, ErrInvalidConsensusSignBytes()
+		}
+		return true, hwm.CheckAndSet(view, signBytes)
+	}
+}
+
+// signBytesView() decodes the sign bytes of a consensus message and returns its view (nil if the bytes aren't exactly those sign bytes)
+func signBytesView(signBytes []byte) *lib.View {
+	// proposer and pacemaker messages sign a stripped copy of the message
+	msg := new(Message)
+	if err := lib.Unmarshal(signBytes, msg); err == nil && (msg.IsProposerMessage() || msg.IsPacemakerMessage()) {
+		// re-encoding must reproduce the bytes, so nothing but the signed fields can be smuggled in
+		if bytes.Equal(msg.SignBytes(), signBytes) {
+			return msg.view()
+		}
+	}
+	// votes sign the certificate they vote for
+	vote := &Message{Qc: new(QC)}
+	if err := lib.Unmarshal(signBytes, vote.Qc); err == nil && vote.IsReplicaMessage() {
+		if bytes.Equal(vote.SignBytes(), signBytes) {
+			return vote.view()
+		}
+	}
+	return nil
+}
+
+// Mark() returns a copy of the current mark (nil if nothing was signed)
+func (s *HighWaterMarkStore) Mark() *HighWaterMark {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.mark == nil {
+		return nil
+	}
+	return &HighWaterMark{View: s.mark.View.Copy(), SignBytesHash: bytes.Clone(s.mark.SignBytesHash)}
+}
+
+// Import() raises the mark to an exported one if it's higher; a lower mark is ignored so an import never lowers protection
+func (s *HighWaterMarkStore) Import(mark *HighWaterMark) (raised bool, err lib.ErrorI) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if mark == nil || mark.View == nil {
+		return false, ErrHighWaterMark(errors.New("missing view"))
+	}
+	// a mark of another network or chain says nothing about this one
+	if s.mark != nil && (mark.View.NetworkId != s.mark.View.NetworkId || mark.View.ChainId != s.mark.View.ChainId) {
+		return false, ErrHighWaterMark(errors.New("the mark is for another network or chain"))
+	}
+	if s.mark != nil && !s.mark.View.Less(mark.View) {
+		return false, nil
+	}
+	if err = s.set(&HighWaterMark{View: mark.View.Copy(), SignBytesHash: bytes.Clone(mark.SignBytesHash)}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// set() persists the mark (if not in memory) before updating it in memory
+func (s *HighWaterMarkStore) set(mark *HighWaterMark) lib.ErrorI {
+	if s.path != "" {
+		bz, err := lib.MarshalJSONIndent(mark)
+		if err != nil {
+			return err
+		}
+		if er := writeFileAtomic(s.path, bz); er != nil {
+			return ErrHighWaterMark(er)
+		}
+	}
+	s.mark = mark
+	return nil
+}
+
+// writeFileAtomic() writes the file to a temporary file, syncs it to disk and renames it over the path
+func writeFileAtomic(path string, bz []byte) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	// cleanup on any error
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if _, err = tmp.Write(bz); err != nil {
+		return err
+	}
+	// ensure the mark is on disk before anything is signed with it
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	// atomic replace (same directory ensures same filesystem)
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,136 @@
+package bft
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/canopy-network/canopy/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighWaterMarkStore(t *testing.T) {
+	dataDir := t.TempDir()
+	view := func(round uint64, phase Phase) *lib.View {
+		return &lib.View{NetworkId: 1, ChainId: lib.CanopyChainId, Height: 2, RootHeight: 2, Round: round, Phase: phase}
+	}
+	s, err := NewHighWaterMarkStoreFromFile(dataDir)
+	require.NoError(t, err)
+	require.Nil(t, s.Mark())
+	// the first signature sets the mark
+	require.NoError(t, s.CheckAndSet(view(1, ProposeVote), []byte("a")))
+	// the exact same message may be re-signed
+	require.NoError(t, s.CheckAndSet(view(1, ProposeVote), []byte("a")))
+	// a different message for the same view is refused
+	require.Error(t, s.CheckAndSet(view(1, ProposeVote), []byte("b")))
+	// a lower view is refused
+	require.Error(t, s.CheckAndSet(view(1, ElectionVote), []byte("c")))
+	require.Error(t, s.CheckAndSet(view(0, CommitProcess), []byte("c")))
+	// a higher view raises the mark
+	require.NoError(t, s.CheckAndSet(view(2, Election), []byte("d")))
+	require.Equal(t, view(2, Election), s.Mark().View)
+	// the mark survives a restart
+	s, err = NewHighWaterMarkStoreFromFile(dataDir)
+	require.NoError(t, err)
+	require.Equal(t, view(2, Election), s.Mark().View)
+	require.Error(t, s.CheckAndSet(view(1, Precommit), []byte("e")))
+	require.Error(t, s.CheckAndSet(view(2, Election), []byte("e")))
+	// a corrupt file isn't silently treated as an empty mark
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, HighWaterMarkFilePath), []byte("{"), 0600))
+	_, err = NewHighWaterMarkStoreFromFile(dataDir)
+	require.Error(t, err)
+}
+
+func TestHighWaterMarkStoreImport(t *testing.T) {
+	view := func(height uint64) *lib.View {
+		return &lib.View{NetworkId: 1, ChainId: lib.CanopyChainId, Height: height, RootHeight: 2, Phase: Election}
+	}
+	s := NewHighWaterMarkStoreInMemory()
+	// an empty store takes any mark
+	raised, err := s.Import(&HighWaterMark{View: view(5), SignBytesHash: []byte("hash")})
+	require.NoError(t, err)
+	require.True(t, raised)
+	require.Equal(t, view(5), s.Mark().View)
+	// a lower mark never lowers the protection
+	raised, err = s.Import(&HighWaterMark{View: view(4)})
+	require.NoError(t, err)
+	require.False(t, raised)
+	require.Equal(t, view(5), s.Mark().View)
+	// a higher mark raises it
+	raised, err = s.Import(&HighWaterMark{View: view(6)})
+	require.NoError(t, err)
+	require.True(t, raised)
+	require.Error(t, s.CheckAndSet(view(5), []byte("a")))
+	// a mark of another chain is refused
+	other := view(7)
+	other.ChainId = 2
+	_, err = s.Import(&HighWaterMark{View: other})
+	require.Error(t, err)
+	_, err = s.Import(&HighWaterMark{})
+	require.Error(t, err)
+}
+
+func TestSendChecksHighWaterMark(t *testing.T) {
+	c := newTestConsensus(t, ProposeVote, 3)
+	vote := func(round uint64) *Message {
+		return &Message{Qc: &QC{Header: &lib.View{NetworkId: 1, ChainId: lib.CanopyChainId, Height: 1, RootHeight: 1, Round: round, Phase: ProposeVote}, BlockHash: []byte("block")}}
+	}
+	// a vote above the mark is sent
+	go c.bft.SendToProposer(vote(1))
+	select {
+	case <-time.After(testTimeout):
+		t.Fatal("timeout")
+	case <-c.cont.sendToProposerChan:
+	}
+	require.Equal(t, uint64(1), c.bft.HighWaterMark.Mark().View.Round)
+	// a vote below the mark is never handed to the controller for signing
+	go c.bft.SendToProposer(vote(0))
+	select {
+	case <-c.cont.sendToProposerChan:
+		t.Fatal("unexpected message")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRemoteSignPolicy(t *testing.T) {
+	view := func(round uint64, phase Phase) *lib.View {
+		return &lib.View{NetworkId: 1, ChainId: lib.CanopyChainId, Height: 2, RootHeight: 2, Round: round, Phase: phase}
+	}
+	signPolicy := NewRemoteSignPolicy(NewHighWaterMarkStoreInMemory(), 1, lib.CanopyChainId)
+	// policy() returns the decision on a consensus message
+	policy := func(signBytes []byte) lib.ErrorI {
+		consensus, err := signPolicy(signBytes)
+		require.True(t, consensus)
+		return err
+	}
+	proposal := func(round uint64, blockHash string) []byte {
+		return (&Message{Header: view(round, Propose), Qc: &QC{Header: view(round, ElectionVote), BlockHash: []byte(blockHash)}}).SignBytes()
+	}
+	vote := func(round uint64, phase Phase, blockHash string) []byte {
+		return (&Message{Qc: &QC{Header: view(round, phase), BlockHash: []byte(blockHash)}}).SignBytes()
+	}
+	// votes, proposals and pacemaker messages are signed in view order
+	require.NoError(t, policy(vote(1, ElectionVote, "")))
+	require.NoError(t, policy(proposal(1, "block")))
+	require.NoError(t, policy(vote(1, ProposeVote, "block")))
+	require.NoError(t, policy((&Message{Qc: &QC{Header: view(1, RoundInterrupt)}}).SignBytes()))
+	// the exact same message may be re-signed
+	require.NoError(t, policy((&Message{Qc: &QC{Header: view(1, RoundInterrupt)}}).SignBytes()))
+	// a conflicting or lower message is refused by the signer's own high-water mark
+	require.Error(t, policy(vote(1, ProposeVote, "other block")))
+	require.Error(t, policy(proposal(1, "other block")))
+	// anything but the exact sign bytes of a consensus message isn't a consensus message, and is refused
+	signed, err := lib.Marshal(&QC{Header: view(2, ProposeVote), BlockHash: []byte("block"), Block: []byte("smuggled")})
+	require.NoError(t, err)
+	for _, signBytes := range [][]byte{[]byte("arbitrary"), append(vote(2, ProposeVote, "block"), 0x0a, 0x00), signed} {
+		consensus, e := signPolicy(signBytes)
+		require.False(t, consensus)
+		require.Error(t, e)
+	}
+	// a message of another chain is refused
+	other := view(2, ProposeVote)
+	other.ChainId = 2
+	require.Error(t, policy((&Message{Qc: &QC{Header: other, BlockHash: []byte("block")}}).SignBytes()))
+	require.NoError(t, policy(vote(2, ProposeVote, "block")))
+}
@@ -39,6 +39,7 @@ func newTestConsensus(t *testing.T, phase Phase, numValidators int) (tc *testCon
 	// Disable VDF service for testing
 	config := lib.DefaultConfig()
 	config.RunVDF = false
+	config.DataDirPath = t.TempDir()
 	// create the bft object using the mocks
 	tc.bft, err = New(config, tc.valKeys[0], 1, 1, tc.cont, config.RunVDF, nil, lib.NewDefaultLogger())
 	tc.bft.ValidatorSet = tc.valSet
@@ -577,6 +578,9 @@ func (t *testController) LoadLastProposers(_ uint64) (*lib.Proposers, lib.ErrorI
 func (t *testController) LoadMaxBlockSize() int {
 	return lib.GlobalMaxBlockSize
 }
+func (t *testController) LoadPhaseTimeoutBounds() (minMS, maxMS int) {
+	return lib.DefaultMinPhaseTimeoutMS, lib.DefaultMaxPhaseTimeoutMS
+}
 func (t *testController) ResetFSM() {}
 func (t *testController) GossipBlock(certificate *lib.QuorumCertificate, sender []byte, timestamp uint64) {
 	t.gossipCertChan <- certificate
@@ -28,10 +28,10 @@ func (b *BFT) HandleMessage(message proto.Message) lib.ErrorI {
 			b.log.Debugf("Received %s message from replica: %s", msg.Qc.Header.ToString(), lib.BytesToTruncatedString(msg.Signature.PublicKey))
 			// store pacemaker messages separate from 'votes'
 			if msg.IsPacemakerMessage() {
-				return b.AddPacemakerMessage(msg)
+				return b.walReceived(msg, false, b.AddPacemakerMessage(msg))
 			}
 			// store Vote
-			return b.AddVote(msg)
+			return b.walReceived(msg, false, b.AddVote(msg))
 		case msg.IsProposerMessage(): // consensus message from the Leader
 			// validate the Leader message
 			partialQC, err := b.CheckProposerMessage(msg, params)
@@ -43,10 +43,10 @@ func (b *BFT) HandleMessage(message proto.Message) lib.ErrorI {
 			// store partial QCs as they may be indicative of byzantine behavior
 			if partialQC {
 				b.log.Errorf("Received partial QC from proposer %s", lib.BytesToTruncatedString(msg.Signature.PublicKey))
-				return b.AddPartialQC(msg)
+				return b.walReceived(msg, true, b.AddPartialQC(msg))
 			}
 			// store Proposal
-			return b.AddProposal(msg)
+			return b.walReceived(msg, false, b.AddProposal(msg))
 		}
 	}
 	return ErrUnknownConsensusMsg(message)
@@ -286,6 +286,14 @@ func (x *Message) IsPacemakerMessage() bool {
 	return x.Qc.Header.Phase == RoundInterrupt
 }
 
+// view() returns the view of the message: proposer messages carry it in the header, votes and pacemaker messages in the QC
+func (x *Message) view() *lib.View {
+	if x.Header == nil && x.Qc != nil {
+		return x.Qc.Header
+	}
+	return x.Header
+}
+
 // checkBasic() performs basic sanity checks on the Message
 func (x *Message) checkBasic(view *lib.View) lib.ErrorI {
 	if x == nil {
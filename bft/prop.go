@@ -54,6 +54,10 @@ func (b *BFT) AddProposal(m *Message) lib.ErrorI {
 	}
 	// add to the global list
 	b.Proposals[m.Header.Round] = roundProposal
+	// measure the phase latency if this proposal completes it (a self-sent proposal has no latency to measure)
+	if !bytes.Equal(m.Signature.GetPublicKey(), b.PublicKey) {
+		b.observePhaseLatency()
+	}
 	return nil
 }
 
@@ -0,0 +1,178 @@
+package bft
+
+import (
+	"slices"
+	"time"
+
+	"github.com/canopy-network/canopy/lib"
+)
+
+/*
+	Adaptive consensus timeouts: the node measures how long each phase takes to reach +2/3 and sizes the phase timeouts to match
+
+	- a latency is measured from the start of a phase's wait until the messages of that phase reach +2/3 (see PhaseHas23Maj)
+	- replicas measure the leader phases (ELECTION, PROPOSE, PRECOMMIT, COMMIT) and leaders measure the vote phases
+	- latencies are always tracked, but only drive the timeouts if 'adaptiveTimeouts' is configured
+	- only the replica side waits (on the leader phases) adapt: a vote phase timeout decides which replicas make it into the
+	  certificate, and sizing it from the time to +2/3 would cut off the slowest third, who'd then be slashed for not signing
+	- an adapted timeout is a multiple of the slowest recent latency, bounded by the governance min/max phase timeouts
+	- until a phase has enough latencies, the configured timeout is used (within the same bounds)
+*/
+
+const (
+	adaptiveTimeoutWindow     = 20 // the number of recent +2/3 latencies a phase timeout is derived from
+	adaptiveTimeoutMinSamples = 3  // the number of latencies needed before a phase timeout adapts
+	adaptiveTimeoutHeadroom   = 2  // the multiple of the slowest recent latency, leaving time for a leader message slower than any recent one
+)
+
+// measuredPhases are the phases that wait on +2/3 and so have their latencies measured
+var measuredPhases = []Phase{Election, ElectionVote, Propose, ProposeVote, Precommit, PrecommitVote, Commit}
+
+// adaptivePhases are the phases that wait on the leader's messages and so have adaptive timeouts
+// NOTE: the vote phases keep the configured timeouts, so a slow minority of replicas is still included in the certificate
+var adaptivePhases = []Phase{Election, Propose, Precommit, Commit}
+
+// PhaseTimeouts tracks how long each phase takes to reach +2/3 and derives the phase timeouts from it
+type PhaseTimeouts struct {
+	latencies map[Phase][]time.Duration // the recent +2/3 latencies of each phase (oldest first)
+	lower     time.Duration             // the governance lower bound of an adaptive timeout
+	upper     time.Duration             // the governance upper bound of an adaptive timeout
+	waiting   Phase                     // the phase whose wait is being measured (UNKNOWN if none)
+	waitStart time.Time                 // when the wait of the phase started
+}
+
+// NewPhaseTimeouts() creates a latency tracker bounded by the protocol defaults
+func NewPhaseTimeouts() *PhaseTimeouts {
+	return &PhaseTimeouts{
+		latencies: make(map[Phase][]time.Duration),
+		lower:     lib.DefaultMinPhaseTimeoutMS * time.Millisecond,
+		upper:     lib.DefaultMaxPhaseTimeoutMS * time.Millisecond,
+	}
+}
+
+// PhaseTimeoutMS() returns the timeout of the phase for round 0: adapted if configured, else as configured
+func (b *BFT) PhaseTimeoutMS(phase Phase) int {
+	configured := time.Duration(b.configuredTimeoutMS(phase)) * time.Millisecond
+	if !b.Config.AdaptiveTimeouts {
+		return int(configured.Milliseconds())
+	}
+	return int(b.Timeouts.timeout(phase, configured).Milliseconds())
+}
+
+// RefreshPhaseTimeoutBounds() loads the governance bounds of the adaptive timeouts
+func (b *BFT) RefreshPhaseTimeoutBounds() {
+	minMS, maxMS := b.Controller.LoadPhaseTimeoutBounds()
+	b.Timeouts.lower, b.Timeouts.upper = time.Duration(minMS)*time.Millisecond, time.Duration(maxMS)*time.Millisecond
+}
+
+// observePhaseLatency() records the latency of the phase being waited on once its messages reach +2/3
+// NOTE: messages that arrived before the wait started aren't measured, as they say nothing about the timeout
+func (b *BFT) observePhaseLatency() {
+	t := b.Timeouts
+	// only measure phases that wait on +2/3, once per wait
+	if !slices.Contains(measuredPhases, t.waiting) || b.Phase != t.waiting+1 || !b.PhaseHas23Maj() {
+		return
+	}
+	phase := t.waiting
+	t.record(phase, b.Clock.Now().Sub(t.waitStart))
+	t.waiting = lib.Phase_UNKNOWN
+	// update the telemetry
+	latency, _ := t.slowest(phase)
+	b.Metrics.UpdatePhaseTimeout(phase, time.Duration(b.PhaseTimeoutMS(phase))*time.Millisecond, latency)
+}
+
+// configuredTimeoutMS() returns the configured timeout of a phase
+func (b *BFT) configuredTimeoutMS(phase Phase) int {
+	switch phase {
+	case Election:
+		return b.Config.ElectionTimeoutMS
+	case ElectionVote:
+		return b.Config.ElectionVoteTimeoutMS
+	case Propose:
+		return b.Config.ProposeTimeoutMS
+	case ProposeVote:
+		return b.Config.ProposeVoteTimeoutMS
+	case Precommit:
+		return b.Config.PrecommitTimeoutMS
+	case PrecommitVote:
+		return b.Config.PrecommitVoteTimeoutMS
+	case Commit:
+		return b.Config.CommitTimeoutMS
+	}
+	return 0
+}
+
+// startWait() begins measuring the wait of a phase
+func (t *PhaseTimeouts) startWait(phase Phase, start time.Time) {
+	t.waiting, t.waitStart = phase, start
+}
+
+// stopWait() stops measuring the current wait (if any)
+func (t *PhaseTimeouts) stopWait() { t.waiting = lib.Phase_UNKNOWN }
+
+// record() adds a +2/3 latency of the phase, dropping the oldest outside the window
+func (t *PhaseTimeouts) record(phase Phase, latency time.Duration) {
+	latencies := append(t.latencies[phase], latency)
+	if len(latencies) > adaptiveTimeoutWindow {
+		latencies = latencies[len(latencies)-adaptiveTimeoutWindow:]
+	}
+	t.latencies[phase] = latencies
+}
+
+// slowest() returns the slowest recent latency of the phase and the number of latencies tracked
+func (t *PhaseTimeouts) slowest(phase Phase) (latency time.Duration, samples int) {
+	latencies := t.latencies[phase]
+	if len(latencies) == 0 {
+		return 0, 0
+	}
+	return slices.Max(latencies), len(latencies)
+}
+
+// timeout() returns the adapted timeout of the phase, falling back to the configured timeout until there are enough latencies
+// NOTE: a vote phase always uses the configured timeout (within the bounds), see adaptivePhases
+func (t *PhaseTimeouts) timeout(phase Phase, configured time.Duration) time.Duration {
+	timeout := configured
+	if latency, samples := t.slowest(phase); samples >= adaptiveTimeoutMinSamples && slices.Contains(adaptivePhases, phase) {
+		timeout = adaptiveTimeoutHeadroom * latency
+	}
+	return max(t.lower, min(timeout, t.upper))
+}
+
+// TimeoutSummary is the state of the phase timeouts, as reported by the consensus info
+type TimeoutSummary struct {
+	Adaptive bool                   `json:"adaptive"` // if the timeouts are adapted to the latencies
+	MinMS    int64                  `json:"minMS"`    // the governance lower bound of an adapted timeout
+	MaxMS    int64                  `json:"maxMS"`    // the governance upper bound of an adapted timeout
+	Phases   []*PhaseTimeoutSummary `json:"phases"`   // the timeout of each phase
+}
+
+// PhaseTimeoutSummary is the state of the timeout of a single phase
+type PhaseTimeoutSummary struct {
+	Phase        string `json:"phase"`        // the phase name
+	Adaptive     bool   `json:"adaptive"`     // if the phase timeout adapts (only the waits on the leader's messages do)
+	ConfiguredMS int    `json:"configuredMS"` // the configured timeout
+	LatencyMS    int64  `json:"latencyMS"`    // the slowest recent time to +2/3
+	Samples      int    `json:"samples"`      // the number of recent latencies
+	TimeoutMS    int    `json:"timeoutMS"`    // the timeout in use (for round 0, later rounds scale it)
+}
+
+// TimeoutSummary() returns the state of the phase timeouts
+func (b *BFT) TimeoutSummary() *TimeoutSummary {
+	summary := &TimeoutSummary{
+		Adaptive: b.Config.AdaptiveTimeouts,
+		MinMS:    b.Timeouts.lower.Milliseconds(),
+		MaxMS:    b.Timeouts.upper.Milliseconds(),
+	}
+	for _, phase := range measuredPhases {
+		latency, samples := b.Timeouts.slowest(phase)
+		summary.Phases = append(summary.Phases, &PhaseTimeoutSummary{
+			Phase:        phase.String(),
+			Adaptive:     slices.Contains(adaptivePhases, phase),
+			ConfiguredMS: b.configuredTimeoutMS(phase),
+			LatencyMS:    latency.Milliseconds(),
+			Samples:      samples,
+			TimeoutMS:    b.PhaseTimeoutMS(phase),
+		})
+	}
+	return summary
+}
@@ -0,0 +1,130 @@
+package bft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/canopy-network/canopy/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhaseTimeouts(t *testing.T) {
+	timeouts := NewPhaseTimeouts()
+	configured := 2 * time.Second
+	// the configured timeout is used until there are enough latencies
+	for i := 0; i < adaptiveTimeoutMinSamples-1; i++ {
+		timeouts.record(Propose, 300*time.Millisecond)
+	}
+	require.Equal(t, configured, timeouts.timeout(Propose, configured))
+	// then the timeout is a multiple of the slowest recent latency
+	timeouts.record(Propose, 400*time.Millisecond)
+	require.Equal(t, adaptiveTimeoutHeadroom*400*time.Millisecond, timeouts.timeout(Propose, configured))
+	// other phases are unaffected
+	require.Equal(t, configured, timeouts.timeout(Precommit, configured))
+	// the vote phases keep the configured timeout
+	for i := 0; i < adaptiveTimeoutMinSamples; i++ {
+		timeouts.record(ProposeVote, 300*time.Millisecond)
+	}
+	require.Equal(t, configured, timeouts.timeout(ProposeVote, configured))
+	// the timeout is bounded
+	timeouts.record(Propose, time.Minute)
+	require.Equal(t, lib.DefaultMaxPhaseTimeoutMS*time.Millisecond, timeouts.timeout(Propose, configured))
+	// old latencies fall out of the window
+	for i := 0; i < adaptiveTimeoutWindow; i++ {
+		timeouts.record(Propose, time.Millisecond)
+	}
+	latency, samples := timeouts.slowest(Propose)
+	require.Equal(t, time.Millisecond, latency)
+	require.Equal(t, adaptiveTimeoutWindow, samples)
+	require.Equal(t, lib.DefaultMinPhaseTimeoutMS*time.Millisecond, timeouts.timeout(Propose, configured))
+}
+
+func TestAdaptiveWaitTime(t *testing.T) {
+	c := newTestConsensus(t, ProposeVote, 3)
+	for i := 0; i < adaptiveTimeoutMinSamples; i++ {
+		c.bft.Timeouts.record(Propose, time.Second)
+	}
+	// the configured timeouts are used unless adaptive timeouts are enabled
+	require.Equal(t, c.bft.Config.ProposeTimeoutMS, c.bft.PhaseTimeoutMS(Propose))
+	c.bft.Config.AdaptiveTimeouts = true
+	require.Equal(t, adaptiveTimeoutHeadroom*1000, c.bft.PhaseTimeoutMS(Propose))
+	// later rounds scale the adapted timeout
+	require.Equal(t, 3*adaptiveTimeoutHeadroom*time.Second, c.bft.WaitTime(Propose, 1))
+	// the summary reports the timeout in use
+	summary := c.bft.TimeoutSummary()
+	require.True(t, summary.Adaptive)
+	for _, phase := range summary.Phases {
+		if phase.Phase == Propose.String() {
+			require.Equal(t, 3, phase.Samples)
+			require.Equal(t, int64(1000), phase.LatencyMS)
+			require.Equal(t, adaptiveTimeoutHeadroom*1000, phase.TimeoutMS)
+		}
+	}
+}
+
+func TestObservePhaseLatency(t *testing.T) {
+	c := newTestConsensus(t, ProposeVote, 3)
+	proposal := func(sender int) *Message {
+		return &Message{
+			Header:    &lib.View{NetworkId: 1, ChainId: lib.CanopyChainId, Height: 1, RootHeight: 1, Phase: Propose},
+			Signature: &lib.Signature{PublicKey: c.valKeys[sender].PublicKey().Bytes()},
+		}
+	}
+	// the PROPOSE wait started 300ms ago
+	c.bft.Timeouts.startWait(Propose, time.Now().Add(-300*time.Millisecond))
+	// a self-sent proposal has no latency to measure
+	require.NoError(t, c.bft.AddProposal(proposal(0)))
+	_, samples := c.bft.Timeouts.slowest(Propose)
+	require.Zero(t, samples)
+	// the leader's proposal completes the phase
+	require.NoError(t, c.bft.AddProposal(proposal(1)))
+	latency, samples := c.bft.Timeouts.slowest(Propose)
+	require.Equal(t, 1, samples)
+	require.GreaterOrEqual(t, latency, 300*time.Millisecond)
+	// the phase is only measured once per wait
+	require.NoError(t, c.bft.AddProposal(proposal(1)))
+	_, samples = c.bft.Timeouts.slowest(Propose)
+	require.Equal(t, 1, samples)
+}
+
+func TestAdaptiveTimeoutIncludesSlowMinority(t *testing.T) {
+	// the node leads the PROPOSE-VOTE phase of 4 equal validators: 3 fast replicas make +2/3, the 4th is slower than twice their latency
+	c := newTestConsensus(t, Precommit, 4)
+	c.bft.Config.AdaptiveTimeouts = true
+	clock := &testClock{now: time.Now()}
+	c.bft.Clock = clock
+	fast, slow := 400*time.Millisecond, adaptiveTimeoutHeadroom*400*time.Millisecond+time.Second
+	_, blockHash, _, resultsHash := c.proposal(t)
+	vote := func(idx int) *Message {
+		msg := &Message{Qc: &lib.QuorumCertificate{Header: c.view(ProposeVote), BlockHash: blockHash, ResultsHash: resultsHash}}
+		require.NoError(t, msg.Sign(c.valKeys[idx]))
+		return msg
+	}
+	for i := 0; i < adaptiveTimeoutWindow; i++ {
+		c.bft.Votes = make(VotesForHeight)
+		start := clock.now
+		c.bft.Timeouts.startWait(ProposeVote, start)
+		// the fast replicas reach +2/3, which is measured
+		clock.now = start.Add(fast)
+		for idx := 0; idx < 3; idx++ {
+			require.NoError(t, c.bft.AddVote(vote(idx)))
+		}
+		latency, _ := c.bft.Timeouts.slowest(ProposeVote)
+		require.Equal(t, fast, latency)
+		// the slow replica's vote still arrives before the leader's wait times out
+		clock.now = start.Add(slow)
+		require.Less(t, slow, c.bft.WaitTime(ProposeVote, 0))
+		require.NoError(t, c.bft.AddVote(vote(3)))
+		// so it's included in the certificate and isn't slashed for not signing
+		_, percent, _ := c.bft.GetLeadingVote()
+		require.Equal(t, uint64(100), percent)
+	}
+	// the timeout of the phase is the configured one, not a multiple of the time to +2/3
+	require.Equal(t, c.bft.Config.ProposeVoteTimeoutMS, c.bft.PhaseTimeoutMS(ProposeVote))
+}
+
+// testClock is a Clock that only moves when the test sets it
+type testClock struct{ now time.Time }
+
+func (c *testClock) Now() time.Time                  { return c.now }
+func (c *testClock) AfterFunc(time.Duration, func()) {}
@@ -60,6 +60,8 @@ func (b *BFT) AddVote(vote *Message) lib.ErrorI {
 	if err := b.addSigToVoteSet(vote, voteSet); err != nil {
 		return err
 	}
+	// measure the phase latency if this vote reaches +2/3
+	b.observePhaseLatency()
 	return nil
 }
 
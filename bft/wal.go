@@ -0,0 +1,276 @@
+package bft
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/canopy-network/canopy/lib"
+	"google.golang.org/protobuf/proto"
+)
+
+/*
+	Consensus write-ahead log: the node records the consensus state of its height, so a crash mid-round doesn't lose it
+
+	- received messages (proposals, votes, partial QCs and pacemaker messages) are recorded once validated and stored
+	- sent messages are recorded once signed, and a lock is recorded before the vote that depends on it is signed
+	- on the first height after a restart, the entries of that height are replayed: the lock, the round and the messages
+	- the log is truncated on every new height, keeping only what survives the reset
+*/
+
+// WALFilePath is the file path for the consensus write-ahead log in the 'data directory'
+const WALFilePath = "consensus.wal"
+
+// walHeaderSize is the size of the header of each record: the length and the crc32 checksum of the entry
+const walHeaderSize = 8
+
+// WAL is the consensus write-ahead log of the current height
+type WAL struct {
+	path    string      // the file the log is persisted to (empty if in memory)
+	file    *os.File    // the open log file (nil if in memory)
+	pending []*WALEntry // the entries found on disk at startup, waiting to be replayed
+	mux     sync.Mutex  // thread safety
+}
+
+// NewWALInMemory() creates a new log that isn't persisted, only for testing
+func NewWALInMemory() *WAL { return &WAL{} }
+
+// NewWALFromFile() opens the log in the data directory, loading its entries to be replayed
+func NewWALFromFile(dataDirPath string) (*WAL, lib.ErrorI) {
+	w := &WAL{path: filepath.Join(dataDirPath, WALFilePath)}
+	bz, err := os.ReadFile(w.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, ErrWAL(err)
+	}
+	// NOTE: a torn or corrupt record (a crash mid-write) ends the log, it and anything after it are dropped
+	var valid int
+	w.pending, valid = decodeWAL(bz)
+	if valid < len(bz) {
+		if err = writeFileAtomic(w.path, bz[:valid]); err != nil {
+			return nil, ErrWAL(err)
+		}
+	}
+	if err = w.open(); err != nil {
+		return nil, ErrWAL(err)
+	}
+	return w, nil
+}
+
+// Append() records an entry, syncing it to disk if the node is about to act on it
+func (w *WAL) Append(entry *WALEntry, sync bool) lib.ErrorI {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	record, err := encodeWALRecord(entry)
+	if err != nil {
+		return err
+	}
+	if _, er := w.file.Write(record); er != nil {
+		return ErrWAL(er)
+	}
+	if sync {
+		if er := w.file.Sync(); er != nil {
+			return ErrWAL(er)
+		}
+	}
+	return nil
+}
+
+// Truncate() starts the log of a height: the pending entries of the height are kept, followed by the entries that
+// survived the reset; everything else is dropped
+func (w *WAL) Truncate(height uint64, keep ...*WALEntry) lib.ErrorI {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	// entries of another height have nothing to restore
+	w.pending = slices.DeleteFunc(w.pending, func(e *WALEntry) bool { return e.Height != height })
+	if w.file == nil {
+		return nil
+	}
+	var bz []byte
+	for _, entry := range append(slices.Clone(w.pending), keep...) {
+		record, err := encodeWALRecord(entry)
+		if err != nil {
+			return err
+		}
+		bz = append(bz, record...)
+	}
+	// replace the log atomically, so a crash can't lose the pending entries
+	if err := writeFileAtomic(w.path, bz); err != nil {
+		return ErrWAL(err)
+	}
+	_ = w.file.Close()
+	if err := w.open(); err != nil {
+		return ErrWAL(err)
+	}
+	return nil
+}
+
+// Replay() returns the pending entries of the height (once), as they're restored
+func (w *WAL) Replay(height uint64) (entries []*WALEntry) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	for _, entry := range w.pending {
+		if entry.Height == height {
+			entries = append(entries, entry)
+		}
+	}
+	w.pending = nil
+	return
+}
+
+// Close() closes the log file
+func (w *WAL) Close() lib.ErrorI {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	if err != nil {
+		return ErrWAL(err)
+	}
+	return nil
+}
+
+// open() opens the log file for appending
+func (w *WAL) open() (err error) {
+	w.file, err = os.OpenFile(w.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	return
+}
+
+// encodeWALRecord() encodes an entry as a record: a length and crc32 header followed by the protobuf bytes
+func encodeWALRecord(entry *WALEntry) ([]byte, lib.ErrorI) {
+	// NOTE: the entry is marshalled from a clone, as marshalling populates the (cached) size of the shared messages
+	bz, err := lib.Marshal(proto.Clone(entry))
+	if err != nil {
+		return nil, err
+	}
+	record := make([]byte, walHeaderSize, walHeaderSize+len(bz))
+	binary.BigEndian.PutUint32(record[:4], uint32(len(bz)))
+	binary.BigEndian.PutUint32(record[4:walHeaderSize], crc32.ChecksumIEEE(bz))
+	return append(record, bz...), nil
+}
+
+// decodeWAL() decodes the records of a log, returning the entries and the number of bytes that were valid
+func decodeWAL(bz []byte) (entries []*WALEntry, valid int) {
+	for {
+		record := bz[valid:]
+		if len(record) < walHeaderSize {
+			return
+		}
+		size := int(binary.BigEndian.Uint32(record[:4]))
+		if len(record)-walHeaderSize < size {
+			return
+		}
+		data := record[walHeaderSize : walHeaderSize+size]
+		if crc32.ChecksumIEEE(data) != binary.BigEndian.Uint32(record[4:walHeaderSize]) {
+			return
+		}
+		entry := new(WALEntry)
+		if err := lib.Unmarshal(data, entry); err != nil {
+			return
+		}
+		entries, valid = append(entries, entry), valid+walHeaderSize+size
+	}
+}
+
+// walReceived() records a received message once it's stored (if it was stored)
+// NOTE: received messages aren't synced to disk, a crash of the process keeps them and peers may resend the rest
+func (b *BFT) walReceived(msg *Message, partialQC bool, err lib.ErrorI) lib.ErrorI {
+	if err != nil {
+		return err
+	}
+	b.walAppend(&WALEntry{Height: msg.view().Height, Received: msg, PartialQc: partialQC}, false)
+	return nil
+}
+
+// walSent() records a message the node signed and sent
+func (b *BFT) walSent(msg *Message) {
+	b.walAppend(&WALEntry{Height: msg.view().Height, Sent: msg}, true)
+}
+
+// walLock() records the proposal the node locked on, before the vote that depends on it is signed
+func (b *BFT) walLock() {
+	b.walAppend(&WALEntry{Height: b.Height, Lock: b.HighQC, RcBuildHeight: b.RCBuildHeight}, true)
+}
+
+// walAppend() records an entry in the write-ahead log, logging any error
+func (b *BFT) walAppend(entry *WALEntry, sync bool) {
+	if err := b.WAL.Append(entry, sync); err != nil {
+		b.log.Error(err.Error())
+	}
+}
+
+// truncateWAL() starts the write-ahead log of a new height, keeping the lock and the proposals that survive the reset
+func (b *BFT) truncateWAL() {
+	var keep []*WALEntry
+	if b.HighQC != nil {
+		keep = append(keep, &WALEntry{Height: b.Height, Lock: b.HighQC, RcBuildHeight: b.RCBuildHeight})
+	}
+	for _, msg := range b.Proposals[0][phaseToString(Election)] {
+		keep = append(keep, &WALEntry{Height: b.Height, Received: msg})
+	}
+	if err := b.WAL.Truncate(b.Height, keep...); err != nil {
+		b.log.Error(err.Error())
+	}
+}
+
+// ReplayWAL() restores the consensus state of the height from the write-ahead log after a restart
+// - the lock is restored, so the node can prove it to the next proposer
+// - the round is restored to the highest round the node sent a message in, rather than starting over at round 0
+// - the received messages are stored again, so the node can still vote on the proposal of its round
+func (b *BFT) ReplayWAL() {
+	b.Controller.Lock()
+	entries := b.WAL.Replay(b.Height)
+	if len(entries) == 0 {
+		b.Controller.Unlock()
+		return
+	}
+	for _, entry := range entries {
+		switch {
+		case entry.Lock != nil:
+			if b.HighQC == nil || b.HighQC.Header.Less(entry.Lock.Header) {
+				b.HighQC, b.RCBuildHeight = entry.Lock, entry.RcBuildHeight
+				b.log.Infof("🔒 Restored lock on proposal %s", lib.BytesToTruncatedString(b.HighQC.BlockHash))
+			}
+		case entry.Sent != nil:
+			if round := entry.Sent.view().Round; round > b.Round {
+				b.Round = round
+				b.round.Store(b.Round)
+			}
+		}
+	}
+	b.Controller.Unlock()
+	// NOTE: the messages were validated when received, so they're stored directly
+	var restored int
+	for _, entry := range entries {
+		msg := entry.Received
+		if msg == nil {
+			continue
+		}
+		var err lib.ErrorI
+		switch {
+		case msg.IsPacemakerMessage():
+			err = b.AddPacemakerMessage(msg)
+		case msg.IsReplicaMessage():
+			err = b.AddVote(msg)
+		case entry.PartialQc:
+			err = b.AddPartialQC(msg)
+		default:
+			err = b.AddProposal(msg)
+		}
+		if err != nil {
+			b.log.Debugf("Skipped replaying a consensus message: %s", err.Error())
+			continue
+		}
+		restored++
+	}
+	b.log.Infof("Replayed the consensus write-ahead log: %d messages restored, resuming at round %d", restored, b.CurrentRound())
+}
@@ -0,0 +1,84 @@
+package bft
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/canopy-network/canopy/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWAL(t *testing.T) {
+	dataDir := t.TempDir()
+	entry := func(height, round uint64) *WALEntry {
+		return &WALEntry{Height: height, Sent: &Message{Qc: &QC{Header: &lib.View{Height: height, Round: round, Phase: ProposeVote}}}}
+	}
+	w, err := NewWALFromFile(dataDir)
+	require.NoError(t, err)
+	require.NoError(t, w.Append(entry(1, 0), true))
+	require.NoError(t, w.Append(entry(2, 0), false))
+	require.NoError(t, w.Append(entry(2, 1), true))
+	require.NoError(t, w.Close())
+	// a torn record at the end of the log is dropped
+	path := filepath.Join(dataDir, WALFilePath)
+	bz, e := os.ReadFile(path)
+	require.NoError(t, e)
+	require.NoError(t, os.WriteFile(path, append(bz, 0, 0, 0, 9, 1, 2), 0600))
+	w, err = NewWALFromFile(dataDir)
+	require.NoError(t, err)
+	stat, e := os.Stat(path)
+	require.NoError(t, e)
+	require.Equal(t, int64(len(bz)), stat.Size())
+	// truncating to a height keeps its entries (and the entries that survived the reset)
+	require.NoError(t, w.Truncate(2, entry(2, 2)))
+	require.NoError(t, w.Close())
+	w, err = NewWALFromFile(dataDir)
+	require.NoError(t, err)
+	entries := w.Replay(2)
+	require.Len(t, entries, 3)
+	for i, en := range entries {
+		require.Equal(t, uint64(i), en.Sent.Qc.Header.Round)
+	}
+	// the entries are only replayed once
+	require.Empty(t, w.Replay(2))
+	// a new height starts an empty log
+	require.NoError(t, w.Truncate(3))
+	require.NoError(t, w.Close())
+	w, err = NewWALFromFile(dataDir)
+	require.NoError(t, err)
+	require.Empty(t, w.Replay(2))
+}
+
+func TestReplayWAL(t *testing.T) {
+	c := newTestConsensus(t, Propose, 4)
+	// receive the pacemaker messages and the PROPOSE-VOTE votes as the leader
+	c.simPacemakerPhase(t)
+	c.simProposeVotePhase(t, true, true, 0)
+	// lock on a proposal
+	_, blockHash, _, resultsHash := c.proposal(t)
+	c.bft.HighQC, c.bft.RCBuildHeight = &QC{Header: c.view(Precommit), BlockHash: blockHash, ResultsHash: resultsHash}, 1
+	c.bft.walLock()
+	// send a pacemaker message at a later round
+	go c.bft.SendToReplicas(c.valSet, &Message{Qc: &QC{Header: c.view(RoundInterrupt, 2)}})
+	select {
+	case <-time.After(testTimeout):
+		t.Fatal("timeout")
+	case <-c.cont.sendToReplicasChan:
+	}
+	// restart the node
+	require.NoError(t, c.bft.WAL.Close())
+	restarted, err := New(c.bft.Config, c.valKeys[0], 1, 1, c.cont, false, nil, lib.NewDefaultLogger())
+	require.NoError(t, err)
+	restarted.ValidatorSet, restarted.CommitteeData = c.valSet, &lib.CommitteeData{}
+	restarted.ReplayWAL()
+	// the lock, round and received messages are restored
+	require.Equal(t, c.bft.HighQC.BlockHash, restarted.HighQC.BlockHash)
+	require.Equal(t, uint64(1), restarted.RCBuildHeight)
+	require.Equal(t, uint64(2), restarted.Round)
+	require.Len(t, restarted.PacemakerMessages, len(c.bft.PacemakerMessages))
+	restarted.Round, restarted.Phase = 0, Precommit
+	_, _, err = restarted.GetMajorityVote()
+	require.NoError(t, err)
+}
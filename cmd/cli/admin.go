@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/canopy-network/canopy/cmd/rpc"
+	"github.com/canopy-network/canopy/fsm"
 	"github.com/canopy-network/canopy/lib"
 	"github.com/canopy-network/canopy/lib/crypto"
 	"github.com/canopy-network/canopy/store"
@@ -21,27 +23,41 @@ var adminCmd = &cobra.Command{
 }
 
 var (
-	pwd             string
-	nick            string
-	data            string
-	fee             uint64
-	mint            bool
-	delegate        bool
-	earlyWithdrawal bool
-	sim             bool
+	pwd              string
+	nick             string
+	data             string
+	fee              uint64
+	mint             bool
+	delegate         bool
+	earlyWithdrawal  bool
+	sim              bool
+	nonce            uint64
+	expiryHeight     uint64
+	partialFill      bool
+	lockAmount       uint64
+	minPoints        uint64
+	minAmount        uint64
+	minCounterAmount uint64
 )
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&pwd, "password", "", "input a private key password (not recommended)")
 	rootCmd.PersistentFlags().StringVar(&nick, "nickname", "", "input nickname for key")
-	adminCmd.PersistentFlags().BoolVar(&sim, "simulate", false, "simulate won't submit a transaction, rather it will print the json of the transaction that would've been submitted")
+	adminCmd.PersistentFlags().BoolVar(&sim, "simulate", false, "simulate won't submit a transaction, rather it will print the json of the transaction that would've been submitted and its execution in the next block on top of the latest state (state changes, events, fee and error)")
 	adminCmd.PersistentFlags().Uint64Var(&fee, "fee", 0, "custom fee, by default will use the minimum fee")
+	adminCmd.PersistentFlags().Uint64Var(&nonce, "nonce", 0, "sign with an account nonce for replay protection instead of the created height (see 'query account-nonce'), a pending tx with the same nonce is replaced if the fee is higher")
 	txStakeCmd.PersistentFlags().BoolVar(&delegate, "delegate", false, "delegate tokens to committee(s) only without actual validator operation")
 	txEditStakeCmd.PersistentFlags().BoolVar(&delegate, "delegate", false, "delegate tokens to committee(s) only without actual validator operation")
 	txDAOTransferCmd.PersistentFlags().BoolVar(&mint, "mint", false, "mint the transfer amount into the DAO pool before executing the treasury grant")
 	txStakeCmd.PersistentFlags().BoolVar(&earlyWithdrawal, "early-withdrawal", false, "immediately withdrawal any rewards (with penalty) directly to output address instead of auto-compounding directly to stake")
 	txEditStakeCmd.PersistentFlags().BoolVar(&earlyWithdrawal, "early-withdrawal", false, "immediately withdrawal any rewards (with penalty) directly to output address instead of auto-compounding directly to stake")
 	txCreateOrderCmd.PersistentFlags().StringVar(&data, "data", "", "data for create order")
+	txDexLimitOrderCmd.PersistentFlags().Uint64Var(&expiryHeight, "expiry-height", 0, "rest the unfilled order in the dex batches until this height, 0 = single batch")
+	txDexLimitOrderCmd.PersistentFlags().BoolVar(&partialFill, "partial-fill", false, "allow the order to be filled in part at the limit price")
+	txDexLiquidityDepositCmd.PersistentFlags().Uint64Var(&minPoints, "min-points", 0, "refund the deposit if it would mint fewer liquidity points, 0 = no minimum")
+	txDexLiquidityWithdrawCmd.PersistentFlags().Uint64Var(&minAmount, "min-amount", 0, "cancel the withdrawal if it would pay less of this chain's asset, 0 = no minimum")
+	txDexLiquidityWithdrawCmd.PersistentFlags().Uint64Var(&minCounterAmount, "min-counter-amount", 0, "cancel the withdrawal if it would pay less of the counter asset, 0 = no minimum")
+	txLockOrderCmd.PersistentFlags().Uint64Var(&lockAmount, "amount", 0, "lock only this amount of the sell order at the order's price, 0 = the whole order")
 	adminCmd.AddCommand(ksCmd)
 	adminCmd.AddCommand(ksNewKeyCmd)
 	adminCmd.AddCommand(ksImportCmd)
@@ -62,12 +78,19 @@ func init() {
 	adminCmd.AddCommand(txEditOrderCmd)
 	adminCmd.AddCommand(txDeleteOrderCmd)
 	adminCmd.AddCommand(txDexLimitOrderCmd)
+	adminCmd.AddCommand(txDexRoutedSwapCmd)
 	adminCmd.AddCommand(txDexLiquidityDepositCmd)
 	adminCmd.AddCommand(txDexLiquidityWithdrawCmd)
 	adminCmd.AddCommand(txLockOrderCmd)
 	adminCmd.AddCommand(txStartPollCmd)
 	adminCmd.AddCommand(approveTxVotePoll)
 	adminCmd.AddCommand(rejectTxVotePoll)
+	adminCmd.AddCommand(txSubmitProposalCmd)
+	adminCmd.AddCommand(txVoteCmd)
+	adminCmd.AddCommand(txCreateMultisigAccountCmd)
+	adminCmd.AddCommand(txMultisigProposeCmd)
+	adminCmd.AddCommand(txMultisigSignCmd)
+	adminCmd.AddCommand(txMultisigSubmitCmd)
 	adminCmd.AddCommand(resourceUsageCmd)
 	adminCmd.AddCommand(peerInfoCmd)
 	adminCmd.AddCommand(peerBookCmd)
@@ -274,38 +297,47 @@ var (
 	}
 
 	txDexLimitOrderCmd = &cobra.Command{
-		Use:   "tx-dex-limit-order <address or nickname> <amount> <receive-amount> <chain-id> --fee=10000 --simulate=true",
+		Use:   "tx-dex-limit-order <address or nickname> <amount> <receive-amount> <chain-id> --expiry-height=0 --partial-fill=false --fee=10000 --simulate=true",
 		Short: "create a new dex limit order - use the simulate flag to generate json only",
 		Args:  cobra.MinimumNArgs(4),
 		Run: func(cmd *cobra.Command, args []string) {
-			writeTxResultToConsole(client.TxDexLimitOrder(argGetAddrOrNickname(args[0]), uint64(argToInt(args[1])), uint64(argToInt(args[2])), uint64(argToInt(args[3])), getPassword(), !sim, fee))
+			writeTxResultToConsole(client.TxDexLimitOrder(argGetAddrOrNickname(args[0]), uint64(argToInt(args[1])), uint64(argToInt(args[2])), uint64(argToInt(args[3])), expiryHeight, partialFill, getPassword(), !sim, fee))
+		},
+	}
+
+	txDexRoutedSwapCmd = &cobra.Command{
+		Use:   "tx-dex-routed-swap <address or nickname> <amount> <minimum-output> <path> --fee=10000 --simulate=true",
+		Short: "create a new multi-hop dex swap along a comma separated path of chain ids starting with this chain (ex. 2,1,3) - use the simulate flag to generate json only",
+		Args:  cobra.MinimumNArgs(4),
+		Run: func(cmd *cobra.Command, args []string) {
+			writeTxResultToConsole(client.TxDexRoutedSwap(argGetAddrOrNickname(args[0]), uint64(argToInt(args[1])), uint64(argToInt(args[2])), argToPath(args[3]), getPassword(), !sim, fee))
 		},
 	}
 
 	txDexLiquidityDepositCmd = &cobra.Command{
-		Use:   "tx-dex-liquidity-deposit <address or nickname> <amount> <chain-id> --fee=10000 --simulate=true",
+		Use:   "tx-dex-liquidity-deposit <address or nickname> <amount> <chain-id> --min-points=0 --fee=10000 --simulate=true",
 		Short: "executes a dex liquidity deposit - use the simulate flag to generate json only",
 		Args:  cobra.MinimumNArgs(3),
 		Run: func(cmd *cobra.Command, args []string) {
-			writeTxResultToConsole(client.TxDexLiquidityDeposit(argGetAddrOrNickname(args[0]), uint64(argToInt(args[1])), uint64(argToInt(args[2])), getPassword(), !sim, fee))
+			writeTxResultToConsole(client.TxDexLiquidityDeposit(argGetAddrOrNickname(args[0]), uint64(argToInt(args[1])), minPoints, uint64(argToInt(args[2])), getPassword(), !sim, fee))
 		},
 	}
 
 	txDexLiquidityWithdrawCmd = &cobra.Command{
-		Use:   "tx-dex-liquidity-withdraw <address or nickname> <percent> <chain-id> --fee=10000 --simulate=true",
+		Use:   "tx-dex-liquidity-withdraw <address or nickname> <percent> <chain-id> --min-amount=0 --min-counter-amount=0 --fee=10000 --simulate=true",
 		Short: "executes a dex liquidity withdraw - use the simulate flag to generate json only",
 		Args:  cobra.MinimumNArgs(3),
 		Run: func(cmd *cobra.Command, args []string) {
-			writeTxResultToConsole(client.TxDexLiquidityWithdraw(argGetAddrOrNickname(args[0]), argToInt(args[1]), uint64(argToInt(args[2])), getPassword(), !sim, fee))
+			writeTxResultToConsole(client.TxDexLiquidityWithdraw(argGetAddrOrNickname(args[0]), argToInt(args[1]), minAmount, minCounterAmount, uint64(argToInt(args[2])), getPassword(), !sim, fee))
 		},
 	}
 
 	txLockOrderCmd = &cobra.Command{
-		Use:   "tx-lock-order <address or nickname> <canopy-receive-address> <order-id> --fee=10000 --simulate=true",
-		Short: "lock an existing sell order - use the simulate flag to generate json only",
+		Use:   "tx-lock-order <address or nickname> <canopy-receive-address> <order-id> --amount=0 --fee=10000 --simulate=true",
+		Short: "lock an existing sell order (or a part of it) - use the simulate flag to generate json only",
 		Args:  cobra.MinimumNArgs(3),
 		Run: func(cmd *cobra.Command, args []string) {
-			writeTxResultToConsole(client.TxLockOrder(argGetAddrOrNickname(args[0]), argGetAddr(args[1]), args[2], getPassword(), !sim, fee))
+			writeTxResultToConsole(client.TxLockOrder(argGetAddrOrNickname(args[0]), argGetAddr(args[1]), args[2], lockAmount, getPassword(), !sim, fee))
 		},
 	}
 
@@ -345,6 +377,62 @@ var (
 		},
 	}
 
+	txSubmitProposalCmd = &cobra.Command{
+		Use:     "tx-submit-proposal <address or nickname> <deposit> <msg-type> <msg-json> --fee=10000 --simulate=true",
+		Short:   "submit a change parameter or dao transfer as an on-chain proposal - use the simulate flag to generate json only",
+		Example: `tx-submit-proposal dfd3c8dff19da7682f7fe5fde062c813b55c9eee 1000000000 daoTransfer '{"address":"dfd3c8dff19da7682f7fe5fde062c813b55c9eee","amount":1000}'`,
+		Args:    cobra.MinimumNArgs(4),
+		Run: func(cmd *cobra.Command, args []string) {
+			writeTxResultToConsole(client.TxSubmitProposal(argGetAddrOrNickname(args[0]), uint64(argToInt(args[1])), args[2], []byte(args[3]), getPassword(), !sim, fee))
+		},
+	}
+
+	txVoteCmd = &cobra.Command{
+		Use:     "tx-vote <validator address or nickname> <signer address or nickname> <proposal-id> <approve> --fee=10000 --simulate=true",
+		Short:   "vote to approve or reject an on-chain proposal with the validator's stake - use the simulate flag to generate json only",
+		Example: "tx-vote dfd3c8dff19da7682f7fe5fde062c813b55c9eee dfd3c8dff19da7682f7fe5fde062c813b55c9eee 6f1d3a1e2b7c9d0e4f5a6b7c8d9e0f1a2b3c4d5e true",
+		Args:    cobra.MinimumNArgs(4),
+		Run: func(cmd *cobra.Command, args []string) {
+			writeTxResultToConsole(client.TxVote(argGetAddrOrNickname(args[0]), argGetAddrOrNickname(args[1]), argStringToBytes(args[2]), argToBool(args[3]), getPassword(), !sim, fee))
+		},
+	}
+
+	txCreateMultisigAccountCmd = &cobra.Command{
+		Use:   "tx-create-multisig-account <address or nickname> <comma-separated-bls-public-keys> <threshold> --fee=10000 --simulate=true",
+		Short: "register a multisig account of member keys and a signature threshold - use the simulate flag to generate json only",
+		Args:  cobra.MinimumNArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			writeTxResultToConsole(client.TxCreateMultisigAccount(argGetAddrOrNickname(args[0]), strings.Split(args[1], ","), uint32(argToInt(args[2])), getPassword(), !sim, fee))
+		},
+	}
+
+	txMultisigProposeCmd = &cobra.Command{
+		Use:   "tx-multisig-propose <multisig-address> <msg-type> <msg-json> --fee=10000",
+		Short: "propose an unsigned transaction from a multisig account for the members to sign",
+		Args:  cobra.MinimumNArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			writeToConsole(client.TxMultisigPropose(args[0], args[1], []byte(args[2]), "", fee))
+		},
+	}
+
+	txMultisigSignCmd = &cobra.Command{
+		Use:   "tx-multisig-sign <address or nickname> <multisig-tx-json>",
+		Short: "add a member signature to a proposed multisig transaction",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			writeToConsole(client.TxMultisigSign(argGetAddrOrNickname(args[0]), []byte(args[1]), getPassword()))
+		},
+	}
+
+	txMultisigSubmitCmd = &cobra.Command{
+		Use:   "tx-multisig-submit <multisig-tx-json> --simulate=true",
+		Short: "aggregate the member signatures of a multisig transaction and submit it - use the simulate flag to generate json only",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			writeTxResultToConsole(client.TxMultisigSubmit([]byte(args[0]), !sim))
+		},
+	}
+
 	resourceUsageCmd = &cobra.Command{
 		Use:   "resource-usage",
 		Short: "get node resource usage",
@@ -453,7 +541,19 @@ var (
 
 func writeTxResultToConsole(hash *string, tx json.RawMessage, e lib.ErrorI) {
 	if sim {
-		writeToConsole(tx, e)
+		if e != nil {
+			l.Fatal(e.Error())
+		}
+		// execute the built transaction against the latest state on the node
+		transaction := new(lib.Transaction)
+		if err := lib.UnmarshalJSON(tx, transaction); err != nil {
+			l.Fatal(err.Error())
+		}
+		simulation, err := client.TransactionSimulate(transaction)
+		writeToConsole(struct {
+			Transaction json.RawMessage       `json:"transaction"`
+			Simulation  *fsm.SimulationResult `json:"simulation"`
+		}{tx, simulation}, err)
 	} else {
 		var hashString string
 		if hash != nil {
@@ -503,6 +603,15 @@ func argToCommittees(arg string) string {
 	return arg
 }
 
+// argToPath() converts a comma separated list of chain ids (ex. 2,1,3) to a dex path
+func argToPath(arg string) []uint64 {
+	path, err := rpc.StringToCommittees(arg)
+	if err != nil {
+		l.Fatal(err.Error())
+	}
+	return path
+}
+
 func getNickname() string {
 	if nick == "" {
 		fmt.Println("Enter nickname:")
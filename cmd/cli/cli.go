@@ -14,11 +14,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/canopy-network/canopy/bft"
 	"github.com/canopy-network/canopy/cmd/rpc"
 	"github.com/canopy-network/canopy/controller"
 	"github.com/canopy-network/canopy/fsm"
 	"github.com/canopy-network/canopy/lib"
 	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/canopy-network/canopy/p2p"
 	"github.com/canopy-network/canopy/store"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -44,6 +46,7 @@ var rootCmd = &cobra.Command{
 			config.AdminRPCUrl = adminURLFlag
 		}
 		client = rpc.NewClient(config.RPCUrl, config.AdminRPCUrl)
+		client.SetTxNonce(nonce)
 		return nil
 	},
 }
@@ -83,6 +86,73 @@ var newValidatorKeyCmd = &cobra.Command{
 	},
 }
 
+var highWaterMarkCmd = &cobra.Command{
+	Use:   "high-water-mark",
+	Short: "move the double-sign protection high-water mark between machines (the node must be stopped)",
+}
+
+var highWaterMarkExportCmd = &cobra.Command{
+	Use:     "export [file]",
+	Short:   "export the highest consensus view the validator key signed, to the file or console",
+	Args:    cobra.MaximumNArgs(1),
+	Example: "export hwm.json",
+	Run: func(cmd *cobra.Command, args []string) {
+		s, err := bft.NewHighWaterMarkStoreFromFile(config.DataDirPath)
+		if err != nil {
+			l.Fatal(err.Error())
+		}
+		mark := s.Mark()
+		if mark == nil {
+			l.Fatalf("No signing high-water mark in %s", config.DataDirPath)
+		}
+		if len(args) == 0 {
+			writeToConsole(mark, nil)
+			return
+		}
+		bz, err := lib.MarshalJSONIndent(mark)
+		if err != nil {
+			l.Fatal(err.Error())
+		}
+		if e := os.WriteFile(args[0], bz, 0600); e != nil {
+			l.Fatal(e.Error())
+		}
+		l.Infof("Exported the signing high-water mark %s to %s", mark.View.ToString(), args[0])
+	},
+}
+
+var highWaterMarkImportCmd = &cobra.Command{
+	Use:     "import <file>",
+	Short:   "raise the local high-water mark to an exported one (a lower mark is ignored)",
+	Args:    cobra.ExactArgs(1),
+	Example: "import hwm.json",
+	Run: func(cmd *cobra.Command, args []string) {
+		bz, e := os.ReadFile(args[0])
+		if e != nil {
+			l.Fatal(e.Error())
+		}
+		mark := new(bft.HighWaterMark)
+		if err := lib.UnmarshalJSON(bz, mark); err != nil {
+			l.Fatal(err.Error())
+		}
+		if mark.View == nil || mark.View.NetworkId != config.NetworkID || mark.View.ChainId != config.ChainId {
+			l.Fatalf("%s isn't a high-water mark for network %d chain %d", args[0], config.NetworkID, config.ChainId)
+		}
+		s, err := bft.NewHighWaterMarkStoreFromFile(config.DataDirPath)
+		if err != nil {
+			l.Fatal(err.Error())
+		}
+		raised, err := s.Import(mark)
+		if err != nil {
+			l.Fatal(err.Error())
+		}
+		if !raised {
+			l.Infof("Kept the local high-water mark %s as it isn't lower than %s", s.Mark().View.ToString(), mark.View.ToString())
+			return
+		}
+		l.Infof("Raised the local high-water mark to %s", mark.View.ToString())
+	},
+}
+
 var (
 	client, config, l                             = &rpc.Client{}, lib.Config{}, lib.LoggerI(nil)
 	DataDir, validatorKey                         = "", crypto.PrivateKeyI(nil)
@@ -115,6 +185,9 @@ func init() {
 	rootCmd.AddCommand(adminCmd)
 	rootCmd.AddCommand(autoCompleteCmd)
 	rootCmd.AddCommand(newValidatorKeyCmd)
+	rootCmd.AddCommand(highWaterMarkCmd)
+	highWaterMarkCmd.AddCommand(highWaterMarkExportCmd)
+	highWaterMarkCmd.AddCommand(highWaterMarkImportCmd)
 	autoCompleteCmd.AddCommand(generateCompleteCmd)
 	autoCompleteCmd.AddCommand(autoCompleteInstallCmd)
 	registerPersistentFlags(rootCmd.PersistentFlags())
@@ -256,10 +329,15 @@ func InitializeDataDirectory(dataDirPath string, log lib.LoggerI) (c lib.Config,
 			log.Fatal(err.Error())
 		}
 	}
-	// make the private key file if missing
+	// load the config object
+	c, err := lib.NewConfigFromFile(configFilePath)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	// make the private key file if missing (a remote signer holds the key elsewhere)
 	privateValKeyPath := filepath.Join(dataDirPath, lib.ValKeyPath)
-	if _, err := os.Stat(privateValKeyPath); errors.Is(err, os.ErrNotExist) {
-		if err := WriteValidatorKeyToFile(dataDirPath, log); err != nil {
+	if _, err = os.Stat(privateValKeyPath); errors.Is(err, os.ErrNotExist) && c.RemoteSigner.Address == "" {
+		if err = WriteValidatorKeyToFile(dataDirPath, log); err != nil {
 			log.Fatal(err.Error())
 		}
 	}
@@ -293,7 +371,11 @@ func InitializeDataDirectory(dataDirPath string, log lib.LoggerI) (c lib.Config,
 		}
 	}
 	// load the private key object
-	privateValKey, err := crypto.NewBLS12381PrivateKeyFromFile(privateValKeyPath)
+	if c.RemoteSigner.Address != "" {
+		privateValKey, err = NewRemoteSigner(dataDirPath, c, log)
+	} else {
+		privateValKey, err = crypto.NewBLS12381PrivateKeyFromFile(privateValKeyPath)
+	}
 	if err != nil {
 		log.Fatal(err.Error())
 	}
@@ -324,11 +406,6 @@ func InitializeDataDirectory(dataDirPath string, log lib.LoggerI) (c lib.Config,
 		log.Infof("Creating %s file", lib.GenesisFilePath)
 		WriteDefaultGenesisFile(privateValKey, genesisFilePath)
 	}
-	// load the config object
-	c, err = lib.NewConfigFromFile(configFilePath)
-	if err != nil {
-		log.Fatal(err.Error())
-	}
 	// if the external address is passed as a flag, it takes precedence over the file config
 	if externalAddressFlag != "" {
 		c.ExternalAddress = externalAddressFlag
@@ -338,6 +415,34 @@ func InitializeDataDirectory(dataDirPath string, log lib.LoggerI) (c lib.Config,
 	return
 }
 
+// NewRemoteSigner() creates the validator key of a remote signer, authenticating with the client key of the data directory
+func NewRemoteSigner(dataDirPath string, c lib.Config, log lib.LoggerI) (crypto.PrivateKeyI, error) {
+	// make the client key file if missing
+	clientKeyPath := filepath.Join(dataDirPath, lib.RemoteSignerClientKeyPath)
+	if _, err := os.Stat(clientKeyPath); errors.Is(err, os.ErrNotExist) {
+		log.Infof("Creating %s file", lib.RemoteSignerClientKeyPath)
+		clientKey, _ := crypto.NewEd25519PrivateKey()
+		if err = crypto.PrivateKeyToFile(clientKey, clientKeyPath); err != nil {
+			return nil, err
+		}
+	}
+	// load the client key
+	jsonBytes, err := os.ReadFile(clientKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	clientKey := new(crypto.ED25519PrivateKey)
+	if err = json.Unmarshal(jsonBytes, clientKey); err != nil {
+		return nil, err
+	}
+	log.Infof("Using remote signer %s with client public key %s", c.RemoteSigner.Address, clientKey.PublicKey().String())
+	remoteSigner, e := p2p.NewRemoteSigner(c, clientKey, log)
+	if e != nil {
+		return nil, e
+	}
+	return remoteSigner, nil
+}
+
 func WriteDefaultGenesisFile(validatorPrivateKey crypto.PrivateKeyI, genesisFilePath string) {
 	consPubKey := validatorPrivateKey.PublicKey()
 	addr := consPubKey.Address()
@@ -1,9 +1,11 @@
 package cli
 
 import (
+	"bytes"
 	"strconv"
 	"strings"
 
+	"github.com/canopy-network/canopy/cmd/rpc"
 	"github.com/canopy-network/canopy/lib"
 	"github.com/spf13/cobra"
 )
@@ -29,6 +31,9 @@ func init() {
 	queryCmd.AddCommand(heightCmd)
 	queryCmd.AddCommand(accountCmd)
 	queryCmd.AddCommand(accountsCmd)
+	queryCmd.AddCommand(multisigAccountCmd)
+	queryCmd.AddCommand(accountNonceCmd)
+	queryCmd.AddCommand(govProposalsCmd)
 	queryCmd.AddCommand(poolCmd)
 	queryCmd.AddCommand(poolsCmd)
 	queryCmd.AddCommand(validatorCmd)
@@ -69,6 +74,14 @@ func init() {
 	queryCmd.AddCommand(dexPriceCmd)
 	queryCmd.AddCommand(dexBatchCmd)
 	queryCmd.AddCommand(nextDexBatchCmd)
+	queryCmd.AddCommand(dexOrdersCmd)
+	queryCmd.AddCommand(dexTWAPCmd)
+	queryCmd.AddCommand(dexQuoteCmd)
+	queryCmd.AddCommand(dexPositionCmd)
+	queryCmd.AddCommand(dexHistoryCmd)
+	queryCmd.AddCommand(dexBatchStatusCmd)
+	queryCmd.AddCommand(proofCmd)
+	proofCmd.Flags().StringVar(&trustedStateRoot, "state-root", "", "trusted state root to verify against; defaults to the root in the node's block header")
 }
 
 var (
@@ -89,6 +102,32 @@ var (
 		},
 	}
 
+	multisigAccountCmd = &cobra.Command{
+		Use:   "multisig-account <address> --height=1",
+		Short: "query the members and threshold of a multisig account",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			writeToConsole(client.MultisigAccount(height, args[0]))
+		},
+	}
+
+	accountNonceCmd = &cobra.Command{
+		Use:   "account-nonce <address>",
+		Short: "query the nonce floor of an account and the next nonce to use with --nonce",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			writeToConsole(client.AccountNonce(height, args[0]))
+		},
+	}
+
+	govProposalsCmd = &cobra.Command{
+		Use:   "gov-proposals --height=1",
+		Short: "query all on-chain governance proposals and their tallies",
+		Run: func(cmd *cobra.Command, args []string) {
+			writeToConsole(client.GovProposals(height))
+		},
+	}
+
 	accountsCmd = &cobra.Command{
 		Use:   "accounts --height=1 --per-page=10 --page-number=1",
 		Short: "query all accounts on the blockchain",
@@ -321,11 +360,16 @@ var (
 	}
 
 	pendingTxsCmd = &cobra.Command{
-		Use:   "pending-txs --per-page=10 --page-number=1",
-		Short: "query a transactions in the local mempool but not yet included in a block",
+		Use:   "pending-txs [sender] --per-page=10 --page-number=1",
+		Short: "query transactions in the local mempool but not yet included in a block, optionally of a single sender",
+		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			_, p := getPaginatedArgs()
-			writeToConsole(client.Pending(p))
+			sender := ""
+			if len(args) == 1 {
+				sender = args[0]
+			}
+			writeToConsole(client.Pending(sender, p))
 		},
 	}
 
@@ -426,6 +470,20 @@ var (
 		},
 	}
 
+	dexBatchStatusCmd = &cobra.Command{
+		Use:   "dex-batch-status <chain-id> --height=1",
+		Short: "query why the dex batch with a chain is stuck and when the liveness fallback fires",
+		Long:  "query the dex batch pipeline status with a chain (or every chain if 0); how long the batch has been locked, when the liveness fallback fires and the recorded reason receipts were skipped",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if chainId := uint64(argToInt(args[0])); chainId != 0 {
+				writeToConsole(client.DexBatchStatus(height, chainId))
+				return
+			}
+			writeToConsole(client.DexBatchStatuses(height))
+		},
+	}
+
 	dexBatchCmd = &cobra.Command{
 		Use:   "dex-batch <chain-id> <with-points> --height=1",
 		Short: "query the locked dex batch at a certain height",
@@ -445,8 +503,135 @@ var (
 			writeToConsole(client.NextDexBatch(height, uint64(argToInt(args[0])), argToBool(args[1])))
 		},
 	}
+
+	dexOrdersCmd = &cobra.Command{
+		Use:   "dex-orders [address] --committee=1 --height=1 --per-page=10 --page-number=1",
+		Short: "query the open dex limit orders (locked or resting in the next batch), optionally of a single seller",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			h, p := getPaginatedArgs()
+			address := ""
+			if len(args) == 1 {
+				address = args[0]
+			}
+			writeToConsole(client.DexOrders(h, committee, address, p))
+		},
+	}
+
+	dexTWAPCmd = &cobra.Command{
+		Use:   "dex-twap <chain-id> <start-height> --height=1",
+		Short: "query the time weighted average dex price from the start height to a certain height",
+		Long:  "query the time weighted average dex price from the start height to a certain height; unlike dex-price it can't be moved within a single batch",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			writeToConsole(client.DexTWAP(height, uint64(argToInt(args[1])), uint64(argToInt(args[0]))))
+		},
+	}
+
+	dexQuoteCmd = &cobra.Command{
+		Use:   "dex-quote <amount> <path> --height=1",
+		Short: "quote the expected output of a dex swap along a comma separated path of chain ids (ex. 2,1,3)",
+		Long:  "quote the expected output of a dex swap along a comma separated path of chain ids (ex. 2,1,3) with the current pool sizes; multi-hop routes are quoted by the root chain",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			writeToConsole(client.DexQuote(height, uint64(argToInt(args[0])), argToPath(args[1])))
+		},
+	}
+
+	dexPositionCmd = &cobra.Command{
+		Use:   "dex-position <address> --committee=1 --height=1",
+		Short: "query the liquidity provider position of an address; redeemable amounts, fees earned and impermanent loss",
+		Long:  "query the liquidity provider position of an address in the pool of the committee (or every pool if 0); redeemable amounts, fees earned and impermanent loss against the cost basis",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if committee == 0 {
+				writeToConsole(client.DexPositions(height, args[0]))
+				return
+			}
+			writeToConsole(client.DexPosition(height, committee, args[0]))
+		},
+	}
+
+	dexHistoryCmd = &cobra.Command{
+		Use:   "dex-history <chain-id> <resolution> [start-time] [end-time] --per-page=100",
+		Short: "query the OHLCV history of a dex pool at a resolution (seconds) between unix times",
+		Long:  "query the OHLCV history (price, volume and liquidity changes) of the dex pool of a counter chain; one bucket per resolution (seconds) between the unix start and end times, up to per-page buckets",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			var startTime, endTime uint64
+			if len(args) > 2 {
+				startTime = uint64(argToInt(args[2]))
+			}
+			if len(args) > 3 {
+				endTime = uint64(argToInt(args[3]))
+			}
+			writeToConsole(client.DexHistory(uint64(argToInt(args[0])), uint64(argToInt(args[1])), startTime, endTime, perPage))
+		},
+	}
+
+	proofCmd = &cobra.Command{
+		Use:   "proof <account|validator|order|key> <selector> --height=1 --committee=1 --state-root=<hex>",
+		Short: "query and locally verify a merkle proof of a state value",
+		Long:  "query a merkle proof of an account, validator, sell order or raw state key against the state root committed in the block header at height, then verify it locally",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				p   *rpc.ProofResponse
+				err lib.ErrorI
+			)
+			switch args[0] {
+			case "account":
+				p, err = client.AccountProof(height, args[1])
+			case "validator":
+				p, err = client.ValidatorProof(height, args[1])
+			case "order":
+				p, err = client.OrderProof(height, args[1], committee)
+			case "key":
+				key, e := lib.StringToBytes(args[1])
+				if e != nil {
+					l.Fatal(e.Error())
+				}
+				p, err = client.Proof(height, key)
+			default:
+				l.Fatalf("unknown proof type %s, expected account, validator, order or key", args[0])
+			}
+			if err != nil {
+				l.Fatal(err.Error())
+			}
+			writeToConsole(verifyProof(p))
+		},
+	}
 )
 
+// trustedStateRoot is an optional state root the proof command verifies against
+var trustedStateRoot = ""
+
+// verifyProofResult is the console output of a locally verified proof
+type verifyProofResult struct {
+	*rpc.ProofResponse
+	Verified bool `json:"verified"`
+}
+
+// verifyProof() checks a proof locally, optionally against a trusted state root
+func verifyProof(p *rpc.ProofResponse) (*verifyProofResult, error) {
+	// if a trusted root is provided, the node's block header must commit to it
+	if trustedStateRoot != "" {
+		root, err := lib.StringToBytes(trustedStateRoot)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(root, p.StateRoot) {
+			return &verifyProofResult{ProofResponse: p}, nil
+		}
+	}
+	// verify the proof nodes hash up to the state root
+	valid, err := p.Verify()
+	if err != nil {
+		return nil, err
+	}
+	return &verifyProofResult{ProofResponse: p, Verified: valid}, nil
+}
+
 func getPoolArgs(args []string) (h uint64, id uint64) {
 	h = height
 	id = uint64(argToInt(args[0]))
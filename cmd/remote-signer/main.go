@@ -0,0 +1,92 @@
+// remote-signer is a reference signer process for validator consensus keys. It holds
+// the validator key and signs votes, proposals and VRFs for the nodes it allows, refusing
+// any other payload and any consensus message that conflicts with its signing high-water mark.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/canopy-network/canopy/bft"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/canopy-network/canopy/p2p"
+)
+
+// main() executes the remote signer until a kill signal is received
+func main() {
+	// start the signer and print any returned error
+	server, err := run(os.Args[1:], os.Stderr)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "remote-signer: %v\n", err)
+		os.Exit(1)
+	}
+	// block until a kill signal is received
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGABRT)
+	<-stop
+	server.Stop()
+}
+
+// run() parses the command-line arguments and starts the signer
+func run(args []string, stderr io.Writer) (*p2p.RemoteSignerServer, error) {
+	// define and parse the flags
+	flags := flag.NewFlagSet("remote-signer", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, `Usage:
+  remote-signer --key-file validator_key.json --allow CLIENT_PUBLIC_KEY[,...] [--listen ADDRESS] [--network-id ID] [--chain-id ID] [--data-dir DIR]
+
+The client public key of a node is logged at startup and is the public key of the
+'remote_signer_client_key.json' file in its data directory.`)
+		flags.PrintDefaults()
+	}
+	keyFile := flags.String("key-file", "", "the validator key file (the format of validator_key.json)")
+	allow := flags.String("allow", "", "comma separated hex public keys of the nodes allowed to request signatures")
+	listen := flags.String("listen", "127.0.0.1:9010", "the tcp address to listen on")
+	networkId := flags.Uint64("network-id", lib.CanopyMainnetNetworkId, "the network id the signer signs for")
+	chainId := flags.Uint64("chain-id", lib.CanopyChainId, "the chain id the signer signs for")
+	dataDir := flags.String("data-dir", "", "the directory of the signing high-water mark (default: the directory of the key file)")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	// validate the required flags
+	if *keyFile == "" || *allow == "" || flags.NArg() != 0 {
+		return nil, fmt.Errorf("remote-signer requires --key-file and --allow")
+	}
+	// load the validator key
+	privateKey, err := crypto.NewBLS12381PrivateKeyFromFile(*keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+	// parse the allowed client public keys
+	var allowed [][]byte
+	for _, s := range strings.Split(*allow, ",") {
+		publicKey, e := crypto.NewPublicKeyFromString(strings.TrimSpace(s))
+		if e != nil {
+			return nil, fmt.Errorf("invalid client public key %q: %w", s, e)
+		}
+		allowed = append(allowed, publicKey.Bytes())
+	}
+	// load the signing high-water mark that protects the key from double signing
+	if *dataDir == "" {
+		*dataDir = filepath.Dir(*keyFile)
+	}
+	highWaterMark, err := bft.NewHighWaterMarkStoreFromFile(*dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("load high-water mark: %w", err)
+	}
+	// start serving
+	policy := bft.NewRemoteSignPolicy(highWaterMark, *networkId, *chainId)
+	server := p2p.NewRemoteSignerServer(privateKey, *networkId, *chainId, allowed, policy, lib.NewDefaultLogger())
+	if err = server.Listen(*listen); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/canopy-network/canopy/bft"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/canopy-network/canopy/p2p"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	// write a validator key file and create a node client key
+	directory := t.TempDir()
+	validatorKey, err := crypto.NewBLS12381PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(directory, lib.ValKeyPath)
+	if err = crypto.PrivateKeyToFile(validatorKey, keyFile); err != nil {
+		t.Fatal(err)
+	}
+	clientKey, err := crypto.NewEd25519PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the required flags are enforced
+	if _, err = run([]string{"--key-file", keyFile}, io.Discard); err == nil {
+		t.Fatal("expected an error without --allow")
+	}
+	if _, err = run([]string{"--key-file", keyFile, "--allow", "not-a-key", "--listen", "127.0.0.1:0"}, io.Discard); err == nil {
+		t.Fatal("expected an error for an invalid client public key")
+	}
+	// start the signer
+	server, err := run([]string{"--key-file", keyFile, "--allow", clientKey.PublicKey().String(), "--listen", "127.0.0.1:0"}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+	// sign through a node configured for the signer
+	config := lib.DefaultConfig()
+	config.RemoteSigner.Address, config.RemoteSigner.PublicKey = server.Addr().String(), validatorKey.PublicKey().String()
+	signer, err := p2p.NewRemoteSigner(config, clientKey, lib.NewDefaultLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	vote := func(blockHash string) []byte {
+		view := &lib.View{NetworkId: config.NetworkID, ChainId: config.ChainId, Height: 1, RootHeight: 1, Phase: bft.ProposeVote}
+		return (&bft.Message{Qc: &bft.QC{Header: view, BlockHash: []byte(blockHash)}}).SignBytes()
+	}
+	msg := vote("block")
+	if !validatorKey.PublicKey().VerifyBytes(msg, signer.Sign(msg)) {
+		t.Fatal("expected a valid signature from the remote signer")
+	}
+	// the signer persists its high-water mark next to the key file and refuses a conflicting vote
+	if _, err = os.Stat(filepath.Join(directory, bft.HighWaterMarkFilePath)); err != nil {
+		t.Fatal(err)
+	}
+	if signer.Sign(vote("other block")) != nil {
+		t.Fatal("expected the signer to refuse a conflicting vote")
+	}
+	// a payload that isn't signed by a validator node is refused
+	if signer.Sign([]byte("vote")) != nil {
+		t.Fatal("expected the signer to refuse arbitrary bytes")
+	}
+}
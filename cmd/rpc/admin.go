@@ -293,6 +293,41 @@ func (s *Server) TransactionDAOTransfer(w http.ResponseWriter, r *http.Request,
 	})
 }
 
+// TransactionSubmitProposal submits a change parameter or dao transfer as an on-chain proposal
+func (s *Server) TransactionSubmitProposal(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Call the transaction handler with a callback that creates the transaction
+	s.txHandler(w, r, func(p crypto.PrivateKeyI, ptr *txRequest) (lib.TransactionI, error) {
+		// build the proposed message from its registered type and json
+		template, found := lib.RegisteredMessages[ptr.MsgType]
+		if !found {
+			return nil, lib.ErrUnknownMessageName(ptr.MsgType)
+		}
+		proposal := template.New()
+		if err := lib.UnmarshalJSON(ptr.Msg, proposal); err != nil {
+			return nil, err
+		}
+		// Retrieve the fee required for this type of transaction
+		if err := s.getFeeFromState(ptr, fsm.MessageSubmitProposalName); err != nil {
+			return nil, err
+		}
+		// Create and return the transaction to be sent
+		return fsm.NewSubmitProposalTx(p, proposal, ptr.Amount, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight(), ptr.Memo)
+	})
+}
+
+// TransactionVote casts a validator's vote on an on-chain proposal
+func (s *Server) TransactionVote(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Call the transaction handler with a callback that creates the transaction
+	s.txHandler(w, r, func(p crypto.PrivateKeyI, ptr *txRequest) (lib.TransactionI, error) {
+		// Retrieve the fee required for this type of transaction
+		if err := s.getFeeFromState(ptr, fsm.MessageVoteName); err != nil {
+			return nil, err
+		}
+		// Create and return the transaction to be sent
+		return fsm.NewVoteTx(p, crypto.NewAddress(ptr.Address), ptr.ProposalId, ptr.Approve, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight(), ptr.Memo)
+	})
+}
+
 // TransactionSubsidy subsidizes the reward pool of a committee
 func (s *Server) TransactionSubsidy(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	// Call the transaction handler with a callback that creates the transaction
@@ -383,7 +418,20 @@ func (s *Server) TransactionDexLimitOrder(w http.ResponseWriter, r *http.Request
 			return nil, err
 		}
 		// Create and return the transaction to be sent
-		return fsm.NewDexLimitOrder(p, ptr.Amount, ptr.ReceiveAmount, chainId, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight(), ptr.Memo)
+		return fsm.NewDexLimitOrder(p, ptr.Amount, ptr.ReceiveAmount, chainId, ptr.ExpiryHeight, ptr.PartialFill, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight(), ptr.Memo)
+	})
+}
+
+// TransactionDexRoutedSwap creates a new (multi-hop) dex routed swap for the 'next batch'
+func (s *Server) TransactionDexRoutedSwap(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Call the transaction handler with a callback that creates the transaction
+	s.txHandler(w, r, func(p crypto.PrivateKeyI, ptr *txRequest) (lib.TransactionI, error) {
+		// Retrieve the fee required for this type of transaction
+		if err := s.getFeeFromState(ptr, fsm.MessageDexRoutedSwapName); err != nil {
+			return nil, err
+		}
+		// Create and return the transaction to be sent
+		return fsm.NewDexRoutedSwap(p, ptr.Amount, ptr.ReceiveAmount, ptr.Path, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight(), ptr.Memo)
 	})
 }
 
@@ -402,7 +450,7 @@ func (s *Server) TransactionDexLiquidityDeposit(w http.ResponseWriter, r *http.R
 			return nil, err
 		}
 		// Create and return the transaction to be sent
-		return fsm.NewDexLiquidityDeposit(p, ptr.Amount, chainId, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight(), ptr.Memo)
+		return fsm.NewDexLiquidityDeposit(p, ptr.Amount, ptr.MinPoints, chainId, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight(), ptr.Memo)
 	})
 }
 
@@ -421,7 +469,7 @@ func (s *Server) TransactionDexLiquidityWithdraw(w http.ResponseWriter, r *http.
 			return nil, err
 		}
 		// Create and return the transaction to be sent
-		return fsm.NewDexLiquidityWithdraw(p, ptr.Percent, chainId, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight(), ptr.Memo)
+		return fsm.NewDexLiquidityWithdraw(p, ptr.Percent, ptr.MinAmount, ptr.MinCounterAmount, chainId, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight(), ptr.Memo)
 	})
 }
 
@@ -439,7 +487,7 @@ func (s *Server) TransactionLockOrder(w http.ResponseWriter, r *http.Request, _
 			return nil, err
 		}
 		// Create and return the transaction to be sent
-		return fsm.NewLockOrderTx(p, lib.LockOrder{OrderId: oId, ChainId: s.config.ChainId, BuyerSendAddress: p.PublicKey().Address().Bytes(), BuyerReceiveAddress: ptr.ReceiveAddress}, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight())
+		return fsm.NewLockOrderTx(p, lib.LockOrder{OrderId: oId, ChainId: s.config.ChainId, BuyerSendAddress: p.PublicKey().Address().Bytes(), BuyerReceiveAddress: ptr.ReceiveAddress, Amount: ptr.Amount}, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight())
 	})
 }
 
@@ -466,11 +514,17 @@ func (s *Server) TransactionCloseOrder(w http.ResponseWriter, r *http.Request, _
 		if err != nil {
 			return nil, err
 		}
+		// the buyer either locked the whole order or a part of it
+		deadline, requestedAmount := order.BuyerChainDeadline, order.RequestedAmount
 		if !bytes.Equal(order.BuyerSendAddress, ptr.Address) {
-			return nil, fmt.Errorf("not buyer")
+			lock := order.GetLock(ptr.Address)
+			if lock == nil {
+				return nil, fmt.Errorf("not buyer")
+			}
+			deadline, requestedAmount = lock.BuyerChainDeadline, lock.RequestedAmount
 		}
 		// Don't allow an order to pass that is less than 10 blocks of the lock deadline
-		if int64(order.BuyerChainDeadline)-int64(s.controller.ChainHeight()) < 10 {
+		if int64(deadline)-int64(s.controller.ChainHeight()) < 10 {
 			return nil, fmt.Errorf("too close to buyer chain deadline")
 		}
 		// convert the order id to bytes
@@ -481,7 +535,7 @@ func (s *Server) TransactionCloseOrder(w http.ResponseWriter, r *http.Request, _
 		// Create the close order structure
 		co := lib.CloseOrder{OrderId: oId, ChainId: s.config.ChainId, CloseOrder: true}
 		// Exit with the new CloseOrderTx
-		return fsm.NewCloseOrderTx(p, co, crypto.NewAddress(order.SellerReceiveAddress), order.RequestedAmount, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight())
+		return fsm.NewCloseOrderTx(p, co, crypto.NewAddress(order.SellerReceiveAddress), requestedAmount, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight())
 	})
 }
 
@@ -511,6 +565,141 @@ func (s *Server) TransactionVotePoll(w http.ResponseWriter, r *http.Request, _ h
 	})
 }
 
+// TransactionCreateMultisigAccount registers a multisig account policy of member keys and a signature threshold
+func (s *Server) TransactionCreateMultisigAccount(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Call the transaction handler with a callback that creates the transaction
+	s.txHandler(w, r, func(p crypto.PrivateKeyI, ptr *txRequest) (lib.TransactionI, error) {
+		// Retrieve the fee required for this type of transaction
+		if err := s.getFeeFromState(ptr, fsm.MessageCreateMultisigAccountName); err != nil {
+			return nil, err
+		}
+		publicKeys := make([][]byte, len(ptr.PublicKeys))
+		for i, pk := range ptr.PublicKeys {
+			publicKeys[i] = pk
+		}
+		// Create and return the transaction to be sent
+		return fsm.NewCreateMultisigAccountTx(p, publicKeys, ptr.Threshold, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight(), ptr.Memo)
+	})
+}
+
+// TransactionMultisigPropose creates an unsigned transaction for a multisig account to collect member signatures on
+func (s *Server) TransactionMultisigPropose(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ptr := new(txRequest)
+	if ok := unmarshal(w, r, ptr); !ok {
+		return
+	}
+	// build the proposed message from its registered type and json
+	template, found := lib.RegisteredMessages[ptr.MsgType]
+	if !found {
+		write(w, lib.ErrUnknownMessageName(ptr.MsgType), http.StatusBadRequest)
+		return
+	}
+	msg := template.New()
+	if err := lib.UnmarshalJSON(ptr.Msg, msg); err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := msg.Check(); err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	// Retrieve the fee required for this type of transaction
+	if err := s.getFeeFromState(ptr, ptr.MsgType); err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	multisigTx := new(MultisigTx)
+	if err := s.readOnlyState(0, func(state *fsm.StateMachine) (err lib.ErrorI) {
+		// load the multisig policy the members will sign against
+		if multisigTx.Account, err = state.GetMultisigAccount(crypto.NewAddress(ptr.Address)); err != nil {
+			return
+		}
+		// ensure the multisig account is allowed to sign the proposed message
+		signers, err := state.GetAuthorizedSignersFor(msg)
+		if err != nil {
+			return
+		}
+		for _, signer := range signers {
+			if bytes.Equal(signer, ptr.Address) {
+				return nil
+			}
+		}
+		return fsm.ErrUnauthorizedTx()
+	}); err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	// create the unsigned transaction
+	tx, err := fsm.NewUnsignedTransaction(msg, s.config.NetworkID, s.config.ChainId, ptr.Fee, s.controller.ChainHeight(), ptr.Memo)
+	if err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	multisigTx.Transaction = tx
+	write(w, multisigTx, http.StatusOK)
+}
+
+// TransactionMultisigSign adds a member's partial signature to a proposed multisig transaction
+func (s *Server) TransactionMultisigSign(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ptr := new(txRequest)
+	if ok := unmarshal(w, r, ptr); !ok {
+		return
+	}
+	if ptr.MultisigTx == nil {
+		write(w, lib.ErrEmptyTransaction(), http.StatusBadRequest)
+		return
+	}
+	keystore, ok := newKeystore(w, s.config.DataDirPath)
+	if !ok {
+		return
+	}
+	// resolve the member address from the nickname if needed
+	getAddressFromNickname(ptr, keystore)
+	privateKey, err := keystore.GetKey(ptr.Address, ptr.Password)
+	if err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	// sign the transaction with the member key
+	if err = ptr.MultisigTx.AddSignature(privateKey); err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	write(w, ptr.MultisigTx, http.StatusOK)
+}
+
+// TransactionMultisigSubmit aggregates the partial signatures of a multisig transaction and optionally submits it
+func (s *Server) TransactionMultisigSubmit(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ptr := new(txRequest)
+	if ok := unmarshal(w, r, ptr); !ok {
+		return
+	}
+	if ptr.MultisigTx == nil {
+		write(w, lib.ErrEmptyTransaction(), http.StatusBadRequest)
+		return
+	}
+	// combine the member signatures into the multisig signature
+	tx, err := ptr.MultisigTx.Aggregate()
+	if err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	// Check if the transaction should be submitted to the network.
+	if ptr.Submit {
+		s.submitTxs(w, []lib.TransactionI{tx})
+		return
+	}
+	// Marshal the transaction into JSON and write it to the response
+	bz, e := lib.MarshalJSONIndent(tx)
+	if e != nil {
+		write(w, e, http.StatusBadRequest)
+		return
+	}
+	if _, er := w.Write(bz); er != nil {
+		s.logger.Error(er.Error())
+	}
+}
+
 // ConsensusInfo retrieves node consensus information
 func (s *Server) ConsensusInfo(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	if err := r.ParseForm(); err != nil {
@@ -593,6 +782,13 @@ func (s *Server) txHandler(w http.ResponseWriter, r *http.Request, callback func
 		write(w, err, http.StatusBadRequest)
 		return
 	}
+	// Opt the transaction into account nonce replay protection if a nonce is requested.
+	if ptr.Nonce != 0 {
+		if err = setTxNonce(p, ptr.Nonce, privateKey); err != nil {
+			write(w, err, http.StatusBadRequest)
+			return
+		}
+	}
 	// Check if the transaction should be submitted to the network.
 	if ptr.Submit {
 		// Submit the transaction for processing.
@@ -611,6 +807,19 @@ func (s *Server) txHandler(w http.ResponseWriter, r *http.Request, callback func
 	}
 }
 
+// setTxNonce() sets the account nonce of a transaction signed by the private key and re-signs it
+func setTxNonce(p lib.TransactionI, nonce uint64, privateKey crypto.PrivateKeyI) lib.ErrorI {
+	tx, ok := p.(*lib.Transaction)
+	if !ok || tx.Signature == nil || !bytes.Equal(tx.Signature.PublicKey, privateKey.PublicKey().Bytes()) {
+		return lib.NewError(lib.CodeInvalidArgument, lib.RPCModule, "a nonce may only be set on transactions signed by the keystore key")
+	}
+	if lib.IsRLPMemo(tx.Memo) {
+		return lib.NewError(lib.CodeInvalidArgument, lib.RPCModule, "the memo is reserved for RLP transactions")
+	}
+	tx.Nonce = nonce
+	return tx.Sign(privateKey)
+}
+
 // AddVote adds a vote to a proposal
 func (s *Server) AddVote(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	// Initialize a map to hold government proposals.
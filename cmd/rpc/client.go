@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/canopy-network/canopy/fsm"
@@ -13,14 +14,19 @@ import (
 	"github.com/canopy-network/canopy/controller"
 	"github.com/canopy-network/canopy/lib"
 	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/canopy-network/canopy/lib/lightclient"
 	"github.com/canopy-network/canopy/p2p"
 	"google.golang.org/protobuf/proto"
 )
 
+// the rpc client serves certificates, validator sets and state proofs to light clients
+var _ lightclient.RPCI = &Client{}
+
 type Client struct {
 	rpcURL      string
 	adminRpcUrl string
 	client      http.Client
+	txNonce     uint64
 }
 
 func NewClient(rpcURL, adminRPCUrl string) *Client {
@@ -110,9 +116,9 @@ func (c *Client) EventsByChainId(id uint64, params lib.PageParams) (p *lib.Page,
 	return
 }
 
-func (c *Client) Pending(params lib.PageParams) (p *lib.Page, err lib.ErrorI) {
+func (c *Client) Pending(sender string, params lib.PageParams) (p *lib.Page, err lib.ErrorI) {
 	p = new(lib.Page)
-	err = c.paginatedAddrRequest(PendingRouteName, "", params, p)
+	err = c.paginatedAddrRequest(PendingRouteName, sender, params, p)
 	return
 }
 
@@ -183,6 +189,23 @@ func (c *Client) Account(height uint64, address string) (p *AccountView, err lib
 	return
 }
 
+func (c *Client) MultisigAccount(height uint64, address string) (p *fsm.MultisigAccount, err lib.ErrorI) {
+	p = new(fsm.MultisigAccount)
+	err = c.heightAndAddressRequest(MultisigAccountRouteName, height, address, p)
+	return
+}
+
+func (c *Client) AccountNonce(height uint64, address string) (p *AccountNonceView, err lib.ErrorI) {
+	p = new(AccountNonceView)
+	err = c.heightAndAddressRequest(AccountNonceRouteName, height, address, p)
+	return
+}
+
+func (c *Client) GovProposals(height uint64) (p []*fsm.Proposal, err lib.ErrorI) {
+	err = c.heightRequest(GovProposalsRouteName, height, &p)
+	return
+}
+
 func (c *Client) Accounts(height uint64, params lib.PageParams) (p *lib.Page, err lib.ErrorI) {
 	p = new(lib.Page)
 	err = c.paginatedHeightRequest(AccountsRouteName, height, params, p)
@@ -279,6 +302,20 @@ func (c *Client) DexPrice(height, chainId uint64) (p *lib.DexPrice, err lib.Erro
 	return
 }
 
+// DexBatchStatus() returns the batch pipeline status with a counter chain; which side of the pair is stuck and why
+func (c *Client) DexBatchStatus(height, chainId uint64) (p *lib.DexBatchStatus, err lib.ErrorI) {
+	p = new(lib.DexBatchStatus)
+	err = c.heightAndIdRequest(DexBatchStatusRouteName, height, chainId, p)
+	return
+}
+
+// DexBatchStatuses() returns the batch pipeline status with every counter chain
+func (c *Client) DexBatchStatuses(height uint64) (p []*lib.DexBatchStatus, err lib.ErrorI) {
+	p = make([]*lib.DexBatchStatus, 0)
+	err = c.heightAndIdRequest(DexBatchStatusRouteName, height, 0, &p)
+	return
+}
+
 func (c *Client) DexBatch(height, chainId uint64, withPoints bool) (p *lib.DexBatch, err lib.ErrorI) {
 	p = new(lib.DexBatch)
 	err = c.heightIdAndPointsRequest(DexBatchRouteName, height, chainId, withPoints, p)
@@ -291,6 +328,102 @@ func (c *Client) NextDexBatch(height, chainId uint64, withPoints bool) (p *lib.D
 	return
 }
 
+// DexOrders() returns a page of the open dex limit orders, optionally filtered by counter chain and seller address
+func (c *Client) DexOrders(height, chainId uint64, address string, params lib.PageParams) (p *lib.Page, err lib.ErrorI) {
+	var addr []byte
+	if address != "" {
+		if addr, err = lib.StringToBytes(address); err != nil {
+			return nil, err
+		}
+	}
+	bz, err := lib.MarshalJSON(dexOrdersRequest{
+		Committee:      chainId,
+		addressRequest: addressRequest{addr},
+		heightRequest:  heightRequest{height},
+		PageParams:     params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p = new(lib.Page)
+	err = c.post(DexOrdersRouteName, bz, p)
+	return
+}
+
+// DexTWAP() returns the time weighted average dex price of a counter chain between startHeight and height (0 = latest)
+func (c *Client) DexTWAP(height, startHeight, chainId uint64) (p *lib.DexTWAP, err lib.ErrorI) {
+	bz, err := lib.MarshalJSON(dexTWAPRequest{
+		StartHeight:        startHeight,
+		heightAndIdRequest: heightAndIdRequest{heightRequest: heightRequest{height}, idRequest: idRequest{chainId}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	p = new(lib.DexTWAP)
+	err = c.post(DexTWAPRouteName, bz, p)
+	return
+}
+
+// DexHistory() returns up to limit OHLCV buckets of the pool of a counter chain at a resolution (seconds) that start
+// within [startTime, endTime] (unix seconds, 0 = no end)
+func (c *Client) DexHistory(chainId, resolution, startTime, endTime uint64, limit int) (p []*lib.DexCandle, err lib.ErrorI) {
+	bz, err := lib.MarshalJSON(dexHistoryRequest{
+		Resolution: resolution,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Limit:      limit,
+		idRequest:  idRequest{chainId},
+	})
+	if err != nil {
+		return nil, err
+	}
+	p = make([]*lib.DexCandle, 0)
+	err = c.post(DexHistoryRouteName, bz, &p)
+	return
+}
+
+// DexPosition() returns the liquidity provider position of an address in the pool of a counter chain
+func (c *Client) DexPosition(height, chainId uint64, address string) (p *lib.DexPosition, err lib.ErrorI) {
+	p = new(lib.DexPosition)
+	err = c.dexPositionRequest(height, chainId, address, p)
+	return
+}
+
+// DexPositions() returns the liquidity provider positions of an address in every pool it holds points in
+func (c *Client) DexPositions(height uint64, address string) (p []*lib.DexPosition, err lib.ErrorI) {
+	p = make([]*lib.DexPosition, 0)
+	err = c.dexPositionRequest(height, 0, address, &p)
+	return
+}
+
+// dexPositionRequest() posts a dex position request and populates the pointer
+func (c *Client) dexPositionRequest(height, chainId uint64, address string, ptr any) lib.ErrorI {
+	addr, err := lib.StringToBytes(address)
+	if err != nil {
+		return err
+	}
+	bz, err := lib.MarshalJSON(dexPositionRequest{
+		Committee:      chainId,
+		addressRequest: addressRequest{addr},
+		heightRequest:  heightRequest{height},
+	})
+	if err != nil {
+		return err
+	}
+	return c.post(DexPositionRouteName, bz, ptr)
+}
+
+// DexQuote() returns the expected output of swapping amount along a path of chains (ex. A, root, B) at height (0 = latest)
+func (c *Client) DexQuote(height, amount uint64, path []uint64) (p *lib.DexQuote, err lib.ErrorI) {
+	bz, err := lib.MarshalJSON(dexQuoteRequest{Amount: amount, Path: path, heightRequest: heightRequest{height}})
+	if err != nil {
+		return nil, err
+	}
+	p = new(lib.DexQuote)
+	err = c.post(DexQuoteRouteName, bz, p)
+	return
+}
+
 func (c *Client) LastProposers(height uint64) (p *lib.Proposers, err lib.ErrorI) {
 	p = new(lib.Proposers)
 	err = c.heightRequest(LastProposersRouteName, height, p)
@@ -309,6 +442,39 @@ func (c *Client) Checkpoint(height, id uint64) (p lib.HexBytes, err lib.ErrorI)
 	return
 }
 
+func (c *Client) Proof(height uint64, key lib.HexBytes) (p *ProofResponse, err lib.ErrorI) {
+	return c.proofRequest(proofRequest{Key: key, heightRequest: heightRequest{height}})
+}
+
+func (c *Client) AccountProof(height uint64, address string) (p *ProofResponse, err lib.ErrorI) {
+	addr, err := lib.StringToBytes(address)
+	if err != nil {
+		return nil, err
+	}
+	return c.proofRequest(proofRequest{Account: addr, heightRequest: heightRequest{height}})
+}
+
+func (c *Client) ValidatorProof(height uint64, address string) (p *ProofResponse, err lib.ErrorI) {
+	addr, err := lib.StringToBytes(address)
+	if err != nil {
+		return nil, err
+	}
+	return c.proofRequest(proofRequest{Validator: addr, heightRequest: heightRequest{height}})
+}
+
+func (c *Client) OrderProof(height uint64, orderId string, chainId uint64) (p *ProofResponse, err lib.ErrorI) {
+	return c.proofRequest(proofRequest{OrderId: orderId, Committee: chainId, heightRequest: heightRequest{height}})
+}
+
+// StateProof() returns the proof of a raw state key as a state entry; satisfies lightclient.RPCI
+func (c *Client) StateProof(height uint64, key lib.HexBytes) (*lib.StateEntry, lib.ErrorI) {
+	p, err := c.Proof(height, key)
+	if err != nil {
+		return nil, err
+	}
+	return &lib.StateEntry{Key: p.Key, Value: p.Value, Proof: p.Proof}, nil
+}
+
 func (c *Client) DoubleSigners(height uint64) (p *[]*lib.DoubleSigner, err lib.ErrorI) {
 	p = new([]*lib.DoubleSigner)
 	err = c.heightRequest(DoubleSignersRouteName, height, p)
@@ -421,6 +587,16 @@ func (c *Client) Transaction(tx lib.TransactionI) (hash *string, err lib.ErrorI)
 	return
 }
 
+func (c *Client) TransactionSimulate(tx lib.TransactionI) (result *fsm.SimulationResult, err lib.ErrorI) {
+	bz, err := lib.MarshalJSON(tx)
+	if err != nil {
+		return nil, err
+	}
+	result = new(fsm.SimulationResult)
+	err = c.post(TxSimulateRouteName, bz, result)
+	return
+}
+
 func (c *Client) Transactions(txs []lib.TransactionI) (hash *string, err lib.ErrorI) {
 	bz, err := lib.MarshalJSON(txs)
 	if err != nil {
@@ -658,6 +834,45 @@ func (c *Client) TxDaoTransfer(from AddrOrNickname, amt, startBlk, endBlk uint64
 	return c.transactionRequest(TxDAOTransferRouteName, txReq, submit)
 }
 
+func (c *Client) TxSubmitProposal(from AddrOrNickname, deposit uint64, msgType string, msg json.RawMessage,
+	pwd string, submit bool, optFee uint64) (hash *string, tx json.RawMessage, e lib.ErrorI) {
+	txReq := txSubmitProposal{
+		Fee:      optFee,
+		Amount:   deposit,
+		MsgType:  msgType,
+		Msg:      msg,
+		Submit:   submit,
+		Password: pwd,
+	}
+	var err lib.ErrorI
+	txReq.fromFields, err = getFrom(from.Address, from.Nickname)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.transactionRequest(TxSubmitProposalRouteName, txReq, submit)
+}
+
+func (c *Client) TxVote(voter, signer AddrOrNickname, proposalId lib.HexBytes, approve bool,
+	pwd string, submit bool, optFee uint64) (hash *string, tx json.RawMessage, e lib.ErrorI) {
+	txReq := txVote{
+		Fee:        optFee,
+		ProposalId: proposalId,
+		Approve:    approve,
+		Submit:     submit,
+		Password:   pwd,
+	}
+	var err lib.ErrorI
+	txReq.signerFields, err = getSigner(signer)
+	if err != nil {
+		return nil, nil, err
+	}
+	txReq.fromFields, err = getFrom(voter.Address, voter.Nickname)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.transactionRequest(TxVoteRouteName, txReq, submit)
+}
+
 func (c *Client) TxSubsidy(from AddrOrNickname, amt, chainId uint64, opCode string,
 	pwd string, submit bool, optFee uint64) (hash *string, tx json.RawMessage, e lib.ErrorI) {
 	txReq := txSubsidy{
@@ -746,12 +961,14 @@ func (c *Client) TxDeleteOrder(from AddrOrNickname, orderId string, chainId uint
 	return c.transactionRequest(TxDeleteOrderRouteName, txReq, submit)
 }
 
-func (c *Client) TxDexLimitOrder(from AddrOrNickname, amount, receiveAmount, chainId uint64,
+func (c *Client) TxDexLimitOrder(from AddrOrNickname, amount, receiveAmount, chainId, expiryHeight uint64, partialFill bool,
 	pwd string, submit bool, optFee uint64) (hash *string, tx json.RawMessage, e lib.ErrorI) {
 	txReq := txDexLimitOrder{
 		Fee:               optFee,
 		Amount:            amount,
 		ReceiveAmount:     receiveAmount,
+		ExpiryHeight:      expiryHeight,
+		PartialFill:       partialFill,
 		Submit:            submit,
 		Password:          pwd,
 		committeesRequest: committeesRequest{fmt.Sprintf("%d", chainId)},
@@ -765,11 +982,31 @@ func (c *Client) TxDexLimitOrder(from AddrOrNickname, amount, receiveAmount, cha
 	return c.transactionRequest(TxDexLimitOrderRouteName, txReq, submit)
 }
 
-func (c *Client) TxDexLiquidityDeposit(from AddrOrNickname, amount, chainId uint64,
+func (c *Client) TxDexRoutedSwap(from AddrOrNickname, amount, minimumOutput uint64, path []uint64,
+	pwd string, submit bool, optFee uint64) (hash *string, tx json.RawMessage, e lib.ErrorI) {
+	txReq := txDexRoutedSwap{
+		Fee:           optFee,
+		Amount:        amount,
+		ReceiveAmount: minimumOutput,
+		Path:          path,
+		Submit:        submit,
+		Password:      pwd,
+	}
+	var err lib.ErrorI
+	txReq.fromFields, err = getFrom(from.Address, from.Nickname)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.transactionRequest(TxDexRoutedSwapRouteName, txReq, submit)
+}
+
+func (c *Client) TxDexLiquidityDeposit(from AddrOrNickname, amount, minPoints, chainId uint64,
 	pwd string, submit bool, optFee uint64) (hash *string, tx json.RawMessage, e lib.ErrorI) {
 	txReq := txDexLiquidityDeposit{
 		Fee:               optFee,
 		Amount:            amount,
+		MinPoints:         minPoints,
 		Submit:            submit,
 		Password:          pwd,
 		committeesRequest: committeesRequest{fmt.Sprintf("%d", chainId)},
@@ -783,11 +1020,13 @@ func (c *Client) TxDexLiquidityDeposit(from AddrOrNickname, amount, chainId uint
 	return c.transactionRequest(TxDexLiquidityDepositRouteName, txReq, submit)
 }
 
-func (c *Client) TxDexLiquidityWithdraw(from AddrOrNickname, percent int, chainId uint64,
+func (c *Client) TxDexLiquidityWithdraw(from AddrOrNickname, percent int, minAmount, minCounterAmount, chainId uint64,
 	pwd string, submit bool, optFee uint64) (hash *string, tx json.RawMessage, e lib.ErrorI) {
 	txReq := txDexLiquidityWithdraw{
 		Fee:               optFee,
 		Percent:           percent,
+		MinAmount:         minAmount,
+		MinCounterAmount:  minCounterAmount,
 		Submit:            submit,
 		Password:          pwd,
 		committeesRequest: committeesRequest{fmt.Sprintf("%d", chainId)},
@@ -801,7 +1040,7 @@ func (c *Client) TxDexLiquidityWithdraw(from AddrOrNickname, percent int, chainI
 	return c.transactionRequest(TxDexLiquidityWithdrawRouteName, txReq, submit)
 }
 
-func (c *Client) TxLockOrder(from AddrOrNickname, receiveAddress string, orderId string,
+func (c *Client) TxLockOrder(from AddrOrNickname, receiveAddress string, orderId string, amount uint64,
 	pwd string, submit bool, optFee uint64) (hash *string, tx json.RawMessage, e lib.ErrorI) {
 	receiveHex, err := lib.NewHexBytesFromString(receiveAddress)
 	if err != nil {
@@ -810,6 +1049,7 @@ func (c *Client) TxLockOrder(from AddrOrNickname, receiveAddress string, orderId
 	txReq := txLockOrder{
 		Fee:            optFee,
 		OrderId:        orderId,
+		Amount:         amount,
 		ReceiveAddress: receiveHex,
 		Submit:         submit,
 		Password:       pwd,
@@ -876,6 +1116,82 @@ func (c *Client) TxVotePoll(from AddrOrNickname, pollJSON json.RawMessage, pollA
 	return c.transactionRequest(TxVotePollRouteName, txReq, submit)
 }
 
+func (c *Client) TxCreateMultisigAccount(from AddrOrNickname, publicKeys []string, threshold uint32,
+	pwd string, submit bool, optFee uint64) (hash *string, tx json.RawMessage, e lib.ErrorI) {
+	txReq := txCreateMultisigAccount{
+		Fee:       optFee,
+		Threshold: threshold,
+		Submit:    submit,
+		Password:  pwd,
+	}
+	for _, publicKey := range publicKeys {
+		bz, err := lib.StringToBytes(publicKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		txReq.PublicKeys = append(txReq.PublicKeys, bz)
+	}
+
+	var err lib.ErrorI
+	txReq.fromFields, err = getFrom(from.Address, from.Nickname)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.transactionRequest(TxCreateMultisigRouteName, txReq, submit)
+}
+
+func (c *Client) TxMultisigPropose(multisigAddress string, msgType string, msg json.RawMessage,
+	memo string, optFee uint64) (multisigTx json.RawMessage, e lib.ErrorI) {
+	txReq := txMultisigPropose{
+		Fee:     optFee,
+		Memo:    memo,
+		MsgType: msgType,
+		Msg:     msg,
+	}
+
+	txReq.fromFields, e = getFrom(multisigAddress, "")
+	if e != nil {
+		return nil, e
+	}
+
+	bz, e := lib.MarshalJSON(txReq)
+	if e != nil {
+		return nil, e
+	}
+	multisigTx = json.RawMessage{}
+	e = c.post(TxMultisigProposeRouteName, bz, &multisigTx, true)
+	return
+}
+
+func (c *Client) TxMultisigSign(signer AddrOrNickname, multisigTx json.RawMessage,
+	pwd string) (signed json.RawMessage, e lib.ErrorI) {
+	txReq := txMultisigSign{
+		MultisigTx: multisigTx,
+		Password:   pwd,
+	}
+
+	txReq.fromFields, e = getFrom(signer.Address, signer.Nickname)
+	if e != nil {
+		return nil, e
+	}
+
+	bz, e := lib.MarshalJSON(txReq)
+	if e != nil {
+		return nil, e
+	}
+	signed = json.RawMessage{}
+	e = c.post(TxMultisigSignRouteName, bz, &signed, true)
+	return
+}
+
+func (c *Client) TxMultisigSubmit(multisigTx json.RawMessage, submit bool) (hash *string, tx json.RawMessage, e lib.ErrorI) {
+	return c.transactionRequest(TxMultisigSubmitRouteName, txMultisigSubmit{
+		MultisigTx: multisigTx,
+		Submit:     submit,
+	}, submit)
+}
+
 func (c *Client) ResourceUsage() (returned *resourceUsageResponse, err lib.ErrorI) {
 	returned = new(resourceUsageResponse)
 	err = c.get(ResourceUsageRouteName, "", returned, true)
@@ -971,11 +1287,19 @@ func (c *Client) txStake(from AddrOrNickname, netAddr string, amt uint64, commit
 	return c.transactionRequest(route, txReq, submit)
 }
 
+// SetTxNonce() opts the transactions built by the client into account nonce replay protection (0 opts out)
+func (c *Client) SetTxNonce(nonce uint64) { c.txNonce = nonce }
+
 func (c *Client) transactionRequest(routeName string, txRequest any, submit bool) (hash *string, tx json.RawMessage, e lib.ErrorI) {
 	bz, e := lib.MarshalJSON(txRequest)
 	if e != nil {
 		return
 	}
+	if c.txNonce != 0 {
+		if bz, e = withTxNonce(bz, c.txNonce); e != nil {
+			return
+		}
+	}
 	if submit {
 		hash = new(string)
 		e = c.post(routeName, bz, hash, true)
@@ -986,6 +1310,16 @@ func (c *Client) transactionRequest(routeName string, txRequest any, submit bool
 	return
 }
 
+// withTxNonce() adds the nonce field to a marshalled transaction request
+func withTxNonce(txRequest []byte, nonce uint64) ([]byte, lib.ErrorI) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(txRequest, &fields); err != nil {
+		return nil, lib.ErrJSONUnmarshal(err)
+	}
+	fields["nonce"] = json.RawMessage(strconv.FormatUint(nonce, 10))
+	return lib.MarshalJSON(fields)
+}
+
 func (c *Client) keystoreRequest(routeName string, keystoreRequest keystoreRequest, ptr any) (err lib.ErrorI) {
 	bz, err := lib.MarshalJSON(keystoreRequest)
 	if err != nil {
@@ -1127,6 +1461,16 @@ func (c *Client) url(routeName, param string, admin ...bool) string {
 	return c.rpcURL + routePaths[routeName].Path + param
 }
 
+func (c *Client) proofRequest(req proofRequest) (p *ProofResponse, err lib.ErrorI) {
+	bz, err := lib.MarshalJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	p = new(ProofResponse)
+	err = c.post(ProofRouteName, bz, p)
+	return
+}
+
 func (c *Client) post(routeName string, json []byte, ptr any, admin ...bool) lib.ErrorI {
 	resp, err := c.client.Post(c.url(routeName, "", admin...), ApplicationJSON, bytes.NewBuffer(json))
 	if err != nil {
@@ -0,0 +1,301 @@
+package rpc
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/canopy-network/canopy/fsm"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/store"
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+)
+
+/* This file serves the Ethereum JSON-RPC interface over websockets with eth_subscribe and eth_unsubscribe */
+
+const (
+	ethSubscriptionNewHeads               = "newHeads"
+	ethSubscriptionLogs                   = "logs"
+	ethSubscriptionNewPendingTransactions = "newPendingTransactions"
+)
+
+// EthereumWebSocket() upgrades a http request to a websockets connection serving the Ethereum JSON-RPC interface
+// - every HTTP method is available, plus eth_subscribe and eth_unsubscribe
+// - subscriptions are published from the '/v1/subscribe' streams and count towards the same subscriber limit
+func (s *Server) EthereumWebSocket(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// upgrade the connection to websockets
+	conn, err := s.subscriptions.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// log the issue (the upgrader already replied with an error)
+		s.logger.Error(err.Error())
+		return
+	}
+	client := &ethWSClient{server: s, subscriptions: make(map[string]*StreamSubscriber)}
+	client.wsSubscriber = newWSSubscriber(conn, s.subscriptions.limits, s.logger)
+	client.onRead, client.onStop = client.handleMessage, client.unsubscribeAll
+	client.Start()
+}
+
+// ethWSClient is a websocket connection serving Ethereum JSON-RPC requests and subscriptions
+type ethWSClient struct {
+	server        *Server                      // the rpc server handling the requests
+	subscriptions map[string]*StreamSubscriber // the active subscriptions by id
+	l             sync.Mutex                   // thread safety
+	*wsSubscriber                              // the underlying ws subscriber
+}
+
+// handleMessage() handles a single or batch JSON-RPC request and writes the response
+func (c *ethWSClient) handleMessage(msg []byte) {
+	var (
+		response any
+		started  []*StreamSubscriber
+	)
+	if strings.HasPrefix(strings.TrimSpace(string(msg)), "[") {
+		var requests []ethRPCRequest
+		if err := json.Unmarshal(msg, &requests); err != nil {
+			c.writeResponse(ethRPCResponse{JSONRPC: "2.0", Error: &ethereumRPCError{Code: -32700, Message: err.Error()}})
+			return
+		}
+		responses := make([]ethRPCResponse, 0, len(requests))
+		for i := range requests {
+			resp, sub := c.handleRequest(&requests[i])
+			responses, started = append(responses, resp), append(started, sub)
+		}
+		response = responses
+	} else {
+		request := new(ethRPCRequest)
+		if err := json.Unmarshal(msg, request); err != nil {
+			c.writeResponse(ethRPCResponse{JSONRPC: "2.0", Error: &ethereumRPCError{Code: -32700, Message: err.Error()}})
+			return
+		}
+		resp, sub := c.handleRequest(request)
+		response, started = resp, append(started, sub)
+	}
+	// write the response before starting new subscriptions, so the id is known before the first notification
+	if !c.writeResponse(response) {
+		return
+	}
+	for _, sub := range started {
+		if sub != nil {
+			go sub.publishLoop()
+		}
+	}
+}
+
+// handleRequest() handles a JSON-RPC request, returning the new subscription (if any) to start once the response is written
+func (c *ethWSClient) handleRequest(ptr *ethRPCRequest) (ethRPCResponse, *StreamSubscriber) {
+	if ptr.Method != `eth_subscribe` && ptr.Method != `eth_unsubscribe` {
+		return c.server.handleEthereumRPCRequest(ptr), nil
+	}
+	var (
+		args   []any
+		result any
+		sub    *StreamSubscriber
+		err    error
+	)
+	if len(ptr.Params) != 0 && string(ptr.Params) != "null" {
+		if err = json.Unmarshal(ptr.Params, &args); err != nil {
+			err = ethInvalidParams(err.Error())
+		}
+	}
+	if err == nil {
+		if ptr.Method == `eth_subscribe` {
+			result, sub, err = c.subscribe(args)
+		} else {
+			result, err = c.unsubscribe(args)
+		}
+	}
+	return ethRPCResponse{ID: ptr.ID, JSONRPC: "2.0", Result: result, Error: ethereumRPCErrorFrom(err)}, sub
+}
+
+// subscribe() creates a subscription publishing to this connection and returns its id
+func (c *ethWSClient) subscribe(args []any) (id string, sub *StreamSubscriber, err error) {
+	kind, err := strFromArgs(args, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	// generate the subscription id
+	uuid := make([]byte, 16)
+	if _, err = rand.Read(uuid); err != nil {
+		return "", nil, err
+	}
+	id = "0x" + hex.EncodeToString(uuid)
+	manager := c.server.subscriptions
+	sub = &StreamSubscriber{wake: make(chan struct{}, 1), cancel: make(chan struct{}), manager: manager, wsSubscriber: c.wsSubscriber}
+	switch kind {
+	case ethSubscriptionNewHeads:
+		sub.stream, sub.deliver = StreamBlocks, c.deliverNewHeads(id)
+	case ethSubscriptionLogs:
+		params, e := filterParamsFromArgs(args[1:])
+		if e != nil {
+			return "", nil, e
+		}
+		if params.filter.BlockHash != "" || params.filter.StartHeight != 0 || params.filter.EndHeight != 0 {
+			return "", nil, ethInvalidParams("logs subscriptions don't support blockHash, fromBlock or toBlock")
+		}
+		// canopy only emits pseudo transfer logs for send transactions
+		sub.stream, sub.filter.MessageType, sub.deliver = StreamTxs, fsm.MessageSendName, c.deliverLogs(id, &params.filter)
+	case ethSubscriptionNewPendingTransactions:
+		sub.stream, sub.deliver = StreamMempool, c.deliverPendingTxs(id, boolFromArgs(args))
+	default:
+		return "", nil, ethInvalidParams(fmt.Sprintf("unsupported subscription type %q", kind))
+	}
+	// start with the next item
+	sub.cursor = manager.liveCursor(sub.stream)
+	if err = manager.AddSubscriber(sub); err != nil {
+		return "", nil, err
+	}
+	c.l.Lock()
+	c.subscriptions[id] = sub
+	c.l.Unlock()
+	return
+}
+
+// unsubscribe() cancels a subscription by id, returning false if it doesn't exist
+func (c *ethWSClient) unsubscribe(args []any) (bool, error) {
+	id, err := strFromArgs(args, 0)
+	if err != nil {
+		return false, err
+	}
+	c.l.Lock()
+	sub, ok := c.subscriptions[id]
+	delete(c.subscriptions, id)
+	c.l.Unlock()
+	if ok {
+		close(sub.cancel)
+		c.server.subscriptions.RemoveSubscriber(sub)
+	}
+	return ok, nil
+}
+
+// unsubscribeAll() cancels every subscription of the connection
+func (c *ethWSClient) unsubscribeAll() {
+	c.l.Lock()
+	defer c.l.Unlock()
+	for id, sub := range c.subscriptions {
+		close(sub.cancel)
+		c.server.subscriptions.RemoveSubscriber(sub)
+		delete(c.subscriptions, id)
+	}
+}
+
+// deliverNewHeads() publishes committed blocks using the eth_getBlockByNumber block synthesis
+func (c *ethWSClient) deliverNewHeads(id string) func([]*StreamMessage) error {
+	return func(msgs []*StreamMessage) error {
+		_, err := c.server.withStore(func(st *store.Store) (any, error) {
+			for _, msg := range msgs {
+				block, err := blockByHeightOrNil(st, msg.Cursor.Height)
+				if err != nil || block == nil {
+					return nil, err
+				}
+				head, err := c.server.blockToEIP1559Block(block, false)
+				if err != nil {
+					return nil, err
+				}
+				if err = c.notify(id, head); err != nil {
+					return nil, err
+				}
+			}
+			return nil, nil
+		})
+		return err
+	}
+}
+
+// deliverLogs() publishes the pseudo transfer logs of committed send transactions using the eth_getLogs synthesis
+func (c *ethWSClient) deliverLogs(id string, filter *ethFilter) func([]*StreamMessage) error {
+	return func(msgs []*StreamMessage) error {
+		if !filterSupportsPseudoTransferLogs(filter) {
+			return nil
+		}
+		_, err := c.server.withStore(func(st *store.Store) (any, error) {
+			// every message in a batch is from the same height
+			block, err := st.GetBlockHeaderByHeight(msgs[0].Cursor.Height)
+			if err != nil {
+				return nil, err
+			}
+			for _, msg := range msgs {
+				tx := msg.Data.(*lib.TxResult)
+				if !c.server.passesAddressFilter(tx.Sender, filter.Sender) || !c.server.passesAddressFilter(tx.Recipient, filter.Recipient) {
+					continue
+				}
+				logs, e := c.server.txToGetLogsResp(block.BlockHeader.Hash, tx)
+				if e != nil {
+					return nil, e
+				}
+				for _, log := range logs {
+					if e = c.notify(id, log); e != nil {
+						return nil, e
+					}
+				}
+			}
+			return nil, nil
+		})
+		return err
+	}
+}
+
+// deliverPendingTxs() publishes the hashes (or the full transaction objects) of mempool admissions
+func (c *ethWSClient) deliverPendingTxs(id string, fullTxs bool) func([]*StreamMessage) error {
+	return func(msgs []*StreamMessage) error {
+		for _, msg := range msgs {
+			tx := msg.Data.(*lib.TxResult)
+			var result any = ethHashStringFromTxResult(tx)
+			if fullTxs {
+				ethTx, err := c.server.txToEthTransaction(nil, tx, true)
+				if err != nil {
+					return err
+				}
+				result = ethTx
+			}
+			if err := c.notify(id, result); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// notify() writes an eth_subscription notification
+func (c *ethWSClient) notify(id string, result any) error {
+	bz, err := json.Marshal(ethSubscriptionNotification{
+		JSONRPC: "2.0",
+		Method:  "eth_subscription",
+		Params:  ethSubscriptionResult{Subscription: id, Result: result},
+	})
+	if err != nil {
+		return err
+	}
+	return c.writeMessage(websocket.TextMessage, bz)
+}
+
+// writeResponse() writes a JSON-RPC response, stopping the client on failure
+func (c *ethWSClient) writeResponse(response any) (ok bool) {
+	bz, err := json.Marshal(response)
+	if err == nil {
+		err = c.writeMessage(websocket.TextMessage, bz)
+	}
+	if err != nil {
+		c.Stop(err)
+		return false
+	}
+	return true
+}
+
+// ethSubscriptionNotification is the JSON RPC 2.0 notification structure of eth_subscribe
+type ethSubscriptionNotification struct {
+	JSONRPC string                `json:"jsonrpc"`
+	Method  string                `json:"method"`
+	Params  ethSubscriptionResult `json:"params"`
+}
+
+// ethSubscriptionResult is the payload of an eth_subscription notification
+type ethSubscriptionResult struct {
+	Subscription string `json:"subscription"`
+	Result       any    `json:"result"`
+}
@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/canopy-network/canopy/controller"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthereumWebSocketSubscriptions(t *testing.T) {
+	db, addrA, addrB := newTestStreamStore(t)
+	log := lib.NewDefaultLogger()
+	sm := newTestRPCStateMachine(t, db, log)
+	config := lib.DefaultConfig()
+	server := &Server{controller: &controller.Controller{FSM: sm}, config: config, logger: log}
+	server.subscriptions = NewSubscriptionManager(server.controller, config, log)
+	router := httprouter.New()
+	router.GET(EthereumRoutePath, server.EthereumWebSocket)
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+EthereumRoutePath, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	type message struct {
+		ID     any             `json:"id"`
+		Method string          `json:"method"`
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		Params struct {
+			Subscription string          `json:"subscription"`
+			Result       json.RawMessage `json:"result"`
+		} `json:"params"`
+	}
+	call := func(id int, method string, params ...any) (msg message) {
+		bz, e := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+		require.NoError(t, e)
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, bz))
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, bz, e = conn.ReadMessage()
+		require.NoError(t, e)
+		require.NoError(t, json.Unmarshal(bz, &msg))
+		require.EqualValues(t, id, msg.ID)
+		return
+	}
+	readNotification := func() (msg message) {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, bz, e := conn.ReadMessage()
+		require.NoError(t, e)
+		require.NoError(t, json.Unmarshal(bz, &msg))
+		require.Equal(t, "eth_subscription", msg.Method)
+		return
+	}
+	// the http methods are served over the connection
+	msg := call(1, "web3_clientVersion")
+	require.Nil(t, msg.Error)
+	require.JSONEq(t, `"Canopy_Eth_Wrapper"`, string(msg.Result))
+	// unsupported subscription types are rejected
+	msg = call(2, "eth_subscribe", "syncing")
+	require.NotNil(t, msg.Error)
+	// subscribe to transfer logs to the recipient and to pending transactions
+	recipientTopic := "0x000000000000000000000000" + addrB.String()
+	msg = call(3, "eth_subscribe", "logs", map[string]any{"topics": []any{transferEventFilterHash, nil, recipientTopic}})
+	require.Nil(t, msg.Error)
+	var logsId string
+	require.NoError(t, json.Unmarshal(msg.Result, &logsId))
+	msg = call(4, "eth_subscribe", "newPendingTransactions")
+	require.Nil(t, msg.Error)
+	var pendingId string
+	require.NoError(t, json.Unmarshal(msg.Result, &pendingId))
+	// a mempool admission publishes its hash
+	pending := newTestStreamTx(addrA, addrB, "send", 3, 0)
+	server.subscriptions.Admit(lib.TxResults{pending})
+	msg = readNotification()
+	require.Equal(t, pendingId, msg.Params.Subscription)
+	require.JSONEq(t, `"0x`+pending.TxHash+`"`, string(msg.Params.Result))
+	// a committed block publishes only the logs passing the filter
+	require.NoError(t, db.IndexBlock(&lib.BlockResult{
+		BlockHeader: &lib.BlockHeader{Height: 2, Hash: crypto.Hash([]byte("eth-sock-block-2")), Time: uint64(time.Now().UnixMicro())},
+		Transactions: []*lib.TxResult{
+			newTestStreamTx(addrB, addrA, "send", 2, 0),
+			newTestStreamTx(addrA, addrB, "send", 2, 1),
+		},
+	}))
+	_, err = db.Commit()
+	require.NoError(t, err)
+	setFSMHeight(t, sm, 3)
+	server.subscriptions.notify(func(*StreamSubscriber) bool { return true })
+	msg = readNotification()
+	require.Equal(t, logsId, msg.Params.Subscription)
+	var log1 ethRPCLog
+	require.NoError(t, json.Unmarshal(msg.Params.Result, &log1))
+	require.EqualValues(t, 2, log1.BlockNumber)
+	require.EqualValues(t, 1, log1.TxIndex)
+	// unsubscribing stops the notifications and the subscription is removed from the manager
+	msg = call(5, "eth_unsubscribe", logsId)
+	require.JSONEq(t, `true`, string(msg.Result))
+	msg = call(6, "eth_unsubscribe", logsId)
+	require.JSONEq(t, `false`, string(msg.Result))
+	server.subscriptions.l.Lock()
+	require.Len(t, server.subscriptions.subscribers, 1)
+	server.subscriptions.l.Unlock()
+}
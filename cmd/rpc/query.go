@@ -50,6 +50,30 @@ func (s *Server) Transactions(w http.ResponseWriter, r *http.Request, _ httprout
 	s.submitTxs(w, txs)
 }
 
+// TransactionSimulate executes a transaction against a discarded view of the latest state without submitting it
+func (s *Server) TransactionSimulate(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Create a new instance of lib.Transaction to hold the incoming transaction data.
+	tx := new(lib.Transaction)
+	if ok := unmarshal(w, r, tx); !ok {
+		return
+	}
+	bz, err := lib.Marshal(tx)
+	if err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	// execute the transaction on a read-only view of the latest state, whose height is that of the next block (last committed + 1)
+	var result *fsm.SimulationResult
+	if err = s.readOnlyState(0, func(state *fsm.StateMachine) (e lib.ErrorI) {
+		result, e = state.SimulateTransaction(bz)
+		return
+	}); err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	write(w, result, http.StatusOK)
+}
+
 // Height responds with the next block version
 func (s *Server) Height(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
 	// Create a read-only state for the latest block and write the height
@@ -73,6 +97,37 @@ func (s *Server) Account(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 	})
 }
 
+// MultisigAccount responds with the member keys and threshold of a multisig account
+func (s *Server) MultisigAccount(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Invoke helper with the HTTP request, response writer and an inline callback
+	s.heightAndAddressParams(w, r, func(s *fsm.StateMachine, a lib.HexBytes) (interface{}, lib.ErrorI) {
+		return s.GetMultisigAccount(crypto.NewAddressFromBytes(a))
+	})
+}
+
+// AccountNonce responds with the nonce floor of an account and the next nonce to sign for a nonce protected transaction
+func (s *Server) AccountNonce(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Invoke helper with the HTTP request, response writer and an inline callback
+	s.heightAndAddressParams(w, r, func(state *fsm.StateMachine, a lib.HexBytes) (interface{}, lib.ErrorI) {
+		address := crypto.NewAddressFromBytes(a)
+		account, err := state.GetAccount(address)
+		if err != nil {
+			return nil, err
+		}
+		// skip past the nonces of the validated pending transactions; native transactions opt in with a non-zero nonce
+		next := max(s.controller.GetPendingNonce(address, account.Nonce), 1)
+		return &AccountNonceView{Address: a, Nonce: account.Nonce, NextNonce: next}, nil
+	})
+}
+
+// GovProposals responds with every on-chain governance proposal
+func (s *Server) GovProposals(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Invoke helper with the HTTP request, response writer and an inline callback
+	s.heightParams(w, r, func(s *fsm.StateMachine) (interface{}, lib.ErrorI) {
+		return s.GetProposals()
+	})
+}
+
 // Accounts responds with accounts based on the page parameters
 func (s *Server) Accounts(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	// Invoke helper with the HTTP request, response writer and an inline callback
@@ -137,6 +192,34 @@ func (s *Server) Checkpoint(w http.ResponseWriter, r *http.Request, _ httprouter
 	})
 }
 
+// Proof retrieves a merkle proof of a state key against the state root committed in a block header
+func (s *Server) Proof(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	req := new(proofRequest)
+	// unmarshal request parameters
+	if ok := unmarshal(w, r, req); !ok {
+		return
+	}
+	// convert the selector into a state key
+	key, err := req.stateKey()
+	if err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	// create a read-only store
+	st, ok := s.setupStore(w)
+	if !ok {
+		return
+	}
+	defer st.Discard()
+	// generate the proof
+	proof, err := getProof(st, req.Height, key)
+	if err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	write(w, proof, http.StatusOK)
+}
+
 // RootChainInfo retrieves the root chain info for the specified chain
 func (s *Server) RootChainInfo(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	req := new(heightAndIdRequest)
@@ -327,6 +410,104 @@ func (s *Server) NextDexBatch(w http.ResponseWriter, r *http.Request, _ httprout
 	})
 }
 
+// DexBatchStatus retrieves the batch pipeline status with a committee: lock age, liveness fallback height and stall reason
+func (s *Server) DexBatchStatus(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Invoke helper with the HTTP request, response writer and an inline callback
+	s.heightAndIdParams(w, r, func(s *fsm.StateMachine, id uint64) (any, lib.ErrorI) {
+		if id == 0 {
+			return s.GetDexBatchStatuses()
+		}
+		return s.GetDexBatchStatus(id)
+	})
+}
+
+// DexOrders retrieves the open dex limit orders (locked or resting in the next batch) with pagination
+func (s *Server) DexOrders(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	req := new(dexOrdersRequest)
+	s.readOnlyStateFromHeightParams(w, r, req, func(state *fsm.StateMachine) lib.ErrorI {
+		// optionally filter by seller
+		var address crypto.AddressI
+		if len(req.Address) != 0 {
+			address = crypto.NewAddress(req.Address)
+		}
+		p, err := state.GetDexOrdersPaginated(req.Committee, address, req.PageParams)
+		if err != nil {
+			write(w, err, http.StatusBadRequest)
+			return nil
+		}
+		write(w, p, http.StatusOK)
+		return nil
+	})
+}
+
+// DexTWAP retrieves the time weighted average dex price of a committee from the start height to the (end) height
+func (s *Server) DexTWAP(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	req := new(dexTWAPRequest)
+	s.readOnlyStateFromHeightParams(w, r, req, func(state *fsm.StateMachine) lib.ErrorI {
+		p, err := state.GetDexTWAP(req.ID, req.StartHeight)
+		if err != nil {
+			write(w, err, http.StatusBadRequest)
+			return nil
+		}
+		write(w, p, http.StatusOK)
+		return nil
+	})
+}
+
+// DexQuote computes the expected output of a (multi-hop) dex swap along a path of chains with the current pool sizes
+func (s *Server) DexQuote(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	req := new(dexQuoteRequest)
+	s.readOnlyStateFromHeightParams(w, r, req, func(state *fsm.StateMachine) lib.ErrorI {
+		p, err := state.QuoteDexRoute(req.Amount, req.Path)
+		if err != nil {
+			write(w, err, http.StatusBadRequest)
+			return nil
+		}
+		write(w, p, http.StatusOK)
+		return nil
+	})
+}
+
+// DexHistory retrieves the OHLCV history buckets of a dex pool aggregated by the indexer
+func (s *Server) DexHistory(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	req := new(dexHistoryRequest)
+	if ok := unmarshal(w, r, req); !ok {
+		return
+	}
+	st, ok := s.setupStore(w)
+	if !ok {
+		return
+	}
+	defer st.Discard()
+	p, err := st.GetDexCandles(req.ID, req.Resolution, req.StartTime, req.EndTime, req.Limit)
+	if err != nil {
+		write(w, err, http.StatusBadRequest)
+		return
+	}
+	write(w, p, http.StatusOK)
+}
+
+// DexPosition retrieves the liquidity provider position(s) of an address with redeemable amounts, fee earnings and impermanent loss
+func (s *Server) DexPosition(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	req := new(dexPositionRequest)
+	s.readOnlyStateFromHeightParams(w, r, req, func(state *fsm.StateMachine) lib.ErrorI {
+		// a committee of 0 returns the positions in every pool
+		var p any
+		var err lib.ErrorI
+		if req.Committee == 0 {
+			p, err = state.GetDexPositions(req.Address)
+		} else {
+			p, err = state.GetDexPosition(req.Committee, req.Address)
+		}
+		if err != nil {
+			write(w, err, http.StatusBadRequest)
+			return nil
+		}
+		write(w, p, http.StatusOK)
+		return nil
+	})
+}
+
 // LastProposers returns the last Proposer addresses
 func (s *Server) LastProposers(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	// Invoke helper with the HTTP request, response writer and an inline callback
@@ -478,11 +659,11 @@ func (s *Server) EventsByChain(w http.ResponseWriter, r *http.Request, _ httprou
 	})
 }
 
-// Pending responds with a page of unconfirmed mempool transactions
+// Pending responds with a page of unconfirmed mempool transactions, optionally filtered by sender
 func (s *Server) Pending(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	// Invoke helper with the HTTP request, response writer and an inline callback
-	s.pageIndexer(w, r, func(_ lib.StoreI, _ crypto.AddressI, p lib.PageParams) (any, lib.ErrorI) {
-		return s.controller.GetPendingPage(p)
+	s.pageIndexer(w, r, func(_ lib.StoreI, sender crypto.AddressI, p lib.PageParams) (any, lib.ErrorI) {
+		return s.controller.GetPendingPage(sender, p)
 	})
 }
 
@@ -1055,6 +1236,79 @@ func (s *Server) setupStore(w http.ResponseWriter) (lib.StoreI, bool) {
 	return st, true
 }
 
+// getProof() proves the value of a key (or its absence) against the state root in the header of the block at height
+// NOTE: the block at height H is applied on top of version H and its state root commits to version H+1
+func getProof(st lib.StoreI, height uint64, key []byte) (*ProofResponse, lib.ErrorI) {
+	// the latest committed block is one below the store version
+	latest := st.Version() - 1
+	// if height is 0; set to the latest committed block
+	if height == 0 {
+		height = latest
+	}
+	if height == 0 || height > latest {
+		return nil, lib.ErrWrongBlockHeight(height, latest)
+	}
+	// load the block header that commits to the state root
+	blk, err := st.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil || blk.BlockHeader == nil {
+		return nil, lib.ErrNilBlockHeader()
+	}
+	// open the state as of the end of the block
+	ro, err := st.NewReadOnly(height + 1)
+	if err != nil {
+		return nil, err
+	}
+	defer ro.Discard()
+	// get the value at the key
+	value, err := ro.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	// get the membership or non-membership proof
+	proof, err := ro.GetProof(key)
+	if err != nil {
+		return nil, err
+	}
+	return &ProofResponse{
+		Height:    height,
+		BlockHash: blk.BlockHeader.Hash,
+		StateRoot: blk.BlockHeader.StateRoot,
+		Key:       key,
+		Value:     value,
+		Exists:    value != nil,
+		Proof:     proof,
+	}, nil
+}
+
+// stateKey() converts the single populated selector of a proof request into a state key
+func (p *proofRequest) stateKey() (key []byte, err lib.ErrorI) {
+	selected := 0
+	if len(p.Key) != 0 {
+		key, selected = p.Key, selected+1
+	}
+	if len(p.Account) != 0 {
+		key, selected = fsm.KeyForAccount(crypto.NewAddressFromBytes(p.Account)), selected+1
+	}
+	if len(p.Validator) != 0 {
+		key, selected = fsm.KeyForValidator(crypto.NewAddressFromBytes(p.Validator)), selected+1
+	}
+	if p.OrderId != "" {
+		orderId, e := lib.StringToBytes(p.OrderId)
+		if e != nil {
+			return nil, e
+		}
+		key, selected = fsm.KeyForOrder(p.Committee, orderId), selected+1
+	}
+	// exactly one selector is allowed
+	if selected != 1 {
+		return nil, lib.ErrInvalidProofQuery()
+	}
+	return
+}
+
 // withStore() executes a read only store function
 func (s *Server) withStore(fn func(st *store.Store) (any, error)) (any, error) {
 	st, err := store.NewStoreWithDB(s.config, s.controller.FSM.Store().(lib.StoreI).DB(), nil, s.logger)
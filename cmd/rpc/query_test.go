@@ -306,6 +306,28 @@ func TestAccountQueryReturnsVestingBreakdown(t *testing.T) {
 	require.Equal(t, uint64(6), got.VestingEndHeight)
 }
 
+func TestAccountNonceQuery(t *testing.T) {
+	server := newTestIndexerBlobServer(t)
+	sm := server.controller.FSM
+	fresh, used := crypto.NewAddress(bytes.Repeat([]byte{0x33}, crypto.AddressSize)), crypto.NewAddress(bytes.Repeat([]byte{0x44}, crypto.AddressSize))
+	require.NoError(t, sm.SetAccount(&fsm.Account{Address: used.Bytes(), Amount: 1, Nonce: 7}))
+	_, err := sm.Store().(lib.StoreI).Commit()
+	require.NoError(t, err)
+	setFSMHeight(t, sm, sm.Store().(lib.StoreI).Version())
+	for address, expected := range map[string]AccountNonceView{
+		fresh.String(): {Address: fresh.Bytes(), Nonce: 0, NextNonce: 1},
+		used.String():  {Address: used.Bytes(), Nonce: 7, NextNonce: 7},
+	} {
+		req := httptest.NewRequest(http.MethodPost, AccountNonceRoutePath, bytes.NewBufferString(`{"address":"`+address+`"}`))
+		rec := httptest.NewRecorder()
+		server.AccountNonce(rec, req, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var got AccountNonceView
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		require.Equal(t, expected, got)
+	}
+}
+
 func TestAccountsQueryReturnsVestingBreakdowns(t *testing.T) {
 	server := newTestIndexerBlobServer(t)
 	sm := server.controller.FSM
@@ -544,3 +566,71 @@ func setUnexportedField(t *testing.T, target any, name string, value any) {
 	require.True(t, field.IsValid(), name)
 	reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem().Set(reflect.ValueOf(value))
 }
+
+func TestProofQueryVerifiesAgainstBlockStateRoot(t *testing.T) {
+	server := newTestIndexerBlobServer(t)
+	sm := server.controller.FSM
+	db := sm.Store().(lib.StoreI)
+	address := crypto.NewAddress(bytes.Repeat([]byte{0x44}, crypto.AddressSize))
+	missing := crypto.NewAddress(bytes.Repeat([]byte{0x55}, crypto.AddressSize))
+
+	// apply block 3 and commit to its state root in the header
+	require.NoError(t, sm.SetAccount(&fsm.Account{Address: address.Bytes(), Amount: 42}))
+	root, err := db.Root()
+	require.NoError(t, err)
+	require.NoError(t, db.IndexBlock(&lib.BlockResult{BlockHeader: &lib.BlockHeader{
+		Height:    3,
+		Hash:      crypto.Hash([]byte("block-3")),
+		StateRoot: root,
+	}}))
+	_, err = db.Commit()
+	require.NoError(t, err)
+	setFSMHeight(t, sm, db.Version())
+
+	query := func(body string) (*httptest.ResponseRecorder, *ProofResponse) {
+		rec := httptest.NewRecorder()
+		server.Proof(rec, httptest.NewRequest(http.MethodPost, ProofRoutePath, bytes.NewBufferString(body)), nil)
+		got := new(ProofResponse)
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), got))
+		}
+		return rec, got
+	}
+
+	// membership proof of an account at the latest block
+	rec, got := query(`{"height":0,"account":"` + address.String() + `"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, uint64(3), got.Height)
+	require.Equal(t, root, []byte(got.StateRoot))
+	require.Equal(t, fsm.KeyForAccount(address), []byte(got.Key))
+	require.True(t, got.Exists)
+	account := new(fsm.Account)
+	require.NoError(t, lib.Unmarshal(got.Value, account))
+	require.Equal(t, uint64(42), account.Amount)
+	valid, e := got.Verify()
+	require.NoError(t, e)
+	require.True(t, valid)
+
+	// a tampered value fails verification
+	got.Value = []byte("bad")
+	valid, _ = got.Verify()
+	require.False(t, valid)
+
+	// non-membership proof of an absent account
+	rec, got = query(`{"height":3,"account":"` + missing.String() + `"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.False(t, got.Exists)
+	valid, e = got.Verify()
+	require.NoError(t, e)
+	require.True(t, valid)
+
+	// exactly one selector is required
+	rec, _ = query(`{"height":3,"account":"` + address.String() + `","validator":"` + address.String() + `"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	rec, _ = query(`{"height":3}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// uncommitted heights are rejected
+	rec, _ = query(`{"height":4,"account":"` + address.String() + `"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -12,6 +12,7 @@ const (
 	VersionRoutePath               = "/v1/"
 	TxRoutePath                    = "/v1/tx"
 	TxsRoutePath                   = "/v1/txs"
+	TxSimulateRoutePath            = "/v1/tx/simulate"
 	HeightRoutePath                = "/v1/query/height"
 	IndexerBlobsRoutePath          = "/v1/query/indexer-blobs"
 	AccountRoutePath               = "/v1/query/account"
@@ -51,6 +52,12 @@ const (
 	DexPriceRoutePath              = "/v1/query/dex-price"
 	DexBatchRoutePath              = "/v1/query/dex-batch"
 	NextDexBatchRoutePath          = "/v1/query/next-dex-batch"
+	DexOrdersRoutePath             = "/v1/query/dex-orders"
+	DexTWAPRoutePath               = "/v1/query/dex-twap"
+	DexQuoteRoutePath              = "/v1/query/dex-quote"
+	DexPositionRoutePath           = "/v1/query/dex-position"
+	DexHistoryRoutePath            = "/v1/query/dex-history"
+	DexBatchStatusRoutePath        = "/v1/query/dex-batch-status"
 	LastProposersRoutePath         = "/v1/query/last-proposers"
 	IsValidDoubleSignerRoutePath   = "/v1/query/valid-double-signer"
 	DoubleSignersRoutePath         = "/v1/query/double-signers"
@@ -63,7 +70,12 @@ const (
 	RootChainInfoRoutePath         = "/v1/query/root-chain-info"
 	ValidatorSetRoutePath          = "/v1/query/validator-set"
 	CheckpointRoutePath            = "/v1/query/checkpoint"
+	ProofRoutePath                 = "/v1/query/proof"
+	MultisigAccountRoutePath       = "/v1/query/multisig-account"
+	AccountNonceRoutePath          = "/v1/query/account-nonce"
+	GovProposalsRoutePath          = "/v1/query/gov-proposals"
 	SubscribeRCInfoPath            = "/v1/subscribe-rc-info"
+	SubscribeRoutePath             = "/v1/subscribe"
 	// eth
 	EthereumRoutePath = "/v1/eth"
 	// admin
@@ -86,6 +98,7 @@ const (
 	TxEditOrderRoutePath       = "/v1/admin/tx-edit-order"
 	TxDeleteOrderRoutePath     = "/v1/admin/tx-delete-order"
 	TxDexLimitOrderPath        = "/v1/admin/tx-dex-limit-order"
+	TxDexRoutedSwapPath        = "/v1/admin/tx-dex-routed-swap"
 	TxDexLiquidityDepositPath  = "/v1/admin/tx-dex-liquidity-deposit"
 	TxDexLiquidityWithdrawPath = "/v1/admin/tx-dex-liquidity-withdraw"
 	TxLockOrderRoutePath       = "/v1/admin/tx-lock-order"
@@ -93,6 +106,12 @@ const (
 	TxSubsidyRoutePath         = "/v1/admin/tx-subsidy"
 	TxStartPollRoutePath       = "/v1/admin/tx-start-poll"
 	TxVotePollRoutePath        = "/v1/admin/tx-vote-poll"
+	TxCreateMultisigRoutePath  = "/v1/admin/tx-create-multisig-account"
+	TxMultisigProposeRoutePath = "/v1/admin/tx-multisig-propose"
+	TxMultisigSignRoutePath    = "/v1/admin/tx-multisig-sign"
+	TxMultisigSubmitRoutePath  = "/v1/admin/tx-multisig-submit"
+	TxSubmitProposalRoutePath  = "/v1/admin/tx-submit-proposal"
+	TxVoteRoutePath            = "/v1/admin/tx-vote"
 	ResourceUsageRoutePath     = "/v1/admin/resource-usage"
 	PeerInfoRoutePath          = "/v1/admin/peer-info"
 	ConsensusInfoRoutePath     = "/v1/admin/consensus-info"
@@ -107,6 +126,7 @@ const (
 	VersionRouteName               = "version"
 	TxRouteName                    = "tx"
 	TxsRouteName                   = "txs"
+	TxSimulateRouteName            = "tx-simulate"
 	HeightRouteName                = "height"
 	IndexerBlobsRouteName          = "indexer-blobs"
 	AccountRouteName               = "account"
@@ -151,6 +171,12 @@ const (
 	DexPriceRouteName              = "dex-price"
 	DexBatchRouteName              = "dex-batch"
 	NextDexBatchRouteName          = "next-dex-batch"
+	DexOrdersRouteName             = "dex-orders"
+	DexTWAPRouteName               = "dex-twap"
+	DexQuoteRouteName              = "dex-quote"
+	DexPositionRouteName           = "dex-position"
+	DexHistoryRouteName            = "dex-history"
+	DexBatchStatusRouteName        = "dex-batch-status"
 	LastProposersRouteName         = "last-proposers"
 	IsValidDoubleSignerRouteName   = "valid-double-signer"
 	DoubleSignersRouteName         = "double-signers"
@@ -158,13 +184,18 @@ const (
 	LotteryRouteName               = "lottery"
 	RootChainInfoRouteName         = "root-chain-info"
 	CheckpointRouteName            = "checkpoint"
+	ProofRouteName                 = "proof"
+	MultisigAccountRouteName       = "multisig-account"
+	AccountNonceRouteName          = "account-nonce"
+	GovProposalsRouteName          = "gov-proposals"
 	// debug
 	DebugBlockedRouteName   = "blocked"
 	DebugHeapRouteName      = "heap"
 	DebugCPURouteName       = "cpu"
 	DebugGoroutineRouteName = "goroutine"
 	// eth
-	EthereumRouteName = "eth"
+	EthereumRouteName   = "eth"
+	EthereumWSRouteName = "eth-ws"
 	// admin
 	KeystoreRouteName               = "keystore"
 	KeystoreNewKeyRouteName         = "keystore-new-key"
@@ -186,12 +217,19 @@ const (
 	TxEditOrderRouteName            = "tx-edit-order"
 	TxDeleteOrderRouteName          = "tx-delete-order"
 	TxDexLimitOrderRouteName        = "tx-dex-limit-order"
+	TxDexRoutedSwapRouteName        = "tx-dex-routed-swap"
 	TxDexLiquidityDepositRouteName  = "tx-dex-liquidity-deposit"
 	TxDexLiquidityWithdrawRouteName = "tx-dex-liquidity-withdraw"
 	TxLockOrderRouteName            = "tx-lock-order"
 	TxCloseOrderRouteName           = "tx-close-order"
 	TxStartPollRouteName            = "tx-start-poll"
 	TxVotePollRouteName             = "tx-vote-poll"
+	TxCreateMultisigRouteName       = "tx-create-multisig-account"
+	TxMultisigProposeRouteName      = "tx-multisig-propose"
+	TxMultisigSignRouteName         = "tx-multisig-sign"
+	TxMultisigSubmitRouteName       = "tx-multisig-submit"
+	TxSubmitProposalRouteName       = "tx-submit-proposal"
+	TxVoteRouteName                 = "tx-vote"
 	ResourceUsageRouteName          = "resource-usage"
 	PeerInfoRouteName               = "peer-info"
 	ConsensusInfoRouteName          = "consensus-info"
@@ -201,6 +239,7 @@ const (
 	AddVoteRouteName                = "add-vote"
 	DelVoteRouteName                = "del-vote"
 	SubscribeRCInfoName             = "subscribe-rc-info"
+	SubscribeRouteName              = "subscribe"
 )
 
 // routes contains the method and path for a canopy command
@@ -214,6 +253,7 @@ var routePaths = routes{
 	VersionRouteName:               {Method: http.MethodGet, Path: VersionRoutePath},
 	TxRouteName:                    {Method: http.MethodPost, Path: TxRoutePath},
 	TxsRouteName:                   {Method: http.MethodPost, Path: TxsRoutePath},
+	TxSimulateRouteName:            {Method: http.MethodPost, Path: TxSimulateRoutePath},
 	HeightRouteName:                {Method: http.MethodPost, Path: HeightRoutePath},
 	IndexerBlobsRouteName:          {Method: http.MethodPost, Path: IndexerBlobsRoutePath},
 	AccountRouteName:               {Method: http.MethodPost, Path: AccountRoutePath},
@@ -253,6 +293,12 @@ var routePaths = routes{
 	DexPriceRouteName:              {Method: http.MethodPost, Path: DexPriceRoutePath},
 	DexBatchRouteName:              {Method: http.MethodPost, Path: DexBatchRoutePath},
 	NextDexBatchRouteName:          {Method: http.MethodPost, Path: NextDexBatchRoutePath},
+	DexOrdersRouteName:             {Method: http.MethodPost, Path: DexOrdersRoutePath},
+	DexTWAPRouteName:               {Method: http.MethodPost, Path: DexTWAPRoutePath},
+	DexQuoteRouteName:              {Method: http.MethodPost, Path: DexQuoteRoutePath},
+	DexPositionRouteName:           {Method: http.MethodPost, Path: DexPositionRoutePath},
+	DexHistoryRouteName:            {Method: http.MethodPost, Path: DexHistoryRoutePath},
+	DexBatchStatusRouteName:        {Method: http.MethodPost, Path: DexBatchStatusRoutePath},
 	LastProposersRouteName:         {Method: http.MethodPost, Path: LastProposersRoutePath},
 	IsValidDoubleSignerRouteName:   {Method: http.MethodPost, Path: IsValidDoubleSignerRoutePath},
 	DoubleSignersRouteName:         {Method: http.MethodPost, Path: DoubleSignersRoutePath},
@@ -265,8 +311,13 @@ var routePaths = routes{
 	RootChainInfoRouteName:         {Method: http.MethodPost, Path: RootChainInfoRoutePath},
 	ValidatorSetRouteName:          {Method: http.MethodPost, Path: ValidatorSetRoutePath},
 	CheckpointRouteName:            {Method: http.MethodPost, Path: CheckpointRoutePath},
+	ProofRouteName:                 {Method: http.MethodPost, Path: ProofRoutePath},
+	MultisigAccountRouteName:       {Method: http.MethodPost, Path: MultisigAccountRoutePath},
+	AccountNonceRouteName:          {Method: http.MethodPost, Path: AccountNonceRoutePath},
+	GovProposalsRouteName:          {Method: http.MethodPost, Path: GovProposalsRoutePath},
 	// eth
-	EthereumRouteName: {Method: http.MethodPost, Path: EthereumRoutePath},
+	EthereumRouteName:   {Method: http.MethodPost, Path: EthereumRoutePath},
+	EthereumWSRouteName: {Method: http.MethodGet, Path: EthereumRoutePath},
 	// admin
 	KeystoreRouteName:               {Method: http.MethodGet, Path: KeystoreRoutePath},
 	KeystoreNewKeyRouteName:         {Method: http.MethodPost, Path: KeystoreNewKeyRoutePath},
@@ -287,6 +338,7 @@ var routePaths = routes{
 	TxEditStakeRouteName:            {Method: http.MethodPost, Path: TxEditStakeRoutePath},
 	TxDeleteOrderRouteName:          {Method: http.MethodPost, Path: TxDeleteOrderRoutePath},
 	TxDexLimitOrderRouteName:        {Method: http.MethodPost, Path: TxDexLimitOrderPath},
+	TxDexRoutedSwapRouteName:        {Method: http.MethodPost, Path: TxDexRoutedSwapPath},
 	TxDexLiquidityWithdrawRouteName: {Method: http.MethodPost, Path: TxDexLiquidityWithdrawPath},
 	TxDexLiquidityDepositRouteName:  {Method: http.MethodPost, Path: TxDexLiquidityDepositPath},
 	TxLockOrderRouteName:            {Method: http.MethodPost, Path: TxLockOrderRoutePath},
@@ -294,6 +346,12 @@ var routePaths = routes{
 	TxSubsidyRouteName:              {Method: http.MethodPost, Path: TxSubsidyRoutePath},
 	TxStartPollRouteName:            {Method: http.MethodPost, Path: TxStartPollRoutePath},
 	TxVotePollRouteName:             {Method: http.MethodPost, Path: TxVotePollRoutePath},
+	TxCreateMultisigRouteName:       {Method: http.MethodPost, Path: TxCreateMultisigRoutePath},
+	TxMultisigProposeRouteName:      {Method: http.MethodPost, Path: TxMultisigProposeRoutePath},
+	TxMultisigSignRouteName:         {Method: http.MethodPost, Path: TxMultisigSignRoutePath},
+	TxMultisigSubmitRouteName:       {Method: http.MethodPost, Path: TxMultisigSubmitRoutePath},
+	TxSubmitProposalRouteName:       {Method: http.MethodPost, Path: TxSubmitProposalRoutePath},
+	TxVoteRouteName:                 {Method: http.MethodPost, Path: TxVoteRoutePath},
 	ResourceUsageRouteName:          {Method: http.MethodGet, Path: ResourceUsageRoutePath},
 	PeerInfoRouteName:               {Method: http.MethodGet, Path: PeerInfoRoutePath},
 	ConsensusInfoRouteName:          {Method: http.MethodGet, Path: ConsensusInfoRoutePath},
@@ -303,6 +361,7 @@ var routePaths = routes{
 	AddVoteRouteName:                {Method: http.MethodPost, Path: AddVoteRoutePath},
 	DelVoteRouteName:                {Method: http.MethodPost, Path: DelVoteRoutePath},
 	SubscribeRCInfoName:             {Method: http.MethodGet, Path: SubscribeRCInfoPath},
+	SubscribeRouteName:              {Method: http.MethodGet, Path: SubscribeRoutePath},
 }
 
 // httpRouteHandlers is a custom type that maps strings to httprouter handle functions
@@ -314,6 +373,7 @@ func createRouter(s *Server) *httprouter.Router {
 		VersionRouteName:               s.Version,
 		TxRouteName:                    s.Transaction,
 		TxsRouteName:                   s.Transactions,
+		TxSimulateRouteName:            s.TransactionSimulate,
 		HeightRouteName:                s.Height,
 		IndexerBlobsRouteName:          s.IndexerBlobs,
 		AccountRouteName:               s.Account,
@@ -354,6 +414,12 @@ func createRouter(s *Server) *httprouter.Router {
 		DexPriceRouteName:              s.DexPrice,
 		DexBatchRouteName:              s.DexBatch,
 		NextDexBatchRouteName:          s.NextDexBatch,
+		DexOrdersRouteName:             s.DexOrders,
+		DexTWAPRouteName:               s.DexTWAP,
+		DexQuoteRouteName:              s.DexQuote,
+		DexPositionRouteName:           s.DexPosition,
+		DexHistoryRouteName:            s.DexHistory,
+		DexBatchStatusRouteName:        s.DexBatchStatus,
 		LastProposersRouteName:         s.LastProposers,
 		IsValidDoubleSignerRouteName:   s.IsValidDoubleSigner,
 		DoubleSignersRouteName:         s.DoubleSigners,
@@ -365,8 +431,14 @@ func createRouter(s *Server) *httprouter.Router {
 		PollRouteName:                  s.Poll,
 		RootChainInfoRouteName:         s.RootChainInfo,
 		CheckpointRouteName:            s.Checkpoint,
+		ProofRouteName:                 s.Proof,
+		MultisigAccountRouteName:       s.MultisigAccount,
+		AccountNonceRouteName:          s.AccountNonce,
+		GovProposalsRouteName:          s.GovProposals,
 		EthereumRouteName:              s.EthereumHandler,
+		EthereumWSRouteName:            s.EthereumWebSocket,
 		SubscribeRCInfoName:            s.WebSocket,
+		SubscribeRouteName:             s.Subscribe,
 	}
 
 	// Initialize a new router using the httprouter package.
@@ -405,6 +477,7 @@ func createAdminRouter(s *Server) *httprouter.Router {
 		TxEditOrderRouteName:            s.TransactionEditOrder,
 		TxDeleteOrderRouteName:          s.TransactionDeleteOrder,
 		TxDexLimitOrderRouteName:        s.TransactionDexLimitOrder,
+		TxDexRoutedSwapRouteName:        s.TransactionDexRoutedSwap,
 		TxDexLiquidityDepositRouteName:  s.TransactionDexLiquidityDeposit,
 		TxDexLiquidityWithdrawRouteName: s.TransactionDexLiquidityWithdraw,
 		TxLockOrderRouteName:            s.TransactionLockOrder,
@@ -412,6 +485,12 @@ func createAdminRouter(s *Server) *httprouter.Router {
 		TxSubsidyRouteName:              s.TransactionSubsidy,
 		TxStartPollRouteName:            s.TransactionStartPoll,
 		TxVotePollRouteName:             s.TransactionVotePoll,
+		TxCreateMultisigRouteName:       s.TransactionCreateMultisigAccount,
+		TxMultisigProposeRouteName:      s.TransactionMultisigPropose,
+		TxMultisigSignRouteName:         s.TransactionMultisigSign,
+		TxMultisigSubmitRouteName:       s.TransactionMultisigSubmit,
+		TxSubmitProposalRouteName:       s.TransactionSubmitProposal,
+		TxVoteRouteName:                 s.TransactionVote,
 		ResourceUsageRouteName:          s.ResourceUsage,
 		PeerInfoRouteName:               s.PeerInfo,
 		ConsensusInfoRouteName:          s.ConsensusInfo,
@@ -58,6 +58,9 @@ type Server struct {
 	// handles interactions with the root chain rpc
 	rcManager *RCManager
 
+	// publishes the block, transaction, event and mempool streams
+	subscriptions *SubscriptionManager
+
 	// handles the indexer blob caching
 	indexerBlobCache *indexerBlobCache
 
@@ -71,6 +74,7 @@ func NewServer(controller *controller.Controller, config lib.Config, logger lib.
 		config:           config,
 		logger:           logger,
 		rcManager:        NewRCManager(controller, config, logger),
+		subscriptions:    NewSubscriptionManager(controller, config, logger),
 		poll:             make(fsm.Poll),
 		pollMux:          &sync.RWMutex{},
 		indexerBlobCache: newIndexerBlobCache(100),
@@ -88,6 +92,7 @@ func (s *Server) Start() {
 	// Start tasks to update poll results and poll root chain information
 	go s.updatePollResults()
 	go s.rcManager.Start()
+	go s.subscriptions.Start()
 	go s.startEthFilterExpireService()
 
 	// Start heap profiler if enabled (warning: causes GC pauses which may affect RPC latency)
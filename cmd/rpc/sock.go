@@ -90,33 +90,14 @@ type RCManager struct {
 	dexBatch      dexBatchCache                 // per-height cache for GetDexBatch
 	orders        ordersCache                   // per-height cache for GetOrders
 	// rc subscriber limits
-	rcSubscriberReadLimitBytes int64
-	rcSubscriberWriteTimeout   time.Duration
-	rcSubscriberPongWait       time.Duration
-	rcSubscriberPingPeriod     time.Duration
-	maxRCSubscribers           int
-	maxRCSubscribersPerChain   int
-	subscriberCount            int
+	subscriberLimits         wsLimits
+	maxRCSubscribers         int
+	maxRCSubscribersPerChain int
+	subscriberCount          int
 }
 
 // NewRCManager() constructs a new instance of a RCManager
 func NewRCManager(controller *controller.Controller, config lib.Config, logger lib.LoggerI) (manager *RCManager) {
-	readLimit := config.RCSubscriberReadLimitBytes
-	if readLimit <= 0 {
-		readLimit = defaultRCSubscriberReadLimitBytes
-	}
-	writeTimeout := time.Duration(config.RCSubscriberWriteTimeoutMS) * time.Millisecond
-	if writeTimeout <= 0 {
-		writeTimeout = defaultRCSubscriberWriteTimeout
-	}
-	pongWait := time.Duration(config.RCSubscriberPongWaitS) * time.Second
-	if pongWait <= 0 {
-		pongWait = defaultRCSubscriberPongWait
-	}
-	pingPeriod := time.Duration(config.RCSubscriberPingPeriodS) * time.Second
-	if pingPeriod <= 0 || pingPeriod >= pongWait {
-		pingPeriod = pongWait * 9 / 10
-	}
 	maxSubscribers := config.MaxRCSubscribers
 	if maxSubscribers <= 0 {
 		maxSubscribers = defaultMaxRCSubscribers
@@ -131,20 +112,17 @@ func NewRCManager(controller *controller.Controller, config lib.Config, logger l
 	}
 	// create the manager
 	manager = &RCManager{
-		c:                          config,
-		controller:                 controller,
-		subscriptions:              make(map[uint64]*RCSubscription),
-		subscribers:                make(map[uint64][]*RCSubscriber),
-		l:                          controller.Mutex,
-		afterRCUpdate:              controller.UpdateRootChainInfo,
-		upgrader:                   websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }},
-		log:                        logger,
-		rcSubscriberReadLimitBytes: readLimit,
-		rcSubscriberWriteTimeout:   writeTimeout,
-		rcSubscriberPongWait:       pongWait,
-		rcSubscriberPingPeriod:     pingPeriod,
-		maxRCSubscribers:           maxSubscribers,
-		maxRCSubscribersPerChain:   maxSubscribersPerChain,
+		c:                        config,
+		controller:               controller,
+		subscriptions:            make(map[uint64]*RCSubscription),
+		subscribers:              make(map[uint64][]*RCSubscriber),
+		l:                        controller.Mutex,
+		afterRCUpdate:            controller.UpdateRootChainInfo,
+		upgrader:                 websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }},
+		log:                      logger,
+		subscriberLimits:         newWSLimits(config),
+		maxRCSubscribers:         maxSubscribers,
+		maxRCSubscribersPerChain: maxSubscribersPerChain,
 	}
 	// set the manager in the controller
 	controller.RCManager = manager
@@ -595,13 +573,122 @@ func (r *RCSubscription) Stop(err error) {
 
 // SUBSCRIBER CODE BELOW (INBOUND)
 
+// wsLimits are the keepalive and size limits applied to inbound websocket subscribers
+type wsLimits struct {
+	readLimitBytes int64         // max bytes allowed in a single ws message from a subscriber
+	writeTimeout   time.Duration // ws write deadline for publishing
+	pongWait       time.Duration // time to wait for pong responses
+	pingPeriod     time.Duration // how often to ping subscribers
+}
+
+// newWSLimits() populates the subscriber limits from the config, falling back to the defaults
+func newWSLimits(config lib.Config) wsLimits {
+	readLimit := config.RCSubscriberReadLimitBytes
+	if readLimit <= 0 {
+		readLimit = defaultRCSubscriberReadLimitBytes
+	}
+	writeTimeout := time.Duration(config.RCSubscriberWriteTimeoutMS) * time.Millisecond
+	if writeTimeout <= 0 {
+		writeTimeout = defaultRCSubscriberWriteTimeout
+	}
+	pongWait := time.Duration(config.RCSubscriberPongWaitS) * time.Second
+	if pongWait <= 0 {
+		pongWait = defaultRCSubscriberPongWait
+	}
+	pingPeriod := time.Duration(config.RCSubscriberPingPeriodS) * time.Second
+	if pingPeriod <= 0 || pingPeriod >= pongWait {
+		pingPeriod = pongWait * 9 / 10
+	}
+	return wsLimits{readLimitBytes: readLimit, writeTimeout: writeTimeout, pongWait: pongWait, pingPeriod: pingPeriod}
+}
+
+// wsSubscriber implements the lifecycle shared by every inbound websocket subscriber: keepalive, bounded writes and teardown
+type wsSubscriber struct {
+	conn     *websocket.Conn // the underlying ws connection
+	limits   wsLimits        // keepalive and size limits
+	onStop   func()          // removes the subscriber from its manager
+	onRead   func([]byte)    // optional: handles inbound messages, which are otherwise discarded
+	log      lib.LoggerI     // stdout log
+	writeMu  sync.Mutex      // protects concurrent writes
+	stopOnce sync.Once       // ensures teardown happens once
+	done     chan struct{}   // closed on teardown
+}
+
+// newWSSubscriber() wraps an upgraded connection
+func newWSSubscriber(conn *websocket.Conn, limits wsLimits, log lib.LoggerI) *wsSubscriber {
+	return &wsSubscriber{conn: conn, limits: limits, log: log, done: make(chan struct{})}
+}
+
+// Start() configures and starts subscriber lifecycle goroutines
+func (r *wsSubscriber) Start() {
+	r.conn.SetReadLimit(r.limits.readLimitBytes)
+	_ = r.conn.SetReadDeadline(time.Now().Add(r.limits.pongWait))
+	r.conn.SetPongHandler(func(string) error {
+		_ = r.conn.SetReadDeadline(time.Now().Add(r.limits.pongWait))
+		return nil
+	})
+	go r.readLoop()
+	go r.pingLoop()
+}
+
+func (r *wsSubscriber) readLoop() {
+	for {
+		_, msg, err := r.conn.ReadMessage()
+		if err != nil {
+			r.Stop(err)
+			return
+		}
+		if r.onRead != nil {
+			r.onRead(msg)
+		}
+	}
+}
+
+func (r *wsSubscriber) pingLoop() {
+	ticker := time.NewTicker(r.limits.pingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			if err := r.writeMessage(websocket.PingMessage, nil); err != nil {
+				r.Stop(err)
+				return
+			}
+		}
+	}
+}
+
+func (r *wsSubscriber) writeMessage(messageType int, data []byte) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	_ = r.conn.SetWriteDeadline(time.Now().Add(r.limits.writeTimeout))
+	return r.conn.WriteMessage(messageType, data)
+}
+
+// Stop() stops the client
+func (r *wsSubscriber) Stop(err error) {
+	r.stopOnce.Do(func() {
+		// log the error
+		r.log.Errorf("WS Failed with err: %s", err.Error())
+		close(r.done)
+		// close the connection
+		if err = r.conn.Close(); err != nil {
+			r.log.Error(err.Error())
+		}
+		// remove from the manager
+		if r.onStop != nil {
+			r.onStop()
+		}
+	})
+}
+
 // RCSubscriber (TransactionRoot Chain Subscriber) implements an efficient publishing service to nested chain subscribers
 type RCSubscriber struct {
-	chainId uint64          // the chain id of the publisher
-	manager *RCManager      // a reference to the manager of the ws clients
-	conn    *websocket.Conn // the underlying ws connection
-	log     lib.LoggerI     // stdout log
-	writeMu sync.Mutex      // protects concurrent writes
+	chainId       uint64     // the chain id of the publisher
+	manager       *RCManager // a reference to the manager of the ws clients
+	*wsSubscriber            // the underlying ws subscriber
 }
 
 // WebSocket() upgrades a http request to a websockets connection
@@ -632,11 +719,11 @@ func (s *Server) WebSocket(w http.ResponseWriter, r *http.Request, _ httprouter.
 	}
 	// create a new web sockets client
 	client := &RCSubscriber{
-		chainId: chainId,
-		conn:    conn,
-		manager: s.rcManager,
-		log:     s.logger,
+		chainId:      chainId,
+		manager:      s.rcManager,
+		wsSubscriber: newWSSubscriber(conn, s.rcManager.subscriberLimits, s.logger),
 	}
+	client.onStop = func() { s.rcManager.RemoveSubscriber(chainId, client) }
 	// add the connection to the manager
 	if err := s.rcManager.AddSubscriber(client); err != nil {
 		http.Error(w, err.Error(), http.StatusServiceUnavailable)
@@ -682,54 +769,3 @@ func (r *RCManager) RemoveSubscriber(chainId uint64, subscriber *RCSubscriber) {
 		r.subscriberCount--
 	}
 }
-
-// Start() configures and starts subscriber lifecycle goroutines
-func (r *RCSubscriber) Start() {
-	r.conn.SetReadLimit(r.manager.rcSubscriberReadLimitBytes)
-	_ = r.conn.SetReadDeadline(time.Now().Add(r.manager.rcSubscriberPongWait))
-	r.conn.SetPongHandler(func(string) error {
-		_ = r.conn.SetReadDeadline(time.Now().Add(r.manager.rcSubscriberPongWait))
-		return nil
-	})
-	go r.readLoop()
-	go r.pingLoop()
-}
-
-func (r *RCSubscriber) readLoop() {
-	for {
-		if _, _, err := r.conn.ReadMessage(); err != nil {
-			r.Stop(err)
-			return
-		}
-	}
-}
-
-func (r *RCSubscriber) pingLoop() {
-	ticker := time.NewTicker(r.manager.rcSubscriberPingPeriod)
-	defer ticker.Stop()
-	for range ticker.C {
-		if err := r.writeMessage(websocket.PingMessage, nil); err != nil {
-			r.Stop(err)
-			return
-		}
-	}
-}
-
-func (r *RCSubscriber) writeMessage(messageType int, data []byte) error {
-	r.writeMu.Lock()
-	defer r.writeMu.Unlock()
-	_ = r.conn.SetWriteDeadline(time.Now().Add(r.manager.rcSubscriberWriteTimeout))
-	return r.conn.WriteMessage(messageType, data)
-}
-
-// Stop() stops the client
-func (r *RCSubscriber) Stop(err error) {
-	// log the error
-	r.log.Errorf("WS Failed with err: %s", err.Error())
-	// close the connection
-	if err = r.conn.Close(); err != nil {
-		r.log.Error(err.Error())
-	}
-	// remove from the manager
-	r.manager.RemoveSubscriber(r.chainId, r)
-}
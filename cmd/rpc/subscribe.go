@@ -0,0 +1,460 @@
+package rpc
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/canopy-network/canopy/controller"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/store"
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+)
+
+/* This file implements the '/v1/subscribe' websocket streams of committed blocks, transactions, events and mempool admissions */
+
+const (
+	StreamBlocks  = "blocks"  // committed block headers
+	StreamTxs     = "txs"     // committed transactions
+	StreamEvents  = "events"  // committed events
+	StreamMempool = "mempool" // transactions admitted to the mempool
+)
+
+const (
+	streamParamName      = "stream"
+	cursorParamName      = "cursor"
+	senderParamName      = "sender"
+	recipientParamName   = "recipient"
+	messageTypeParamName = "messageType"
+	eventTypeParamName   = "eventType"
+	addressParamName     = "address"
+
+	defaultMaxStreamSubscribers    = 512
+	defaultStreamMempoolBufferSize = 10_000
+	// streamPollInterval is how often the manager checks for newly committed heights
+	streamPollInterval = 500 * time.Millisecond
+	// streamMaxHeightsPerPass bounds the heights replayed at once so a far-behind subscriber yields between passes
+	streamMaxHeightsPerPass = 100
+)
+
+// StreamCursor is a resumable position in a stream: a height and the index of an item within that height
+// - blocks are indexed 0, transactions by their index in the block, events by their order in the block
+// - mempool admissions are indexed by their admission order at the pending height
+type StreamCursor struct {
+	Height uint64 `json:"height"`
+	Index  uint64 `json:"index"`
+}
+
+// Before() returns true if the cursor is positioned before another
+func (c StreamCursor) Before(o StreamCursor) bool {
+	return c.Height < o.Height || (c.Height == o.Height && c.Index < o.Index)
+}
+
+// String() returns the '<height>:<index>' form used by the cursor query parameter
+func (c StreamCursor) String() string { return fmt.Sprintf("%d:%d", c.Height, c.Index) }
+
+// parseStreamCursor() parses the '<height>:<index>' form of a cursor
+func parseStreamCursor(s string) (*StreamCursor, error) {
+	height, index, found := strings.Cut(s, ":")
+	if !found {
+		return nil, fmt.Errorf("invalid cursor %q, expected <height>:<index>", s)
+	}
+	h, err := strconv.ParseUint(height, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor height: %s", err.Error())
+	}
+	i, err := strconv.ParseUint(index, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor index: %s", err.Error())
+	}
+	return &StreamCursor{Height: h, Index: i}, nil
+}
+
+// StreamMessage is a single item published to a stream subscriber
+type StreamMessage struct {
+	Stream string       `json:"stream"` // the stream name
+	Cursor StreamCursor `json:"cursor"` // reconnect with this cursor to resume after this message
+	Data   any          `json:"data"`   // the block result, tx result or event
+}
+
+// StreamFilter narrows the items published to a subscriber; empty fields match everything
+type StreamFilter struct {
+	Sender      lib.HexBytes `json:"sender"`      // txs and mempool: the sender address
+	Recipient   lib.HexBytes `json:"recipient"`   // txs and mempool: the recipient address
+	MessageType string       `json:"messageType"` // txs and mempool: the message type name
+	EventType   string       `json:"eventType"`   // events: the event type
+	Address     lib.HexBytes `json:"address"`     // events: the address the event is indexed by
+	ChainId     uint64       `json:"chainId"`     // events: the chain id the event is indexed by
+}
+
+// matchesTx() returns true if the transaction result passes the filter
+func (f *StreamFilter) matchesTx(tx *lib.TxResult) bool {
+	if len(f.Sender) != 0 && !bytes.Equal(f.Sender, tx.Sender) {
+		return false
+	}
+	if len(f.Recipient) != 0 && !bytes.Equal(f.Recipient, tx.Recipient) {
+		return false
+	}
+	return f.MessageType == "" || f.MessageType == tx.MessageType
+}
+
+// matchesEvent() returns true if the event passes the filter
+func (f *StreamFilter) matchesEvent(e *lib.Event) bool {
+	if f.EventType != "" && f.EventType != e.EventType {
+		return false
+	}
+	if len(f.Address) != 0 && !bytes.Equal(f.Address, e.Address) {
+		return false
+	}
+	return f.ChainId == 0 || f.ChainId == e.ChainId
+}
+
+// streamIndexer is the subset of the indexer the committed streams read from
+type streamIndexer interface {
+	GetBlockHeaderByHeight(height uint64) (*lib.BlockResult, lib.ErrorI)
+	GetTxsByHeightNonPaginated(height uint64, newestToOldest bool) ([]*lib.TxResult, lib.ErrorI)
+	GetEventsNonPaginated(height uint64, newestToOldest bool) ([]*lib.Event, lib.ErrorI)
+}
+
+// readCommitted() reads the filtered items of a committed stream at a certain height in cursor order
+func readCommitted(st streamIndexer, stream string, filter *StreamFilter, height uint64) (msgs []*StreamMessage, err lib.ErrorI) {
+	switch stream {
+	case StreamBlocks:
+		block, e := st.GetBlockHeaderByHeight(height)
+		if e != nil {
+			return nil, e
+		}
+		if block == nil || block.BlockHeader == nil {
+			return nil, lib.ErrNilBlockHeader()
+		}
+		return []*StreamMessage{{Stream: stream, Cursor: StreamCursor{Height: height}, Data: block}}, nil
+	case StreamTxs:
+		txs, e := st.GetTxsByHeightNonPaginated(height, false)
+		if e != nil {
+			return nil, e
+		}
+		for _, tx := range txs {
+			if filter.matchesTx(tx) {
+				msgs = append(msgs, &StreamMessage{Stream: stream, Cursor: StreamCursor{Height: height, Index: tx.Index}, Data: tx})
+			}
+		}
+	case StreamEvents:
+		events, e := st.GetEventsNonPaginated(height, false)
+		if e != nil {
+			return nil, e
+		}
+		for i, event := range events {
+			if filter.matchesEvent(event) {
+				msgs = append(msgs, &StreamMessage{Stream: stream, Cursor: StreamCursor{Height: height, Index: uint64(i)}, Data: event})
+			}
+		}
+	}
+	return
+}
+
+// SubscriptionManager publishes the '/v1/subscribe' streams to websocket subscribers
+// - committed streams are replayed from the indexer so a subscriber resuming from a cursor never misses an item
+// - mempool admissions are kept in a bounded buffer, so resuming is best-effort beyond its capacity
+type SubscriptionManager struct {
+	controller     *controller.Controller         // reference to controller for state and mempool access
+	config         lib.Config                     // the global node config
+	subscribers    map[*StreamSubscriber]struct{} // the active subscribers
+	admissions     []*StreamMessage               // the most recent mempool admissions in cursor order
+	lastAdmission  StreamCursor                   // the cursor of the latest mempool admission
+	bufferSize     int                            // max mempool admissions kept
+	maxSubscribers int                            // max active subscribers
+	limits         wsLimits                       // keepalive and size limits of subscribers
+	upgrader       websocket.Upgrader             // upgrade http connection to ws
+	l              sync.Mutex                     // thread safety
+	log            lib.LoggerI                    // stdout log
+}
+
+// NewSubscriptionManager() constructs a new instance of a SubscriptionManager
+func NewSubscriptionManager(controller *controller.Controller, config lib.Config, logger lib.LoggerI) *SubscriptionManager {
+	maxSubscribers := config.MaxStreamSubscribers
+	if maxSubscribers <= 0 {
+		maxSubscribers = defaultMaxStreamSubscribers
+	}
+	bufferSize := config.StreamMempoolBufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultStreamMempoolBufferSize
+	}
+	return &SubscriptionManager{
+		controller:     controller,
+		config:         config,
+		subscribers:    make(map[*StreamSubscriber]struct{}),
+		bufferSize:     bufferSize,
+		maxSubscribers: maxSubscribers,
+		limits:         newWSLimits(config),
+		upgrader:       websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }},
+		log:            logger,
+	}
+}
+
+// Start() listens for mempool admissions and wakes the committed stream subscribers on each new height
+func (m *SubscriptionManager) Start() {
+	m.controller.OnMempoolAdmission(m.Admit)
+	lastHeight := m.controller.ChainHeight()
+	for range time.Tick(streamPollInterval) {
+		if height := m.controller.ChainHeight(); height != lastHeight {
+			lastHeight = height
+			m.notify(func(s *StreamSubscriber) bool { return s.stream != StreamMempool })
+		}
+	}
+}
+
+// Admit() buffers newly admitted mempool transactions and wakes the mempool subscribers
+// NOTE: this is called under the mempool lock so it must not block
+func (m *SubscriptionManager) Admit(admitted lib.TxResults) {
+	m.l.Lock()
+	for _, tx := range admitted {
+		// assign the next cursor at the pending height
+		cursor := StreamCursor{Height: tx.Height}
+		if len(m.admissions) != 0 && !m.lastAdmission.Before(cursor) {
+			// admissions at the same pending height continue its index sequence
+			cursor = StreamCursor{Height: m.lastAdmission.Height, Index: m.lastAdmission.Index + 1}
+		}
+		m.lastAdmission = cursor
+		m.admissions = append(m.admissions, &StreamMessage{Stream: StreamMempool, Cursor: cursor, Data: tx})
+	}
+	// drop the oldest admissions beyond the buffer size
+	if overflow := len(m.admissions) - m.bufferSize; overflow > 0 {
+		m.admissions = append([]*StreamMessage(nil), m.admissions[overflow:]...)
+	}
+	m.l.Unlock()
+	m.notify(func(s *StreamSubscriber) bool { return s.stream == StreamMempool })
+}
+
+// admissionsAfter() returns the buffered mempool admissions after a cursor that pass the filter
+func (m *SubscriptionManager) admissionsAfter(cursor StreamCursor, filter *StreamFilter) (msgs []*StreamMessage, last StreamCursor) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	last = cursor
+	for _, msg := range m.admissions {
+		if !cursor.Before(msg.Cursor) {
+			continue
+		}
+		last = msg.Cursor
+		if filter.matchesTx(msg.Data.(*lib.TxResult)) {
+			msgs = append(msgs, msg)
+		}
+	}
+	return
+}
+
+// notify() wakes the subscribers selected by the callback without blocking
+func (m *SubscriptionManager) notify(selected func(s *StreamSubscriber) bool) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	for s := range m.subscribers {
+		if !selected(s) {
+			continue
+		}
+		select {
+		case s.wake <- struct{}{}:
+		default: // already pending a wake up
+		}
+	}
+}
+
+// AddSubscriber() adds the subscriber to the manager
+func (m *SubscriptionManager) AddSubscriber(subscriber *StreamSubscriber) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+	if len(m.subscribers) >= m.maxSubscribers {
+		return fmt.Errorf("subscriber limit reached")
+	}
+	m.subscribers[subscriber] = struct{}{}
+	return nil
+}
+
+// RemoveSubscriber() gracefully deletes a stream subscriber
+func (m *SubscriptionManager) RemoveSubscriber(subscriber *StreamSubscriber) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	delete(m.subscribers, subscriber)
+}
+
+// latestHeight() returns the latest committed (indexed) height
+func (m *SubscriptionManager) latestHeight() uint64 {
+	if height := m.controller.ChainHeight(); height > 0 {
+		return height - 1
+	}
+	return 0
+}
+
+// Subscribe() upgrades a http request to a websockets connection publishing a filtered stream
+func (s *Server) Subscribe(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// parse the stream, filter and cursor before upgrading so errors are reported over http
+	query := r.URL.Query()
+	subscriber := &StreamSubscriber{stream: query.Get(streamParamName), wake: make(chan struct{}, 1), manager: s.subscriptions}
+	switch subscriber.stream {
+	case StreamBlocks, StreamTxs, StreamEvents, StreamMempool:
+	default:
+		http.Error(w, fmt.Sprintf("invalid stream %q", subscriber.stream), http.StatusBadRequest)
+		return
+	}
+	if err := subscriber.filter.fromQuery(query); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if c := query.Get(cursorParamName); c != "" {
+		cursor, err := parseStreamCursor(c)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		subscriber.cursor = *cursor
+	} else {
+		// without a cursor, start with the next item
+		subscriber.cursor = s.subscriptions.liveCursor(subscriber.stream)
+	}
+	// upgrade the connection to websockets
+	conn, err := s.subscriptions.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// log the issue (the upgrader already replied with an error)
+		s.logger.Error(err.Error())
+		return
+	}
+	subscriber.wsSubscriber = newWSSubscriber(conn, s.subscriptions.limits, s.logger)
+	subscriber.onStop = func() { s.subscriptions.RemoveSubscriber(subscriber) }
+	// add the connection to the manager
+	if err = s.subscriptions.AddSubscriber(subscriber); err != nil {
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, err.Error()), time.Now().Add(time.Second))
+		if closeErr := conn.Close(); closeErr != nil {
+			s.logger.Error(closeErr.Error())
+		}
+		return
+	}
+	subscriber.Start()
+	go subscriber.publishLoop()
+}
+
+// liveCursor() returns the cursor positioned after every item published so far
+func (m *SubscriptionManager) liveCursor(stream string) StreamCursor {
+	if stream == StreamMempool {
+		m.l.Lock()
+		defer m.l.Unlock()
+		return m.lastAdmission
+	}
+	return StreamCursor{Height: m.latestHeight(), Index: math.MaxUint64}
+}
+
+// fromQuery() populates the filter from the url query parameters
+func (f *StreamFilter) fromQuery(query map[string][]string) (err error) {
+	get := func(name string) string {
+		if v := query[name]; len(v) != 0 {
+			return v[0]
+		}
+		return ""
+	}
+	for name, ptr := range map[string]*lib.HexBytes{senderParamName: &f.Sender, recipientParamName: &f.Recipient, addressParamName: &f.Address} {
+		if v := get(name); v != "" {
+			if *ptr, err = lib.StringToBytes(v); err != nil {
+				return fmt.Errorf("invalid %s: %s", name, err.Error())
+			}
+		}
+	}
+	if v := get(chainIdParamName); v != "" {
+		if f.ChainId, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return fmt.Errorf("invalid %s: %s", chainIdParamName, err.Error())
+		}
+	}
+	f.MessageType, f.EventType = get(messageTypeParamName), get(eventTypeParamName)
+	return
+}
+
+// StreamSubscriber publishes a single filtered stream over a websocket connection
+type StreamSubscriber struct {
+	stream        string                            // the stream name
+	filter        StreamFilter                      // narrows the published items
+	cursor        StreamCursor                      // the position after which items are published
+	wake          chan struct{}                     // signals new items may be available
+	cancel        chan struct{}                     // optional: closed to end the stream without closing the connection
+	deliver       func(msgs []*StreamMessage) error // optional: overrides how messages are written to the connection
+	manager       *SubscriptionManager              // a reference to the manager of the ws clients
+	*wsSubscriber                                   // the underlying ws subscriber
+}
+
+// publishLoop() publishes every item after the cursor, then waits to be woken for more
+func (s *StreamSubscriber) publishLoop() {
+	for {
+		more, err := s.publish()
+		if err != nil {
+			s.Stop(err)
+			return
+		}
+		if more {
+			continue
+		}
+		select {
+		case <-s.done:
+			return
+		case <-s.cancel:
+			return
+		case <-s.wake:
+		}
+	}
+}
+
+// publish() writes the next batch of items after the cursor and returns if more are immediately available
+func (s *StreamSubscriber) publish() (more bool, err error) {
+	var msgs []*StreamMessage
+	if s.stream == StreamMempool {
+		msgs, s.cursor = s.manager.admissionsAfter(s.cursor, &s.filter)
+		return false, s.write(msgs)
+	}
+	// the heights still to read: resume within the cursor height unless it was fully read
+	from, latest := s.cursor.Height, s.manager.latestHeight()
+	if s.cursor.Index == math.MaxUint64 {
+		from++
+	}
+	from = max(from, 1)
+	if from > latest {
+		return false, nil
+	}
+	to := min(latest, from+streamMaxHeightsPerPass-1)
+	st, e := store.NewStoreWithDB(s.manager.config, s.manager.controller.FSM.Store().(lib.StoreI).DB(), nil, s.log)
+	if e != nil {
+		return false, e
+	}
+	defer st.Discard()
+	for height := from; height <= to; height++ {
+		if msgs, e = readCommitted(st, s.stream, &s.filter, height); e != nil {
+			return false, e
+		}
+		// skip the items at or before the cursor
+		for len(msgs) != 0 && !s.cursor.Before(msgs[0].Cursor) {
+			msgs = msgs[1:]
+		}
+		if err = s.write(msgs); err != nil {
+			return false, err
+		}
+		// mark the height as fully read
+		s.cursor = StreamCursor{Height: height, Index: math.MaxUint64}
+	}
+	return to < latest, nil
+}
+
+// write() publishes the messages as json text frames
+func (s *StreamSubscriber) write(msgs []*StreamMessage) error {
+	if len(msgs) != 0 && s.deliver != nil {
+		return s.deliver(msgs)
+	}
+	for _, msg := range msgs {
+		bz, e := lib.MarshalJSON(msg)
+		if e != nil {
+			return e
+		}
+		if err := s.writeMessage(websocket.TextMessage, bz); err != nil {
+			return err
+		}
+	}
+	return nil
+}
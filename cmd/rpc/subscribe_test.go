@@ -0,0 +1,224 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/canopy-network/canopy/controller"
+	"github.com/canopy-network/canopy/fsm"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/canopy-network/canopy/store"
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStreamCursor(t *testing.T) {
+	tests := []struct {
+		name     string
+		detail   string
+		cursor   string
+		expected *StreamCursor
+		error    string
+	}{
+		{
+			name:   "missing separator",
+			detail: "the cursor must have a height and an index",
+			cursor: "10",
+			error:  "expected <height>:<index>",
+		},
+		{
+			name:   "invalid index",
+			detail: "the index must be a number",
+			cursor: "10:a",
+			error:  "invalid cursor index",
+		},
+		{
+			name:     "valid",
+			detail:   "the cursor is parsed into height and index",
+			cursor:   "10:2",
+			expected: &StreamCursor{Height: 10, Index: 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseStreamCursor(test.cursor)
+			require.Equal(t, test.error != "", err != nil, err)
+			if err != nil {
+				require.ErrorContains(t, err, test.error)
+				return
+			}
+			require.Equal(t, test.expected, got)
+			require.Equal(t, test.cursor, got.String())
+		})
+	}
+}
+
+func TestReadCommitted(t *testing.T) {
+	db, addrA, addrB := newTestStreamStore(t)
+	st := db.(*store.Store)
+	tests := []struct {
+		name     string
+		detail   string
+		stream   string
+		filter   StreamFilter
+		expected []StreamCursor
+	}{
+		{
+			name:     "block",
+			detail:   "the block header is published at index 0",
+			stream:   StreamBlocks,
+			expected: []StreamCursor{{Height: 1}},
+		},
+		{
+			name:     "all txs",
+			detail:   "every tx is published at its index",
+			stream:   StreamTxs,
+			expected: []StreamCursor{{Height: 1}, {Height: 1, Index: 1}, {Height: 1, Index: 2}},
+		},
+		{
+			name:     "txs by sender",
+			detail:   "only the txs from the sender are published",
+			stream:   StreamTxs,
+			filter:   StreamFilter{Sender: addrA},
+			expected: []StreamCursor{{Height: 1}, {Height: 1, Index: 2}},
+		},
+		{
+			name:     "txs by recipient and message type",
+			detail:   "only the txs matching every field are published",
+			stream:   StreamTxs,
+			filter:   StreamFilter{Recipient: addrB, MessageType: "stake"},
+			expected: []StreamCursor{{Height: 1, Index: 1}},
+		},
+		{
+			name:     "events by type and address",
+			detail:   "only the events matching every field are published",
+			stream:   StreamEvents,
+			filter:   StreamFilter{EventType: string(lib.EventTypeReward), Address: addrB},
+			expected: []StreamCursor{{Height: 1, Index: 1}},
+		},
+		{
+			name:     "events by chain id",
+			detail:   "only the events for the chain are published",
+			stream:   StreamEvents,
+			filter:   StreamFilter{ChainId: 2},
+			expected: []StreamCursor{{Height: 1, Index: 2}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msgs, err := readCommitted(st, test.stream, &test.filter, 1)
+			require.NoError(t, err)
+			var got []StreamCursor
+			for _, msg := range msgs {
+				require.Equal(t, test.stream, msg.Stream)
+				got = append(got, msg.Cursor)
+			}
+			require.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestSubscribeResumesFromCursor(t *testing.T) {
+	db, addrA, _ := newTestStreamStore(t)
+	log := lib.NewDefaultLogger()
+	sm := newTestRPCStateMachine(t, db, log)
+	setFSMHeight(t, sm, 2)
+	config := lib.DefaultConfig()
+	server := &Server{controller: &controller.Controller{FSM: sm}, config: config, logger: log}
+	server.subscriptions = NewSubscriptionManager(server.controller, config, log)
+	router := httprouter.New()
+	router.GET(SubscribeRoutePath, server.Subscribe)
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+	dial := func(query string) *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+SubscribeRoutePath+"?"+query, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	read := func(conn *websocket.Conn) (msg struct {
+		Stream string          `json:"stream"`
+		Cursor StreamCursor    `json:"cursor"`
+		Data   json.RawMessage `json:"data"`
+	}) {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, bz, err := conn.ReadMessage()
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bz, &msg))
+		return
+	}
+	// an invalid stream is rejected before upgrading
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+SubscribeRoutePath+"?stream=unknown", nil)
+	require.Error(t, err)
+	require.Equal(t, 400, resp.StatusCode)
+	// resume the sender's txs after the first one
+	conn := dial("stream=txs&sender=" + addrA.String() + "&cursor=1:0")
+	msg := read(conn)
+	require.Equal(t, StreamTxs, msg.Stream)
+	require.Equal(t, StreamCursor{Height: 1, Index: 2}, msg.Cursor)
+	// commit the next block and wake the subscribers
+	require.NoError(t, db.IndexBlock(&lib.BlockResult{
+		BlockHeader:  &lib.BlockHeader{Height: 2, Hash: crypto.Hash([]byte("stream-block-2")), Time: uint64(time.Now().UnixMicro())},
+		Transactions: []*lib.TxResult{newTestStreamTx(addrA, addrA, "send", 2, 0)},
+	}))
+	_, err = db.Commit()
+	require.NoError(t, err)
+	setFSMHeight(t, sm, 3)
+	server.subscriptions.notify(func(*StreamSubscriber) bool { return true })
+	msg = read(conn)
+	require.Equal(t, StreamCursor{Height: 2, Index: 0}, msg.Cursor)
+	// mempool admissions are replayed after the cursor
+	server.subscriptions.Admit(lib.TxResults{newTestStreamTx(addrA, addrA, "send", 3, 0), newTestStreamTx(addrA, addrA, "stake", 3, 1)})
+	mempool := dial("stream=mempool&messageType=stake&cursor=0:0")
+	msg = read(mempool)
+	require.Equal(t, StreamMempool, msg.Stream)
+	require.Equal(t, StreamCursor{Height: 3, Index: 1}, msg.Cursor)
+	// new admissions at the same pending height continue the index
+	server.subscriptions.Admit(lib.TxResults{newTestStreamTx(addrA, addrA, "stake", 3, 0)})
+	msg = read(mempool)
+	require.Equal(t, StreamCursor{Height: 3, Index: 2}, msg.Cursor)
+}
+
+// newTestStreamStore() creates an in-memory store with a committed block at height 1 with 3 txs and 3 events
+func newTestStreamStore(t *testing.T) (db lib.StoreI, addrA, addrB lib.HexBytes) {
+	t.Helper()
+	db, err := store.NewStoreInMemory(lib.NewDefaultLogger())
+	require.NoError(t, err)
+	addrA, addrB = bytes.Repeat([]byte{0xA1}, crypto.AddressSize), bytes.Repeat([]byte{0xB2}, crypto.AddressSize)
+	require.NoError(t, db.IndexBlock(&lib.BlockResult{
+		BlockHeader: &lib.BlockHeader{Height: 1, Hash: crypto.Hash([]byte("stream-block-1")), Time: uint64(time.Now().UnixMicro())},
+		Transactions: []*lib.TxResult{
+			newTestStreamTx(addrA, addrB, "send", 1, 0),
+			newTestStreamTx(addrB, addrB, "stake", 1, 1),
+			newTestStreamTx(addrA, addrA, "stake", 1, 2),
+		},
+		Events: []*lib.Event{
+			{EventType: string(lib.EventTypeReward), Address: addrA, Height: 1},
+			{EventType: string(lib.EventTypeReward), Address: addrB, Height: 1},
+			{EventType: string(lib.EventTypeSlash), Address: addrB, Height: 1, ChainId: 2},
+		},
+	}))
+	_, err = db.Commit()
+	require.NoError(t, err)
+	return
+}
+
+// newTestStreamTx() creates a transaction result with a unique hash
+func newTestStreamTx(sender, recipient lib.HexBytes, messageType string, height, index uint64) *lib.TxResult {
+	msg, _ := lib.NewAny(&fsm.MessageSend{FromAddress: sender, ToAddress: recipient, Amount: 1})
+	return &lib.TxResult{
+		Sender:      sender,
+		Recipient:   recipient,
+		MessageType: messageType,
+		Height:      height,
+		Index:       index,
+		Transaction: &lib.Transaction{MessageType: messageType, Msg: msg},
+		TxHash:      crypto.HashString([]byte{byte(height), byte(index), messageType[1]}),
+	}
+}
@@ -1,12 +1,14 @@
 package rpc
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/canopy-network/canopy/fsm"
 	"sync"
 
 	"github.com/canopy-network/canopy/lib"
 	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/canopy-network/canopy/store"
 )
 
 // =====================================================
@@ -36,6 +38,47 @@ type ordersRequest struct {
 	lib.PageParams
 }
 
+type dexOrdersRequest struct {
+	Committee uint64 `json:"committee"`
+	addressRequest
+	heightRequest
+	lib.PageParams
+}
+
+type dexTWAPRequest struct {
+	StartHeight uint64 `json:"startHeight"`
+	heightAndIdRequest
+}
+
+type dexPositionRequest struct {
+	Committee uint64 `json:"committee"`
+	addressRequest
+	heightRequest
+}
+
+type dexHistoryRequest struct {
+	Resolution uint64 `json:"resolution"`
+	StartTime  uint64 `json:"startTime"`
+	EndTime    uint64 `json:"endTime"`
+	Limit      int    `json:"limit"`
+	idRequest
+}
+
+type dexQuoteRequest struct {
+	Amount uint64   `json:"amount"`
+	Path   []uint64 `json:"path"`
+	heightRequest
+}
+
+type proofRequest struct {
+	Key       lib.HexBytes `json:"key"`
+	Account   lib.HexBytes `json:"account"`
+	Validator lib.HexBytes `json:"validator"`
+	OrderId   string       `json:"orderId"`
+	Committee uint64       `json:"committee"`
+	heightRequest
+}
+
 type heightsRequest struct {
 	heightRequest
 	StartHeight uint64 `json:"startHeight"`
@@ -188,6 +231,121 @@ type AccountView struct {
 
 type AccountViewPage []*AccountView
 
+// AccountNonceView is the account nonce state used to sign nonce protected transactions
+type AccountNonceView struct {
+	Address   lib.HexBytes `json:"address"`   // the account address
+	Nonce     uint64       `json:"nonce"`     // the committed nonce floor: the minimum nonce the next transaction may use
+	NextNonce uint64       `json:"nextNonce"` // the recommended nonce after the validated pending transactions
+}
+
+// ProofResponse is a merkle proof of a single state key against the state root committed in a block header
+type ProofResponse struct {
+	Height    uint64       `json:"height"`          // the height of the block that commits to the state root
+	BlockHash lib.HexBytes `json:"blockHash"`       // the hash of the block header
+	StateRoot lib.HexBytes `json:"stateRoot"`       // the state root in the block header
+	Key       lib.HexBytes `json:"key"`             // the state key being proven
+	Value     lib.HexBytes `json:"value,omitempty"` // the value at the key; empty for non-membership proofs
+	Exists    bool         `json:"exists"`          // true for a membership proof, false for a non-membership proof
+	Proof     []*lib.Node  `json:"proof"`           // the sparse merkle tree nodes from the leaf up to the root
+}
+
+// Verify() checks the proof against the state root
+// NOTE: the state root must be compared to a trusted block header for the result to be trustless
+func (p *ProofResponse) Verify() (bool, lib.ErrorI) {
+	return store.VerifyStateProof(p.Key, p.Value, p.Exists, p.StateRoot, p.Proof)
+}
+
+// MultisigTx is an unsigned multisig account transaction along with the member signatures collected so far
+type MultisigTx struct {
+	Transaction *lib.Transaction     `json:"transaction"` // the unsigned transaction proposed for the multisig account
+	Account     *fsm.MultisigAccount `json:"account"`     // the members and threshold of the multisig account
+	Signatures  []*MultisigSignature `json:"signatures"`  // the partial signatures collected from the members
+}
+
+// MultisigSignature is a single member's signature over the sign bytes of a multisig transaction
+type MultisigSignature struct {
+	PublicKey lib.HexBytes `json:"publicKey"`
+	Signature lib.HexBytes `json:"signature"`
+}
+
+// AddSignature() signs the transaction with a member key, replacing any previous signature from that member
+func (m *MultisigTx) AddSignature(pk crypto.PrivateKeyI) lib.ErrorI {
+	if m.Transaction == nil || m.Account == nil {
+		return lib.ErrEmptyTransaction()
+	}
+	publicKey := pk.PublicKey().Bytes()
+	// ensure the signer is a member of the multisig account
+	if _, err := m.memberIndex(publicKey); err != nil {
+		return err
+	}
+	signBytes, err := m.Transaction.GetSignBytes()
+	if err != nil {
+		return err
+	}
+	signature := &MultisigSignature{PublicKey: publicKey, Signature: pk.Sign(signBytes)}
+	// overwrite a previous signature from the same member
+	for i, s := range m.Signatures {
+		if bytes.Equal(s.PublicKey, publicKey) {
+			m.Signatures[i] = signature
+			return nil
+		}
+	}
+	m.Signatures = append(m.Signatures, signature)
+	return nil
+}
+
+// Aggregate() combines the collected member signatures into a single multisig signature on the transaction
+func (m *MultisigTx) Aggregate() (*lib.Transaction, lib.ErrorI) {
+	if m.Transaction == nil || m.Account == nil {
+		return nil, lib.ErrEmptyTransaction()
+	}
+	multiKey, err := m.Account.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	signBytes, err := m.Transaction.GetSignBytes()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range m.Signatures {
+		index, e := m.memberIndex(s.PublicKey)
+		if e != nil {
+			return nil, e
+		}
+		// verify each partial signature individually so a bad member signature is reported clearly
+		publicKey, er := crypto.NewPublicKeyFromBytes(s.PublicKey)
+		if er != nil {
+			return nil, fsm.ErrInvalidPublicKey(er)
+		}
+		if !publicKey.VerifyBytes(signBytes, s.Signature) {
+			return nil, fsm.ErrInvalidSignature()
+		}
+		if er = multiKey.AddSigner(s.Signature, index); er != nil {
+			return nil, fsm.ErrInvalidSignature()
+		}
+	}
+	// ensure enough members signed to satisfy the threshold
+	if signers := uint32(multiKey.EnabledSignerCount()); signers < m.Account.Threshold {
+		return nil, lib.ErrMultisigThresholdUnmet(signers, m.Account.Threshold)
+	}
+	signature, er := multiKey.AggregateSignatures()
+	if er != nil {
+		return nil, fsm.ErrInvalidSignature()
+	}
+	m.Transaction.Signature = &lib.Signature{PublicKey: multiKey.Bytes(), Signature: signature}
+	return m.Transaction, nil
+}
+
+// memberIndex() returns the index of a public key in the multisig account's member list
+func (m *MultisigTx) memberIndex(publicKey []byte) (int, lib.ErrorI) {
+	for i, member := range m.Account.PublicKeys {
+		if bytes.Equal(member, publicKey) {
+			return i, nil
+		}
+	}
+	return 0, lib.ErrNotMultisigMember(lib.BytesToString(publicKey))
+}
+
 // AccountViewPage satisfies the Page interface.
 func (p *AccountViewPage) New() lib.Pageable { return &AccountViewPage{} }
 
@@ -311,6 +469,8 @@ type txDexLimitOrder struct {
 	Fee           uint64 `json:"fee"`
 	Amount        uint64 `json:"amount"`
 	ReceiveAmount uint64 `json:"receiveAmount"`
+	ExpiryHeight  uint64 `json:"expiryHeight"`
+	PartialFill   bool   `json:"partialFill"`
 	Submit        bool   `json:"submit"`
 	Password      string `json:"password"`
 	fromFields
@@ -318,21 +478,35 @@ type txDexLimitOrder struct {
 	committeesRequest
 }
 
+type txDexRoutedSwap struct {
+	Fee           uint64   `json:"fee"`
+	Amount        uint64   `json:"amount"`
+	ReceiveAmount uint64   `json:"receiveAmount"`
+	Path          []uint64 `json:"path"`
+	Submit        bool     `json:"submit"`
+	Password      string   `json:"password"`
+	fromFields
+	txChangeParamRequest
+}
+
 type txDexLiquidityDeposit struct {
-	Fee      uint64 `json:"fee"`
-	Amount   uint64 `json:"amount"`
-	Submit   bool   `json:"submit"`
-	Password string `json:"password"`
+	Fee       uint64 `json:"fee"`
+	Amount    uint64 `json:"amount"`
+	MinPoints uint64 `json:"minPoints"`
+	Submit    bool   `json:"submit"`
+	Password  string `json:"password"`
 	fromFields
 	txChangeParamRequest
 	committeesRequest
 }
 
 type txDexLiquidityWithdraw struct {
-	Fee      uint64 `json:"fee"`
-	Percent  int    `json:"percent"`
-	Submit   bool   `json:"submit"`
-	Password string `json:"password"`
+	Fee              uint64 `json:"fee"`
+	Percent          int    `json:"percent"`
+	MinAmount        uint64 `json:"minAmount"`
+	MinCounterAmount uint64 `json:"minCounterAmount"`
+	Submit           bool   `json:"submit"`
+	Password         string `json:"password"`
 	fromFields
 	txChangeParamRequest
 	committeesRequest
@@ -341,6 +515,7 @@ type txDexLiquidityWithdraw struct {
 type txLockOrder struct {
 	Fee            uint64       `json:"fee"`
 	OrderId        string       `json:"orderId"`
+	Amount         uint64       `json:"amount"`
 	ReceiveAddress lib.HexBytes `json:"receiveAddress"`
 	Submit         bool         `json:"submit"`
 	Password       string       `json:"password"`
@@ -374,6 +549,54 @@ type txVotePoll struct {
 	fromFields
 }
 
+type txCreateMultisigAccount struct {
+	Fee        uint64         `json:"fee"`
+	PublicKeys []lib.HexBytes `json:"publicKeys"`
+	Threshold  uint32         `json:"threshold"`
+	Submit     bool           `json:"submit"`
+	Password   string         `json:"password"`
+	fromFields
+}
+
+type txSubmitProposal struct {
+	Fee      uint64          `json:"fee"`
+	Amount   uint64          `json:"amount"`
+	MsgType  string          `json:"msgType"`
+	Msg      json.RawMessage `json:"msg"`
+	Submit   bool            `json:"submit"`
+	Password string          `json:"password"`
+	fromFields
+}
+
+type txVote struct {
+	Fee        uint64       `json:"fee"`
+	ProposalId lib.HexBytes `json:"proposalId"`
+	Approve    bool         `json:"approve"`
+	Submit     bool         `json:"submit"`
+	Password   string       `json:"password"`
+	fromFields
+	signerFields
+}
+
+type txMultisigPropose struct {
+	Fee     uint64          `json:"fee"`
+	Memo    string          `json:"memo"`
+	MsgType string          `json:"msgType"`
+	Msg     json.RawMessage `json:"msg"`
+	fromFields
+}
+
+type txMultisigSign struct {
+	MultisigTx json.RawMessage `json:"multisigTx"`
+	Password   string          `json:"password"`
+	fromFields
+}
+
+type txMultisigSubmit struct {
+	MultisigTx json.RawMessage `json:"multisigTx"`
+	Submit     bool            `json:"submit"`
+}
+
 type txChangeParamRequest struct {
 	ParamSpace string `json:"paramSpace"`
 	ParamKey   string `json:"paramKey"`
@@ -419,6 +642,20 @@ type txRequest struct {
 	PollApprove        bool            `json:"pollApprove"`
 	Signer             lib.HexBytes    `json:"signer"`
 	SignerNickname     string          `json:"signerNickname"`
+	PublicKeys         []lib.HexBytes  `json:"publicKeys"`
+	Threshold          uint32          `json:"threshold"`
+	MsgType            string          `json:"msgType"`
+	Msg                json.RawMessage `json:"msg"`
+	MultisigTx         *MultisigTx     `json:"multisigTx"`
+	ProposalId         lib.HexBytes    `json:"proposalId"`
+	Approve            bool            `json:"approve"`
+	Nonce              uint64          `json:"nonce"`
+	ExpiryHeight       uint64          `json:"expiryHeight"`
+	PartialFill        bool            `json:"partialFill"`
+	Path               []uint64        `json:"path"`
+	MinPoints          uint64          `json:"minPoints"`
+	MinAmount          uint64          `json:"minAmount"`
+	MinCounterAmount   uint64          `json:"minCounterAmount"`
 	addressRequest
 	nicknameRequest
 	passwordRequest
@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/canopy-network/canopy/fsm"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultisigTxAggregate(t *testing.T) {
+	// create 3 member keys with a 2 of 3 policy
+	var (
+		members    []crypto.PrivateKeyI
+		publicKeys [][]byte
+	)
+	for i := 0; i < 3; i++ {
+		pk, err := crypto.NewBLS12381PrivateKey()
+		require.NoError(t, err)
+		members = append(members, pk)
+		publicKeys = append(publicKeys, pk.PublicKey().Bytes())
+	}
+	multiKey, err := fsm.NewMultisigPublicKey(publicKeys, 2)
+	require.NoError(t, err)
+	account := &fsm.MultisigAccount{Address: multiKey.Address().Bytes(), PublicKeys: publicKeys, Threshold: 2}
+	// propose an unsigned send from the multisig address
+	tx, err := fsm.NewUnsignedTransaction(&fsm.MessageSend{
+		FromAddress: account.Address,
+		ToAddress:   newTestAddressBytes(t),
+		Amount:      100,
+	}, 1, 1, 10000, 1, "")
+	require.NoError(t, err)
+	multisigTx := &MultisigTx{Transaction: tx, Account: account}
+	// a non member may not sign
+	outsider, e := crypto.NewBLS12381PrivateKey()
+	require.NoError(t, e)
+	require.ErrorContains(t, multisigTx.AddSignature(outsider), "not a member")
+	// a single signature doesn't satisfy the threshold
+	require.NoError(t, multisigTx.AddSignature(members[2]))
+	_, err = multisigTx.Aggregate()
+	require.ErrorContains(t, err, "1 of 2 required signatures")
+	// signing twice with the same member replaces the signature
+	require.NoError(t, multisigTx.AddSignature(members[2]))
+	require.Len(t, multisigTx.Signatures, 1)
+	// pass the partially signed transaction between members as json
+	bz, e := json.Marshal(multisigTx)
+	require.NoError(t, e)
+	multisigTx = new(MultisigTx)
+	require.NoError(t, json.Unmarshal(bz, multisigTx))
+	require.NoError(t, multisigTx.AddSignature(members[0]))
+	// aggregate and verify the multisig signature
+	signed, err := multisigTx.Aggregate()
+	require.NoError(t, err)
+	signer, e := crypto.NewPublicKeyFromBytes(signed.Signature.PublicKey)
+	require.NoError(t, e)
+	require.Equal(t, lib.HexBytes(account.Address), lib.HexBytes(signer.Address().Bytes()))
+	signBytes, err := signed.GetSignBytes()
+	require.NoError(t, err)
+	require.True(t, signer.VerifyBytes(signBytes, signed.Signature.Signature))
+}
+
+// newTestAddressBytes() generates a random address for test messages
+func newTestAddressBytes(t *testing.T) []byte {
+	pk, err := crypto.NewBLS12381PrivateKey()
+	require.NoError(t, err)
+	return pk.PublicKey().Address().Bytes()
+}
@@ -25,73 +25,71 @@ func (c *Controller) ListenForBlock() {
 	cache, syncDetector := lib.NewMessageCache(), lib.NewBlockTracker(c.Sync, c.log)
 	// wait and execute for each inbound message received
 	for msg := range c.P2P.Inbox(Block) {
-		// create a variable to signal a 'stop loop'
-		var quit bool
-		// wrap in a function call to use 'defer' functionality
-		func() {
-			// lock the controller to prevent multi-thread conflicts
-			c.Lock()
-			// when iteration completes, unlock
-			defer c.Unlock()
-			// add a convenience variable to track the sender
-			sender := msg.Sender.Address.PublicKey
-			// check and add the message to the cache to prevent duplicates
-			if ok := cache.Add(msg); !ok {
-				// if fallen out of sync
-				quit = syncDetector.AddIfHas(sender, msg.Message, c.P2P.PeerCount())
-				// exit iteration
-				return
-			}
-			c.log.Debug("Handling block message")
-			// log the receipt of the block message
-			c.log.Infof("Received new block from %s ✉️", lib.BytesToTruncatedString(sender))
-			// try to unmarshal the message to a block message
-			blockMessage := new(lib.BlockMessage)
-			if err := lib.Unmarshal(msg.Message, blockMessage); err != nil {
-				// log the error
-				c.log.Debug("Invalid Peer Block Message")
-				// slash the peer's reputation
-				c.P2P.ChangeReputation(msg.Sender.Address.PublicKey, p2p.InvalidBlockRep)
-				// exit iteration
-				return
-			}
-			// 'handle' the peer block and certificate appropriately
-			qc, err := c.HandlePeerBlock(blockMessage, false)
-			// ensure no error
-			if err != nil {
-				// if new height notified
-				if err.Error() == lib.ErrNewHeight().Error() {
-					// if fallen out of sync
-					if quit = syncDetector.Add(sender, msg.Message, blockMessage.BlockAndCertificate.Header.Height, c.P2P.PeerCount()); quit {
-						// exit iteration
-						return
-					}
-				}
-				// log the error
-				c.log.Warnf("Peer block invalid:\n%s", err.Error())
-				// slash the peer's reputation
-				c.P2P.ChangeReputation(msg.Sender.Address.PublicKey, p2p.InvalidBlockRep)
-				// exit iteration
-				return
-			}
-			// if not syncing - gossip the block
-			if !c.Syncing().Load() {
-				// gossip the block to our peers
-				c.GossipBlock(qc, sender, blockMessage.Time)
-				// signal a reset to the bft module
-				c.Consensus.ResetBFT <- bft.ResetBFT{StartTime: time.UnixMicro(int64(blockMessage.Time))}
-			}
-			// reset 'syncDetector' because a new block was received properly
-			syncDetector.Reset()
-		}()
 		// if quit signaled
-		if quit {
+		if quit := c.handleBlockMessage(msg, cache, syncDetector); quit {
 			// exit the loop
 			return
 		}
 	}
 }
 
+// handleBlockMessage() handles a single inbound block message, returning 'quit' if the node has fallen out of sync
+func (c *Controller) handleBlockMessage(msg *lib.MessageAndMetadata, cache *lib.MessageCache, syncDetector *lib.NewHeightTracker) (quit bool) {
+	// lock the controller to prevent multi-thread conflicts
+	c.Lock()
+	// when iteration completes, unlock
+	defer c.Unlock()
+	// add a convenience variable to track the sender
+	sender := msg.Sender.Address.PublicKey
+	// check and add the message to the cache to prevent duplicates
+	if ok := cache.Add(msg); !ok {
+		// if fallen out of sync
+		return syncDetector.AddIfHas(sender, msg.Message, c.P2P.PeerCount())
+	}
+	c.log.Debug("Handling block message")
+	// log the receipt of the block message
+	c.log.Infof("Received new block from %s ✉️", lib.BytesToTruncatedString(sender))
+	// try to unmarshal the message to a block message
+	blockMessage := new(lib.BlockMessage)
+	if err := lib.Unmarshal(msg.Message, blockMessage); err != nil {
+		// log the error
+		c.log.Debug("Invalid Peer Block Message")
+		// slash the peer's reputation
+		c.P2P.ChangeReputation(msg.Sender.Address.PublicKey, p2p.InvalidBlockRep)
+		// exit
+		return
+	}
+	// 'handle' the peer block and certificate appropriately
+	qc, err := c.HandlePeerBlock(blockMessage, false)
+	// ensure no error
+	if err != nil {
+		// if new height notified
+		if err.Error() == lib.ErrNewHeight().Error() {
+			// if fallen out of sync
+			if quit = syncDetector.Add(sender, msg.Message, blockMessage.BlockAndCertificate.Header.Height, c.P2P.PeerCount()); quit {
+				// exit
+				return
+			}
+		}
+		// log the error
+		c.log.Warnf("Peer block invalid:\n%s", err.Error())
+		// slash the peer's reputation
+		c.P2P.ChangeReputation(msg.Sender.Address.PublicKey, p2p.InvalidBlockRep)
+		// exit
+		return
+	}
+	// if not syncing - gossip the block
+	if !c.Syncing().Load() {
+		// gossip the block to our peers
+		c.GossipBlock(qc, sender, blockMessage.Time)
+		// signal a reset to the bft module
+		c.Consensus.ResetBFT <- bft.ResetBFT{StartTime: time.UnixMicro(int64(blockMessage.Time))}
+	}
+	// reset 'syncDetector' because a new block was received properly
+	syncDetector.Reset()
+	return
+}
+
 // PUBLISHERS BELOW
 
 // GossipBlock() gossips a certificate (with block) through the P2P network for a specific chainId
@@ -228,7 +226,11 @@ func (c *Controller) ValidateProposal(rcBuildHeight uint64, qc *lib.QuorumCertif
 		return
 	}
 	// create a comparable certificate results (includes reward recipients, slash recipients, swap commands, etc)
-	compareResults := c.NewCertificateResults(c.FSM, block, blockResult, evidence, rcBuildHeight)
+	compareResults, err := c.NewCertificateResults(c.FSM, block, blockResult, evidence, rcBuildHeight)
+	if err != nil {
+		// exit with error
+		return
+	}
 	// ensure generated the same results
 	if !qc.Results.Equals(compareResults) {
 		// exit with error
@@ -727,7 +729,7 @@ func (c *Controller) currentProposalVoteConfig() fsm.GovProposalVoteConfig {
 	// if consensus is below round 3 AND it hasn't been more than 3 block times since the last block
 	if c.Consensus.CurrentRound() < 3 {
 		deadline := c.Consensus.ProposalVoteDeadlineUnixMilli()
-		if deadline != 0 && time.Now().UnixMilli() < deadline {
+		if deadline != 0 && c.Consensus.Clock.Now().UnixMilli() < deadline {
 			return fsm.GovProposalVoteConfig_APPROVE_LIST
 		}
 	}
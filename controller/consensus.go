@@ -91,6 +91,13 @@ func (c *Controller) Sync() {
 			return
 		}
 	}
+	// bootstrap a fresh node from a verified state snapshot (if enabled)
+	if c.Config.StateSync {
+		// on failure the store is back at its starting height, which the node block syncs from
+		if err = c.StateSync(); err != nil {
+			c.log.Warnf("State-sync failed, falling back to block sync: %s", err.Error())
+		}
+	}
 	// Find the height the FSM is expecting to receive next
 	fsmHeight := c.FSM.Height()
 	// queue contains block requests either in-flight or completed
@@ -118,7 +125,7 @@ func (c *Controller) Sync() {
 			// Get current chain height
 			fsmHeight := c.FSM.Height()
 			// Get an updated list of available peers
-			peers, _, _ := c.P2P.PeerSet.GetAllInfos()
+			peers, _, _ := c.P2P.GetAllInfos()
 			// Update syncing peers list
 			syncingPeers := make([]string, 0, len(peers))
 			for _, peer := range peers {
@@ -314,42 +321,43 @@ func (c *Controller) ListenForConsensus() {
 			// disregard the consensus message
 			continue
 		}
-		// execute in a sub-function to unify error handling and enable 'defer' functionality
-		if err := func() (err lib.ErrorI) {
-			// check and add the message to the cache to prevent duplicates
-			if ok := cache.Add(msg); !ok {
-				// duplicate, exit
-				return
-			}
-			// create a new 'consensus message' to unmarshal the bytes to
-			bftMsg := new(bft.Message)
-			// try to unmarshal into a consensus message
-			if err = lib.Unmarshal(msg.Message, bftMsg); err != nil {
-				// exit with error
-				return
-			}
-			// check whether the message should be gossiped
-			gossip, exit := c.ShouldGossip(bftMsg)
-			if gossip {
-				c.GossipConsensus(bftMsg, msg.Sender.Address.PublicKey)
-			}
-			// some messages should only be gossiped
-			if exit {
-				return
-			}
-			// route the message to the consensus module
-			if err = c.Consensus.HandleMessage(bftMsg); err != nil {
-				// exit with error
-				return
-			}
-			// exit
+		// route the message to the consensus module
+		c.handleConsensusMessage(msg, cache)
+	}
+}
+
+// handleConsensusMessage() handles a single inbound consensus message, slashing the reputation of the peer if it's invalid
+func (c *Controller) handleConsensusMessage(msg *lib.MessageAndMetadata, cache *lib.MessageCache) {
+	// execute in a sub-function to unify error handling
+	if err := func() (err lib.ErrorI) {
+		// check and add the message to the cache to prevent duplicates
+		if ok := cache.Add(msg); !ok {
+			// duplicate, exit
+			return
+		}
+		// create a new 'consensus message' to unmarshal the bytes to
+		bftMsg := new(bft.Message)
+		// try to unmarshal into a consensus message
+		if err = lib.Unmarshal(msg.Message, bftMsg); err != nil {
+			// exit with error
 			return
-		}(); err != nil {
-			// log the error
-			c.log.Errorf("Handling consensus message failed with err: %s", err.Error())
-			// slash the reputation of the peer
-			c.P2P.ChangeReputation(msg.Sender.Address.PublicKey, p2p.InvalidMsgRep)
 		}
+		// check whether the message should be gossiped
+		gossip, exit := c.ShouldGossip(bftMsg)
+		if gossip {
+			c.GossipConsensus(bftMsg, msg.Sender.Address.PublicKey)
+		}
+		// some messages should only be gossiped
+		if exit {
+			return
+		}
+		// route the message to the consensus module
+		return c.Consensus.HandleMessage(bftMsg)
+	}(); err != nil {
+		// log the error
+		c.log.Errorf("Handling consensus message failed with err: %s", err.Error())
+		// slash the reputation of the peer
+		c.P2P.ChangeReputation(msg.Sender.Address.PublicKey, p2p.InvalidMsgRep)
 	}
 }
 
@@ -630,7 +638,7 @@ func (c *Controller) UpdateP2PMustConnect(v *lib.ConsensusValidators) {
 		}
 		c.log.Infof("Updating must connects with %d validators, gossip: %t", lenMustConnects, gossip)
 		// send the list to the p2p module
-		c.P2P.MustConnectsReceiver <- mustConnects
+		c.P2P.SetMustConnects(mustConnects)
 	} else {
 		c.log.Info("Self IS NOT a validator 👎")
 	}
@@ -796,6 +804,7 @@ func (c *Controller) ConsensusSummary() ([]byte, lib.ErrorI) {
 		MinimumPowerFor23Maj: c.Consensus.ValidatorSet.MinimumMaj23,
 		Votes:                c.Consensus.Votes,
 		Status:               "",
+		Timeouts:             c.Consensus.TimeoutSummary(),
 	}
 	consensusSummary.BlockHash = c.Consensus.BlockHash
 	// if exists, populate the proposal hash
@@ -850,4 +859,5 @@ type ConsensusSummary struct {
 	MinimumPowerFor23Maj uint64                 `json:"minimumPowerFor23Maj"`
 	Votes                bft.VotesForHeight     `json:"votes"`
 	Status               string                 `json:"status"`
+	Timeouts             *bft.TimeoutSummary    `json:"timeouts"`
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/canopy-network/canopy/lib"
 	"github.com/canopy-network/canopy/lib/crypto"
 	"github.com/canopy-network/canopy/p2p"
+	"google.golang.org/protobuf/proto"
 )
 
 /* This file contains the 'Controller' implementation which acts as a bus between the bft, p2p, fsm, and store modules to create the node */
@@ -37,16 +38,40 @@ type Controller struct {
 	FSM       *fsm.StateMachine // the core protocol component responsible for maintaining and updating the state of the blockchain
 	Mempool   *Mempool          // the in memory list of pending transactions
 	Consensus *bft.BFT          // the async consensus process between the committee members for the chain
-	P2P       *p2p.P2P          // the P2P module the node uses to connect to the network
+	P2P       P2PI              // the P2P module the node uses to connect to the network
 
 	RCManager   lib.RCManagerI                     // the data manager for the 'root chain'
 	Plugin      *lib.Plugin                        // extensible plugin for FSM
+	Watcher     ExternalChainWatcherI              // witness of swap payments on an external chain (optional)
 	checkpoints map[uint64]map[uint64]lib.HexBytes // cached checkpoints loaded from file
 	isSyncing   *atomic.Bool                       // is the chain currently being downloaded from peers
 	log         lib.LoggerI                        // object for logging
 	*sync.Mutex                                    // mutex for thread safety
 }
 
+// P2PI is the peer-to-peer network the controller communicates through: the p2p module, or a simulated network in tests
+type P2PI interface {
+	Start()                                                                           // connect to the network
+	Stop()                                                                            // disconnect from the network
+	Inbox(topic lib.Topic) chan *lib.MessageAndMetadata                               // the inbound messages of a topic
+	SelfSend(fromPublicKey []byte, topic lib.Topic, payload proto.Message) lib.ErrorI // route a message to self
+	SendTo(publicKey []byte, topic lib.Topic, msg proto.Message) lib.ErrorI           // send a message to a peer
+	SendToPeers(topic lib.Topic, msg proto.Message, excludeKeys ...string) lib.ErrorI // send a message to all peers (gossip)
+	SendToRandPeer(topic lib.Topic, msg proto.Message) (*lib.PeerInfo, lib.ErrorI)    // send a message to a random peer
+	ChangeReputation(publicKey []byte, delta int32)                                   // reward or slash the reputation of a peer
+	PeerCount() int                                                                   // the number of connected peers
+	MaxPossiblePeers() int                                                            // the maximum number of connected peers
+	GetAllInfos() (res []*lib.PeerInfo, numInbound, numOutbound int)                  // the connected peers
+	GetBookPeers() []*p2p.BookPeer                                                    // the peers in the peer book
+	ID() *lib.PeerAddress                                                             // the self peer address
+	WaitForMinimumPeers()                                                             // block until the minimum peers are connected
+	GossipMode() bool                                                                 // if consensus messages are gossiped rather than sent directly
+	SetGossipMode(gossip bool)                                                        // set the gossip mode
+	SetMustConnects(mustConnects []*lib.PeerAddress)                                  // set the peers that must be connected to (the committee)
+}
+
+var _ P2PI = new(p2p.P2P)
+
 // New() creates a new instance of a Controller, this is the entry point when initializing an instance of a Canopy application
 func New(fsm *fsm.StateMachine, c lib.Config, valKey crypto.PrivateKeyI, metrics *lib.Metrics, l lib.LoggerI) (controller *Controller, err lib.ErrorI) {
 	address := valKey.PublicKey().Address()
@@ -81,6 +106,12 @@ func New(fsm *fsm.StateMachine, c lib.Config, valKey crypto.PrivateKeyI, metrics
 	}
 	// load checkpoints from file (if provided)
 	controller.loadCheckpointsFile()
+	// setup the external chain watcher if enabled
+	if c.SwapWatcher.EVMRPCUrl != "" {
+		if controller.Watcher, err = NewEVMWatcher(c.SwapWatcher); err != nil {
+			return nil, err
+		}
+	}
 	// setup plugin if enabled
 	if c.Plugin != "" {
 		if err = controller.PluginExecute(c.Plugin); err != nil {
@@ -163,6 +194,8 @@ func (c *Controller) StartListeners() {
 	c.log.Debug("Listening for inbound txs, block requests, and consensus messages")
 	// listen for syncing peers
 	go c.ListenForBlockRequests()
+	// listen for state-syncing peers
+	go c.ListenForStateRequests()
 	// listen for inbound consensus messages
 	go c.ListenForConsensus()
 	// listen for inbound
@@ -182,6 +215,10 @@ func (c *Controller) Stop() {
 	if err := c.FSM.Store().(lib.StoreI).Close(); err != nil {
 		c.log.Error(err.Error())
 	}
+	// close the consensus write-ahead log
+	if err := c.Consensus.WAL.Close(); err != nil {
+		c.log.Error(err.Error())
+	}
 	// stop the p2p module
 	c.P2P.Stop()
 	// stop the plugin process if configured
@@ -453,6 +490,20 @@ func (c *Controller) LoadMaxBlockSize() int {
 	return int(params.BlockSize)
 }
 
+// LoadPhaseTimeoutBounds() gets the bounds of adaptive phase timeouts from the state
+func (c *Controller) LoadPhaseTimeoutBounds() (minMS, maxMS int) {
+	// load the bounds from the nested chain FSM
+	params, _ := c.FSM.GetParamsCons()
+	// if the parameters are empty
+	if params == nil {
+		// use the protocol defaults
+		return lib.DefaultMinPhaseTimeoutMS, lib.DefaultMaxPhaseTimeoutMS
+	}
+	// return the bounds as set by the governance params
+	minBound, maxBound := params.PhaseTimeoutBounds()
+	return int(minBound), int(maxBound)
+}
+
 // LoadLastCommitTime() gets a timestamp from the most recent Quorum Block
 func (c *Controller) LoadLastCommitTime(height uint64) time.Time {
 	// load the certificate (and block) from the indexer
@@ -566,4 +617,6 @@ const (
 	Block        = lib.Topic_BLOCK
 	Tx           = lib.Topic_TX
 	Cons         = lib.Topic_CONSENSUS
+	StateRequest = lib.Topic_STATE_REQUEST
+	State        = lib.Topic_STATE
 )
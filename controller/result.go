@@ -17,7 +17,7 @@ const slowCertificateResultsThreshold = 500 * time.Millisecond
 // NewCertificateResults() creates a structure to hold the results of the certificate produced by a quorum in consensus
 func (c *Controller) NewCertificateResults(
 	fsm *fsm.StateMachine, block *lib.Block, blockResult *lib.BlockResult,
-	evidence *bft.ByzantineEvidence, rcBuildHeight uint64) (results *lib.CertificateResult) {
+	evidence *bft.ByzantineEvidence, rcBuildHeight uint64) (results *lib.CertificateResult, err lib.ErrorI) {
 	startTime := time.Now()
 	defer lib.TimeTrack(c.log, startTime, slowCertificateResultsThreshold)
 	// calculate reward recipients, creating a 'certificate results' object reference in the process
@@ -29,7 +29,9 @@ func (c *Controller) NewCertificateResults(
 	}
 	// handle swaps
 	swapsStartTime := time.Now()
-	c.HandleSwaps(fsm, blockResult, results, rcBuildHeight)
+	if err = c.HandleSwaps(fsm, blockResult, results, rcBuildHeight); err != nil {
+		return nil, err
+	}
 	swapsDuration := time.Since(swapsStartTime)
 	if c.Metrics != nil {
 		c.Metrics.CertResultsSwaps.Observe(swapsDuration.Seconds())
@@ -211,21 +213,22 @@ func (c *Controller) addPaymentPercent(toAdd *lib.LotteryWinner, results *lib.Ce
 }
 
 // HandleSwaps() handles the 'buy' side of the sell orders
-func (c *Controller) HandleSwaps(fsm *fsm.StateMachine, blockResult *lib.BlockResult, results *lib.CertificateResult, rootChainHeight uint64) {
+// NOTE: only a failure to witness the external chain is returned, as the results can't be built without it
+func (c *Controller) HandleSwaps(fsm *fsm.StateMachine, blockResult *lib.BlockResult, results *lib.CertificateResult, rootChainHeight uint64) lib.ErrorI {
 	var orders *lib.OrderBook
 	// load the root chain id
 	rootChainId, err := fsm.GetRootChainId()
 	if err != nil {
 		c.log.Error(err.Error())
 		// exit without handling
-		return
+		return nil
 	}
 	// check if own root
 	ownRoot, err := fsm.LoadIsOwnRoot()
 	if err != nil {
 		c.log.Error(err.Error())
 		// exit without handling
-		return
+		return nil
 	}
 	// execute a remote call to get the root chains order book to enact the 'buyer side'
 	if !ownRoot {
@@ -238,16 +241,27 @@ func (c *Controller) HandleSwaps(fsm *fsm.StateMachine, blockResult *lib.BlockRe
 	if err != nil {
 		c.log.Error(err.Error())
 		// exit without handling
-		return
+		return nil
 	}
 	// process the root chain order book against the state
-	lockOrders, closeOrders, resetOrders := fsm.ProcessRootChainOrderBook(orders, blockResult)
+	lockOrders, closeOrders, resetOrders, closeLocks, resetLocks := fsm.ProcessRootChainOrderBook(orders, blockResult)
+	commands := &lib.Orders{LockOrders: lockOrders, ResetOrders: resetOrders, CloseOrders: closeOrders, ResetLocks: resetLocks, CloseLocks: closeLocks}
+	// witness the payments of the 'counter asset' on an external chain (if configured)
+	if e := c.WitnessExternalPayments(fsm, orders, blockResult, commands); e != nil {
+		c.log.Errorf("External chain watcher failed: %s", e.Error())
+		// exit with error, as replicas that witnessed the external chain would build different results
+		return e
+	}
 	// add the orders to the certificate result - truncating the 'lock orders' for defensive spam protection
 	results.Orders = &lib.Orders{
-		LockOrders:  lib.TruncateSlice(lockOrders, 1000),
-		ResetOrders: resetOrders,
-		CloseOrders: closeOrders,
-	}
+		LockOrders:       lib.TruncateSlice(commands.LockOrders, 1000),
+		ResetOrders:      commands.ResetOrders,
+		CloseOrders:      commands.CloseOrders,
+		ResetLocks:       lib.TruncateSlice(commands.ResetLocks, lib.MaxLocksPerCertificate),
+		CloseLocks:       lib.TruncateSlice(commands.CloseLocks, lib.MaxLocksPerCertificate),
+		ExternalPayments: lib.TruncateSlice(commands.ExternalPayments, lib.MaxExternalPayments),
+	}
+	return nil
 }
 
 // CalculateSlashRecipients() calculates the addresses who receive slashes on the root-chain
@@ -335,8 +349,14 @@ func (c *Controller) HandleDex(sm *fsm.StateMachine, results *lib.CertificateRes
 	}
 	// if nested, populate the root dex batch structure with the
 	if rcId != c.Config.ChainId {
+		// get the governance set liveness fallback window
+		fallbackBlocks, e := sm.GetDexLivenessFallbackBlocks()
+		if e != nil {
+			c.log.Error(e.Error())
+			return
+		}
 		// determine if we should activate liveness fallback
-		livenessFallback := isTriggerBlock && !batch.IsEmpty() && (sm.Height()-batch.LockedHeight) >= lib.LivenessFallbackBlocks
+		livenessFallback := isTriggerBlock && !batch.IsEmpty() && (sm.Height()-batch.LockedHeight) >= fallbackBlocks
 		// set the root chain dex batch
 		if results.RootDexBatch, err = c.RCManager.GetDexBatch(rcId, rcBuildHeight, c.Config.ChainId, livenessFallback); err != nil {
 			c.log.Error(err.Error())
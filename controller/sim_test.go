@@ -0,0 +1,600 @@
+package controller
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/canopy-network/canopy/bft"
+	"github.com/canopy-network/canopy/fsm"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/canopy-network/canopy/p2p"
+	"github.com/canopy-network/canopy/store"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+/*
+	Deterministic consensus simulation: full nodes (fsm + controller + bft) wired through an in-process network on a virtual clock
+
+	- every message delivery, phase timer and delayed call is an event on a single queue, ordered by (virtual time, sequence)
+	- the events run one at a time on the test goroutine, so no real sockets, sleeps or threads are involved
+	- message latencies, drops and Byzantine choices are drawn from a seeded source, so a seed replays the same schedule
+	- faults are scripted: latency, drop rate, partitions, crashes and Byzantine leaders (silent or equivocating)
+	- after every event the committed blocks are checked for safety: no two nodes may commit different blocks at a height
+	- liveness is asserted by running until every live node reached a height, failing if a virtual deadline passes first
+
+	NOTE: block sync is replaced by catchUp(), the block timestamps and map iteration order of the nodes aren't virtualized;
+	so block hashes differ between runs while the schedule (the rounds and proposers of each height) is reproduced
+*/
+
+// simGenesisTime is the virtual time the simulated network starts at
+var simGenesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// simByzantine is the (mis)behavior of a simulated node when it's the proposer
+type simByzantine int
+
+const (
+	simHonest             simByzantine = iota // follows the protocol
+	simSilentLeader                           // withholds its proposer messages from the replicas
+	simEquivocatingLeader                     // sends a conflicting proposal to the replicas with an even id
+)
+
+// simNetwork is an in-process network of nodes on a virtual clock
+type simNetwork struct {
+	t         *testing.T
+	now       time.Time               // the virtual time
+	queue     simQueue                // the scheduled events
+	seq       uint64                  // the sequence of the last scheduled event, breaking ties in time
+	rng       *rand.Rand              // the seeded source of latencies, drops and Byzantine choices
+	nodes     []*simNode              // the nodes of the network
+	latency   time.Duration           // the minimum latency of a message between two nodes
+	jitter    time.Duration           // the random latency added to each message
+	dropRate  float64                 // the probability a message between two nodes is lost
+	groups    map[int]int             // node id -> partition group; messages between groups are lost (nil if healed)
+	committed map[uint64]lib.HexBytes // height -> the hash of the block committed
+	trace     []string                // the height, round and proposer of each commit, in order
+	byzantine int                     // the number of messages a Byzantine node withheld or forged
+}
+
+// simNode is a full node of the simulated network
+type simNode struct {
+	id           int                   // the index of the node in the network
+	net          *simNetwork           // the network the node is part of
+	key          crypto.PrivateKeyI    // the validator key of the node
+	c            *Controller           // the node itself
+	height       uint64                // the next height to check for a commit
+	consCache    *lib.MessageCache     // the duplicate filter of the consensus listener
+	blockCache   *lib.MessageCache     // the duplicate filter of the block listener
+	syncDetector *lib.NewHeightTracker // the out-of-sync detector of the block listener
+	crashed      bool                  // if the node stopped (it neither sends, receives nor fires timers)
+	behavior     simByzantine          // the behavior of the node as a proposer
+}
+
+// newSimNetwork() creates a network of validators with equal stake from a shared genesis (with any extra accounts), each node at height 1
+func newSimNetwork(t *testing.T, numNodes int, seed uint64, accounts ...*fsm.Account) *simNetwork {
+	n := &simNetwork{
+		t:         t,
+		now:       simGenesisTime,
+		rng:       rand.New(rand.NewPCG(seed, seed)),
+		latency:   10 * time.Millisecond,
+		jitter:    40 * time.Millisecond,
+		committed: make(map[uint64]lib.HexBytes),
+	}
+	genesis := &fsm.GenesisState{Time: uint64(simGenesisTime.UnixMicro()), Params: fsm.DefaultParams(), Accounts: accounts}
+	for i := range numNodes {
+		// derive the keys from the index, so the leader election is the same in every run
+		scalar := crypto.Hash(fmt.Appendf(nil, "simulated validator %d", i))
+		scalar[0] &= 0x3f // keep the scalar below the order of the curve
+		key, err := crypto.BytesToBLS12381PrivateKey(scalar)
+		require.NoError(t, err)
+		address := key.PublicKey().Address().Bytes()
+		genesis.Accounts = append(genesis.Accounts, &fsm.Account{Address: address, Amount: 1000000})
+		genesis.Validators = append(genesis.Validators, &fsm.Validator{
+			Address:      address,
+			PublicKey:    key.PublicKey().Bytes(),
+			Committees:   []uint64{lib.CanopyChainId},
+			NetAddress:   fmt.Sprintf("tcp://node-%d", i),
+			StakedAmount: 1000000000000,
+			Output:       address,
+			Compound:     true,
+		})
+		n.nodes = append(n.nodes, &simNode{id: i, net: n, key: key})
+	}
+	genesisJSON, e := json.Marshal(genesis)
+	require.NoError(t, e)
+	for _, node := range n.nodes {
+		node.start(genesisJSON)
+	}
+	return n
+}
+
+// start() creates the node from the genesis and starts its consensus at height 1
+func (node *simNode) start(genesisJSON []byte) {
+	t, log := node.net.t, lib.NewNullLogger()
+	config := lib.DefaultConfig()
+	config.DataDirPath, config.InMemory, config.RunVDF = t.TempDir(), true, false
+	require.NoError(t, os.WriteFile(filepath.Join(config.DataDirPath, lib.GenesisFilePath), genesisJSON, 0600))
+	db, err := store.New(config, nil, log)
+	require.NoError(t, err)
+	sm, err := fsm.New(config, db, nil, nil, log)
+	require.NoError(t, err)
+	node.c, err = New(sm, config, node.key, nil, log)
+	require.NoError(t, err)
+	t.Cleanup(func() { node.c.Mempool.FSM.Discard(); _ = node.c.FSM.Store().(lib.StoreI).Close() })
+	// replace the network, the root chain and the clock with their simulated counterparts
+	node.c.P2P, node.c.RCManager = &simP2P{node: node}, &simRootChain{node: node}
+	node.c.Consensus.Clock, node.c.Consensus.PhaseTimer = node, &simTimer{node: node}
+	node.height = sm.Height()
+	node.consCache, node.blockCache = lib.NewMessageCache(), lib.NewMessageCache()
+	node.syncDetector = lib.NewBlockTracker(func() {}, log)
+	// like Start(), validate the mempool and cache the first proposal before consensus begins
+	resetProposalConfig := node.c.SetFSMInConsensusModeForProposals()
+	require.NoError(t, node.c.Mempool.CheckMempool())
+	resetProposalConfig()
+	// like a node that finished syncing, start the first height
+	node.c.Consensus.HandleResetBFT(bft.ResetBFT{StartTime: node.net.now})
+}
+
+// EVENTS BELOW
+
+// simEvent is a function scheduled at a virtual time
+type simEvent struct {
+	at  time.Time // the virtual time of the event
+	seq uint64    // the order the event was scheduled in
+	fn  func()    // the function to execute
+}
+
+// simQueue is a min-heap of events ordered by time, then by sequence
+type simQueue []*simEvent
+
+func (q simQueue) Len() int { return len(q) }
+func (q simQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+func (q simQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *simQueue) Push(x any)   { *q = append(*q, x.(*simEvent)) }
+func (q *simQueue) Pop() any {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
+
+// schedule() executes the function once the virtual duration elapsed
+func (n *simNetwork) schedule(d time.Duration, fn func()) {
+	n.seq++
+	heap.Push(&n.queue, &simEvent{at: n.now.Add(d), seq: n.seq, fn: fn})
+}
+
+// step() executes the next event, then the consensus resets it triggered, then checks the safety of the commits
+func (n *simNetwork) step() {
+	e := heap.Pop(&n.queue).(*simEvent)
+	n.now = e.at
+	e.fn()
+	for _, node := range n.nodes {
+		for !node.crashed && len(node.c.Consensus.ResetBFT) > 0 {
+			node.c.Consensus.HandleResetBFT(<-node.c.Consensus.ResetBFT)
+		}
+		n.checkCommits(node)
+	}
+}
+
+// runFor() executes the events of a virtual duration
+func (n *simNetwork) runFor(d time.Duration) {
+	end := n.now.Add(d)
+	for n.queue.Len() != 0 && !n.queue[0].at.After(end) {
+		n.step()
+	}
+	n.now = end
+}
+
+// runUntilHeight() executes events until every live node reached the height, failing if the virtual deadline passes first
+func (n *simNetwork) runUntilHeight(height uint64, deadline time.Duration) {
+	end := n.now.Add(deadline)
+	for !n.allReached(height) {
+		if n.queue.Len() == 0 || n.queue[0].at.After(end) {
+			n.t.Fatalf("liveness: heights %v didn't reach %d within %s", n.heights(), height, deadline)
+		}
+		n.step()
+	}
+}
+
+// checkCommits() fails the test if the node committed a different block than another node at the same height
+func (n *simNetwork) checkCommits(node *simNode) {
+	for ; node.height < node.c.FSM.Height(); node.height++ {
+		qc, err := node.c.FSM.LoadCertificate(node.height)
+		require.NoError(n.t, err)
+		hash, found := n.committed[node.height]
+		if !found {
+			n.committed[node.height] = qc.BlockHash
+			n.trace = append(n.trace, fmt.Sprintf("height=%d round=%d proposer=%d", node.height, qc.Header.Round, n.nodeOf(qc.ProposerKey).id))
+			continue
+		}
+		if !bytes.Equal(hash, qc.BlockHash) {
+			n.t.Fatalf("safety: node %d committed %s at height %d, after %s was committed", node.id, qc.BlockHash, node.height, hash)
+		}
+	}
+}
+
+// FAULTS BELOW
+
+// partition() splits the network into groups that can't reach each other (nodes left out form their own group)
+func (n *simNetwork) partition(groups ...[]int) {
+	n.groups = make(map[int]int)
+	for _, node := range n.nodes {
+		n.groups[node.id] = -1 - node.id
+	}
+	for i, group := range groups {
+		for _, id := range group {
+			n.groups[id] = i
+		}
+	}
+}
+
+// heal() removes the partition
+func (n *simNetwork) heal() { n.groups = nil }
+
+// crash() stops a node: it no longer sends, receives or fires timers
+func (n *simNetwork) crash(id int) { n.nodes[id].crashed = true }
+
+// send() delivers a message after a random latency, unless it's lost to a crash, a partition or a random drop
+func (n *simNetwork) send(from, to *simNode, topic lib.Topic, payload proto.Message) {
+	if from.crashed || to.crashed {
+		return
+	}
+	// deliver the wire bytes, so the nodes never share a message
+	bz, err := lib.Marshal(payload)
+	require.NoError(n.t, err)
+	var delay time.Duration
+	if from != to {
+		if n.groups != nil && n.groups[from.id] != n.groups[to.id] {
+			return
+		}
+		if n.rng.Float64() < n.dropRate {
+			return
+		}
+		if bz = from.misbehave(to, topic, bz); bz == nil {
+			return
+		}
+		delay = n.latency + time.Duration(n.rng.Int64N(int64(n.jitter)+1))
+	}
+	msg := &lib.MessageAndMetadata{Message: bz, Sender: &lib.PeerInfo{Address: &lib.PeerAddress{PublicKey: from.c.PublicKey}}}
+	n.schedule(delay, func() { to.receive(from, topic, msg) })
+}
+
+// misbehave() applies the Byzantine behavior of the node to an outbound message (nil if withheld)
+func (node *simNode) misbehave(to *simNode, topic lib.Topic, bz []byte) []byte {
+	if node.behavior == simHonest || topic != Cons {
+		return bz
+	}
+	msg := new(bft.Message)
+	require.NoError(node.net.t, lib.Unmarshal(bz, msg))
+	if !msg.IsProposerMessage() {
+		return bz
+	}
+	switch {
+	case node.behavior == simSilentLeader:
+		node.net.byzantine++
+		return nil
+	case node.behavior == simEquivocatingLeader && msg.Header.Phase == bft.Propose && to.id%2 == 0:
+		// forge a different (but valid) block for the same view by changing its timestamp, and sign it again
+		block := new(lib.Block)
+		require.NoError(node.net.t, lib.Unmarshal(msg.Qc.Block, block))
+		block.BlockHeader.Time++
+		hash, err := block.BlockHeader.SetHash()
+		require.NoError(node.net.t, err)
+		blockBytes, err := lib.Marshal(block)
+		require.NoError(node.net.t, err)
+		msg.Qc.Block, msg.Qc.BlockHash = blockBytes, hash
+		require.NoError(node.net.t, msg.Sign(node.key))
+		forged, err := lib.Marshal(msg)
+		require.NoError(node.net.t, err)
+		node.net.byzantine++
+		return forged
+	}
+	return bz
+}
+
+// receive() routes an inbound message to the listener of its topic, like the p2p inbox would
+func (node *simNode) receive(from *simNode, topic lib.Topic, msg *lib.MessageAndMetadata) {
+	if node.crashed {
+		return
+	}
+	switch topic {
+	case Cons:
+		node.c.handleConsensusMessage(msg, node.consCache)
+	case Block:
+		node.catchUp(from, msg)
+		node.c.handleBlockMessage(msg, node.blockCache, node.syncDetector)
+	}
+}
+
+// catchUp() stands in for block sync: a node that missed blocks commits them from the certificates of the sender
+func (node *simNode) catchUp(from *simNode, msg *lib.MessageAndMetadata) {
+	blockMessage := new(lib.BlockMessage)
+	if err := lib.Unmarshal(msg.Message, blockMessage); err != nil || blockMessage.BlockAndCertificate.GetHeader() == nil {
+		return
+	}
+	for node.c.FSM.Height() < blockMessage.BlockAndCertificate.Header.Height {
+		qc, err := from.c.FSM.LoadCertificate(node.c.FSM.Height())
+		require.NoError(node.net.t, err)
+		node.c.Lock()
+		_, err = node.c.HandlePeerBlock(&lib.BlockMessage{ChainId: node.c.Config.ChainId, BlockAndCertificate: qc}, false)
+		node.c.Unlock()
+		require.NoError(node.net.t, err)
+	}
+}
+
+// HELPERS BELOW
+
+// allReached() returns true if every live node reached the height
+func (n *simNetwork) allReached(height uint64) bool {
+	for _, node := range n.nodes {
+		if !node.crashed && node.c.FSM.Height() < height {
+			return false
+		}
+	}
+	return true
+}
+
+// heights() returns the height of each node
+func (n *simNetwork) heights() (heights []uint64) {
+	for _, node := range n.nodes {
+		heights = append(heights, node.c.FSM.Height())
+	}
+	return
+}
+
+// nodeOf() returns the node with the public key (nil if none)
+func (n *simNetwork) nodeOf(publicKey []byte) *simNode {
+	for _, node := range n.nodes {
+		if bytes.Equal(node.c.PublicKey, publicKey) {
+			return node
+		}
+	}
+	return nil
+}
+
+// Now() returns the virtual time
+func (node *simNode) Now() time.Time { return node.net.now }
+
+// AfterFunc() schedules the function as an event, dropped if the node crashed in the meantime
+func (node *simNode) AfterFunc(d time.Duration, f func()) {
+	node.net.schedule(d, func() {
+		if !node.crashed {
+			f()
+		}
+	})
+}
+
+// simTimer is the phase timer of a node, firing the phase as an event rather than on a channel
+type simTimer struct {
+	node       *simNode
+	generation uint64 // incremented on every reset or stop, invalidating the events scheduled before
+}
+
+// C() returns a nil channel, as the simulation fires the phase directly
+func (t *simTimer) C() <-chan time.Time { return nil }
+
+// Reset() schedules the phase of the node after the duration
+func (t *simTimer) Reset(d time.Duration) {
+	t.generation++
+	generation := t.generation
+	t.node.AfterFunc(d, func() {
+		if t.generation == generation {
+			t.node.c.Consensus.HandlePhaseTimer()
+		}
+	})
+}
+
+// Stop() invalidates the scheduled phase
+func (t *simTimer) Stop() { t.generation++ }
+
+// simP2P is the network interface of a node: every node is a connected peer
+type simP2P struct{ node *simNode }
+
+var _ P2PI = new(simP2P)
+
+func (p *simP2P) Start()                                       {}
+func (p *simP2P) Stop()                                        {}
+func (p *simP2P) Inbox(lib.Topic) chan *lib.MessageAndMetadata { return nil }
+func (p *simP2P) ChangeReputation([]byte, int32)               {}
+func (p *simP2P) PeerCount() int                               { return len(p.node.net.nodes) - 1 }
+func (p *simP2P) MaxPossiblePeers() int                        { return len(p.node.net.nodes) }
+func (p *simP2P) GetBookPeers() []*p2p.BookPeer                { return nil }
+func (p *simP2P) ID() *lib.PeerAddress                         { return &lib.PeerAddress{PublicKey: p.node.c.PublicKey} }
+func (p *simP2P) WaitForMinimumPeers()                         {}
+func (p *simP2P) GossipMode() bool                             { return false }
+func (p *simP2P) SetGossipMode(bool)                           {}
+func (p *simP2P) SetMustConnects([]*lib.PeerAddress)           {}
+func (p *simP2P) SelfSend(_ []byte, topic lib.Topic, payload proto.Message) lib.ErrorI {
+	p.node.net.send(p.node, p.node, topic, payload)
+	return nil
+}
+
+func (p *simP2P) SendTo(publicKey []byte, topic lib.Topic, msg proto.Message) lib.ErrorI {
+	to := p.node.net.nodeOf(publicKey)
+	if to == nil || to == p.node {
+		return p2p.ErrPeerNotFound(lib.BytesToTruncatedString(publicKey))
+	}
+	p.node.net.send(p.node, to, topic, msg)
+	return nil
+}
+
+func (p *simP2P) SendToPeers(topic lib.Topic, msg proto.Message, excludeKeys ...string) lib.ErrorI {
+	for _, to := range p.node.net.nodes {
+		if to != p.node && !slices.Contains(excludeKeys, lib.BytesToString(to.c.PublicKey)) {
+			p.node.net.send(p.node, to, topic, msg)
+		}
+	}
+	return nil
+}
+
+func (p *simP2P) SendToRandPeer(topic lib.Topic, msg proto.Message) (*lib.PeerInfo, lib.ErrorI) {
+	to := p.node.net.nodes[(p.node.id+1+p.node.net.rng.IntN(len(p.node.net.nodes)-1))%len(p.node.net.nodes)]
+	p.node.net.send(p.node, to, topic, msg)
+	return &lib.PeerInfo{Address: &lib.PeerAddress{PublicKey: to.c.PublicKey}}, nil
+}
+
+func (p *simP2P) GetAllInfos() (res []*lib.PeerInfo, numInbound, numOutbound int) {
+	for _, peer := range p.node.net.nodes {
+		if peer != p.node {
+			res = append(res, &lib.PeerInfo{Address: &lib.PeerAddress{PublicKey: peer.c.PublicKey}, IsOutbound: true})
+		}
+	}
+	return res, 0, len(res)
+}
+
+// simRootChain is the root chain of a node: the chain is its own root, so it's answered from the node's state
+type simRootChain struct{ node *simNode }
+
+var _ lib.RCManagerI = new(simRootChain)
+
+func (r *simRootChain) Publish(uint64, *lib.RootChainInfo) {}
+func (r *simRootChain) ChainIds() []uint64                 { return nil }
+func (r *simRootChain) GetHeight(uint64) uint64            { return r.node.c.FSM.Height() }
+func (r *simRootChain) GetRootChainInfo(_, chainId uint64) (*lib.RootChainInfo, lib.ErrorI) {
+	return r.node.c.FSM.LoadRootChainInfo(chainId, 0)
+}
+
+func (r *simRootChain) GetValidatorSet(_, id, rootHeight uint64) (lib.ValidatorSet, lib.ErrorI) {
+	return r.node.c.FSM.LoadCommittee(id, rootHeight)
+}
+
+func (r *simRootChain) GetLotteryWinner(_, height, id uint64) (*lib.LotteryWinner, lib.ErrorI) {
+	sm, err := r.node.c.FSM.TimeMachine(height)
+	if err != nil {
+		return nil, err
+	}
+	defer sm.Discard()
+	return sm.LotteryWinner(id)
+}
+
+func (r *simRootChain) GetOrders(_, rootHeight, id uint64) (*lib.OrderBook, lib.ErrorI) {
+	sm, err := r.node.c.FSM.TimeMachine(rootHeight)
+	if err != nil {
+		return nil, err
+	}
+	defer sm.Discard()
+	return sm.GetOrderBook(id)
+}
+
+func (r *simRootChain) GetOrder(uint64, uint64, string, uint64) (*lib.SellOrder, lib.ErrorI) {
+	return nil, lib.ErrNotSubscribed()
+}
+
+func (r *simRootChain) GetDexBatch(_, height, committee uint64, withPoints bool) (*lib.DexBatch, lib.ErrorI) {
+	sm, err := r.node.c.FSM.TimeMachine(height)
+	if err != nil {
+		return nil, err
+	}
+	defer sm.Discard()
+	return sm.GetDexBatch(committee, true, withPoints)
+}
+
+func (r *simRootChain) IsValidDoubleSigner(_, height uint64, address string) (*bool, lib.ErrorI) {
+	bz, err := lib.StringToBytes(address)
+	if err != nil {
+		return nil, err
+	}
+	valid, err := r.node.c.FSM.Store().(lib.StoreI).IsValidDoubleSigner(bz, height)
+	return &valid, err
+}
+
+func (r *simRootChain) GetMinimumEvidenceHeight(uint64, uint64) (*uint64, lib.ErrorI) {
+	height, err := r.node.c.FSM.LoadMinimumEvidenceHeight()
+	return &height, err
+}
+
+func (r *simRootChain) GetCheckpoint(_, height, id uint64) (lib.HexBytes, lib.ErrorI) {
+	return r.node.c.FSM.Store().(lib.StoreI).GetCheckpoint(id, height)
+}
+
+func (r *simRootChain) Transaction(uint64, lib.TransactionI) (*string, lib.ErrorI) {
+	return nil, lib.ErrNotSubscribed()
+}
+
+// SCENARIOS BELOW
+
+func TestSimulationLatency(t *testing.T) {
+	n := newSimNetwork(t, 4, 1)
+	n.runUntilHeight(6, 10*time.Minute)
+	require.Len(t, n.committed, 5)
+}
+
+func TestSimulationDrops(t *testing.T) {
+	n := newSimNetwork(t, 4, 2)
+	n.dropRate = 0.1
+	n.runUntilHeight(4, 30*time.Minute)
+}
+
+func TestSimulationPartition(t *testing.T) {
+	n := newSimNetwork(t, 4, 3)
+	n.runUntilHeight(2, 5*time.Minute)
+	// split in halves: neither has +2/3 of the voting power, so nothing is committed
+	n.partition([]int{0, 1}, []int{2, 3})
+	committed := len(n.committed)
+	n.runFor(5 * time.Minute)
+	require.Len(t, n.committed, committed)
+	// once healed, the network makes progress again
+	n.heal()
+	n.runUntilHeight(n.heights()[0]+2, 30*time.Minute)
+}
+
+func TestSimulationMinorityPartition(t *testing.T) {
+	n := newSimNetwork(t, 4, 4)
+	// the majority (+2/3) keeps committing without the isolated node
+	n.partition([]int{0, 1, 2})
+	n.runFor(3 * time.Minute)
+	require.Greater(t, n.heights()[0], n.heights()[3]+1)
+	// once healed, the isolated node catches up and takes part again
+	n.heal()
+	n.runUntilHeight(n.heights()[0]+2, 30*time.Minute)
+}
+
+func TestSimulationCrash(t *testing.T) {
+	n := newSimNetwork(t, 4, 5)
+	n.runUntilHeight(2, 5*time.Minute)
+	n.crash(2)
+	n.runUntilHeight(5, 30*time.Minute)
+}
+
+func TestSimulationSilentLeader(t *testing.T) {
+	n := newSimNetwork(t, 4, 6)
+	n.nodes[3].behavior = simSilentLeader
+	n.runUntilHeight(6, 30*time.Minute)
+	// the silent node was elected at least once, and no block it proposed was committed
+	require.NotZero(t, n.byzantine)
+	for _, entry := range n.trace {
+		require.NotContains(t, entry, "proposer=3")
+	}
+}
+
+func TestSimulationEquivocatingLeader(t *testing.T) {
+	n := newSimNetwork(t, 4, 7)
+	n.nodes[3].behavior = simEquivocatingLeader
+	n.runUntilHeight(6, 30*time.Minute)
+	require.NotZero(t, n.byzantine)
+}
+
+func TestSimulationDeterministic(t *testing.T) {
+	run := func() []string {
+		n := newSimNetwork(t, 4, 8)
+		n.dropRate = 0.1
+		n.runUntilHeight(4, 30*time.Minute)
+		return n.trace
+	}
+	require.Equal(t, run(), run())
+}
@@ -0,0 +1,521 @@
+package controller
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/canopy-network/canopy/fsm"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/p2p"
+	"github.com/canopy-network/canopy/store"
+)
+
+/* This file contains the logic to bootstrap a fresh node from a verified state snapshot of its peers */
+
+const (
+	// Maximum number of key value pairs served in a single state chunk
+	stateChunkMaxEntries = 256
+	// Maximum number of consecutive failed chunk attempts before state-sync gives up
+	stateSyncMaxAttempts = 10
+	// How long an idle state cursor is kept open for a requesting peer
+	stateCursorTTL = time.Minute
+)
+
+// stateCursor is an open iterator over a state snapshot that allows consecutive chunk requests from the same peer to
+// resume where the last chunk ended rather than re-scanning the state from the beginning
+type stateCursor struct {
+	height   uint64        // the version of the state snapshot
+	lastKey  []byte        // the last key served from the iterator
+	store    lib.StoreI    // the read-only store the iterator belongs to
+	it       lib.IteratorI // the open iterator positioned at the next key to serve
+	lastUsed time.Time     // the last time the cursor served a chunk
+}
+
+// close() releases the iterator and the read-only store of the cursor
+func (s *stateCursor) close() {
+	s.it.Close()
+	s.store.Discard()
+}
+
+// StateSync() bootstraps a fresh node from a verified state snapshot rather than replaying every block from genesis
+// - the certificate at the trusted height and its parent are downloaded and hash-chained to the trusted hash
+// - the state before the trusted block is downloaded chunk by chunk and each entry is proven against the parent's state root
+// - the state after the trusted block is downloaded and each entry is proven against the trusted block's state root
+// - the restored root must equal the header state root, which guarantees no entries were withheld by the peers
+// - if only the parent state is restored, the store is rolled back to its starting height so the node can block sync from there
+// NOTE: historical state and blocks below the trusted height are unavailable on a state-synced node
+func (c *Controller) StateSync() (err lib.ErrorI) {
+	// get the trusted height and block hash to restore to
+	height, hash, err := c.stateSyncAnchor()
+	if err != nil {
+		return
+	}
+	// state-sync is only useful if the node is behind the trusted height
+	if c.FSM.Height() >= height {
+		c.log.Infof("Skipping state-sync, already at height %d", c.FSM.Height())
+		return
+	}
+	c.log.Infof("State-sync started 🔄 to height %d with hash %s", height, lib.BytesToTruncatedString(hash))
+	// create a limiter to prevent peers from disconnecting and slashing rep
+	limiter := lib.NewLimiter(p2p.MaxStateReqPerWindow, c.P2P.MaxPossiblePeers()*p2p.MaxStateReqPerWindow, p2p.StateReqWindowS, "STATE_SYNC", c.log)
+	// download the trusted certificate and block
+	qc, block, err := c.stateSyncBlock(height, hash)
+	if err != nil {
+		return
+	}
+	// download the parent certificate and block, chained by the last block hash
+	parentQC, parent, err := c.stateSyncBlock(height-1, block.BlockHeader.LastBlockHash)
+	if err != nil {
+		return
+	}
+	// cast the store to ensure the proper store type to complete this operation
+	st, ok := c.FSM.Store().(*store.Store)
+	if !ok {
+		return fsm.ErrWrongStoreType()
+	}
+	// save the starting height to roll back a partial restore
+	startHeight := st.Version()
+	// restore the state *before* the trusted block is applied; the trusted block's ancestry is needed to apply its child
+	if err = c.restoreSnapshot(st, parentQC, parent, height, limiter); err != nil {
+		return
+	}
+	// restore the state *after* the trusted block is applied
+	restoreErr := c.restoreSnapshot(st, qc, block, height+1, limiter)
+	// lock the controller to swap the state machine
+	c.Lock()
+	defer c.Unlock()
+	// the parent state alone can't apply the trusted block, as its committee is read from the history below it
+	// NOTE: consensus hasn't started yet, so the store can be rolled back like on a stopped node
+	if restoreErr != nil {
+		if err = st.Rollback(startHeight); err != nil {
+			return
+		}
+	}
+	// set up the finite state machine for the next height
+	if c.FSM, err = fsm.New(c.Config, st, c.Plugin, c.Metrics, c.log); err != nil {
+		return
+	}
+	if restoreErr != nil {
+		c.log.Warnf("State-sync rolled back the parent state, continuing from height %d", c.FSM.Height())
+		return restoreErr
+	}
+	c.log.Infof("State-sync complete ✅ continuing from height %d", c.FSM.Height())
+	return
+}
+
+// stateSyncAnchor() returns the trusted height and block hash state-sync restores to
+// the configured trust height and hash take precedence, falling back to the highest checkpoint in the checkpoints file
+func (c *Controller) stateSyncAnchor() (height uint64, hash lib.HexBytes, err lib.ErrorI) {
+	// if a trust height is configured
+	if height = c.Config.StateSyncTrustHeight; height != 0 {
+		// if a trust hash is configured
+		if c.Config.StateSyncTrustHash != "" {
+			// convert the hash to bytes
+			hash, err = lib.StringToBytes(c.Config.StateSyncTrustHash)
+			return
+		}
+		// else try the checkpoints file at that height
+		if hash = c.checkpointFromFile(height, c.Config.ChainId); hash == nil {
+			return 0, nil, lib.ErrNoStateSyncAnchor()
+		}
+		return
+	}
+	// use the highest checkpoint for this chain
+	for h, checkpoint := range c.checkpoints[c.Config.ChainId] {
+		if h > height {
+			height, hash = h, checkpoint
+		}
+	}
+	// at least the parent of the trusted block must be after genesis
+	if height < 2 || len(hash) == 0 {
+		return 0, nil, lib.ErrNoStateSyncAnchor()
+	}
+	return
+}
+
+// stateSyncBlock() requests the certificate and block at a height from random peers until one
+// responds with a block that hashes to the expected value
+func (c *Controller) stateSyncBlock(height uint64, expectedHash []byte) (qc *lib.QuorumCertificate, block *lib.Block, err lib.ErrorI) {
+	for attempt := 0; attempt < stateSyncMaxAttempts; attempt++ {
+		// request the block from a random peer
+		peerInfo, e := c.P2P.SendToRandPeer(BlockRequest, &lib.BlockRequestMessage{ChainId: c.Config.ChainId, Height: height})
+		if e != nil || peerInfo == nil {
+			c.log.Warnf("Unable to request block %d for state-sync, retrying", height)
+			time.Sleep(time.Second)
+			continue
+		}
+		// wait for the response
+		msg := c.waitForPeer(Block, peerInfo.Address.PublicKey, p2p.SyncTimeoutS)
+		if msg == nil {
+			continue
+		}
+		// verify the response against the expected hash
+		if qc, block, err = c.verifyStateSyncBlock(msg, height, expectedHash); err != nil {
+			c.log.Warnf("Invalid state-sync block %d: %s", height, err.Error())
+			c.P2P.ChangeReputation(peerInfo.Address.PublicKey, p2p.InvalidBlockRep)
+			continue
+		}
+		// success, increase the peer reputation
+		c.P2P.ChangeReputation(peerInfo.Address.PublicKey, p2p.GoodBlockRep)
+		return
+	}
+	return nil, nil, lib.ErrStateSyncTimeout()
+}
+
+// verifyStateSyncBlock() ensures the block message contains a certificate and block that hash to the expected value
+func (c *Controller) verifyStateSyncBlock(msg *lib.MessageAndMetadata, height uint64, expectedHash []byte) (qc *lib.QuorumCertificate, block *lib.Block, err lib.ErrorI) {
+	// try to unmarshal the p2p message to a block message
+	blockMessage := new(lib.BlockMessage)
+	if err = lib.Unmarshal(msg.Message, blockMessage); err != nil {
+		return
+	}
+	// do a basic validation on the certificate
+	if qc = blockMessage.BlockAndCertificate; qc == nil || qc.Header == nil || qc.Header.Height != height {
+		return nil, nil, lib.ErrWrongBlockHeight(qc.GetHeader().GetHeight(), height)
+	}
+	// ensure the certificate justifies the expected block
+	if !bytes.Equal(qc.BlockHash, expectedHash) {
+		return nil, nil, lib.ErrMismatchQCBlockHash()
+	}
+	// convert the block bytes into a block
+	block = new(lib.Block)
+	if err = lib.Unmarshal(qc.Block, block); err != nil {
+		return
+	}
+	// ensure the block is well-formed
+	if err = block.Check(c.Config.NetworkID, c.Config.ChainId); err != nil {
+		return
+	}
+	// re-compute the block hash to ensure the header isn't forged
+	hash, err := block.Hash()
+	if err != nil {
+		return
+	}
+	if !bytes.Equal(hash, expectedHash) || block.BlockHeader.Height != height {
+		return nil, nil, lib.ErrMismatchQCBlockHash()
+	}
+	return
+}
+
+// restoreSnapshot() downloads the state snapshot at a version, verifies it against the state root of the block that
+// produced it, indexes the block and its certificate, and commits it to the store
+func (c *Controller) restoreSnapshot(st *store.Store, qc *lib.QuorumCertificate, block *lib.Block, version uint64, limiter *lib.SimpleLimiter) (err lib.ErrorI) {
+	root := block.BlockHeader.StateRoot
+	// save the version to restore it in case of failure
+	startVersion := st.Version()
+	// move the store to the version *before* the snapshot, so the commit produces the snapshot's version
+	if err = st.SetVersion(version - 1); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			// discard the partial snapshot and restore the version
+			_ = st.SetVersion(startVersion)
+		}
+	}()
+	// walk the local state alongside the snapshot to delete any local keys that aren't part of it (i.e. genesis state)
+	// NOTE: the iterator is opened before anything is written, so it only streams the local state
+	local, err := st.Iterator(nil)
+	if err != nil {
+		return
+	}
+	defer local.Close()
+	restored := 0
+	// download the snapshot chunk by chunk
+	for startKey, done := []byte(nil), false; !done; {
+		chunk, e := c.requestStateChunk(version, startKey, root, limiter)
+		if e != nil {
+			return e
+		}
+		// delete the local keys in the range of the chunk that it doesn't contain
+		if err = deleteUnseen(st, local, chunk); err != nil {
+			return
+		}
+		// write each entry to the store
+		for _, entry := range chunk.Entries {
+			// skip unchanged entries to avoid writing redundant history
+			if value, _ := st.Get(entry.Key); value != nil && bytes.Equal(value, entry.Value) {
+				continue
+			}
+			if err = st.Set(entry.Key, entry.Value); err != nil {
+				return
+			}
+		}
+		// advance to the next chunk
+		if len(chunk.Entries) != 0 {
+			startKey = chunk.Entries[len(chunk.Entries)-1].Key
+		}
+		done, restored = chunk.Done, restored+len(chunk.Entries)
+		c.log.Debugf("Restored %d state entries at version %d", restored, version)
+	}
+	// ensure the restored state matches the state root in the trusted header before writing anything
+	restoredRoot, err := st.Root()
+	if err != nil {
+		return
+	}
+	if !bytes.Equal(restoredRoot, root) {
+		return lib.ErrMismatchStateSyncRoot(restoredRoot, root)
+	}
+	// index the quorum certificate in the store
+	if err = st.IndexQC(qc); err != nil {
+		return
+	}
+	// index the block header in the store; the transaction results are not part of the snapshot
+	if err = st.IndexBlock(&lib.BlockResult{BlockHeader: block.BlockHeader}); err != nil {
+		return
+	}
+	// atomically write the snapshot to the actual database
+	if _, err = st.Commit(); err != nil {
+		return
+	}
+	c.log.Infof("Restored %d state entries at version %d 🔒", restored, version)
+	return
+}
+
+// deleteUnseen() advances the local state iterator over the key range of a chunk, deleting every local key the chunk doesn't contain
+// both the iterator and the (verified) chunk entries are sorted, so a merge walk needs no more memory than the chunk itself
+// NOTE: the range of a chunk ends at its last key, unless it's the final chunk which covers the rest of the local state
+func deleteUnseen(st *store.Store, local lib.IteratorI, chunk *lib.StateChunkMessage) lib.ErrorI {
+	var lastKey []byte
+	if len(chunk.Entries) != 0 {
+		lastKey = chunk.Entries[len(chunk.Entries)-1].Key
+	}
+	for next := 0; local.Valid(); local.Next() {
+		key := local.Key()
+		// the local keys past the chunk are covered by the next chunk
+		if !chunk.Done && bytes.Compare(key, lastKey) > 0 {
+			return nil
+		}
+		// skip the entries before the local key
+		for next < len(chunk.Entries) && bytes.Compare(chunk.Entries[next].Key, key) < 0 {
+			next++
+		}
+		// keep the local key if it's part of the snapshot
+		if next < len(chunk.Entries) && bytes.Equal(chunk.Entries[next].Key, key) {
+			continue
+		}
+		if err := st.Delete(bytes.Clone(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requestStateChunk() requests a chunk of the state snapshot from random peers until one responds with a valid chunk
+func (c *Controller) requestStateChunk(version uint64, startKey, root []byte, limiter *lib.SimpleLimiter) (*lib.StateChunkMessage, lib.ErrorI) {
+	for attempt := 0; attempt < stateSyncMaxAttempts; attempt++ {
+		// reset the limiter if it's time
+		select {
+		case <-limiter.TimeToReset():
+			limiter.Reset()
+		default:
+		}
+		// get an updated list of available peers
+		peers, _, _ := c.P2P.GetAllInfos()
+		candidates := make([]string, 0, len(peers))
+		for _, peer := range peers {
+			candidates = append(candidates, lib.BytesToString(peer.Address.PublicKey))
+		}
+		// find a random peer that is not rate limited
+		allowedPeer := getRandomAllowedPeer(candidates, limiter)
+		if allowedPeer == "" {
+			time.Sleep(time.Second)
+			continue
+		}
+		peerPublicKey, _ := lib.StringToBytes(allowedPeer)
+		// send the state chunk request to the selected peer
+		if err := c.P2P.SendTo(peerPublicKey, StateRequest, &lib.StateChunkRequestMessage{
+			ChainId:  c.Config.ChainId,
+			Height:   version,
+			StartKey: startKey,
+		}); err != nil {
+			c.log.Warnf("Error requesting state chunk from %s", lib.BytesToTruncatedString(peerPublicKey))
+			continue
+		}
+		// wait for the response
+		msg := c.waitForPeer(State, peerPublicKey, p2p.StateSyncTimeoutS)
+		if msg == nil {
+			continue
+		}
+		// try to unmarshal the p2p message to a state chunk
+		chunk := new(lib.StateChunkMessage)
+		if err := lib.Unmarshal(msg.Message, chunk); err != nil {
+			c.P2P.ChangeReputation(peerPublicKey, p2p.InvalidStateChunkRep)
+			continue
+		}
+		// verify the chunk against the trusted root
+		if err := c.verifyStateChunk(chunk, version, startKey, root); err != nil {
+			c.log.Warnf("Invalid state chunk from %s: %s", lib.BytesToTruncatedString(peerPublicKey), err.Error())
+			c.P2P.ChangeReputation(peerPublicKey, p2p.InvalidStateChunkRep)
+			continue
+		}
+		// success, increase the peer reputation
+		c.P2P.ChangeReputation(peerPublicKey, p2p.GoodStateChunkRep)
+		return chunk, nil
+	}
+	return nil, lib.ErrStateSyncTimeout()
+}
+
+// verifyStateChunk() ensures a state chunk answers the request and every entry is proven against the trusted root
+// NOTE: membership proofs can't prove the absence of withheld keys; completeness is enforced by the final root check
+func (c *Controller) verifyStateChunk(chunk *lib.StateChunkMessage, version uint64, startKey, root []byte) lib.ErrorI {
+	// ensure the chunk is a response to the request
+	if chunk.ChainId != c.Config.ChainId || chunk.Height != version || !bytes.Equal(chunk.StartKey, startKey) {
+		return lib.ErrInvalidStateChunk("unexpected response")
+	}
+	// a chunk that isn't the last must make progress
+	if len(chunk.Entries) == 0 && !chunk.Done {
+		return lib.ErrInvalidStateChunk("empty chunk")
+	}
+	// ensure the entries are strictly ordered after the start key and are members of the trusted state
+	lastKey := startKey
+	for _, entry := range chunk.Entries {
+		if len(entry.Key) == 0 || bytes.Compare(entry.Key, lastKey) <= 0 {
+			return lib.ErrInvalidStateChunk("unordered keys")
+		}
+		valid, err := store.VerifyStateProof(entry.Key, entry.Value, true, root, entry.Proof)
+		if err != nil || !valid {
+			return lib.ErrInvalidStateChunk("invalid proof")
+		}
+		lastKey = entry.Key
+	}
+	return nil
+}
+
+// waitForPeer() waits for a message on a topic from a specific peer, discarding messages from other peers
+func (c *Controller) waitForPeer(topic lib.Topic, peerPublicKey []byte, timeoutS int) *lib.MessageAndMetadata {
+	timer := time.NewTimer(time.Duration(timeoutS) * time.Second)
+	defer timer.Stop()
+	for {
+		select {
+		case msg := <-c.P2P.Inbox(topic):
+			if bytes.Equal(msg.Sender.Address.PublicKey, peerPublicKey) {
+				return msg
+			}
+			c.log.Debugf("Discarding unexpected %s message from %s", topic, lib.BytesToTruncatedString(msg.Sender.Address.PublicKey))
+		case <-timer.C:
+			c.log.Warnf("Timed out waiting for %s message from %s", topic, lib.BytesToTruncatedString(peerPublicKey))
+			return nil
+		}
+	}
+}
+
+// ListenForStateRequests() listen for inbound state chunk requests from state-syncing peers, handles and answer them
+func (c *Controller) ListenForStateRequests() {
+	// initialize a rate limiter for the inbound state requests
+	l := lib.NewLimiter(p2p.MaxStateReqPerWindow, c.P2P.MaxPossiblePeers()*p2p.MaxStateReqPerWindow, p2p.StateReqWindowS, "STATE_REQUEST", c.log)
+	// keep an open cursor per requester to resume iteration between consecutive chunks
+	cursors := make(map[string]*stateCursor)
+	// for the lifetime of the Controller
+	for {
+		select {
+		// wait and execute for each inbound state request
+		case msg := <-c.P2P.Inbox(StateRequest):
+			// create a convenience variable for the sender of the state request
+			senderID := msg.Sender.Address.PublicKey
+			// check with the rate limiter to see if *this peer* or *all peers* are blocked
+			blocked, allBlocked := l.NewRequest(lib.BytesToString(senderID))
+			// if *this peer* or *all peers* are blocked
+			if blocked || allBlocked {
+				// if only this specific peer is blocked, slash the reputation
+				if blocked {
+					c.log.Warnf("Rate-limit hit for peer %s", lib.BytesToTruncatedString(senderID))
+					c.P2P.ChangeReputation(senderID, p2p.StateReqExceededRep)
+				}
+				continue
+			}
+			// try to unmarshal the p2p msg to a state chunk request message
+			request := new(lib.StateChunkRequestMessage)
+			if err := lib.Unmarshal(msg.Message, request); err != nil {
+				c.log.Warnf("Invalid state-request msg from peer %s", lib.BytesToTruncatedString(senderID))
+				c.P2P.ChangeReputation(senderID, p2p.InvalidMsgRep)
+				continue
+			}
+			// load the chunk, resuming the requester's cursor if possible
+			chunk, err := c.LoadStateChunk(cursors, lib.BytesToString(senderID), request)
+			if err != nil {
+				c.log.Error(err.Error())
+				continue
+			}
+			// send the chunk back to the requester
+			if err = c.P2P.SendTo(senderID, State, chunk); err != nil {
+				c.log.Error(err.Error())
+			}
+		// limiter is ready to be reset
+		case <-l.TimeToReset():
+			// reset the limiter
+			l.Reset()
+			// close any idle cursors
+			for id, cursor := range cursors {
+				if time.Since(cursor.lastUsed) > stateCursorTTL {
+					cursor.close()
+					delete(cursors, id)
+				}
+			}
+		}
+	}
+}
+
+// LoadStateChunk() reads the next chunk of the state snapshot at the requested height along with the merkle proof of each entry
+func (c *Controller) LoadStateChunk(cursors map[string]*stateCursor, requester string, request *lib.StateChunkRequestMessage) (chunk *lib.StateChunkMessage, err lib.ErrorI) {
+	// get the cursor of the requester
+	cursor, found := cursors[requester]
+	// if the cursor can't be resumed from the start key
+	if !found || cursor.height != request.Height || !bytes.Equal(cursor.lastKey, request.StartKey) {
+		// close the stale cursor
+		if found {
+			cursor.close()
+			delete(cursors, requester)
+		}
+		// open a new cursor positioned after the start key
+		if cursor, err = c.newStateCursor(request.Height, request.StartKey); err != nil {
+			return
+		}
+		cursors[requester] = cursor
+	}
+	chunk = &lib.StateChunkMessage{ChainId: c.Config.ChainId, Height: request.Height, StartKey: request.StartKey}
+	// populate the chunk with the next entries and their proofs
+	for ; cursor.it.Valid() && len(chunk.Entries) < stateChunkMaxEntries; cursor.it.Next() {
+		key, value := bytes.Clone(cursor.it.Key()), bytes.Clone(cursor.it.Value())
+		proof, e := cursor.store.GetProof(key)
+		if e != nil {
+			return nil, e
+		}
+		chunk.Entries = append(chunk.Entries, &lib.StateEntry{Key: key, Value: value, Proof: proof})
+		cursor.lastKey = key
+	}
+	// signal the final chunk once the iterator is exhausted
+	chunk.Done, cursor.lastUsed = !cursor.it.Valid(), time.Now()
+	return
+}
+
+// newStateCursor() opens a read-only iterator over the state at a height, positioned after the start key
+func (c *Controller) newStateCursor(height uint64, startKey []byte) (*stateCursor, lib.ErrorI) {
+	// lock the controller for thread safety
+	c.Lock()
+	fsmHeight, st := c.FSM.Height(), c.FSM.Store()
+	c.Unlock()
+	// only serve committed heights
+	if height == 0 || height > fsmHeight {
+		return nil, lib.ErrWrongBlockHeight(height, fsmHeight)
+	}
+	// cast the store to ensure the proper store type to complete this operation
+	s, ok := st.(*store.Store)
+	if !ok {
+		return nil, fsm.ErrWrongStoreType()
+	}
+	// create a read-only view of the state at the requested height
+	ro, err := s.NewReadOnly(height)
+	if err != nil {
+		return nil, err
+	}
+	it, err := ro.Iterator(nil)
+	if err != nil {
+		ro.Discard()
+		return nil, err
+	}
+	// skip to the first key after the start key
+	for ; it.Valid() && len(startKey) != 0 && bytes.Compare(it.Key(), startKey) <= 0; it.Next() {
+	}
+	return &stateCursor{height: height, lastKey: bytes.Clone(startKey), store: ro, it: it}, nil
+}
@@ -0,0 +1,215 @@
+package controller
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canopy-network/canopy/fsm"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/canopy-network/canopy/store"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLoadAndVerifyStateChunks(t *testing.T) {
+	log := lib.NewDefaultLogger()
+	db, err := store.NewStoreInMemory(log)
+	require.NoError(t, err)
+	// populate the state with more entries than fit in a single chunk
+	numEntries := 2*stateChunkMaxEntries + 10
+	for i := range numEntries {
+		require.NoError(t, db.Set(lib.JoinLenPrefix([]byte{1}, []byte{byte(i >> 8), byte(i)}), []byte{byte(i)}))
+	}
+	require.NoError(t, db.IndexBlock(&lib.BlockResult{BlockHeader: &lib.BlockHeader{Height: 1}}))
+	root, err := db.Commit()
+	require.NoError(t, err)
+	sm, err := fsm.New(lib.DefaultConfig(), db, nil, nil, log)
+	require.NoError(t, err)
+	c := &Controller{Config: lib.DefaultConfig(), FSM: sm, log: log, Mutex: &sync.Mutex{}}
+	cursors := make(map[string]*stateCursor)
+	// page through the snapshot like a state-syncing peer
+	var (
+		startKey []byte
+		total    int
+		chunks   int
+	)
+	for done := false; !done; chunks++ {
+		chunk, e := c.LoadStateChunk(cursors, "peer", &lib.StateChunkRequestMessage{ChainId: c.Config.ChainId, Height: 1, StartKey: startKey})
+		require.NoError(t, e)
+		require.NoError(t, c.verifyStateChunk(chunk, 1, startKey, root))
+		total += len(chunk.Entries)
+		if len(chunk.Entries) != 0 {
+			startKey = chunk.Entries[len(chunk.Entries)-1].Key
+		}
+		done = chunk.Done
+	}
+	require.Equal(t, numEntries, total)
+	require.Equal(t, 3, chunks)
+	// a fresh requester may start from any key
+	chunk, err := c.LoadStateChunk(cursors, "other", &lib.StateChunkRequestMessage{ChainId: c.Config.ChainId, Height: 1, StartKey: startKey})
+	require.NoError(t, err)
+	require.True(t, chunk.Done)
+	require.Empty(t, chunk.Entries)
+	// tampered values are rejected
+	chunk, err = c.LoadStateChunk(cursors, "tamper", &lib.StateChunkRequestMessage{ChainId: c.Config.ChainId, Height: 1})
+	require.NoError(t, err)
+	chunk.Entries[0].Value = []byte("bad")
+	require.Error(t, c.verifyStateChunk(chunk, 1, nil, root))
+	// unordered entries are rejected
+	chunk, err = c.LoadStateChunk(cursors, "unordered", &lib.StateChunkRequestMessage{ChainId: c.Config.ChainId, Height: 1})
+	require.NoError(t, err)
+	chunk.Entries[0], chunk.Entries[1] = chunk.Entries[1], chunk.Entries[0]
+	require.Error(t, c.verifyStateChunk(chunk, 1, nil, root))
+	// uncommitted heights are not served
+	_, err = c.LoadStateChunk(cursors, "future", &lib.StateChunkRequestMessage{ChainId: c.Config.ChainId, Height: 2})
+	require.Error(t, err)
+}
+
+func TestStateSync(t *testing.T) {
+	// a network whose state doesn't fit in a single chunk commits a few blocks
+	var accounts []*fsm.Account
+	for i := range 2 * stateChunkMaxEntries {
+		accounts = append(accounts, &fsm.Account{Address: crypto.Hash([]byte{byte(i >> 8), byte(i)})[:20], Amount: uint64(i + 1)})
+	}
+	n := newSimNetwork(t, 4, 9, accounts...)
+	n.runUntilHeight(6, 10*time.Minute)
+	server := n.nodes[0]
+	// a fresh node state-syncs to height 4 from it
+	c, p := newStateSyncController(t, server, 4, 2*stateChunkMaxEntries)
+	require.NoError(t, c.StateSync())
+	require.Equal(t, uint64(5), c.FSM.Height())
+	require.Greater(t, p.chunks, 2)
+	// the restored state is the state of the network, without the local keys the snapshot doesn't have
+	for i := range 2 * stateChunkMaxEntries {
+		value, err := c.FSM.Store().(lib.StoreI).Get(stateSyncLocalKey(i))
+		require.NoError(t, err)
+		require.Nil(t, value)
+	}
+	account, err := c.FSM.GetAccount(crypto.NewAddress(accounts[7].Address))
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), account.Amount)
+	// the node continues with block sync from the restored height
+	stateSyncApplyBlock(t, c, server)
+	require.Equal(t, uint64(6), c.FSM.Height())
+	// a node whose second snapshot can't be restored rolls back the parent state and block syncs from genesis
+	c, p = newStateSyncController(t, server, 4, 0)
+	p.tamper = 5
+	require.Error(t, c.StateSync())
+	require.Equal(t, uint64(1), c.FSM.Height())
+	stateSyncApplyBlock(t, c, server)
+	require.Equal(t, uint64(2), c.FSM.Height())
+}
+
+// newStateSyncController() creates a fresh node of the network's genesis, with a number of local keys no snapshot has, trusting the server's block at the height
+func newStateSyncController(t *testing.T, server *simNode, height uint64, localKeys int) (*Controller, *stateSyncP2P) {
+	log := lib.NewNullLogger()
+	config := lib.DefaultConfig()
+	config.DataDirPath, config.InMemory, config.RunVDF = t.TempDir(), true, false
+	genesisJSON, e := os.ReadFile(filepath.Join(server.c.Config.DataDirPath, lib.GenesisFilePath))
+	require.NoError(t, e)
+	require.NoError(t, os.WriteFile(filepath.Join(config.DataDirPath, lib.GenesisFilePath), genesisJSON, 0600))
+	// trust the server's block at the height
+	qc, err := server.c.FSM.LoadCertificate(height)
+	require.NoError(t, err)
+	config.StateSyncTrustHeight, config.StateSyncTrustHash = height, lib.BytesToString(qc.BlockHash)
+	// the local keys are committed with the genesis state
+	db, err := store.New(config, nil, log)
+	require.NoError(t, err)
+	for i := range localKeys {
+		require.NoError(t, db.Set(stateSyncLocalKey(i), []byte("local")))
+	}
+	sm, err := fsm.New(config, db, nil, nil, log)
+	require.NoError(t, err)
+	key, e := crypto.NewBLS12381PrivateKey()
+	require.NoError(t, e)
+	c, err := New(sm, config, key, nil, log)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Mempool.FSM.Discard(); _ = c.FSM.Store().(lib.StoreI).Close() })
+	p := &stateSyncP2P{server: server.c, cursors: make(map[string]*stateCursor), inbox: make(map[lib.Topic]chan *lib.MessageAndMetadata)}
+	c.P2P = p
+	return c, p
+}
+
+// stateSyncLocalKey() returns a local key of a fresh node, spread over the key space of the state
+// NOTE: no key may extend another in the store, so none is placed under the bare supply key
+func stateSyncLocalKey(i int) []byte {
+	prefix := []byte{byte(i % 32)}
+	if bytes.Equal(lib.JoinLenPrefix(prefix), fsm.SupplyPrefix()) {
+		prefix[0] = 32
+	}
+	return lib.JoinLenPrefix(prefix, crypto.Hash([]byte{byte(i >> 8), byte(i)})[:8])
+}
+
+// stateSyncApplyBlock() applies the next block of the server like block sync does
+func stateSyncApplyBlock(t *testing.T, c *Controller, server *simNode) {
+	qc, err := server.c.FSM.LoadCertificate(c.FSM.Height())
+	require.NoError(t, err)
+	// like Sync(), the node is flagged as syncing while the block is committed
+	c.isSyncing.Store(true)
+	defer c.isSyncing.Store(false)
+	c.Lock()
+	defer c.Unlock()
+	_, err = c.HandlePeerBlock(&lib.BlockMessage{ChainId: c.Config.ChainId, BlockAndCertificate: qc}, true)
+	require.NoError(t, err)
+}
+
+// stateSyncP2P is the network of a state-syncing node: its only peer is a simulated node that answers right away
+type stateSyncP2P struct {
+	P2PI                                               // the methods state-sync doesn't use
+	server  *Controller                                // the peer
+	cursors map[string]*stateCursor                    // the state cursors of the peer
+	inbox   map[lib.Topic]chan *lib.MessageAndMetadata // the answers of the peer
+	tamper  uint64                                     // the snapshot version whose chunks are tampered with (0 if none)
+	chunks  int                                        // the number of state chunks served
+}
+
+func (p *stateSyncP2P) Inbox(topic lib.Topic) chan *lib.MessageAndMetadata {
+	if p.inbox[topic] == nil {
+		p.inbox[topic] = make(chan *lib.MessageAndMetadata, 1)
+	}
+	return p.inbox[topic]
+}
+
+func (p *stateSyncP2P) SendToRandPeer(topic lib.Topic, msg proto.Message) (*lib.PeerInfo, lib.ErrorI) {
+	return &lib.PeerInfo{Address: &lib.PeerAddress{PublicKey: p.server.PublicKey}}, p.SendTo(p.server.PublicKey, topic, msg)
+}
+
+func (p *stateSyncP2P) SendTo(_ []byte, topic lib.Topic, msg proto.Message) lib.ErrorI {
+	var (
+		answer proto.Message
+		err    lib.ErrorI
+	)
+	switch request := msg.(type) {
+	case *lib.BlockRequestMessage:
+		qc, e := p.server.FSM.LoadCertificate(request.Height)
+		answer, err, topic = &lib.BlockMessage{ChainId: request.ChainId, BlockAndCertificate: qc}, e, Block
+	case *lib.StateChunkRequestMessage:
+		chunk, e := p.server.LoadStateChunk(p.cursors, "fresh", request)
+		if e == nil && request.Height == p.tamper && len(chunk.Entries) != 0 {
+			chunk.Entries[0].Value = []byte("tampered")
+		}
+		answer, err, topic = chunk, e, State
+		p.chunks++
+	}
+	if err != nil {
+		return err
+	}
+	bz, err := lib.Marshal(answer)
+	if err != nil {
+		return err
+	}
+	p.Inbox(topic) <- &lib.MessageAndMetadata{Message: bz, Sender: &lib.PeerInfo{Address: &lib.PeerAddress{PublicKey: p.server.PublicKey}}}
+	return nil
+}
+
+func (p *stateSyncP2P) GetAllInfos() ([]*lib.PeerInfo, int, int) {
+	return []*lib.PeerInfo{{Address: &lib.PeerAddress{PublicKey: p.server.PublicKey}}}, 0, 1
+}
+
+func (p *stateSyncP2P) ChangeReputation([]byte, int32) {}
+func (p *stateSyncP2P) MaxPossiblePeers() int          { return 1 }
@@ -73,6 +73,11 @@ func (c *Controller) ListenForTx() {
 			}
 			// route the transactions to the mempool handler
 			if err := c.Mempool.HandleTransactions(txMsg.Txs...); err != nil {
+				// a transaction the mempool refused by policy isn't invalid (gossip may deliver it after the one it lost to)
+				if lib.IsMempoolRefusal(err) {
+					c.log.Debugf("Tx from %s refused by the mempool: %s", lib.BytesToTruncatedString(senderID), err.Error())
+					return
+				}
 				// else - warn of the error
 				c.log.Warnf("Handle tx from %s failed with err: %s", lib.BytesToTruncatedString(senderID), err.Error())
 				// slash the peers reputation score
@@ -116,6 +121,13 @@ func (c *Controller) CheckMempool() {
 		func() {
 			c.Mempool.L.Lock()
 			defer c.Mempool.L.Unlock()
+			// remove the transactions that outlived the ttl, informing the users through the failed transactions cache
+			if expired := c.Mempool.Expire(); len(expired) != 0 {
+				for _, tx := range expired {
+					c.Mempool.cachedFailedTxs.Add(lib.NewFailedTx(tx, lib.ErrMempoolTxExpired()))
+				}
+				c.Mempool.dirtyVersion.Add(1)
+			}
 			// get the proposal block
 			p, ok := c.GetProposalBlockFromMempool()
 			// check for stale proposal
@@ -177,6 +189,7 @@ type Mempool struct {
 	FSM             *fsm.StateMachine  // the ephemeral finite state machine used to validate inbound transactions
 	cachedResults   lib.TxResults      // a memory cache of transaction results for the json rpc
 	cachedFailedTxs *lib.FailedTxCache // a memory cache of failed transactions for tracking
+	onAdmission     AdmissionCallback  // an optional callback notified of transactions newly admitted to the pending set
 	metrics         *lib.Metrics       // telemetry
 	address         crypto.AddressI    // validator identity
 	cachedProposal  atomic.Value       // the cached block proposal set when mempool is 'checked'
@@ -231,26 +244,38 @@ func (m *Mempool) handleTransactions(verifyRetained bool, replace []byte, tx ...
 	// lock the mempool
 	m.L.Lock()
 	defer m.L.Unlock()
+	// a replacement priced by the caller frees its (sender, nonce) slot before the add
+	if len(replace) != 0 {
+		m.DeleteTransaction(replace)
+	}
 	// add a transaction to the mempool
 	recheck, err := m.AddTransactions(tx...)
-	if err != nil {
-		// exit with the error
-		return
-	}
-	// invalidate the cached proposal version when the mempool changed
+	// invalidate the cached proposal version when the mempool changed (a refused transaction doesn't stop the others)
 	if recheck {
 		m.dirtyVersion.Add(1)
 	}
+	if err != nil {
+		// restore the replaced transaction and exit with the error
+		m.restoreReplaced(replace)
+		return
+	}
 	if verifyRetained && !m.Contains(crypto.HashString(tx[0])) {
+		m.restoreReplaced(replace)
 		return lib.NewError(lib.CodeInvalidArgument, lib.ConsensusModule, "transaction evicted from mempool")
 	}
-	if len(replace) != 0 {
-		m.DeleteTransaction(replace)
-	}
 	// exit
 	return
 }
 
+// restoreReplaced() re-adds a replaced transaction when its replacement wasn't retained
+func (m *Mempool) restoreReplaced(replace []byte) {
+	if len(replace) == 0 {
+		return
+	}
+	// the replaced transaction was previously admitted, so it passes the basic checks again
+	_, _ = m.AddTransactions(replace)
+}
+
 // CheckMempool() Checks each transaction in the mempool and caches a block proposal
 func (m *Mempool) CheckMempool() (err lib.ErrorI) {
 	startTime := time.Now()
@@ -322,11 +347,16 @@ func (m *Mempool) CheckMempool() (err lib.ErrorI) {
 	blockResult = &lib.BlockResult{BlockHeader: block.BlockHeader, Transactions: result.Results, Events: result.Events}
 	// cache the proposal
 	certResultsStartTime := time.Now()
-	certResults := m.controller.NewCertificateResults(m.FSM, block, blockResult, &bft.ByzantineEvidence{DSE: bft.DoubleSignEvidences{}}, rcBuildHeight)
+	certResults, err := m.controller.NewCertificateResults(m.FSM, block, blockResult, &bft.ByzantineEvidence{DSE: bft.DoubleSignEvidences{}}, rcBuildHeight)
 	certResultsDuration := time.Since(certResultsStartTime)
 	if m.metrics != nil {
 		m.metrics.ProposalCertResultsTime.Observe(certResultsDuration.Seconds())
 	}
+	if err != nil {
+		// don't cache a proposal with results the other replicas wouldn't build; the next check retries
+		m.log.Warnf("Check Mempool skipped: unable to build the certificate results: %s", err.Error())
+		return err
+	}
 	m.cachedProposal.Store(&CachedProposal{
 		Block:              block,
 		BlockResult:        blockResult,
@@ -353,6 +383,11 @@ func (m *Mempool) CheckMempool() (err lib.ErrorI) {
 			m.log.Warnf("%s", f.Error)
 		}
 	}
+	// remember the previously pending transactions to detect new admissions
+	previouslyPending := make(map[string]struct{}, len(m.cachedResults))
+	for _, tx := range m.cachedResults {
+		previouslyPending[tx.TxHash] = struct{}{}
+	}
 	// reset the RPC cached results
 	m.cachedResults = nil
 	// add results to cache
@@ -366,6 +401,18 @@ func (m *Mempool) CheckMempool() (err lib.ErrorI) {
 		o.Index = uint64(len(m.cachedResults))
 		m.cachedResults = append(m.cachedResults, o)
 	}
+	// notify the admission listener of the transactions that weren't pending before this check
+	if m.onAdmission != nil {
+		var admitted lib.TxResults
+		for _, tx := range m.cachedResults {
+			if _, found := previouslyPending[tx.TxHash]; !found {
+				admitted = append(admitted, tx)
+			}
+		}
+		if len(admitted) != 0 {
+			m.onAdmission(admitted)
+		}
+	}
 	m.log.Info("Done checking mempool")
 	totalDuration := time.Since(startTime)
 	if m.metrics != nil {
@@ -380,8 +427,8 @@ func (m *Mempool) CheckMempool() (err lib.ErrorI) {
 	return nil
 }
 
-// GetPendingPage() returns a page of unconfirmed mempool transactions
-func (c *Controller) GetPendingPage(p lib.PageParams) (page *lib.Page, err lib.ErrorI) {
+// GetPendingPage() returns a page of unconfirmed mempool transactions, optionally only those of a single sender
+func (c *Controller) GetPendingPage(sender crypto.AddressI, p lib.PageParams) (page *lib.Page, err lib.ErrorI) {
 	// try to acquire the mempool lock without blocking - if block processing holds it, return empty
 	// rather than queuing (100+/sec callers queueing cause TCP write timeouts)
 	if c == nil || c.Mempool == nil || c.Mempool.L == nil || !c.Mempool.L.TryLock() {
@@ -404,12 +451,33 @@ func (c *Controller) GetPendingPage(p lib.PageParams) (page *lib.Page, err lib.E
 		// exit callback
 		return
 	}
+	// filter the 'cached results' by sender if specified
+	results := c.Mempool.cachedResults
+	if sender != nil && len(sender.Bytes()) != 0 {
+		results = make(lib.TxResults, 0)
+		for _, result := range c.Mempool.cachedResults {
+			if bytes.Equal(result.Sender, sender.Bytes()) {
+				results = append(results, result)
+			}
+		}
+	}
 	// populate the page using the 'cached results'
-	err = page.LoadArray(c.Mempool.cachedResults, &txResults, callback)
+	err = page.LoadArray(results, &txResults, callback)
 	// exit
 	return
 }
 
+// AdmissionCallback is notified (under the mempool lock) of transactions newly admitted to the pending set
+// NOTE: the callback must not block as it runs during mempool checks
+type AdmissionCallback func(admitted lib.TxResults)
+
+// OnMempoolAdmission() registers a callback notified of transactions newly admitted to the pending set
+func (c *Controller) OnMempoolAdmission(callback AdmissionCallback) {
+	c.Mempool.L.Lock()
+	defer c.Mempool.L.Unlock()
+	c.Mempool.onAdmission = callback
+}
+
 // GetPendingTxByHash() returns an unconfirmed mempool transaction by hash.
 func (c *Controller) GetPendingTxByHash(hash string) (*lib.TxResult, bool) {
 	// try to acquire the mempool lock without blocking - return not-found if block processing holds it
@@ -451,7 +519,7 @@ func (c *Controller) GetPendingNonce(address crypto.AddressI, confirmed uint64)
 	}
 	defer c.Mempool.L.Unlock()
 	for _, result := range c.Mempool.cachedResults {
-		if result == nil || result.Transaction == nil || result.Transaction.Memo != fsm.RLPV2Indicator ||
+		if result == nil || !result.Transaction.IsNonceProtected() ||
 			!bytes.Equal(result.Sender, address.Bytes()) || result.Transaction.Nonce < confirmed || result.Transaction.Nonce == math.MaxUint64 {
 			continue
 		}
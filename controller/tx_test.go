@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"bytes"
 	"math/big"
 	"sync"
 	"testing"
@@ -110,7 +111,7 @@ func TestPendingReadersReturnImmediatelyWhenMempoolIsLocked(t *testing.T) {
 	mempool.L.Lock()
 	defer mempool.L.Unlock()
 
-	page, err := ctrl.GetPendingPage(lib.PageParams{PageNumber: 1, PerPage: 10})
+	page, err := ctrl.GetPendingPage(nil, lib.PageParams{PageNumber: 1, PerPage: 10})
 	require.NoError(t, err)
 	require.Zero(t, page.Count)
 	require.Zero(t, page.TotalCount)
@@ -130,8 +131,28 @@ func TestGetPendingNonceUsesValidatedResults(t *testing.T) {
 		cachedResults: lib.TxResults{
 			{Sender: address.Bytes(), Transaction: &lib.Transaction{Memo: fsm.RLPV2Indicator, Nonce: 2}},
 			{Sender: address.Bytes(), Transaction: &lib.Transaction{Memo: fsm.RLPV2Indicator, Nonce: 5}},
+			{Sender: address.Bytes(), Transaction: &lib.Transaction{Nonce: 6}},
+			{Sender: address.Bytes(), Transaction: &lib.Transaction{Memo: fsm.RLPIndicator, Nonce: 9}},
 		},
 	}}
 
-	require.EqualValues(t, 6, ctrl.GetPendingNonce(address, 1))
+	// native nonce protected transactions share the sequence, legacy RLP transactions don't
+	require.EqualValues(t, 7, ctrl.GetPendingNonce(address, 1))
+}
+
+func TestGetPendingPageFiltersBySender(t *testing.T) {
+	a, b := bytes.Repeat([]byte{1}, crypto.AddressSize), bytes.Repeat([]byte{2}, crypto.AddressSize)
+	ctrl := &Controller{Mempool: &Mempool{L: &sync.Mutex{}, cachedResults: lib.TxResults{
+		{Sender: a, TxHash: "a1"}, {Sender: b, TxHash: "b1"}, {Sender: a, TxHash: "a2"},
+	}}}
+	page, err := ctrl.GetPendingPage(crypto.NewAddress(a), lib.PageParams{PageNumber: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, page.TotalCount)
+	results := *page.Results.(*lib.TxResults)
+	require.Equal(t, "a1", results[0].TxHash)
+	require.Equal(t, "a2", results[1].TxHash)
+	// an empty address returns the whole pool
+	page, err = ctrl.GetPendingPage(crypto.NewAddress(nil), lib.PageParams{PageNumber: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, page.TotalCount)
 }
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/canopy-network/canopy/fsm"
+	"github.com/canopy-network/canopy/lib"
+)
+
+/* This file implements the 'external chain watcher' that witnesses the 'counter asset' leg of order book swaps outside of Canopy */
+
+// ExternalChainWatcherI is a pluggable witness of payments on a chain outside of Canopy
+// The locks are views of sell orders (see SellOrder.ForLock) where the buyer is expected to send exactly 'RequestedAmount'
+// from 'BuyerSendAddress' to 'SellerReceiveAddress' on the external chain
+// A payment only pays a lock if it was made at or after the lock's 'LockTime', at or before the lock's deadline time
+// (if the deadline was reached) and isn't already spent on another lock
+// NOTE: the result must be deterministic for a block time, as every replica of the committee re-computes the certificate results
+type ExternalChainWatcherI interface {
+	// WitnessPayments() returns the subset of locks that the buyer paid for in the external blocks confirmed at the block time
+	// and the id of the payment of each (in the same order); payments that are 'spent' are skipped
+	// the deadline of each lock (in the same order) is the time (unix micro) of its deadline block, or 0 if not yet reached
+	WitnessPayments(locks []*lib.SellOrder, deadlines []uint64, blockTime time.Time, spent func(paymentId []byte) bool) (paid []*lib.SellOrder, payments [][]byte, err lib.ErrorI)
+}
+
+// WitnessExternalPayments() adds the locked orders (and partial locks) whose buyer paid on the external chain to the close orders
+// and the ids of the payments, which the certificate result records in state so no payment closes a second lock
+// Expired locks stay open until the confirmation lag has passed their deadline, so a payment made just before the deadline
+// is still witnessed; their resets are withheld until then
+// NOTE: an error means the external chain couldn't be witnessed, and the certificate results must not be built without it
+func (c *Controller) WitnessExternalPayments(sm *fsm.StateMachine, book *lib.OrderBook, blockResult *lib.BlockResult, orders *lib.Orders) lib.ErrorI {
+	// exit if no watcher is configured or there's nothing to witness
+	if c.Watcher == nil || book == nil || blockResult == nil || blockResult.BlockHeader == nil {
+		return nil
+	}
+	// the end of the external window: only payments up to this time are confirmed at the block time
+	blockTime := time.UnixMicro(int64(blockResult.BlockHeader.Time))
+	confirmed := uint64(blockTime.Add(-time.Duration(c.Config.SwapWatcher.ConfirmationLagS) * time.Second).UnixMicro())
+	// collect the pending locks of the book
+	pending, err := pendingExternalLocks(sm, book, blockResult.BlockHeader.Height, confirmed, orders)
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+	locks, deadlines := make([]*lib.SellOrder, len(pending)), make([]uint64, len(pending))
+	for i, lock := range pending {
+		locks[i], deadlines[i] = lock.view, lock.deadline
+	}
+	// a payment recorded by an earlier certificate result is spent (a failed lookup is treated as spent to stay safe)
+	spent := func(paymentId []byte) bool {
+		found, e := sm.HasExternalPayment(paymentId, c.Config.ChainId)
+		return e != nil || found
+	}
+	// anchor the witnessing to the block time so every replica looks at the same external blocks
+	paid, paymentIds, err := c.Watcher.WitnessPayments(locks, deadlines, blockTime, spent)
+	if err != nil {
+		return err
+	}
+	if len(paid) != len(paymentIds) {
+		return lib.ErrExternalChain(fmt.Errorf("the watcher returned %d payments for %d paid locks", len(paymentIds), len(paid)))
+	}
+	// index the paid locks
+	paidSet := make(map[*lib.SellOrder][]byte, len(paid))
+	for i, lock := range paid {
+		paidSet[lock] = paymentIds[i]
+	}
+	// add the paid locks in the (deterministic) order of the book
+	for _, lock := range pending {
+		paymentId, found := paidSet[lock.view]
+		if !found {
+			continue
+		}
+		// a payment is only recorded with its close, so skip the closes past the certificate limits
+		if lock.partial && len(orders.CloseLocks) >= lib.MaxLocksPerCertificate || !lock.partial && len(orders.CloseOrders) >= lib.MaxOrdersPerDexBatch {
+			continue
+		}
+		// a partial lock is closed by its lock id, a whole lock by the order id
+		if lock.partial {
+			orders.CloseLocks = append(orders.CloseLocks, lock.id)
+		} else {
+			orders.CloseOrders = append(orders.CloseOrders, lock.id.OrderId)
+		}
+		orders.ExternalPayments = append(orders.ExternalPayments, paymentId)
+	}
+	return nil
+}
+
+// externalLock is a lock of the book pending on the external chain
+type externalLock struct {
+	id       *lib.OrderLockId // the order id and (for partial locks) the buyer send address
+	view     *lib.SellOrder   // the sell order view of the lock
+	partial  bool             // true if the lock reserves part of the order
+	deadline uint64           // the time (unix micro) of the deadline block, 0 if not yet reached
+}
+
+// pendingExternalLocks() returns the locks in the book that are not already closed, and either not expired or still within
+// the confirmation lag of their deadline; the resets of the latter are removed from the orders
+func pendingExternalLocks(sm *fsm.StateMachine, book *lib.OrderBook, height, confirmed uint64, orders *lib.Orders) (pending []*externalLock, err lib.ErrorI) {
+	// index the already closed orders and locks
+	closed := make(map[string]struct{}, len(orders.CloseOrders)+len(orders.CloseLocks))
+	for _, id := range orders.CloseOrders {
+		closed[lib.BytesToString(id)] = struct{}{}
+	}
+	for _, id := range orders.CloseLocks {
+		closed[id.Key()] = struct{}{}
+	}
+	// the resets withheld until the payments made before the deadline are confirmed
+	withheld := make(map[string]struct{})
+	// add the lock if it's still pending
+	add := func(lock *externalLock, key string, buyerChainDeadline uint64) lib.ErrorI {
+		if _, found := closed[key]; found {
+			return nil
+		}
+		// past the deadline, payments are only accepted up to the time of the deadline block
+		if height > buyerChainDeadline {
+			deadline, e := sm.LoadBlockTime(buyerChainDeadline)
+			if e != nil {
+				return e
+			}
+			if deadline == 0 {
+				return lib.ErrExternalChain(fmt.Errorf("missing the time of the deadline block %d", buyerChainDeadline))
+			}
+			// once the confirmed window passed the deadline, this is the last time the lock is witnessed before its reset
+			if confirmed <= deadline {
+				withheld[key] = struct{}{}
+			}
+			lock.deadline = deadline
+		}
+		pending = append(pending, lock)
+		return nil
+	}
+	// for each order in the book
+	for _, order := range book.Orders {
+		// if the whole order is locked
+		if order.Locked() {
+			id := &lib.OrderLockId{OrderId: order.Id}
+			if err = add(&externalLock{id: id, view: order}, lib.BytesToString(order.Id), order.BuyerChainDeadline); err != nil {
+				return
+			}
+			continue
+		}
+		// for each partial lock
+		for _, lock := range order.Locks {
+			id := &lib.OrderLockId{OrderId: order.Id, BuyerSendAddress: lock.BuyerSendAddress}
+			if err = add(&externalLock{id: id, view: order.ForLock(lock), partial: true}, id.Key(), lock.BuyerChainDeadline); err != nil {
+				return
+			}
+		}
+	}
+	// withhold the resets of the locks still within the confirmation lag of their deadline
+	orders.ResetOrders = slices.DeleteFunc(orders.ResetOrders, func(id []byte) bool {
+		_, found := withheld[lib.BytesToString(id)]
+		return found
+	})
+	orders.ResetLocks = slices.DeleteFunc(orders.ResetLocks, func(id *lib.OrderLockId) bool {
+		_, found := withheld[id.Key()]
+		return found
+	})
+	return
+}
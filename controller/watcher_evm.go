@@ -0,0 +1,357 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+/* This file implements an ExternalChainWatcherI that witnesses ERC-20 transfers over EVM JSON-RPC */
+
+// erc20TransferTopic is the keccak256 hash of the ERC-20 event signature 'Transfer(address,address,uint256)'
+const erc20TransferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// evmBlockTimeCacheSize bounds the cached block timestamps (a window and the binary searches over the chain fit well within)
+const evmBlockTimeCacheSize = 4096
+
+var _ ExternalChainWatcherI = new(EVMWatcher) // ensure the EVM watcher implements the interface
+
+// EVMWatcher witnesses payments of swaps as ERC-20 'Transfer' logs on an EVM chain
+//   - the ERC-20 contract of an order is its 'data' (20 bytes) or else the configured default token contract
+//   - a transfer pays a lock if it moves exactly 'RequestedAmount' from 'BuyerSendAddress' to 'SellerReceiveAddress'
+//     in a block no older than the lock's 'LockTime' and no newer than its deadline (if reached)
+//   - a transfer is identified by its tx hash and log index (40 bytes) and pays at most one lock, ever
+//   - only blocks with a timestamp in (blockTime - lag - window, blockTime - lag] are witnessed; as the lag is past the
+//     finality of the external chain, every replica sees the same blocks
+type EVMWatcher struct {
+	config       lib.SwapWatcherConfig      // the watcher configuration
+	defaultToken []byte                     // the ERC-20 contract for orders that don't name one
+	client       *http.Client               // the JSON-RPC client
+	blockTimes   *lru.Cache[uint64, uint64] // bounded cache of block number -> timestamp (confirmed blocks don't change)
+}
+
+// NewEVMWatcher() creates a new EVM watcher from the configuration
+func NewEVMWatcher(config lib.SwapWatcherConfig) (w *EVMWatcher, err lib.ErrorI) {
+	blockTimes, _ := lru.New[uint64, uint64](evmBlockTimeCacheSize)
+	w = &EVMWatcher{
+		config:     config,
+		client:     &http.Client{Timeout: time.Duration(config.TimeoutMS) * time.Millisecond},
+		blockTimes: blockTimes,
+	}
+	// parse the default token contract (if any)
+	if config.TokenContract != "" {
+		if w.defaultToken, err = lib.StringToBytes(strings.TrimPrefix(config.TokenContract, "0x")); err != nil {
+			return nil, err
+		}
+		if len(w.defaultToken) != crypto.AddressSize {
+			return nil, lib.ErrExternalChain(fmt.Errorf("invalid token contract %s", config.TokenContract))
+		}
+	}
+	return
+}
+
+// WitnessPayments() returns the subset of locks that the buyer paid for in the external blocks confirmed at the block time
+// and the id of the payment of each (in the same order); payments that are 'spent' are skipped
+// the deadline of each lock (in the same order) is the time (unix micro) of its deadline block, or 0 if not yet reached
+// NOTE: the watcher holds no lock while calling the external node; the only shared state is the (thread safe) block time cache
+func (w *EVMWatcher) WitnessPayments(locks []*lib.SellOrder, deadlines []uint64, blockTime time.Time, spent func(paymentId []byte) bool) (paid []*lib.SellOrder, payments [][]byte, err lib.ErrorI) {
+	// calculate the time window of the external blocks to witness
+	end := blockTime.Unix() - int64(w.config.ConfirmationLagS)
+	start := end - int64(w.config.WindowS)
+	if start < 0 {
+		return nil, nil, nil
+	}
+	// convert the window to a range of external blocks
+	from, to, err := w.blockRange(uint64(start), uint64(end))
+	if err != nil || from > to {
+		return nil, nil, err
+	}
+	// collect the tokens and recipients of the locks
+	var tokens, recipients [][]byte
+	for _, lock := range locks {
+		if token := w.tokenOf(lock); token != nil {
+			tokens, recipients = appendUnique(tokens, token), appendUnique(recipients, lock.SellerReceiveAddress)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, nil, nil
+	}
+	// get the transfers to the recipients in the range
+	transfers, err := w.transferLogs(from, to, tokens, recipients)
+	if err != nil {
+		return nil, nil, err
+	}
+	// a transfer pays at most one lock: within this call via 'used' and across calls via the spent payments
+	used := make([]bool, len(transfers))
+	for i, t := range transfers {
+		used[i] = spent != nil && spent(t.id())
+	}
+	for j, lock := range locks {
+		token, amount := w.tokenOf(lock), new(big.Int).SetUint64(lock.RequestedAmount)
+		for i, t := range transfers {
+			if used[i] || !bytes.Equal(t.token, token) || t.amount.Cmp(amount) != 0 ||
+				!bytes.Equal(t.from, lock.BuyerSendAddress) || !bytes.Equal(t.to, lock.SellerReceiveAddress) {
+				continue
+			}
+			// a transfer made before the lock or after its deadline can't pay for it
+			if at := t.time * uint64(time.Second/time.Microsecond); at < lock.LockTime || j < len(deadlines) && deadlines[j] != 0 && at > deadlines[j] {
+				continue
+			}
+			used[i], paid, payments = true, append(paid, lock), append(payments, t.id())
+			break
+		}
+	}
+	return
+}
+
+// tokenOf() returns the ERC-20 contract of a lock
+func (w *EVMWatcher) tokenOf(lock *lib.SellOrder) []byte {
+	if len(lock.Data) == crypto.AddressSize {
+		return lock.Data
+	}
+	return w.defaultToken
+}
+
+// blockRange() converts a (start, end] time window (unix seconds) to a range of external blocks
+func (w *EVMWatcher) blockRange(start, end uint64) (from, to uint64, err lib.ErrorI) {
+	// get the latest block of the external chain
+	var headHex string
+	if err = w.call("eth_blockNumber", []any{}, &headHex); err != nil {
+		return
+	}
+	head, err := parseHexUint(headHex)
+	if err != nil {
+		return
+	}
+	headTime, err := w.blockTime(head)
+	if err != nil {
+		return
+	}
+	// the external chain must have passed the window, otherwise replicas may disagree on it
+	if headTime < end {
+		return 0, 0, lib.ErrExternalChain(fmt.Errorf("the external chain time %d is behind the window end %d", headTime, end))
+	}
+	// the first block after the start
+	if from, err = w.firstBlockAfter(start, head); err != nil {
+		return
+	}
+	// the last block at or before the end
+	after, err := w.firstBlockAfter(end, head)
+	if err != nil || after == 0 {
+		return 1, 0, err
+	}
+	return from, after - 1, nil
+}
+
+// firstBlockAfter() binary searches for the first block with a timestamp after t (head + 1 if none)
+func (w *EVMWatcher) firstBlockAfter(t, head uint64) (uint64, lib.ErrorI) {
+	lo, hi := uint64(0), head+1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		midTime, err := w.blockTime(mid)
+		if err != nil {
+			return 0, err
+		}
+		if midTime > t {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
+
+// blockTime() returns the timestamp (unix seconds) of an external block
+func (w *EVMWatcher) blockTime(number uint64) (uint64, lib.ErrorI) {
+	if t, found := w.blockTimes.Get(number); found {
+		return t, nil
+	}
+	block := new(struct {
+		Timestamp string `json:"timestamp"`
+	})
+	if err := w.call("eth_getBlockByNumber", []any{toHexUint(number), false}, block); err != nil {
+		return 0, err
+	}
+	t, err := parseHexUint(block.Timestamp)
+	if err != nil {
+		return 0, err
+	}
+	w.blockTimes.Add(number, t)
+	return t, nil
+}
+
+// evmTransfer is a parsed ERC-20 'Transfer' log
+type evmTransfer struct {
+	token, from, to []byte
+	txHash          []byte
+	amount          *big.Int
+	block, index    uint64
+	time            uint64 // the timestamp (unix seconds) of the block
+}
+
+// id() returns the unique id of the transfer: the tx hash followed by the big endian log index
+func (t *evmTransfer) id() []byte {
+	return binary.BigEndian.AppendUint64(bytes.Clone(t.txHash), t.index)
+}
+
+// transferLogs() returns the ERC-20 transfers of the tokens to the recipients within the block range (in chain order)
+func (w *EVMWatcher) transferLogs(from, to uint64, tokens, recipients [][]byte) (transfers []*evmTransfer, err lib.ErrorI) {
+	// filter by the token contracts, the event and the recipient (the 2nd indexed parameter)
+	var addresses, recipientTopics []string
+	for _, token := range tokens {
+		addresses = append(addresses, "0x"+hex.EncodeToString(token))
+	}
+	for _, recipient := range recipients {
+		recipientTopics = append(recipientTopics, toTopic(recipient))
+	}
+	filter := map[string]any{
+		"fromBlock": toHexUint(from),
+		"toBlock":   toHexUint(to),
+		"address":   addresses,
+		"topics":    []any{erc20TransferTopic, nil, recipientTopics},
+	}
+	var logs []struct {
+		Address         string   `json:"address"`
+		Topics          []string `json:"topics"`
+		Data            string   `json:"data"`
+		BlockNumber     string   `json:"blockNumber"`
+		TransactionHash string   `json:"transactionHash"`
+		LogIndex        string   `json:"logIndex"`
+		Removed         bool     `json:"removed"`
+	}
+	if err = w.call("eth_getLogs", []any{filter}, &logs); err != nil {
+		return
+	}
+	for _, l := range logs {
+		// skip reorged and non-standard logs
+		if l.Removed || len(l.Topics) != 3 || !strings.EqualFold(l.Topics[0], erc20TransferTopic) {
+			continue
+		}
+		t := &evmTransfer{token: parseHexAddress(l.Address), from: parseHexAddress(l.Topics[1]), to: parseHexAddress(l.Topics[2])}
+		amount, ok := new(big.Int).SetString(strings.TrimPrefix(l.Data, "0x"), 16)
+		txHash, e := hex.DecodeString(strings.TrimPrefix(l.TransactionHash, "0x"))
+		if !ok || e != nil || len(txHash) != crypto.HashSize || t.token == nil || t.from == nil || t.to == nil {
+			continue
+		}
+		t.amount, t.txHash = amount, txHash
+		if t.block, err = parseHexUint(l.BlockNumber); err != nil {
+			return nil, err
+		}
+		if t.index, err = parseHexUint(l.LogIndex); err != nil {
+			return nil, err
+		}
+		// the block time decides if the transfer was made after the lock
+		if t.time, err = w.blockTime(t.block); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, t)
+	}
+	// defensive: ensure chain order regardless of the endpoint
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if transfers[i].block != transfers[j].block {
+			return transfers[i].block < transfers[j].block
+		}
+		return transfers[i].index < transfers[j].index
+	})
+	return
+}
+
+// jsonRPCRequest is an EVM JSON-RPC request
+type jsonRPCRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	Id      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+// jsonRPCResponse is an EVM JSON-RPC response
+type jsonRPCResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// call() executes an EVM JSON-RPC call and populates the result
+func (w *EVMWatcher) call(method string, params []any, result any) lib.ErrorI {
+	reqBz, e := json.Marshal(jsonRPCRequest{JSONRPC: "2.0", Id: 1, Method: method, Params: params})
+	if e != nil {
+		return lib.ErrJSONMarshal(e)
+	}
+	resp, e := w.client.Post(w.config.EVMRPCUrl, "application/json", bytes.NewReader(reqBz))
+	if e != nil {
+		return lib.ErrPostRequest(e)
+	}
+	defer resp.Body.Close()
+	bz, e := io.ReadAll(resp.Body)
+	if e != nil {
+		return lib.ErrReadBody(e)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return lib.ErrHttpStatus(resp.Status, resp.StatusCode, bz)
+	}
+	response := new(jsonRPCResponse)
+	if e = json.Unmarshal(bz, response); e != nil {
+		return lib.ErrJSONUnmarshal(e)
+	}
+	if response.Error != nil {
+		return lib.ErrExternalChain(fmt.Errorf("%s: %s (code %d)", method, response.Error.Message, response.Error.Code))
+	}
+	if len(response.Result) == 0 || string(response.Result) == "null" {
+		return lib.ErrExternalChain(fmt.Errorf("%s: empty result", method))
+	}
+	if e = json.Unmarshal(response.Result, result); e != nil {
+		return lib.ErrJSONUnmarshal(e)
+	}
+	return nil
+}
+
+// toHexUint() encodes a number as an EVM JSON-RPC quantity
+func toHexUint(n uint64) string { return "0x" + strconv.FormatUint(n, 16) }
+
+// parseHexUint() decodes an EVM JSON-RPC quantity
+func parseHexUint(s string) (uint64, lib.ErrorI) {
+	n, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+	if err != nil {
+		return 0, lib.ErrExternalChain(fmt.Errorf("invalid quantity %q", s))
+	}
+	return n, nil
+}
+
+// toTopic() left pads an address to a 32 byte log topic
+func toTopic(address []byte) string {
+	return "0x" + strings.Repeat("00", 32-len(address)) + hex.EncodeToString(address)
+}
+
+// parseHexAddress() decodes an address (or the last 20 bytes of a topic)
+func parseHexAddress(s string) []byte {
+	bz, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(bz) < crypto.AddressSize {
+		return nil
+	}
+	return bz[len(bz)-crypto.AddressSize:]
+}
+
+// appendUnique() appends bytes to the list if not already present
+func appendUnique(list [][]byte, bz []byte) [][]byte {
+	for _, item := range list {
+		if bytes.Equal(item, bz) {
+			return list
+		}
+	}
+	return append(list, bz)
+}
@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/canopy-network/canopy/fsm"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/store"
+	"github.com/stretchr/testify/require"
+)
+
+// testEVMLog is an ERC-20 transfer served by the stand-in EVM node
+type testEVMLog struct {
+	token, from, to []byte
+	amount, block   uint64
+	removed         bool
+}
+
+// newTestEVMNode() starts a stand-in EVM JSON-RPC server with one block every 12 seconds starting at genesisTime
+func newTestEVMNode(t *testing.T, head, genesisTime uint64, logs []testEVMLog) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := new(struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		})
+		require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		var result any
+		switch req.Method {
+		case "eth_blockNumber":
+			result = toHexUint(head)
+		case "eth_getBlockByNumber":
+			var number string
+			require.NoError(t, json.Unmarshal(req.Params[0], &number))
+			n, err := parseHexUint(number)
+			require.NoError(t, err)
+			result = map[string]string{"timestamp": toHexUint(genesisTime + 12*n)}
+		case "eth_getLogs":
+			filter := new(struct {
+				FromBlock string `json:"fromBlock"`
+				ToBlock   string `json:"toBlock"`
+			})
+			require.NoError(t, json.Unmarshal(req.Params[0], filter))
+			from, _ := parseHexUint(filter.FromBlock)
+			to, _ := parseHexUint(filter.ToBlock)
+			var out []map[string]any
+			for i, l := range logs {
+				if l.block < from || l.block > to {
+					continue
+				}
+				out = append(out, map[string]any{
+					"address":         "0x" + hex.EncodeToString(l.token),
+					"topics":          []string{erc20TransferTopic, toTopic(l.from), toTopic(l.to)},
+					"data":            "0x" + strings.Repeat("0", 48) + strconv.FormatUint(l.amount, 16),
+					"blockNumber":     toHexUint(l.block),
+					"transactionHash": toTopic([]byte{byte(i + 1)}),
+					"logIndex":        toHexUint(uint64(i)),
+					"removed":         l.removed,
+				})
+			}
+			result = out
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result}))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestEVMWatcherWitnessPayments(t *testing.T) {
+	token, otherToken := newTestWatcherAddress(0xA), newTestWatcherAddress(0xB)
+	seller, buyer, other := newTestWatcherAddress(1), newTestWatcherAddress(2), newTestWatcherAddress(3)
+	// blocks 0..1000 every 12 seconds from unix time 1,000,000
+	const genesis, lag, window = uint64(1_000_000), 120, 600
+	// the block time puts the window at blocks (4500, 5100] seconds after genesis -> blocks 376..425
+	blockTime := time.Unix(int64(genesis+5100+lag), 0)
+	locks := []*lib.SellOrder{
+		{Id: []byte{1}, RequestedAmount: 100, SellerReceiveAddress: seller, BuyerSendAddress: buyer},                   // paid
+		{Id: []byte{2}, RequestedAmount: 200, SellerReceiveAddress: seller, BuyerSendAddress: buyer},                   // wrong amount
+		{Id: []byte{3}, RequestedAmount: 300, SellerReceiveAddress: seller, BuyerSendAddress: other},                   // paid too late
+		{Id: []byte{4}, RequestedAmount: 400, SellerReceiveAddress: seller, BuyerSendAddress: buyer, Data: otherToken}, // paid in own token
+		{Id: []byte{5}, RequestedAmount: 500, SellerReceiveAddress: seller, BuyerSendAddress: other},                   // reorged
+		{Id: []byte{6}, RequestedAmount: 100, SellerReceiveAddress: seller, BuyerSendAddress: buyer},                   // log already consumed
+		{Id: []byte{7}, RequestedAmount: 700, SellerReceiveAddress: seller, BuyerSendAddress: buyer, // paid before the lock
+			LockTime: uint64(time.Unix(int64(genesis+12*410+1), 0).UnixMicro())},
+		{Id: []byte{8}, RequestedAmount: 800, SellerReceiveAddress: seller, BuyerSendAddress: buyer, // paid after the lock
+			LockTime: uint64(time.Unix(int64(genesis+12*410), 0).UnixMicro())},
+	}
+	server := newTestEVMNode(t, 1000, genesis, []testEVMLog{
+		{token: token, from: buyer, to: seller, amount: 100, block: 380},
+		{token: token, from: buyer, to: seller, amount: 201, block: 390},
+		{token: token, from: other, to: seller, amount: 300, block: 426},
+		{token: otherToken, from: buyer, to: seller, amount: 400, block: 400},
+		{token: token, from: other, to: seller, amount: 500, block: 400, removed: true},
+		{token: token, from: buyer, to: seller, amount: 700, block: 410},
+		{token: token, from: buyer, to: seller, amount: 800, block: 410},
+	})
+	watcher, err := NewEVMWatcher(lib.SwapWatcherConfig{
+		EVMRPCUrl:        server.URL,
+		TokenContract:    "0x" + hex.EncodeToString(token),
+		ConfirmationLagS: lag,
+		WindowS:          window,
+		TimeoutMS:        1000,
+	})
+	require.NoError(t, err)
+	paid, payments, err := watcher.WitnessPayments(locks, nil, blockTime, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*lib.SellOrder{locks[0], locks[3], locks[7]}, paid)
+	// each payment is identified by the tx hash and the log index
+	paymentId := func(i int) []byte {
+		id, e := hex.DecodeString(strings.TrimPrefix(toTopic([]byte{byte(i + 1)}), "0x"))
+		require.NoError(t, e)
+		return append(id, 0, 0, 0, 0, 0, 0, 0, byte(i))
+	}
+	require.Equal(t, [][]byte{paymentId(0), paymentId(3), paymentId(6)}, payments)
+	// a spent payment never pays another lock, even as the window slides to a later block
+	spent := func(id []byte) bool {
+		return slices.ContainsFunc(payments, func(p []byte) bool { return bytes.Equal(p, id) })
+	}
+	paid, payments, err = watcher.WitnessPayments(locks[5:6], nil, blockTime.Add(12*time.Second), spent)
+	require.NoError(t, err)
+	require.Empty(t, paid)
+	require.Empty(t, payments)
+	// a transfer made after the deadline of the lock doesn't pay for it
+	paid, _, err = watcher.WitnessPayments(locks[:1], []uint64{uint64(time.Unix(int64(genesis+12*380-1), 0).UnixMicro())}, blockTime, nil)
+	require.NoError(t, err)
+	require.Empty(t, paid)
+	// the external chain hasn't reached the end of the window
+	_, _, err = watcher.WitnessPayments(locks, nil, time.Unix(int64(genesis+12*1000+lag+1), 0), nil)
+	require.Error(t, err)
+}
+
+func TestWitnessExternalPayments(t *testing.T) {
+	token, seller, buyer, other := newTestWatcherAddress(0xA), newTestWatcherAddress(1), newTestWatcherAddress(2), newTestWatcherAddress(3)
+	const genesis, lag, window = uint64(1_000_000), 120, 600
+	server := newTestEVMNode(t, 1000, genesis, []testEVMLog{
+		{token: token, from: buyer, to: seller, amount: 100, block: 380},
+		{token: token, from: other, to: seller, amount: 50, block: 381},
+	})
+	watcher, err := NewEVMWatcher(lib.SwapWatcherConfig{EVMRPCUrl: server.URL, TokenContract: hex.EncodeToString(token),
+		ConfirmationLagS: lag, WindowS: window, TimeoutMS: 1000})
+	require.NoError(t, err)
+	log := lib.NewDefaultLogger()
+	db, err := store.NewStoreInMemory(log)
+	require.NoError(t, err)
+	require.NoError(t, db.IndexBlock(&lib.BlockResult{BlockHeader: &lib.BlockHeader{Height: 1}}))
+	_, err = db.Commit()
+	require.NoError(t, err)
+	sm, err := fsm.New(lib.DefaultConfig(), db, nil, nil, log)
+	require.NoError(t, err)
+	c := &Controller{Config: lib.DefaultConfig(), Watcher: watcher, log: log}
+	book := &lib.OrderBook{Orders: []*lib.SellOrder{
+		// a whole lock paid by the buyer
+		{Id: []byte{1}, AmountForSale: 10, RequestedAmount: 100, SellerReceiveAddress: seller, BuyerSendAddress: buyer,
+			BuyerReceiveAddress: buyer, BuyerChainDeadline: 100},
+		// a partial lock paid by the other buyer
+		{Id: []byte{2}, AmountForSale: 20, RequestedAmount: 200, SellerReceiveAddress: seller, Locks: []*lib.SellOrderLock{
+			{BuyerSendAddress: other, BuyerReceiveAddress: other, BuyerChainDeadline: 100, Amount: 5, RequestedAmount: 50},
+		}},
+	}}
+	blockResult := &lib.BlockResult{BlockHeader: &lib.BlockHeader{Height: 10, Time: uint64(time.Unix(int64(genesis+5100+lag), 0).UnixMicro())}}
+	orders := new(lib.Orders)
+	require.NoError(t, c.WitnessExternalPayments(sm, book, blockResult, orders))
+	require.Equal(t, [][]byte{{1}}, orders.CloseOrders)
+	require.Len(t, orders.CloseLocks, 1)
+	require.Equal(t, (&lib.OrderLockId{OrderId: []byte{2}, BuyerSendAddress: other}).Key(), orders.CloseLocks[0].Key())
+	require.Len(t, orders.ExternalPayments, 2)
+	// already closed orders aren't closed twice
+	orders = &lib.Orders{CloseOrders: [][]byte{{1}}}
+	require.NoError(t, c.WitnessExternalPayments(sm, book, blockResult, orders))
+	require.Equal(t, [][]byte{{1}}, orders.CloseOrders)
+	require.Len(t, orders.CloseLocks, 1)
+	require.Len(t, orders.ExternalPayments, 1)
+	// payments recorded in state by an earlier certificate result aren't spent again
+	sm.HandleCommitteeSwaps(&lib.Orders{ExternalPayments: orders.ExternalPayments}, c.Config.ChainId)
+	orders = new(lib.Orders)
+	require.NoError(t, c.WitnessExternalPayments(sm, book, blockResult, orders))
+	require.Equal(t, [][]byte{{1}}, orders.CloseOrders)
+	require.Empty(t, orders.CloseLocks)
+	require.Len(t, orders.ExternalPayments, 1)
+	// a failing external node fails the results rather than dropping the closes
+	server.Close()
+	require.Error(t, c.WitnessExternalPayments(sm, book, blockResult, new(lib.Orders)))
+}
+
+func TestWitnessExternalPaymentsPastDeadline(t *testing.T) {
+	token, seller, buyer, other := newTestWatcherAddress(0xA), newTestWatcherAddress(1), newTestWatcherAddress(2), newTestWatcherAddress(3)
+	const genesis, lag, window = uint64(1_000_000), 120, 600
+	// the buyer pays before the deadline block (4600s after genesis), the other buyer after it
+	server := newTestEVMNode(t, 1000, genesis, []testEVMLog{
+		{token: token, from: buyer, to: seller, amount: 100, block: 380},
+		{token: token, from: other, to: seller, amount: 50, block: 390},
+	})
+	watcher, err := NewEVMWatcher(lib.SwapWatcherConfig{EVMRPCUrl: server.URL, TokenContract: hex.EncodeToString(token),
+		ConfirmationLagS: lag, WindowS: window, TimeoutMS: 1000})
+	require.NoError(t, err)
+	log := lib.NewDefaultLogger()
+	db, err := store.NewStoreInMemory(log)
+	require.NoError(t, err)
+	require.NoError(t, db.IndexBlock(&lib.BlockResult{BlockHeader: &lib.BlockHeader{Height: 1}}))
+	require.NoError(t, db.IndexBlock(&lib.BlockResult{BlockHeader: &lib.BlockHeader{Height: 100, Time: uint64(time.Unix(int64(genesis+4600), 0).UnixMicro())}}))
+	_, err = db.Commit()
+	require.NoError(t, err)
+	sm, err := fsm.New(lib.DefaultConfig(), db, nil, nil, log)
+	require.NoError(t, err)
+	config := lib.DefaultConfig()
+	config.SwapWatcher.ConfirmationLagS = lag
+	c := &Controller{Config: config, Watcher: watcher, log: log}
+	lockId := &lib.OrderLockId{OrderId: []byte{2}, BuyerSendAddress: other}
+	book := &lib.OrderBook{Orders: []*lib.SellOrder{
+		{Id: []byte{1}, AmountForSale: 10, RequestedAmount: 100, SellerReceiveAddress: seller, BuyerSendAddress: buyer,
+			BuyerReceiveAddress: buyer, BuyerChainDeadline: 100},
+		{Id: []byte{2}, AmountForSale: 20, RequestedAmount: 200, SellerReceiveAddress: seller, Locks: []*lib.SellOrderLock{
+			{BuyerSendAddress: other, BuyerReceiveAddress: other, BuyerChainDeadline: 100, Amount: 5, RequestedAmount: 50},
+		}},
+	}}
+	// past the deadline, but before the lag passed it: the payment before the deadline closes the order and no lock is reset
+	blockResult := &lib.BlockResult{BlockHeader: &lib.BlockHeader{Height: 101, Time: uint64(time.Unix(int64(genesis+4590+lag), 0).UnixMicro())}}
+	orders := &lib.Orders{ResetOrders: [][]byte{{1}}, ResetLocks: []*lib.OrderLockId{lockId}}
+	require.NoError(t, c.WitnessExternalPayments(sm, book, blockResult, orders))
+	require.Equal(t, [][]byte{{1}}, orders.CloseOrders)
+	require.Empty(t, orders.ResetOrders)
+	require.Empty(t, orders.ResetLocks)
+	require.Len(t, orders.ExternalPayments, 1)
+	sm.HandleCommitteeSwaps(&lib.Orders{ExternalPayments: orders.ExternalPayments}, c.Config.ChainId)
+	// once the lag passed the deadline, the payment after the deadline doesn't count and the locks are reset
+	blockResult.BlockHeader.Height, blockResult.BlockHeader.Time = 102, uint64(time.Unix(int64(genesis+4700+lag), 0).UnixMicro())
+	orders = &lib.Orders{ResetOrders: [][]byte{{1}}, ResetLocks: []*lib.OrderLockId{lockId}}
+	require.NoError(t, c.WitnessExternalPayments(sm, book, blockResult, orders))
+	require.Empty(t, orders.CloseOrders)
+	require.Empty(t, orders.CloseLocks)
+	require.Equal(t, [][]byte{{1}}, orders.ResetOrders)
+	require.Equal(t, []*lib.OrderLockId{lockId}, orders.ResetLocks)
+	// without the time of the deadline block the payments can't be witnessed
+	book.Orders[0].BuyerChainDeadline = 50
+	require.Error(t, c.WitnessExternalPayments(sm, book, blockResult, new(lib.Orders)))
+}
+
+// newTestWatcherAddress() returns a deterministic 20 byte address
+func newTestWatcherAddress(b byte) []byte {
+	address := make([]byte, 20)
+	for i := range address {
+		address[i] = b
+	}
+	return address
+}
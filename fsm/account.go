@@ -10,6 +10,7 @@ import (
 
 	"github.com/canopy-network/canopy/lib"
 	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/drand/kyber"
 	"sort"
 )
 
@@ -367,6 +368,102 @@ func (s *StateMachine) clearAccountVestingIfFullyVested(account *Account) {
 	account.VestingEndHeight = 0
 }
 
+// MULTISIG ACCOUNT CODE BELOW
+
+/*
+	Multisig accounts are regular accounts whose address is derived from a set of BLS member keys and a threshold
+	instead of a single key pair. Registering the policy in state is what allows an aggregated signature of at least
+	'threshold' members to authorize transactions for the address
+*/
+
+// MaxMultisigMembers is the maximum number of member keys a multisig account may have
+const MaxMultisigMembers = 100
+
+// GetMultisigAccount() returns the registered multisig policy for an address
+func (s *StateMachine) GetMultisigAccount(address crypto.AddressI) (*MultisigAccount, lib.ErrorI) {
+	// retrieve the policy from the state store
+	bz, err := s.Get(KeyForMultisigAccount(address))
+	if err != nil {
+		return nil, err
+	}
+	// if the address isn't a registered multisig account
+	if bz == nil {
+		return nil, ErrMultisigAccountNotFound()
+	}
+	// convert the bytes into a structure
+	account := new(MultisigAccount)
+	if err = lib.Unmarshal(bz, account); err != nil {
+		return nil, err
+	}
+	// convert the address into bytes and set it
+	account.Address = address.Bytes()
+	return account, nil
+}
+
+// SetMultisigAccount() inserts a multisig policy into the state
+func (s *StateMachine) SetMultisigAccount(account *MultisigAccount) lib.ErrorI {
+	// convert the policy into bytes
+	bz, err := lib.Marshal(account)
+	if err != nil {
+		return err
+	}
+	// set the bytes under the multisig key for the address
+	return s.Set(KeyForMultisigAccount(crypto.NewAddressFromBytes(account.Address)), bz)
+}
+
+// NewMultisigPublicKey() converts member keys and a threshold into an account authorization BLS multi public key
+// NOTE: the member order is preserved as it defines the signer indices of the aggregated signature, while the address
+// is derived from the sorted member keys; so the same members in any order share one address (and one registration)
+func NewMultisigPublicKey(publicKeys [][]byte, threshold uint32) (*crypto.BLS12381MultiPublicKey, lib.ErrorI) {
+	// validate the policy structure
+	if err := checkMultisigPolicy(publicKeys, threshold); err != nil {
+		return nil, err
+	}
+	// convert each member key into a point on the curve
+	points := make([]kyber.Point, 0, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		point, e := crypto.BytesToBLS12381Point(publicKey)
+		if e != nil {
+			return nil, ErrInvalidPublicKey(e)
+		}
+		points = append(points, point)
+	}
+	// create the threshold multi public key
+	key, e := crypto.NewAccountAuthMultiBLSFromPoints(points, nil, threshold)
+	if e != nil {
+		return nil, ErrInvalidPublicKey(e)
+	}
+	return key.(*crypto.BLS12381MultiPublicKey), nil
+}
+
+// PublicKey() returns the BLS multi public key of the multisig account
+func (x *MultisigAccount) PublicKey() (*crypto.BLS12381MultiPublicKey, lib.ErrorI) {
+	return NewMultisigPublicKey(x.PublicKeys, x.Threshold)
+}
+
+// checkMultisigPolicy() validates the member keys and threshold of a multisig policy
+func checkMultisigPolicy(publicKeys [][]byte, threshold uint32) lib.ErrorI {
+	numMembers := len(publicKeys)
+	if numMembers == 0 || numMembers > MaxMultisigMembers {
+		return ErrInvalidMultisigMembers()
+	}
+	if threshold == 0 || threshold > uint32(numMembers) {
+		return ErrInvalidMultisigThreshold()
+	}
+	// ensure each member is a unique BLS key
+	seen := make(map[string]struct{}, numMembers)
+	for _, publicKey := range publicKeys {
+		if len(publicKey) != crypto.BLS12381PubKeySize {
+			return ErrInvalidMultisigMembers()
+		}
+		if _, found := seen[string(publicKey)]; found {
+			return ErrInvalidMultisigMembers()
+		}
+		seen[string(publicKey)] = struct{}{}
+	}
+	return nil
+}
+
 // POOL CODE BELOW
 
 /*
@@ -984,6 +1081,35 @@ func (x *Account) UnmarshalJSON(bz []byte) (err error) {
 	return
 }
 
+// multisigAccount is the json.Marshaller and json.Unmarshaler implementation for the MultisigAccount object
+type multisigAccount struct {
+	Address    lib.HexBytes   `json:"address"`
+	PublicKeys []lib.HexBytes `json:"publicKeys"`
+	Threshold  uint32         `json:"threshold"`
+}
+
+// MarshalJSON() is the json.Marshaller implementation for the MultisigAccount object
+func (x *MultisigAccount) MarshalJSON() ([]byte, error) {
+	publicKeys := make([]lib.HexBytes, 0, len(x.PublicKeys))
+	for _, pk := range x.PublicKeys {
+		publicKeys = append(publicKeys, pk)
+	}
+	return json.Marshal(multisigAccount{Address: x.Address, PublicKeys: publicKeys, Threshold: x.Threshold})
+}
+
+// UnmarshalJSON() is the json.Unmarshaler implementation for the MultisigAccount object
+func (x *MultisigAccount) UnmarshalJSON(bz []byte) (err error) {
+	a := new(multisigAccount)
+	if err = json.Unmarshal(bz, a); err != nil {
+		return err
+	}
+	x.Address, x.PublicKeys, x.Threshold = a.Address, nil, a.Threshold
+	for _, pk := range a.PublicKeys {
+		x.PublicKeys = append(x.PublicKeys, pk)
+	}
+	return
+}
+
 // GetPointsFor() returns the amount of points an address has
 func (x *Pool) GetPointsFor(address []byte) (points uint64, err lib.ErrorI) {
 	// add to existing if found
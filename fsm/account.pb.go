@@ -139,6 +139,71 @@ func (x *Account) GetNonce() uint64 {
 	return 0
 }
 
+// A multisig account is an account controlled by a threshold of BLS keys rather than a single key pair
+// Its address is derived from the member public keys and the threshold, so registering it pins the signer policy
+type MultisigAccount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// address: the short version of the multisig public key (sorted member keys + threshold)
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// public_keys: the BLS public keys of the members in signer index order
+	PublicKeys [][]byte `protobuf:"bytes,2,rep,name=public_keys,json=publicKeys,proto3" json:"publicKeys"` // @gotags: json:"publicKeys"
+	// threshold: the minimum number of member signatures required to authorize a transaction
+	Threshold     uint32 `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultisigAccount) Reset() {
+	*x = MultisigAccount{}
+	mi := &file_account_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultisigAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultisigAccount) ProtoMessage() {}
+
+func (x *MultisigAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultisigAccount.ProtoReflect.Descriptor instead.
+func (*MultisigAccount) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MultisigAccount) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *MultisigAccount) GetPublicKeys() [][]byte {
+	if x != nil {
+		return x.PublicKeys
+	}
+	return nil
+}
+
+func (x *MultisigAccount) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
 // A pool is like an account without an owner, holding funds that are managed directly by the blockchain protocol
 // It's very similar to an account but instead of an address it has a unique ID and operates based on predefined
 // blockchain rules rather than individual control
@@ -160,7 +225,7 @@ type Pool struct {
 
 func (x *Pool) Reset() {
 	*x = Pool{}
-	mi := &file_account_proto_msgTypes[1]
+	mi := &file_account_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -172,7 +237,7 @@ func (x *Pool) String() string {
 func (*Pool) ProtoMessage() {}
 
 func (x *Pool) ProtoReflect() protoreflect.Message {
-	mi := &file_account_proto_msgTypes[1]
+	mi := &file_account_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -185,7 +250,7 @@ func (x *Pool) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Pool.ProtoReflect.Descriptor instead.
 func (*Pool) Descriptor() ([]byte, []int) {
-	return file_account_proto_rawDescGZIP(), []int{1}
+	return file_account_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Pool) GetId() uint64 {
@@ -238,7 +303,7 @@ type Supply struct {
 
 func (x *Supply) Reset() {
 	*x = Supply{}
-	mi := &file_account_proto_msgTypes[2]
+	mi := &file_account_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -250,7 +315,7 @@ func (x *Supply) String() string {
 func (*Supply) ProtoMessage() {}
 
 func (x *Supply) ProtoReflect() protoreflect.Message {
-	mi := &file_account_proto_msgTypes[2]
+	mi := &file_account_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -263,7 +328,7 @@ func (x *Supply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Supply.ProtoReflect.Descriptor instead.
 func (*Supply) Descriptor() ([]byte, []int) {
-	return file_account_proto_rawDescGZIP(), []int{2}
+	return file_account_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Supply) GetTotal() uint64 {
@@ -313,7 +378,12 @@ const file_account_proto_rawDesc = "" +
 	"\x14vesting_start_height\x18\x04 \x01(\x04R\x12vestingStartHeight\x120\n" +
 	"\x14vesting_cliff_height\x18\x05 \x01(\x04R\x12vestingCliffHeight\x12,\n" +
 	"\x12vesting_end_height\x18\x06 \x01(\x04R\x10vestingEndHeight\x12\x14\n" +
-	"\x05nonce\x18\a \x01(\x04R\x05nonce\"\x85\x01\n" +
+	"\x05nonce\x18\a \x01(\x04R\x05nonce\"j\n" +
+	"\x0fMultisigAccount\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x1f\n" +
+	"\vpublic_keys\x18\x02 \x03(\fR\n" +
+	"publicKeys\x12\x1c\n" +
+	"\tthreshold\x18\x03 \x01(\rR\tthreshold\"\x85\x01\n" +
 	"\x04Pool\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x04R\x06amount\x12)\n" +
@@ -338,17 +408,18 @@ func file_account_proto_rawDescGZIP() []byte {
 	return file_account_proto_rawDescData
 }
 
-var file_account_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_account_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_account_proto_goTypes = []any{
-	(*Account)(nil),        // 0: types.Account
-	(*Pool)(nil),           // 1: types.Pool
-	(*Supply)(nil),         // 2: types.Supply
-	(*lib.PoolPoints)(nil), // 3: types.PoolPoints
+	(*Account)(nil),         // 0: types.Account
+	(*MultisigAccount)(nil), // 1: types.MultisigAccount
+	(*Pool)(nil),            // 2: types.Pool
+	(*Supply)(nil),          // 3: types.Supply
+	(*lib.PoolPoints)(nil),  // 4: types.PoolPoints
 }
 var file_account_proto_depIdxs = []int32{
-	3, // 0: types.Pool.points:type_name -> types.PoolPoints
-	1, // 1: types.Supply.committee_staked:type_name -> types.Pool
-	1, // 2: types.Supply.committee_delegated_only:type_name -> types.Pool
+	4, // 0: types.Pool.points:type_name -> types.PoolPoints
+	2, // 1: types.Supply.committee_staked:type_name -> types.Pool
+	2, // 2: types.Supply.committee_delegated_only:type_name -> types.Pool
 	3, // [3:3] is the sub-list for method output_type
 	3, // [3:3] is the sub-list for method input_type
 	3, // [3:3] is the sub-list for extension type_name
@@ -367,7 +438,7 @@ func file_account_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_account_proto_rawDesc), len(file_account_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -86,6 +86,10 @@ func (s *StateMachine) EndBlock(proposerAddress []byte) (events lib.Events, err
 	if err = s.DeleteFinishedUnstaking(); err != nil {
 		return
 	}
+	// tally and execute on-chain governance proposals whose voting period ends this block
+	if err = s.TallyProposals(); err != nil {
+		return
+	}
 	// optimization to include any last minute dex ops in the batch
 	if err = s.IncludeSameBlockDex(); err != nil {
 		return
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"github.com/canopy-network/canopy/lib"
 	"github.com/canopy-network/canopy/lib/crypto"
+	"maps"
 	"math/big"
 	"slices"
 	"sort"
@@ -86,6 +87,14 @@ func (s *StateMachine) HandleDexBatch(chainId uint64, results *lib.CertificateRe
 	}
 	// exit without handling as the 'rootHeight' explicitly not set
 	if remoteBatch == nil || liqPoolSize == 0 {
+		// a nested chain without the root chain's batch can't receipt its locked batch
+		if isNested && remoteBatch == nil && liqPoolSize != 0 {
+			localBatch, e := s.GetDexBatch(chainId, true)
+			if e != nil {
+				return e
+			}
+			return s.RecordDexBatchStall(chainId, lib.DexStallReason_DEX_STALL_MISSING_COUNTER_BATCH, localBatch, nil)
+		}
 		return
 	}
 	// get the local locked dex batch for the counter chain
@@ -166,7 +175,7 @@ func (s *StateMachine) HandleDexBatch(chainId uint64, results *lib.CertificateRe
 //
 // ─────────────────────────────────────────────────────────────────────────────
 func (s *StateMachine) HandleRemoteDexBatch(remoteBatch *lib.DexBatch, chainId uint64) (err lib.ErrorI) {
-	var receipts []uint64
+	var receipts, fills []uint64
 	processRemote := !remoteBatch.IsEmpty()
 	// copy because ledgers are mutated during processing
 	remoteBatchCopy := remoteBatch.Copy()
@@ -193,7 +202,7 @@ func (s *StateMachine) HandleRemoteDexBatch(remoteBatch *lib.DexBatch, chainId u
 			return
 		}
 		// 2) CREATE RECEIPTS FOR THE REMOTE CHAIN'S LOCKED BATCH
-		receipts, err = s.HandleRemoteChainLockedBatch(remoteBatchCopy, &counterPoolSizeMirror, chainId)
+		receipts, fills, err = s.HandleRemoteChainLockedBatch(remoteBatchCopy, &counterPoolSizeMirror, chainId)
 		if err != nil {
 			return
 		}
@@ -206,7 +215,7 @@ func (s *StateMachine) HandleRemoteDexBatch(remoteBatch *lib.DexBatch, chainId u
 		canonicalRemote.LivenessFallback = false
 		receiptsHash = canonicalRemote.Hash()
 	}
-	return s.RotateDexBatches(receiptsHash, midPointPoolSize, counterPoolSizeMirror, chainId, receipts)
+	return s.RotateDexBatches(receiptsHash, midPointPoolSize, counterPoolSizeMirror, chainId, receipts, fills, remoteBatch.PoolConfig)
 }
 
 // HandleReceiptsForOurLockedBatch() 1. processes receipts for our locked batch
@@ -217,8 +226,8 @@ func (s *StateMachine) HandleReceiptsForOurLockedBatch(remoteBatch *lib.DexBatch
 		return false, err
 	}
 	// ensure receipt not mismatch (expected while waiting on counter chain)
-	receiptMismatch := !bytes.Equal(remoteBatch.ReceiptHash, localBatch.Hash()) || len(localBatch.Orders) != len(remoteBatch.Receipts)
-	if receiptMismatch {
+	hashMismatch := !bytes.Equal(remoteBatch.ReceiptHash, localBatch.Hash())
+	if hashMismatch || len(localBatch.Orders) != len(remoteBatch.Receipts) {
 		// purposefully only log here because while the origin chain waits for the counter to process their batch
 		//   this error is expected
 		// 1: Root chain & Nested Chain are perfectly in sync AND Nested Chain sends certificate result at END_BLOCK
@@ -229,13 +238,21 @@ func (s *StateMachine) HandleReceiptsForOurLockedBatch(remoteBatch *lib.DexBatch
 		//  H=101 Shows Up In State| H=101 Checking w/ RootHeight 100
 		//  H=102                  | H=102 Checking w/ RootHeight 101
 		s.log.Debug(ErrMismatchDexBatchReceipt().Error())
-		return true, nil
+		// record why the receipts were skipped for operators
+		reason := lib.DexStallReason_DEX_STALL_RECEIPT_HASH_MISMATCH
+		if !hashMismatch {
+			reason = lib.DexStallReason_DEX_STALL_RECEIPT_COUNT_MISMATCH
+		}
+		return true, s.RecordDexBatchStall(counterChainId, reason, localBatch, remoteBatch)
 	}
 	// get the local pool size
 	localPoolSize, err := s.GetPoolBalance(counterChainId + LiquidityPoolAddend)
 	if err != nil {
 		return false, err
 	}
+	// price with the config the counter chain produced the receipts with: the one carried by our locked batch
+	// (nil prices with the default config)
+	config := localBatch.PoolConfig
 	// handle receipts for orders within our locked batch:
 	//  moving funds from holding pool to liquidity pool on success or refunding on fail
 	if err = s.HandleOrderReceipts(localBatch, remoteBatch, counterChainId, &localPoolSize, counterPoolSizeMirror); err != nil {
@@ -248,22 +265,28 @@ func (s *StateMachine) HandleReceiptsForOurLockedBatch(remoteBatch *lib.DexBatch
 	}
 	// handle 'implied' receipts for liquidity deposits within our locked batch:
 	//  issuing points and moving tokens from the hold pool to the liquid pool
-	if err = s.HandleBatchDeposit(localBatch, counterChainId, &localPoolSize, counterPoolSizeMirror, true); err != nil {
+	if err = s.HandleBatchDeposit(localBatch, counterChainId, &localPoolSize, counterPoolSizeMirror, true, config); err != nil {
+		return false, err
+	}
+	// the receipts are processed, so the pair is no longer stalled
+	if err = s.ClearDexBatchStall(counterChainId); err != nil {
 		return false, err
 	}
 	// remove lockedBatch to lift the 'atomic lock' - enabling orders to be sent in the next transaction
 	return false, s.Delete(KeyForLockedBatch(counterChainId))
 }
 
-// HandleRemoteChainLockedBatch() 2. handles the locked batch for the remote chain, producing receipts
-func (s *StateMachine) HandleRemoteChainLockedBatch(remoteBatch *lib.DexBatch, counterPoolSizeMirror *uint64, chainId uint64) (receipts []uint64, err lib.ErrorI) {
+// HandleRemoteChainLockedBatch() 2. handles the locked batch for the remote chain, producing receipts (and fills)
+func (s *StateMachine) HandleRemoteChainLockedBatch(remoteBatch *lib.DexBatch, counterPoolSizeMirror *uint64, chainId uint64) (receipts, fills []uint64, err lib.ErrorI) {
 	// get the balance for the proper liquidity pool
 	localPoolSize, err := s.GetPoolBalance(chainId + LiquidityPoolAddend)
 	if err != nil {
 		return
 	}
+	// price with the config carried by the remote chain's locked batch, like the remote chain does with our receipts
+	config := remoteBatch.PoolConfig
 	// handle the orders for the remote chain's locked batch (y represents the 'distribute pool balance')
-	receipts, err = s.HandleDexBatchOrders(remoteBatch, counterPoolSizeMirror, &localPoolSize, chainId)
+	receipts, fills, err = s.HandleDexBatchOrders(remoteBatch, counterPoolSizeMirror, &localPoolSize, chainId, config)
 	if err != nil {
 		return
 	}
@@ -272,7 +295,7 @@ func (s *StateMachine) HandleRemoteChainLockedBatch(remoteBatch *lib.DexBatch, c
 		return
 	}
 	// for each liquidity deposit, move the funds from the holding pool to the liquidity pool
-	if err = s.HandleBatchDeposit(remoteBatch, chainId, counterPoolSizeMirror, &localPoolSize, false); err != nil {
+	if err = s.HandleBatchDeposit(remoteBatch, chainId, counterPoolSizeMirror, &localPoolSize, false, config); err != nil {
 		return
 	}
 	// exit
@@ -280,16 +303,47 @@ func (s *StateMachine) HandleRemoteChainLockedBatch(remoteBatch *lib.DexBatch, c
 }
 
 // HandleOrderReceipts() handles receipts for orders within our locked batch
+// the unsold part of an order that hasn't expired stays in the holding pool and rests in the next batch
 func (s *StateMachine) HandleOrderReceipts(localBatch, remoteBatch *lib.DexBatch, chainId uint64, x, y *uint64) (err lib.ErrorI) {
+	// ensure the fills (if any) correspond to the receipts
+	if len(remoteBatch.Fills) != 0 && len(remoteBatch.Fills) != len(remoteBatch.Receipts) {
+		return lib.ErrInvalidDexBatchFills()
+	}
+	// get the next batch the unsold orders rest in
+	next, err := s.GetDexBatch(chainId, false)
+	if err != nil {
+		return
+	}
+	var resting []*lib.DexLimitOrder
 	// for each order, move the funds in the holding pool depending on the success or failure
 	for i, o := range localBatch.Orders {
-		// remove funds from the holding pool
-		if err = s.PoolSub(chainId+HoldingPoolAddend, o.AmountForSale); err != nil {
-			return
-		}
 		// convenience variable for amount counter asset amount received
 		dY := remoteBatch.Receipts[i]
 		success := dY != 0
+		// the amount sold: the whole order unless the counter chain filled it in part
+		sold := o.AmountForSale
+		if !success {
+			sold = 0
+		} else if len(remoteBatch.Fills) != 0 {
+			sold = remoteBatch.Fills[i]
+		}
+		if (success && sold == 0) || sold > o.AmountForSale || (sold != o.AmountForSale && sold != 0 && !o.PartialFill) {
+			return lib.ErrInvalidDexBatchFills()
+		}
+		// the unsold part of the order rests in the next batch until the order expires (if the batch has room)
+		var remainder *lib.DexLimitOrder
+		if sold != o.AmountForSale && o.ExpiryHeight > s.Height() && len(next.Orders)+len(resting) < lib.MaxOrdersPerDexBatch {
+			remainder = o.Remainder(sold)
+			resting = append(resting, remainder)
+		}
+		// remove the sold amount and any refund from the holding pool
+		release := o.AmountForSale
+		if remainder != nil {
+			release = sold
+		}
+		if err = s.PoolSub(chainId+HoldingPoolAddend, release); err != nil {
+			return
+		}
 		if success {
 			// Mirror the remote chain's ledger:
 			// - remoteBatch.PoolSize is our local shadow of the counter chain's pool at the mid-point snapshot they sent us.
@@ -299,45 +353,87 @@ func (s *StateMachine) HandleOrderReceipts(localBatch, remoteBatch *lib.DexBatch
 				return ErrRemotePoolSizeDebit()
 			}
 			// update ledgers
-			*x += o.AmountForSale
+			*x += sold
 			*y -= dY
 			// add to the pool
-			err = s.PoolAdd(chainId+LiquidityPoolAddend, o.AmountForSale)
-		} else {
-			// failed order
-			err = s.AccountAdd(crypto.NewAddress(o.Address), o.AmountForSale)
+			if err = s.PoolAdd(chainId+LiquidityPoolAddend, sold); err != nil {
+				return
+			}
 		}
-		if err != nil {
-			return
+		// refund the unsold part of the order unless it's resting
+		if refund := release - sold; refund != 0 {
+			if err = s.refundDexOrder(o, refund); err != nil {
+				return
+			}
 		}
-		// emit event
-		if err = s.EventDexSwap(o.Address, o.OrderId, o.AmountForSale, dY,
+		// emit event (a failed event reports the whole order as sold, like before partial fills)
+		soldAmount, remaining := o.AmountForSale, uint64(0)
+		if success {
+			soldAmount = sold
+		}
+		if remainder != nil {
+			remaining = remainder.AmountForSale
+		}
+		if err = s.EventDexSwap(o.Address, o.OrderId, soldAmount, dY, o.FilledAmount+sold, remaining,
 			localBatch.Committee, true, success); err != nil {
 			return
 		}
 	}
-	// exit
-	return
+	// exit if no orders rest
+	if len(resting) == 0 {
+		return
+	}
+	// rest the unsold orders at the front of the next batch
+	next.Orders = append(resting, next.Orders...)
+	return s.SetDexBatch(KeyForNextBatch(chainId), next)
+}
+
+// refundDexOrder() refunds the unsold part of an order (already out of the holding pool) to the seller
+// a failed forwarded leg of a routed order instead swaps its proceeds back through the origin chain's pool at the current
+// price: the proceeds are escrowed in the holding pool of the origin chain and sold in its next batch like any local order
+func (s *StateMachine) refundDexOrder(order *lib.DexLimitOrder, refund uint64) lib.ErrorI {
+	if order.OriginChainId != 0 {
+		next, err := s.GetDexBatch(order.OriginChainId, false)
+		if err != nil {
+			return err
+		}
+		// past a full batch the proceeds are refunded here like an unrouted order
+		if len(next.Orders) < lib.MaxOrdersPerDexBatch {
+			if err = s.PoolAdd(order.OriginChainId+HoldingPoolAddend, refund); err != nil {
+				return err
+			}
+			// not forwarded and without a minimum, so a failed swap back refunds the proceeds here
+			next.Orders = append(next.Orders, &lib.DexLimitOrder{
+				AmountForSale: refund,
+				Address:       order.Address,
+				OrderId:       order.OrderId,
+			})
+			return s.SetDexBatch(KeyForNextBatch(order.OriginChainId), next)
+		}
+	}
+	return s.AccountAdd(crypto.NewAddress(order.Address), refund)
 }
 
-// HandleRemoteChainLockedBatch() executes AMM logic over a 'batch' of limit orders
+// HandleDexBatchOrders() executes AMM logic over a 'batch' of limit orders
 // (1) sorts orders pseudorandomly by last block hash
 // (2) determines successful orders & distributes from the liquidity pool
-// (3) returns the receipts
-// x = counter chain pool shadow, y = local pool (both advanced as orders execute)
-func (s *StateMachine) HandleDexBatchOrders(remoteBatch *lib.DexBatch, x, y *uint64, chainId uint64) (receipts []uint64, err lib.ErrorI) {
-	receipts, result := make([]uint64, len(remoteBatch.Orders)), map[string]uint64{}
+// (3) returns the receipts and, if the batch has partially fillable orders, the amount sold of each order
+// x = counter chain pool shadow, y = local pool (both advanced as orders execute), priced with the curve and fee of the config
+func (s *StateMachine) HandleDexBatchOrders(remoteBatch *lib.DexBatch, x, y *uint64, chainId uint64, config *lib.DexPoolConfig) (receipts, fills []uint64, err lib.ErrorI) {
+	receipts, result, sold := make([]uint64, len(remoteBatch.Orders)), map[string]uint64{}, map[string]uint64{}
+	// the next legs of routed orders (root chain only)
+	legs := map[uint64]*dexRouteLeg{}
 	// load the last block from the indexer; caller triggers after genesis so height >= 1
 	prevBlk, err := s.LoadBlock(s.Height() - 1)
 	if err != nil || prevBlk == nil || prevBlk.BlockHeader == nil {
-		return nil, lib.ErrNilBlock()
+		return nil, nil, lib.ErrNilBlock()
 	}
 	// make 2 copies of the orders with hash keys
 	sorted, orders := remoteBatch.CopyOrders(prevBlk.BlockHeader.Hash)
 	// sort pseudorandomly by hash key
 	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
 	if *x == 0 || *y == 0 {
-		return nil, ErrInvalidLiquidityPool()
+		return nil, nil, ErrInvalidLiquidityPool()
 	}
 	// for each order (pseudorandomly ordered above so the settlement cap selects fairly)
 	for i, order := range sorted {
@@ -347,25 +443,40 @@ func (s *StateMachine) HandleDexBatchOrders(remoteBatch *lib.DexBatch, x, y *uin
 		}
 		// set up 'deltaX'
 		dX := order.AmountForSale
-		// 'deltaY' = (dX * y) / (x + dX)
-		dY := SafeComputeDY(*x, *y, dX)
-		// if the distribute amount would be below the minimum requested: the order failed
-		if dY < order.RequestedAmount {
-			dY = 0
+		// 'deltaY' per the pool curve, ex. constant product: (dX * y) / (x + dX)
+		dY := config.ComputeDY(*x, *y, dX)
+		// a routed order's requested amount is the minimum output of the whole route, so the next leg is quoted instead
+		if len(order.Route) != 0 {
+			if dY, err = s.routeDexOrder(order.DexLimitOrder, dY, chainId, legs); err != nil {
+				return nil, nil, err
+			}
+		} else if dY < order.RequestedAmount {
+			// if the distribute amount would be below the minimum requested: the order failed or fills in part
+			if dY = 0; order.PartialFill {
+				dX, dY = config.ComputePartialFill(*x, *y, order.AmountForSale, order.RequestedAmount)
+			}
+		}
+		if dY == 0 {
+			dX = 0
 		}
 		// capture result in map to save receipts later
-		result[order.Key] = dY
+		result[order.Key], sold[order.Key] = dY, dX
 		// update dx with overflow protection
 		var xAfter uint64
 		if dY != 0 {
 			var overflow bool
 			xAfter, overflow = lib.AddUint64(*x, dX)
 			if overflow {
-				return nil, ErrInvalidLiquidityPool()
+				return nil, nil, ErrInvalidLiquidityPool()
 			}
 		}
-		// emit swap event
-		if err = s.EventDexSwap(order.Address, order.OrderId, dX, dY, chainId, false, dY != 0); err != nil {
+		// emit swap event (a failed event reports the whole order as sold, like before partial fills)
+		soldAmount := dX
+		if dY == 0 {
+			soldAmount = order.AmountForSale
+		}
+		if err = s.EventDexSwap(order.Address, order.OrderId, soldAmount, dY, order.FilledAmount+dX, order.AmountForSale-dX,
+			chainId, false, dY != 0); err != nil {
 			return
 		}
 		// if succeeded: update pool ledgers like uniswap would
@@ -373,27 +484,136 @@ func (s *StateMachine) HandleDexBatchOrders(remoteBatch *lib.DexBatch, x, y *uin
 			*x, *y = xAfter, *y-dY
 		}
 	}
+	// fills are only sent when the batch has partially fillable orders, keeping other batches unchanged
+	if slices.ContainsFunc(remoteBatch.Orders, func(o *lib.DexLimitOrder) bool { return o.PartialFill }) {
+		fills = make([]uint64, len(remoteBatch.Orders))
+	}
 	// set success in the receipt
 	for i, order := range orders {
 		// setup convenience variable
 		out := result[order.Key]
 		// save receipt
 		receipts[i] = out
+		if fills != nil {
+			fills[i] = sold[order.Key]
+		}
 		// if order succeeded
 		if out != 0 {
 			// distribute from pool
 			if err = s.PoolSub(chainId+LiquidityPoolAddend, out); err != nil {
 				return
 			}
+			// forward the proceeds of a routed order to its next leg
+			if len(order.Route) != 0 {
+				legs[order.Route[0]].forward(order.DexLimitOrder, out, chainId)
+				continue
+			}
 			// add to account
 			if err = s.AccountAdd(crypto.NewAddress(order.Address), out); err != nil {
 				return
 			}
 		}
 	}
+	// add the forwarded orders to the next batches of their legs
+	err = s.handleDexRouteLegs(legs)
 	return
 }
 
+// dexRouteLeg is the next leg of the routed orders of a batch being executed by the root chain
+type dexRouteLeg struct {
+	chainId   uint64               // the counter chain of the leg
+	x, y      uint64               // the (virtual) pool of the leg; updated with every quoted order of the batch
+	config    *lib.DexPoolConfig   // the pool config of the leg
+	room      int                  // the orders that still fit in the next batch of the leg
+	forwarded []*lib.DexLimitOrder // the orders forwarded to the next batch of the leg
+}
+
+// forward() queues the proceeds of a routed order sold from the origin chain as an order of the next leg
+func (l *dexRouteLeg) forward(order *lib.DexLimitOrder, proceeds, originChainId uint64) {
+	l.forwarded = append(l.forwarded, &lib.DexLimitOrder{
+		AmountForSale:   proceeds,
+		RequestedAmount: order.RequestedAmount,
+		Address:         order.Address,
+		OrderId:         order.OrderId,
+		Route:           slices.Clone(order.Route[1:]),
+		OriginChainId:   originChainId,
+	})
+}
+
+// routeDexOrder() returns the proceeds of the first leg of a routed order if the route meets its minimum output, else 0
+// NOTE: only the root chain pairs with every chain, so only it forwards routed orders; the next leg is quoted with the
+// pool it will (likely) execute against, including the earlier routed orders of this batch
+func (s *StateMachine) routeDexOrder(order *lib.DexLimitOrder, proceeds, chainId uint64, legs map[uint64]*dexRouteLeg) (uint64, lib.ErrorI) {
+	next := order.Route[0]
+	leg, ok := legs[next]
+	if !ok {
+		rootChainId, err := s.GetRootChainId()
+		if err != nil {
+			return 0, err
+		}
+		// load the next leg if this is the root chain and the leg is a pool of it
+		if s.Config.ChainId == rootChainId && next != chainId && next != rootChainId {
+			if leg, err = s.newDexRouteLeg(next); err != nil {
+				return 0, err
+			}
+		}
+		legs[next] = leg
+	}
+	if proceeds == 0 || leg == nil || leg.room == 0 {
+		return 0, nil
+	}
+	xAfter, overflow := lib.AddUint64(leg.x, proceeds)
+	if overflow {
+		return 0, nil
+	}
+	out := leg.config.ComputeDY(leg.x, leg.y, proceeds)
+	if out == 0 || out < order.RequestedAmount {
+		return 0, nil
+	}
+	// reserve the leg so the later routed orders of the batch are quoted after this one
+	leg.x, leg.y, leg.room = xAfter, leg.y-out, leg.room-1
+	return proceeds, nil
+}
+
+// newDexRouteLeg() loads the next leg of routed orders to the counter chain; nil if the pool isn't live
+func (s *StateMachine) newDexRouteLeg(chainId uint64) (*dexRouteLeg, lib.ErrorI) {
+	x, y, config, err := s.dexLegPool(s.Config.ChainId, chainId)
+	if err != nil || x == 0 || y == 0 {
+		return nil, err
+	}
+	batch, err := s.GetDexBatch(chainId, false)
+	if err != nil {
+		return nil, err
+	}
+	return &dexRouteLeg{chainId: chainId, x: x, y: y, config: config, room: lib.MaxOrdersPerDexBatch - len(batch.Orders)}, nil
+}
+
+// handleDexRouteLegs() escrows the forwarded orders in the holding pools and adds them to the next batches of their legs
+func (s *StateMachine) handleDexRouteLegs(legs map[uint64]*dexRouteLeg) lib.ErrorI {
+	// iterate the legs in a deterministic order
+	for _, chainId := range slices.Sorted(maps.Keys(legs)) {
+		leg := legs[chainId]
+		if leg == nil || len(leg.forwarded) == 0 {
+			continue
+		}
+		batch, err := s.GetDexBatch(chainId, false)
+		if err != nil {
+			return err
+		}
+		for _, order := range leg.forwarded {
+			// the proceeds are already out of the first leg's liquidity pool
+			if err = s.PoolAdd(chainId+HoldingPoolAddend, order.AmountForSale); err != nil {
+				return err
+			}
+			batch.Orders = append(batch.Orders, order)
+		}
+		if err = s.SetDexBatch(KeyForNextBatch(chainId), batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Two-chain LP accounting:
 // - Mirror liquidity ledger on both chains for symmetry
 // - Outbound deposits/withdraws: update ledger + move tokens
@@ -427,29 +647,17 @@ func (s *StateMachine) handleBatchWithdraw(batch *lib.DexBatch, counterChainId u
 		pointsByAddress[string(point.Address)] = point
 	}
 	// collect withdrawals
-	for _, w := range batch.Withdrawals {
+	accepted := make([]bool, len(batch.Withdrawals))
+	for i, w := range batch.Withdrawals {
 		if w == nil {
 			s.log.Warnf("an error occurred retrieving the pool points for: %x, nil withdrawal", []byte{})
 			continue // defensive
 		}
-		holder := pointsByAddress[string(w.Address)]
-		if holder == nil {
+		if pointsByAddress[string(w.Address)] == nil {
 			s.log.Errorf("an error occurred retrieving the pool points for: %x", w.Address)
 			continue // defensive
 		}
-		// update the total points to remove
-		pointsToRemove := lib.SafeMulDiv(holder.Points, w.Percent, 100)
-		var overflow bool
-		totalPointsToRemove, overflow = lib.AddUint64(totalPointsToRemove, pointsToRemove)
-		if overflow {
-			return ErrInvalidLiquidityPool()
-		}
-	}
-	if totalPointsToRemove == 0 || p.TotalPoolPoints == 0 {
-		if persist {
-			return s.SetPool(p)
-		}
-		return nil
+		accepted[i] = true
 	}
 	// compute totals; actual paid amounts are tracked below to avoid burning rounding dust
 	// x = 1000 RONI; totalPoolPoints = 100 ; Pablo has 50 points
@@ -458,26 +666,70 @@ func (s *StateMachine) handleBatchWithdraw(batch *lib.DexBatch, counterChainId u
 	// Pablo has 50 pool points and Pablo withdrawals 50% = 25 points
 	// AmountCNPYToReceiveInWithdrawal = 100 x 25 / 100
 	// AmountRONIToReceiveInWithdrawal = 1000 x 25 / 100
-	totalYWithdrawal := lib.SafeMulDiv(*y, totalPointsToRemove, p.TotalPoolPoints)
-	totalXWithdraw := lib.SafeMulDiv(*x, totalPointsToRemove, p.TotalPoolPoints)
+	// NOTE: x is always the pool of the chain that batched the withdrawals, so 'minAmount' applies to x and 'minCounterAmount' to y
+	var totalYWithdrawal, totalXWithdraw uint64
+	for {
+		// update the total points to remove
+		totalPointsToRemove = 0
+		for i, w := range batch.Withdrawals {
+			if !accepted[i] {
+				continue
+			}
+			pointsToRemove := lib.SafeMulDiv(pointsByAddress[string(w.Address)].Points, w.Percent, 100)
+			var overflow bool
+			totalPointsToRemove, overflow = lib.AddUint64(totalPointsToRemove, pointsToRemove)
+			if overflow {
+				return ErrInvalidLiquidityPool()
+			}
+		}
+		if totalPointsToRemove == 0 || p.TotalPoolPoints == 0 {
+			if persist {
+				return s.SetPool(p)
+			}
+			return nil
+		}
+		totalYWithdrawal = lib.SafeMulDiv(*y, totalPointsToRemove, p.TotalPoolPoints)
+		totalXWithdraw = lib.SafeMulDiv(*x, totalPointsToRemove, p.TotalPoolPoints)
+		// cancel the withdrawals that would pay less than their minimums (slippage protection); cancelling changes the
+		// totals, so it repeats until every remaining withdrawal passes
+		cancelled := false
+		for i, w := range batch.Withdrawals {
+			if !accepted[i] || (w.MinAmount == 0 && w.MinCounterAmount == 0) {
+				continue
+			}
+			points := lib.SafeMulDiv(pointsByAddress[string(w.Address)].Points, w.Percent, 100)
+			xShare := lib.SafeMulDiv(totalXWithdraw, points, totalPointsToRemove)
+			yShare := lib.SafeMulDiv(totalYWithdrawal, points, totalPointsToRemove)
+			if xShare >= w.MinAmount && yShare >= w.MinCounterAmount {
+				continue
+			}
+			// keep the points and emit a failed (zero-share) withdraw event
+			if err = s.EventDexLiquidityWithdraw(w.Address, w.OrderId, 0, 0, 0, w.Percent, counterChainId); err != nil {
+				return err
+			}
+			accepted[i], cancelled = false, true
+		}
+		if !cancelled {
+			break
+		}
+	}
 	var paidY, paidX uint64
 	// distribute tokens
-	for _, w := range batch.Withdrawals {
-		if w == nil {
-			s.log.Warnf("an error occurred retrieving the pool points for: %x, nil withdrawal", []byte{})
-			continue // defensive
+	for i, w := range batch.Withdrawals {
+		if !accepted[i] {
+			continue
 		}
 		holder := pointsByAddress[string(w.Address)]
-		if holder == nil {
-			s.log.Warnf("an error occurred retrieving the pool points for: %x", w.Address)
-			continue // defensive
-		}
 		// calculate points from percent
 		points := lib.SafeMulDiv(holder.Points, w.Percent, 100)
 		// calculate share
 		yShare := lib.SafeMulDiv(totalYWithdrawal, points, totalPointsToRemove)
 		// calculate virtual share
 		xShare := lib.SafeMulDiv(totalXWithdraw, points, totalPointsToRemove)
+		// reduce the cost basis of the provider pro-rata
+		if err = s.reduceDexPositionBasis(counterChainId, w.Address, holder.Points-points, holder.Points); err != nil {
+			return err
+		}
 		// remove points from pool
 		p.TotalPoolPoints -= points
 		holder.Points -= points
@@ -520,12 +772,13 @@ func (s *StateMachine) handleBatchWithdraw(batch *lib.DexBatch, counterChainId u
 
 // HandleBatchDeposit() handles local/remote liquidity deposits.
 // local=true: x=local pool (actual token movement), y=counter mirror. local=false: x=counter mirror, y=local pool.
-func (s *StateMachine) HandleBatchDeposit(batch *lib.DexBatch, chainId uint64, x, y *uint64, local bool) lib.ErrorI {
-	return s.handleBatchDeposit(batch, chainId, x, y, local, true, nil, true)
+// Points are minted against the liquidity invariant of the pool config's curve.
+func (s *StateMachine) HandleBatchDeposit(batch *lib.DexBatch, chainId uint64, x, y *uint64, local bool, config *lib.DexPoolConfig) lib.ErrorI {
+	return s.handleBatchDeposit(batch, chainId, x, y, local, true, nil, true, config)
 }
 
 // handleBatchDeposit() is a helper function for handling the batch deposits
-func (s *StateMachine) handleBatchDeposit(batch *lib.DexBatch, chainId uint64, x, y *uint64, local, checkCap bool, p *Pool, persist bool) lib.ErrorI {
+func (s *StateMachine) handleBatchDeposit(batch *lib.DexBatch, chainId uint64, x, y *uint64, local, checkCap bool, p *Pool, persist bool, config *lib.DexPoolConfig) lib.ErrorI {
 	if len(batch.Deposits) == 0 {
 		return nil
 	}
@@ -539,7 +792,7 @@ func (s *StateMachine) handleBatchDeposit(batch *lib.DexBatch, chainId uint64, x
 	}
 	// if capacity should be checked (recursive func artifact)
 	if checkCap {
-		if handled, e := s.handleCappedBatchDeposit(batch, p, chainId, x, y, local); handled {
+		if handled, e := s.handleCappedBatchDeposit(batch, p, chainId, x, y, local, config); handled {
 			return e
 		}
 	}
@@ -579,17 +832,7 @@ func (s *StateMachine) handleBatchDeposit(batch *lib.DexBatch, chainId uint64, x
 		}
 		// new LP at capacity: refund (local side) and skip its points
 		if isNewHolder && projectedHolders >= lib.MaxLiquidityProviders {
-			if local {
-				// return the escrowed funds to the depositor
-				if err = s.PoolSub(chainId+HoldingPoolAddend, deposit.Amount); err != nil {
-					return err
-				}
-				if err = s.AccountAdd(crypto.NewAddress(deposit.Address), deposit.Amount); err != nil {
-					return err
-				}
-			}
-			// emit a failed (zero-share) deposit event
-			if err = s.EventDexLiquidityDeposit(deposit.Address, deposit.OrderId, deposit.Amount, 0, chainId, local); err != nil {
+			if err = s.refundDexDeposit(deposit, chainId, local); err != nil {
 				return err
 			}
 			continue
@@ -609,21 +852,26 @@ func (s *StateMachine) handleBatchDeposit(batch *lib.DexBatch, chainId uint64, x
 	if totalDeposit == 0 {
 		return nil
 	}
-	// if no liq points yet assigned - initialize to 'dead' address
-	if L == 0 {
-		// calculate the initial pool points using L = √( x * y )
-		L = lib.SqrtProductUint64(*x, *y)
-		// add points to the dead address
+	// if no liq points yet assigned - calculate the initial pool points using the liquidity invariant, ex. constant product: L = √( x * y )
+	initialize := L == 0
+	if initialize {
+		L = config.Liquidity(*x, *y)
+	}
+	// PASS 2: refund the deposits that would mint fewer points than their minimum (slippage protection)
+	// and calculate points as if all remaining deposits are one deposit
+	totalDL, totalDeposit, err := s.enforceDexDepositMinPoints(batch, accepted, config, L, *x, *y, totalDeposit, chainId, local)
+	if err != nil || totalDeposit == 0 {
+		return err
+	}
+	// initialize the pool points to the 'dead' address
+	if initialize {
 		if err = p.AddPoints(deadAddr.Bytes(), L); err != nil {
 			return err
 		}
 	}
-	// calculate points as if all accepted deposits are one deposit
-	totalDL, err := liquidityDepositPoints(L, *x, *y, totalDeposit)
-	if err != nil {
-		return err
-	}
-	// PASS 2: distribute points for the accepted deposits
+	// the liquidity per point before the deposits, to measure the liquidity each deposit adds to the cost basis
+	oldK := config.Liquidity(*x, *y)
+	// PASS 3: distribute points for the accepted deposits
 	var distributed uint64
 	var overflow bool
 	for i, deposit := range batch.Deposits {
@@ -638,6 +886,10 @@ func (s *StateMachine) handleBatchDeposit(batch *lib.DexBatch, chainId uint64, x
 		if err = p.AddPoints(deposit.Address, share); err != nil {
 			return err
 		}
+		// add the deposit to the cost basis of the provider
+		if err = s.addDexPositionBasis(chainId, deposit.Address, deposit.Amount, lib.SafeMulDiv(share, oldK, L), local); err != nil {
+			return err
+		}
 		// if 'local' request - (actually move from holding pool to liquidity pool, don't *just* update the ledger)
 		if local {
 			if err = s.PoolSub(chainId+HoldingPoolAddend, deposit.Amount); err != nil {
@@ -669,9 +921,52 @@ func (s *StateMachine) handleBatchDeposit(batch *lib.DexBatch, chainId uint64, x
 	return nil
 }
 
+// enforceDexDepositMinPoints() refunds the accepted deposits that would mint fewer points than their minimum and returns the
+// points minted by the remaining deposits as one deposit (and their total)
+// NOTE: refunding a deposit changes the points of the rest, so it repeats until every remaining deposit passes; both chains
+// compute the same points from the mirrored pools, so both reach the same outcome without an explicit receipt
+func (s *StateMachine) enforceDexDepositMinPoints(batch *lib.DexBatch, accepted []bool, config *lib.DexPoolConfig, L, x, y, totalDeposit, chainId uint64, local bool) (totalDL, remaining uint64, err lib.ErrorI) {
+	for totalDeposit != 0 {
+		// calculate points as if all accepted deposits are one deposit
+		if totalDL, err = liquidityDepositPoints(config, L, x, y, totalDeposit); err != nil {
+			return
+		}
+		refunded := false
+		for i, deposit := range batch.Deposits {
+			if !accepted[i] || lib.SafeMulDiv(totalDL, deposit.Amount, totalDeposit) >= deposit.MinPoints {
+				continue
+			}
+			// refund the deposit and remove it from the batch totals
+			if err = s.refundDexDeposit(deposit, chainId, local); err != nil {
+				return
+			}
+			accepted[i], refunded, totalDeposit = false, true, totalDeposit-deposit.Amount
+		}
+		if !refunded {
+			return totalDL, totalDeposit, nil
+		}
+	}
+	return 0, 0, nil
+}
+
+// refundDexDeposit() fails a liquidity deposit: returning the escrowed funds (origin chain only) and emitting a zero-share event
+func (s *StateMachine) refundDexDeposit(deposit *lib.DexLiquidityDeposit, chainId uint64, local bool) lib.ErrorI {
+	if local {
+		// return the escrowed funds to the depositor
+		if err := s.PoolSub(chainId+HoldingPoolAddend, deposit.Amount); err != nil {
+			return err
+		}
+		if err := s.AccountAdd(crypto.NewAddress(deposit.Address), deposit.Amount); err != nil {
+			return err
+		}
+	}
+	// emit a failed (zero-share) deposit event
+	return s.EventDexLiquidityDeposit(deposit.Address, deposit.OrderId, deposit.Amount, 0, chainId, local)
+}
+
 // handleCappedBatchDeposit deterministically admits the best-funded newcomers.
 // MaxLiquidityProviders bounds serialized point entries, so the permanent dead address consumes one slot.
-func (s *StateMachine) handleCappedBatchDeposit(batch *lib.DexBatch, p *Pool, chainId uint64, x, y *uint64, local bool) (bool, lib.ErrorI) {
+func (s *StateMachine) handleCappedBatchDeposit(batch *lib.DexBatch, p *Pool, chainId uint64, x, y *uint64, local bool, config *lib.DexPoolConfig) (bool, lib.ErrorI) {
 	// initialize vars
 	var err lib.ErrorI
 	type candidate struct {
@@ -712,7 +1007,7 @@ func (s *StateMachine) handleCappedBatchDeposit(batch *lib.DexBatch, p *Pool, ch
 		return false, nil
 	}
 	// otherwise; update all incumbent deposits first
-	if err := s.handleBatchDeposit(&lib.DexBatch{Deposits: incumbents}, chainId, x, y, local, false, p, false); err != nil {
+	if err := s.handleBatchDeposit(&lib.DexBatch{Deposits: incumbents}, chainId, x, y, local, false, p, false, config); err != nil {
 		return true, err
 	}
 	// sort the newcomers by total deposit amount then receiptHash + address
@@ -724,7 +1019,7 @@ func (s *StateMachine) handleCappedBatchDeposit(batch *lib.DexBatch, p *Pool, ch
 	})
 	// create an apply deposit callback
 	apply := func(newcomer *candidate) lib.ErrorI {
-		return s.handleBatchDeposit(&lib.DexBatch{Deposits: newcomer.deposits}, chainId, x, y, local, false, p, false)
+		return s.handleBatchDeposit(&lib.DexBatch{Deposits: newcomer.deposits}, chainId, x, y, local, false, p, false, config)
 	}
 	// for each newcomer
 	var lowest *lib.PoolPoints
@@ -751,16 +1046,20 @@ func (s *StateMachine) handleCappedBatchDeposit(batch *lib.DexBatch, p *Pool, ch
 		xOut := lib.SafeMulDiv(*x, lowest.Points, p.TotalPoolPoints)
 		yOut := lib.SafeMulDiv(*y, lowest.Points, p.TotalPoolPoints)
 		// calculate the newcomer's share assuming the lowest LP has already been removed
-		totalShare, e := liquidityDepositPoints(p.TotalPoolPoints-lowest.GetPoints(), *x-xOut, *y-yOut, newcomer.amount)
+		totalShare, e := liquidityDepositPoints(config, p.TotalPoolPoints-lowest.GetPoints(), *x-xOut, *y-yOut, newcomer.amount)
 		if e != nil {
 			return true, e
 		}
 		var share uint64
+		var slipped bool
 		for _, deposit := range newcomer.deposits {
-			share += lib.SafeMulDiv(totalShare, deposit.Amount, newcomer.amount)
+			depositShare := lib.SafeMulDiv(totalShare, deposit.Amount, newcomer.amount)
+			// a deposit below its minimum points fails the newcomer before anyone is evicted
+			slipped = slipped || depositShare < deposit.MinPoints
+			share += depositShare
 		}
-		// if the newcomer is less than the lowest points
-		if share <= lowest.Points {
+		// if the newcomer is less than the lowest points (or slipped past its minimum)
+		if share <= lowest.Points || slipped {
 			// reject the newcomer - returning his amount in escrow (holding pool) back to his account
 			if local {
 				if err = s.PoolSub(chainId+HoldingPoolAddend, newcomer.amount); err != nil {
@@ -794,13 +1093,13 @@ func liquidityProviderTieBreakHash(receiptHash, address []byte) []byte {
 	return crypto.Hash(append(bytes.Clone(receiptHash), address...))
 }
 
-// liquidityDepositPoints() calculates points minted by a one-sided deposit against the geometric-mean invariant.
-func liquidityDepositPoints(totalPoints, x, y, amount uint64) (uint64, lib.ErrorI) {
+// liquidityDepositPoints() calculates points minted by a one-sided deposit against the liquidity invariant of the curve.
+func liquidityDepositPoints(config *lib.DexPoolConfig, totalPoints, x, y, amount uint64) (uint64, lib.ErrorI) {
 	xAfter, overflow := lib.AddUint64(x, amount)
 	if overflow {
 		return 0, ErrInvalidLiquidityPool()
 	}
-	oldK, newK := lib.SqrtProductUint64(x, y), lib.SqrtProductUint64(xAfter, y)
+	oldK, newK := config.Liquidity(x, y), config.Liquidity(xAfter, y)
 	if oldK == 0 || newK < oldK {
 		return 0, ErrInvalidLiquidityPool()
 	}
@@ -811,7 +1110,8 @@ func liquidityDepositPoints(totalPoints, x, y, amount uint64) (uint64, lib.Error
 // (1) checks if locked batch is processed yet - if not exit
 // (2) sets the upcoming 'sell' batch as 'last' sell batch
 // (3) returns the upcoming 'sell' batch to be sent to the root
-func (s *StateMachine) RotateDexBatches(receiptsHash []byte, lPoolSize, counterPoolSize, chainId uint64, receipts []uint64) (err lib.ErrorI) {
+// NOTE: remoteConfig is the pool config carried by the counter chain's batch, which a nested chain carries forward
+func (s *StateMachine) RotateDexBatches(receiptsHash []byte, lPoolSize, counterPoolSize, chainId uint64, receipts, fills []uint64, remoteConfig *lib.DexPoolConfig) (err lib.ErrorI) {
 	// get locked sell batch
 	lockedBatch, err := s.GetDexBatch(chainId, true)
 	// exit with error or nil if last sell batch not yet processed by root (atomic protection)
@@ -831,14 +1131,31 @@ func (s *StateMachine) RotateDexBatches(receiptsHash []byte, lPoolSize, counterP
 	nextSellBatch.CounterPoolSize = counterPoolSize
 	// set the locked height
 	nextSellBatch.LockedHeight = s.Height()
+	// stamp the pool config both chains price this batch with: the root chain's governance chosen config, which only
+	// the root chain has, so a nested chain stamps the root chain's config it last received; a config change then
+	// applies to the batches locked after it, never to one in flight
+	if nextSellBatch.PoolConfig, err = s.getDexPoolConfig(chainId); err != nil {
+		return
+	}
+	if nextSellBatch.PoolConfig == nil {
+		nextSellBatch.PoolConfig = remoteConfig
+	}
 	// set receipts
 	if len(receipts) != 0 {
 		nextSellBatch.Receipts = receipts
 	}
+	// set the amount sold of each order (only if the receipted batch had partially fillable orders)
+	if len(fills) != 0 {
+		nextSellBatch.Fills = fills
+	}
 	// delete 'next sell batch'
 	if err = s.Delete(KeyForNextBatch(chainId)); err != nil {
 		return
 	}
+	// update the price oracle with the spot price of the newly locked batch
+	if err = s.UpdateDexPriceAccumulator(chainId, nextSellBatch); err != nil {
+		return
+	}
 	// set the upcoming sell batch as 'last'
 	return s.SetDexBatch(KeyForLockedBatch(chainId), nextSellBatch)
 }
@@ -933,6 +1250,136 @@ func (s *StateMachine) HandleLivenessFallback(rcId uint64, localBatch, remoteBat
 	if err = s.SetDexBatch(KeyForLockedBatch(rcId), &lib.DexBatch{}); err != nil {
 		return
 	}
+	// nothing is waiting on receipts anymore
+	return s.ClearDexBatchStall(rcId)
+}
+
+// GetDexLivenessFallbackBlocks() returns the number of blocks a nested chain's locked batch may wait on receipts before
+// the nested chain falls back
+func (s *StateMachine) GetDexLivenessFallbackBlocks() (uint64, lib.ErrorI) {
+	params, err := s.GetParamsVal()
+	if err != nil {
+		return 0, err
+	}
+	// chains with params from before the parameter existed use the protocol default
+	if params.DexLivenessFallbackBlocks == 0 {
+		return lib.LivenessFallbackBlocks, nil
+	}
+	return params.DexLivenessFallbackBlocks, nil
+}
+
+// BATCH STALL DIAGNOSTICS BELOW
+
+// RecordDexBatchStall() saves why processing the receipts for the locked batch of a chain pair was skipped at this height
+// the remote batch is nil if it's missing
+func (s *StateMachine) RecordDexBatchStall(chainId uint64, reason lib.DexStallReason, localBatch, remoteBatch *lib.DexBatch) lib.ErrorI {
+	// nothing is waiting on receipts without a locked batch
+	if localBatch.IsEmpty() {
+		return nil
+	}
+	stall, err := s.GetDexBatchStall(chainId)
+	if err != nil {
+		return err
+	}
+	// restart the record if the reason changed
+	if stall == nil || stall.Reason != reason {
+		stall = &lib.DexBatchStall{ChainId: chainId, Reason: reason, SinceHeight: s.Height()}
+	}
+	stall.LastHeight, stall.Count = s.Height(), stall.Count+1
+	stall.ExpectedReceiptHash, stall.ExpectedReceipts = localBatch.Hash(), uint64(len(localBatch.Orders))
+	stall.ReceivedReceiptHash, stall.ReceivedReceipts = nil, 0
+	if remoteBatch != nil {
+		stall.ReceivedReceiptHash, stall.ReceivedReceipts = remoteBatch.ReceiptHash, uint64(len(remoteBatch.Receipts))
+	}
+	bz, err := lib.Marshal(stall)
+	if err != nil {
+		return err
+	}
+	return s.Set(KeyForDexBatchStall(chainId), bz)
+}
+
+// GetDexBatchStall() returns the stall record of a chain pair (nil if not stalled)
+func (s *StateMachine) GetDexBatchStall(chainId uint64) (*lib.DexBatchStall, lib.ErrorI) {
+	bz, err := s.Get(KeyForDexBatchStall(chainId))
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+	stall := new(lib.DexBatchStall)
+	if err = lib.Unmarshal(bz, stall); err != nil {
+		return nil, err
+	}
+	return stall, nil
+}
+
+// ClearDexBatchStall() removes the stall record of a chain pair (if any)
+func (s *StateMachine) ClearDexBatchStall(chainId uint64) lib.ErrorI {
+	stall, err := s.GetDexBatchStall(chainId)
+	if err != nil || stall == nil {
+		return err
+	}
+	return s.Delete(KeyForDexBatchStall(chainId))
+}
+
+// GetDexBatchStatus() returns a diagnostic view of the batch pipeline with a counter chain: how long our batch has been
+// locked, when the liveness fallback fires and which side of the pair is holding up the receipts
+func (s *StateMachine) GetDexBatchStatus(chainId uint64) (*lib.DexBatchStatus, lib.ErrorI) {
+	locked, err := s.GetDexBatch(chainId, true)
+	if err != nil {
+		return nil, err
+	}
+	stall, err := s.GetDexBatchStall(chainId)
+	if err != nil {
+		return nil, err
+	}
+	fallbackBlocks, err := s.GetDexLivenessFallbackBlocks()
+	if err != nil {
+		return nil, err
+	}
+	rootChainId, err := s.GetRootChainId()
+	if err != nil {
+		return nil, err
+	}
+	status := &lib.DexBatchStatus{
+		LocalChainId:           s.Config.ChainId,
+		RemoteChainId:          chainId,
+		Height:                 s.Height(),
+		LivenessFallbackBlocks: fallbackBlocks,
+		Stuck:                  lib.DexStuckNone,
+		Stall:                  stall,
+	}
+	if !locked.IsEmpty() {
+		status.LockedHeight, status.LockedHash = locked.LockedHeight, locked.Hash()
+		status.LockedOrders, status.LockedDeposits = uint64(len(locked.Orders)), uint64(len(locked.Deposits))
+		status.LockedWithdrawals = uint64(len(locked.Withdrawals))
+		if s.Height() > locked.LockedHeight {
+			status.BlocksLocked = s.Height() - locked.LockedHeight
+		}
+		// only nested chains fall back, at the first trigger block past the window
+		if rootChainId != s.Config.ChainId {
+			triggers := (fallbackBlocks + lib.TriggerModuloBlocks - 1) / lib.TriggerModuloBlocks
+			status.LivenessFallbackHeight = locked.LockedHeight + triggers*lib.TriggerModuloBlocks
+		}
+	}
+	if stall != nil {
+		status.Stuck = stall.Reason.StuckSide()
+	}
+	return status, nil
+}
+
+// GetDexBatchStatuses() returns the batch pipeline status with every counter chain
+func (s *StateMachine) GetDexBatchStatuses() (statuses []*lib.DexBatchStatus, err lib.ErrorI) {
+	batches, err := s.GetDexBatches(true)
+	if err != nil {
+		return nil, err
+	}
+	statuses = make([]*lib.DexBatchStatus, 0, len(batches))
+	for _, batch := range batches {
+		status, e := s.GetDexBatchStatus(batch.Committee)
+		if e != nil {
+			return nil, e
+		}
+		statuses = append(statuses, status)
+	}
 	return
 }
 
@@ -1016,6 +1463,64 @@ func (s *StateMachine) GetDexBatches(lockedBatch bool) (b []*lib.DexBatch, err l
 	return
 }
 
+func init() {
+	// register the page for converting bytes of Page into the correct Page object
+	lib.RegisteredPageables[DexOrdersPageName] = new(DexOrderPage)
+}
+
+const DexOrdersPageName = "dexOrders" // name for page of open 'DexOrders'
+
+// DexOrder is an open dex limit order and the batch it's waiting in
+type DexOrder struct {
+	ChainId uint64             `json:"chainId"` // the counter chain the order sells to
+	Locked  bool               `json:"locked"`  // in the locked batch awaiting the counter chain, otherwise in the next batch
+	Order   *lib.DexLimitOrder `json:"order"`   // the order (a resting order's amounts are its unfilled remainder)
+}
+
+type DexOrderPage []*DexOrder
+
+// DexOrderPage satisfies the Page interface
+func (p *DexOrderPage) New() lib.Pageable { return &DexOrderPage{} }
+
+// GetDexOrdersPaginated() returns the open orders of the locked and next dex batches
+// optionally filtered by counter chain (0 = all chains) and seller address (nil = all sellers)
+func (s *StateMachine) GetDexOrdersPaginated(chainId uint64, address crypto.AddressI, p lib.PageParams) (*lib.Page, lib.ErrorI) {
+	var open []*DexOrder
+	for _, locked := range []bool{true, false} {
+		var batches []*lib.DexBatch
+		if chainId != 0 {
+			batch, err := s.GetDexBatch(chainId, locked)
+			if err != nil {
+				return nil, err
+			}
+			batches = append(batches, batch)
+		} else {
+			var err lib.ErrorI
+			if batches, err = s.GetDexBatches(locked); err != nil {
+				return nil, err
+			}
+		}
+		for _, batch := range batches {
+			for _, order := range batch.Orders {
+				if address != nil && !bytes.Equal(address.Bytes(), order.Address) {
+					continue
+				}
+				open = append(open, &DexOrder{ChainId: batch.Committee, Locked: locked, Order: order})
+			}
+		}
+	}
+	// load the page from the open orders
+	page, res := lib.NewPage(p, DexOrdersPageName), make(DexOrderPage, 0)
+	return page, page.LoadArray(open, &res, func(i any) lib.ErrorI {
+		order, ok := i.(*DexOrder)
+		if !ok {
+			return lib.ErrInvalidArgument()
+		}
+		res = append(res, order)
+		return nil
+	})
+}
+
 // GetDexPrice() returns the chain price
 func (s *StateMachine) GetDexPrice(chainId uint64) (p *lib.DexPrice, err lib.ErrorI) {
 	// get the dex batch
@@ -1065,27 +1570,304 @@ func (s *StateMachine) getPrice(batch *lib.DexBatch) (p *lib.DexPrice, err lib.E
 	}, nil
 }
 
-// SafeComputeDY() executes overflow protected uniswap V2 formula
+// DEX PRICE ORACLE CODE BELOW
+
+// UpdateDexPriceAccumulator() accumulates the price in effect since the last update and sets the spot price of the locked batch
+// NOTE: a pool without liquidity on either side has no price, so the last price simply stays in effect
+func (s *StateMachine) UpdateDexPriceAccumulator(chainId uint64, lockedBatch *lib.DexBatch) lib.ErrorI {
+	if lockedBatch.PoolSize == 0 || lockedBatch.CounterPoolSize == 0 {
+		return nil
+	}
+	price, err := s.getPrice(lockedBatch)
+	if err != nil {
+		return err
+	}
+	accumulator, err := s.GetDexPriceAccumulator(chainId)
+	if err != nil {
+		return err
+	}
+	accumulator.Update(s.Height(), price.E6ScaledPrice)
+	bz, err := lib.Marshal(accumulator)
+	if err != nil {
+		return err
+	}
+	return s.Set(KeyForDexPriceAccumulator(chainId), bz)
+}
+
+// GetDexPriceAccumulator() returns the cumulative price oracle for a counter chain (zero valued if never updated)
+func (s *StateMachine) GetDexPriceAccumulator(chainId uint64) (*lib.DexPriceAccumulator, lib.ErrorI) {
+	accumulator := &lib.DexPriceAccumulator{ChainId: chainId}
+	bz, err := s.Get(KeyForDexPriceAccumulator(chainId))
+	if err != nil || len(bz) == 0 {
+		return accumulator, err
+	}
+	if err = lib.Unmarshal(bz, accumulator); err != nil {
+		return nil, err
+	}
+	return accumulator, nil
+}
+
+// GetDexTWAP() returns the time weighted average price of a counter chain from startHeight to the current height
+// NOTE: unlike the spot price, moving the TWAP requires holding a manipulated price across many batches
+func (s *StateMachine) GetDexTWAP(chainId, startHeight uint64) (*lib.DexTWAP, lib.ErrorI) {
+	endHeight := s.Height()
+	if startHeight == 0 || startHeight >= endHeight {
+		return nil, lib.ErrInvalidDexTWAPWindow()
+	}
+	// get the accumulator at the end of the window
+	end, err := s.GetDexPriceAccumulator(chainId)
+	if err != nil {
+		return nil, err
+	}
+	// get the accumulator at the start of the window using a historical view of the state
+	historical, err := s.TimeMachine(startHeight)
+	if err != nil {
+		return nil, err
+	}
+	// memory cleanup
+	if historical != s {
+		defer historical.Discard()
+	}
+	start, err := historical.GetDexPriceAccumulator(chainId)
+	if err != nil {
+		return nil, err
+	}
+	// compute the time weighted average price
+	twap, err := lib.ComputeDexTWAP(start, end, startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+	return &lib.DexTWAP{
+		LocalChainId:      s.Config.ChainId,
+		RemoteChainId:     chainId,
+		StartHeight:       startHeight,
+		EndHeight:         endHeight,
+		E6ScaledTwap:      twap,
+		E6ScaledSpotPrice: end.LastE6Price,
+	}, nil
+}
+
+// QuoteDexRoute() returns the expected output of swapping amountIn along a path of chains with the current pool sizes
+// NOTE: a chain only knows the pools it's part of, so the root chain is the one able to quote multi-hop routes
+func (s *StateMachine) QuoteDexRoute(amountIn uint64, path []uint64) (*lib.DexQuote, lib.ErrorI) {
+	if amountIn == 0 {
+		return nil, ErrInvalidAmount()
+	}
+	if err := checkDexPath(path); err != nil {
+		return nil, err
+	}
+	quote, amount := &lib.DexQuote{Path: path, AmountIn: amountIn}, amountIn
+	for i := 1; i < len(path); i++ {
+		x, y, config, err := s.dexLegPool(path[i-1], path[i])
+		if err != nil {
+			return nil, err
+		}
+		if x == 0 || y == 0 {
+			return nil, ErrInvalidLiquidityPool()
+		}
+		amount = config.ComputeDY(x, y, amount)
+		quote.LegOutputs = append(quote.LegOutputs, amount)
+	}
+	quote.AmountOut = amount
+	return quote, nil
+}
+
+// dexLegPool() returns the pool (x = the sold asset's side, y = the bought asset's side) and pool config a swap from -> to
+// executes against; the counter chain's side is mirrored from the last locked batch
+func (s *StateMachine) dexLegPool(from, to uint64) (x, y uint64, config *lib.DexPoolConfig, err lib.ErrorI) {
+	counterChainId := to
+	switch s.Config.ChainId {
+	case from:
+	case to:
+		counterChainId = from
+	default:
+		return 0, 0, nil, lib.ErrInvalidDexRoute()
+	}
+	localPoolSize, err := s.GetPoolBalance(counterChainId + LiquidityPoolAddend)
+	if err != nil {
+		return
+	}
+	locked, err := s.GetDexBatch(counterChainId, true)
+	if err != nil {
+		return
+	}
+	if config, err = s.GetDexPoolConfig(counterChainId); err != nil {
+		return
+	}
+	if counterChainId == to {
+		return localPoolSize, locked.CounterPoolSize, config, nil
+	}
+	return locked.CounterPoolSize, localPoolSize, config, nil
+}
+
+// SafeComputeDY() executes overflow protected uniswap V2 formula with the default 1% fee
 func SafeComputeDY(x, y, dX uint64) uint64 {
-	bx := new(big.Int).SetUint64(x)
-	by := new(big.Int).SetUint64(y)
-	bdX := new(big.Int).SetUint64(dX)
+	return lib.DefaultDexPoolConfig(0).ComputeDY(x, y, dX)
+}
+
+// DEX POOL CONFIG CODE BELOW
+
+// GetDexPoolConfig() returns the governance chosen config of the liquidity pool for a counter chain
+// NOTE: defaults to a constant product pool with a 1% fee if governance hasn't chosen one
+func (s *StateMachine) GetDexPoolConfig(chainId uint64) (*lib.DexPoolConfig, lib.ErrorI) {
+	config, err := s.getDexPoolConfig(chainId)
+	if err != nil || config != nil {
+		return config, err
+	}
+	return lib.DefaultDexPoolConfig(chainId), nil
+}
+
+// getDexPoolConfig() returns the governance chosen config of the liquidity pool for a counter chain or nil if not set
+func (s *StateMachine) getDexPoolConfig(chainId uint64) (*lib.DexPoolConfig, lib.ErrorI) {
+	bz, err := s.Get(KeyForDexPoolConfig(chainId))
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+	config := new(lib.DexPoolConfig)
+	if err = lib.Unmarshal(bz, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// SetDexPoolConfig() sets the config of the liquidity pool for a counter chain
+func (s *StateMachine) SetDexPoolConfig(config *lib.DexPoolConfig) lib.ErrorI {
+	if err := config.Check(); err != nil {
+		return err
+	}
+	bz, err := lib.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return s.Set(KeyForDexPoolConfig(config.ChainId), bz)
+}
+
+// GetDexPoolConfigs() returns the governance chosen configs of all liquidity pools
+func (s *StateMachine) GetDexPoolConfigs() (configs []*lib.DexPoolConfig, err lib.ErrorI) {
+	configs = make([]*lib.DexPoolConfig, 0)
+	err = s.IterateAndExecute(lib.JoinLenPrefix(dexPrefix, poolConfigSegment), func(_, value []byte) lib.ErrorI {
+		config := new(lib.DexPoolConfig)
+		if e := lib.Unmarshal(value, config); e != nil {
+			return e
+		}
+		configs = append(configs, config)
+		return nil
+	})
+	return
+}
 
-	// amountInWithFee = dX * 990 (1% fee)
-	amountInWithFee := new(big.Int).Mul(bdX, big.NewInt(990))
+// DEX LIQUIDITY POSITION CODE BELOW
 
-	// numerator = amountInWithFee * y
-	numerator := new(big.Int).Mul(amountInWithFee, by)
+// GetDexPosition() returns the position of a liquidity provider in the pool of a counter chain
+// valued with the pool sizes of the locked batch, the same sizes withdrawals are paid against
+func (s *StateMachine) GetDexPosition(chainId uint64, address []byte) (*lib.DexPosition, lib.ErrorI) {
+	// get the locked batch with the pool points
+	batch, err := s.GetDexBatch(chainId, true, true)
+	if err != nil {
+		return nil, err
+	}
+	// get the points of the provider
+	var points uint64
+	for _, point := range batch.PoolPoints {
+		if bytes.Equal(point.Address, address) {
+			points = point.Points
+			break
+		}
+	}
+	// get the curve to measure the liquidity of the pool
+	config, err := s.GetDexPoolConfig(chainId)
+	if err != nil {
+		return nil, err
+	}
+	// get the cost basis of the provider
+	basis, err := s.GetDexPositionBasis(chainId, address)
+	if err != nil {
+		return nil, err
+	}
+	liquidity := config.Liquidity(batch.PoolSize, batch.CounterPoolSize)
+	return lib.NewDexPosition(s.Config.ChainId, chainId, address, points, batch.TotalPoolPoints,
+		batch.PoolSize, batch.CounterPoolSize, liquidity, basis), nil
+}
 
-	// denominator = x*1000 + amountInWithFee
-	denominator := new(big.Int).Mul(bx, big.NewInt(1000))
-	denominator.Add(denominator, amountInWithFee)
+// GetDexPositions() returns the positions of a liquidity provider in every pool it holds points in
+func (s *StateMachine) GetDexPositions(address []byte) (positions []*lib.DexPosition, err lib.ErrorI) {
+	positions = make([]*lib.DexPosition, 0)
+	// get all locked batches
+	batches, err := s.GetDexBatches(true)
+	if err != nil {
+		return nil, err
+	}
+	for _, batch := range batches {
+		position, e := s.GetDexPosition(batch.Committee, address)
+		if e != nil {
+			return nil, e
+		}
+		// skip pools the provider isn't in
+		if position.Points != 0 {
+			positions = append(positions, position)
+		}
+	}
+	return
+}
 
-	// dY = numerator / denominator
-	dY := new(big.Int).Div(numerator, denominator)
+// GetDexPositionBasis() returns the cost basis of a liquidity provider in the pool of a counter chain (nil if untracked)
+func (s *StateMachine) GetDexPositionBasis(chainId uint64, address []byte) (*lib.DexPositionBasis, lib.ErrorI) {
+	bz, err := s.Get(KeyForDexPositionBasis(chainId, address))
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+	basis := new(lib.DexPositionBasis)
+	if err = lib.Unmarshal(bz, basis); err != nil {
+		return nil, err
+	}
+	return basis, nil
+}
+
+// SetDexPositionBasis() sets the cost basis of a liquidity provider or deletes it if empty
+func (s *StateMachine) SetDexPositionBasis(chainId uint64, address []byte, basis *lib.DexPositionBasis) lib.ErrorI {
+	key := KeyForDexPositionBasis(chainId, address)
+	if basis.LocalDeposited == 0 && basis.RemoteDeposited == 0 && basis.Liquidity == 0 {
+		return s.Delete(key)
+	}
+	bz, err := lib.Marshal(basis)
+	if err != nil {
+		return err
+	}
+	return s.Set(key, bz)
+}
+
+// addDexPositionBasis() adds a deposit of the local (or counter) asset and the liquidity it added to the cost basis
+func (s *StateMachine) addDexPositionBasis(chainId uint64, address []byte, amount, liquidity uint64, local bool) lib.ErrorI {
+	basis, err := s.GetDexPositionBasis(chainId, address)
+	if err != nil {
+		return err
+	}
+	if basis == nil {
+		basis = new(lib.DexPositionBasis)
+	}
+	deposited := &basis.RemoteDeposited
+	if local {
+		deposited = &basis.LocalDeposited
+	}
+	var overflow, liquidityOverflow bool
+	*deposited, overflow = lib.AddUint64(*deposited, amount)
+	basis.Liquidity, liquidityOverflow = lib.AddUint64(basis.Liquidity, liquidity)
+	if overflow || liquidityOverflow {
+		return ErrInvalidLiquidityPool()
+	}
+	return s.SetDexPositionBasis(chainId, address, basis)
+}
 
-	// integer flooring
-	return dY.Uint64()
+// reduceDexPositionBasis() reduces the cost basis pro-rata when a liquidity provider withdraws points (average cost)
+func (s *StateMachine) reduceDexPositionBasis(chainId uint64, address []byte, remainingPoints, points uint64) lib.ErrorI {
+	basis, err := s.GetDexPositionBasis(chainId, address)
+	if err != nil || basis == nil {
+		return err
+	}
+	basis.LocalDeposited = lib.SafeMulDiv(basis.LocalDeposited, remainingPoints, points)
+	basis.RemoteDeposited = lib.SafeMulDiv(basis.RemoteDeposited, remainingPoints, points)
+	basis.Liquidity = lib.SafeMulDiv(basis.Liquidity, remainingPoints, points)
+	return s.SetDexPositionBasis(chainId, address, basis)
 }
 
 var deadAddr, _ = crypto.NewAddressFromString(strings.Repeat("dead", 10))
@@ -2193,7 +2193,7 @@ func TestRotateDexSellBatch(t *testing.T) {
 			balance, err := sm.GetPoolBalance(test.chainId + LiquidityPoolAddend)
 			require.NoError(t, err)
 
-			err = sm.RotateDexBatches(test.buyBatch.Hash(), balance, test.buyBatch.PoolSize, test.chainId, test.receipts)
+			err = sm.RotateDexBatches(test.buyBatch.Hash(), balance, test.buyBatch.PoolSize, test.chainId, test.receipts, nil, nil)
 
 			if test.expectError {
 				require.Error(t, err)
@@ -2559,7 +2559,7 @@ func TestHandleDexBatchOrdersRespectsRequestedAmount(t *testing.T) {
 	x, y := uint64(10_000), uint64(10_000)
 	x0, y0 := x, y
 
-	receipts, err := sm.HandleDexBatchOrders(batch, &x, &y, chainId)
+	receipts, _, err := sm.HandleDexBatchOrders(batch, &x, &y, chainId, nil)
 	require.NoError(t, err)
 	require.Len(t, receipts, len(batch.Orders))
 
@@ -2589,7 +2589,7 @@ func TestHandleDexBatchOrdersRejectsReserveOverflow(t *testing.T) {
 	x, y := uint64(math.MaxUint64-1), uint64(math.MaxUint64)
 	x0, y0 := x, y
 
-	receipts, err := sm.HandleDexBatchOrders(batch, &x, &y, chainId)
+	receipts, _, err := sm.HandleDexBatchOrders(batch, &x, &y, chainId, nil)
 	require.Error(t, err)
 	require.Equal(t, ErrInvalidLiquidityPool().Code(), err.Code())
 	require.Nil(t, receipts)
@@ -2616,7 +2616,7 @@ func TestHandleDexBatchOrdersEnforcesSettlementCap(t *testing.T) {
 		orders[i] = &lib.DexLimitOrder{AmountForSale: 1_000, Address: addr, OrderId: addr}
 	}
 	x, y := pool, pool
-	receipts, err := sm.HandleDexBatchOrders(&lib.DexBatch{Committee: chainId, Orders: orders}, &x, &y, chainId)
+	receipts, _, err := sm.HandleDexBatchOrders(&lib.DexBatch{Committee: chainId, Orders: orders}, &x, &y, chainId, nil)
 	require.NoError(t, err)
 	require.Len(t, receipts, total)
 	var settled int
@@ -2648,7 +2648,7 @@ func TestWithdrawThenRedeploy_NoPointGain(t *testing.T) {
 			{Amount: depositAmt, Address: user.Bytes(), OrderId: []byte{0x03}},
 		},
 	}
-	require.NoError(t, sm.HandleBatchDeposit(depositBatch, chainId, &x, &y, true))
+	require.NoError(t, sm.HandleBatchDeposit(depositBatch, chainId, &x, &y, true, nil))
 	pool, err := sm.GetPool(chainId + LiquidityPoolAddend)
 	require.NoError(t, err)
 	initialPoints, err := pool.GetPointsFor(user.Bytes())
@@ -2677,7 +2677,7 @@ func TestWithdrawThenRedeploy_NoPointGain(t *testing.T) {
 			{Amount: payout, Address: user.Bytes(), OrderId: []byte{0x05}},
 		},
 	}
-	require.NoError(t, sm.HandleBatchDeposit(redepositBatch, chainId, &x, &y, true))
+	require.NoError(t, sm.HandleBatchDeposit(redepositBatch, chainId, &x, &y, true, nil))
 
 	finalPool, err := sm.GetPool(chainId + LiquidityPoolAddend)
 	require.NoError(t, err)
@@ -2707,7 +2707,7 @@ func TestHandleBatchDeposit_RejectsReserveOverflow(t *testing.T) {
 		}},
 	}
 
-	err := sm.HandleBatchDeposit(batch, chainId, &x, &y, false)
+	err := sm.HandleBatchDeposit(batch, chainId, &x, &y, false, nil)
 	require.Error(t, err)
 	require.Equal(t, ErrInvalidLiquidityPool().Code(), err.Code())
 }
@@ -2735,7 +2735,7 @@ func TestHandleBatchDepositRejectsPoolPointsOverflow(t *testing.T) {
 		}},
 	}
 
-	err := sm.HandleBatchDeposit(batch, chainId, &x, &y, false)
+	err := sm.HandleBatchDeposit(batch, chainId, &x, &y, false, nil)
 	require.Error(t, err)
 	require.Equal(t, ErrInvalidAmount().Code(), err.Code())
 }
@@ -2764,7 +2764,7 @@ func TestHandleBatchDepositZeroShareDoesNotCreateGhostProvider(t *testing.T) {
 			Amount:  1, // rounds to zero LP share at this pool size
 			OrderId: []byte{0x21},
 		}},
-	}, chainId, &x, &y, true)
+	}, chainId, &x, &y, true, nil)
 	require.NoError(t, err)
 
 	pool, err := sm.GetPool(chainId + LiquidityPoolAddend)
@@ -2808,7 +2808,7 @@ func TestHandleBatchDepositRemoteReplacesLowestProvider(t *testing.T) {
 			Amount:  100,
 			OrderId: depositOrderId,
 		}},
-	}, chainId, &x, &y, false)
+	}, chainId, &x, &y, false, nil)
 	require.NoError(t, err)
 	pool, err := sm.GetPool(chainId + LiquidityPoolAddend)
 	require.NoError(t, err)
@@ -2848,7 +2848,7 @@ func TestHandleBatchDepositSplitNewProviderUsesOneSlot(t *testing.T) {
 	require.NoError(t, sm.HandleBatchDeposit(&lib.DexBatch{Deposits: []*lib.DexLiquidityDeposit{
 		{Address: newProvider.Bytes(), Amount: 1_000, OrderId: []byte{1}},
 		{Address: newProvider.Bytes(), Amount: 1_000, OrderId: []byte{2}},
-	}}, chainID, &x, &y, false))
+	}}, chainID, &x, &y, false, nil))
 
 	pool, err := sm.GetPool(chainID + LiquidityPoolAddend)
 	require.NoError(t, err)
@@ -2881,7 +2881,7 @@ func TestHandleBatchDepositRanksNewcomersByProviderTotal(t *testing.T) {
 		{Address: split.Bytes(), Amount: 60},
 		{Address: single.Bytes(), Amount: 100},
 		{Address: split.Bytes(), Amount: 60},
-	}}, chainID, &x, &y, false))
+	}}, chainID, &x, &y, false, nil))
 
 	pool, err := sm.GetPool(chainID + LiquidityPoolAddend)
 	require.NoError(t, err)
@@ -2918,7 +2918,7 @@ func TestHandleBatchDepositUsesReceiptHashForEqualStakeTie(t *testing.T) {
 	require.NoError(t, sm.HandleBatchDeposit(&lib.DexBatch{ReceiptHash: seed, Deposits: []*lib.DexLiquidityDeposit{
 		{Address: loser.Bytes(), Amount: 100},
 		{Address: winner.Bytes(), Amount: 100},
-	}}, chainID, &x, &y, false))
+	}}, chainID, &x, &y, false, nil))
 
 	pool, err := sm.GetPool(chainID + LiquidityPoolAddend)
 	require.NoError(t, err)
@@ -2956,7 +2956,7 @@ func TestHandleBatchDepositRejectedEntryDoesNotChangeIncumbents(t *testing.T) {
 			deposits = append(deposits, &lib.DexLiquidityDeposit{Address: rejected.Bytes(), Amount: 1})
 		}
 		x, y := uint64(1_000_000), uint64(1_000_000)
-		require.NoError(t, sm.HandleBatchDeposit(&lib.DexBatch{Deposits: deposits}, chainID, &x, &y, false))
+		require.NoError(t, sm.HandleBatchDeposit(&lib.DexBatch{Deposits: deposits}, chainID, &x, &y, false, nil))
 		pool, err := sm.GetPool(chainID + LiquidityPoolAddend)
 		require.NoError(t, err)
 		return pool, x
@@ -2993,7 +2993,7 @@ func TestHandleBatchDepositRefundsRejectedLocalNewcomer(t *testing.T) {
 	x, y := uint64(1_000_000), uint64(1_000_000)
 	require.NoError(t, sm.HandleBatchDeposit(&lib.DexBatch{Deposits: []*lib.DexLiquidityDeposit{{
 		Address: newcomer.Bytes(), Amount: 1,
-	}}}, chainID, &x, &y, true))
+	}}}, chainID, &x, &y, true, nil))
 
 	balance, err := sm.GetAccountBalance(newcomer)
 	require.NoError(t, err)
@@ -4118,3 +4118,625 @@ func (m *MockRCManager) GetCheckpoint(rootChainId, height, id uint64) (blockHash
 func (m *MockRCManager) Transaction(rootChainId uint64, tx lib.TransactionI) (hash *string, err lib.ErrorI) {
 	return
 }
+
+func TestDexPoolConfigGovernance(t *testing.T) {
+	sm := newTestStateMachine(t)
+	chainId := uint64(2)
+	// a pool without a config uses the default constant product config
+	config, err := sm.GetDexPoolConfig(chainId)
+	require.NoError(t, err)
+	require.True(t, config.Equals(lib.DefaultDexPoolConfig(chainId)))
+	// the root chain's governance chooses the config
+	msg := &MessageDexPoolConfig{ChainId: chainId, Curve: lib.DexCurve_STABLE_SWAP, Amplification: 100, FeeBasisPoints: 5}
+	require.NoError(t, msg.Check())
+	require.NoError(t, sm.ExecuteGovProposal(msg))
+	config, err = sm.GetDexPoolConfig(chainId)
+	require.NoError(t, err)
+	require.True(t, config.Equals(msg.PoolConfig()))
+	configs, err := sm.GetDexPoolConfigs()
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	// the rotated batch carries the config to the nested chain
+	require.NoError(t, sm.SetDexBatch(KeyForNextBatch(chainId), &lib.DexBatch{Committee: chainId}))
+	require.NoError(t, sm.RotateDexBatches(nil, 0, 0, chainId, nil, nil, nil))
+	locked, err := sm.GetDexBatch(chainId, true)
+	require.NoError(t, err)
+	require.True(t, locked.PoolConfig.Equals(config))
+	// an invalid config is rejected
+	require.Error(t, (&MessageDexPoolConfig{ChainId: chainId, Curve: lib.DexCurve_STABLE_SWAP, FeeBasisPoints: 5}).Check())
+	// a nested chain can't choose the config
+	sm.Config.ChainId = chainId + 1
+	require.ErrorContains(t, sm.ExecuteGovProposal(msg), lib.ErrInvalidDexPoolConfig().Error())
+}
+
+func TestHandleDexBatchOrdersStableSwap(t *testing.T) {
+	sm := newTestStateMachine(t)
+	chainId := uint64(2)
+	addr := newTestAddress(t, 1)
+	require.NoError(t, sm.SetPool(&Pool{Id: chainId + LiquidityPoolAddend, Amount: 1_000_000}))
+	batch := &lib.DexBatch{Committee: chainId, Orders: []*lib.DexLimitOrder{
+		{AmountForSale: 10_000, RequestedAmount: 9_900, Address: addr.Bytes(), OrderId: []byte{0x01}},
+	}}
+	// the constant product curve fails the order's slippage gate
+	x, y := uint64(1_000_000), uint64(1_000_000)
+	receipts, _, err := sm.HandleDexBatchOrders(batch, &x, &y, chainId, nil)
+	require.NoError(t, err)
+	require.Zero(t, receipts[0])
+	// the stable swap curve fills it
+	stable := &lib.DexPoolConfig{ChainId: chainId, Curve: lib.DexCurve_STABLE_SWAP, Amplification: 100, FeeBasisPoints: 5}
+	x, y = 1_000_000, 1_000_000
+	receipts, _, err = sm.HandleDexBatchOrders(batch, &x, &y, chainId, stable)
+	require.NoError(t, err)
+	require.Equal(t, stable.ComputeDY(1_000_000, 1_000_000, 10_000), receipts[0])
+	require.GreaterOrEqual(t, receipts[0], uint64(9_900))
+}
+
+func TestDexPoolConfigInFlight(t *testing.T) {
+	const rootId, nestedId, poolAmount = uint64(1), uint64(2), uint64(1_000_000)
+	// initialize the chains and pools
+	root, nested := newTestStateMachine(t), newTestStateMachine(t)
+	nested.Config.ChainId = nestedId
+	account := newTestAddress(t, 0)
+	require.NoError(t, nested.AccountAdd(account, 10_000))
+	require.NoError(t, root.SetPool(&Pool{Id: nestedId + LiquidityPoolAddend, Amount: poolAmount}))
+	require.NoError(t, nested.SetPool(&Pool{Id: rootId + LiquidityPoolAddend, Amount: poolAmount}))
+	// the root chain's governance chose a stable swap curve, which it stamps on its locked batch
+	stable := &lib.DexPoolConfig{ChainId: nestedId, Curve: lib.DexCurve_STABLE_SWAP, Amplification: 100, FeeBasisPoints: 5}
+	require.NoError(t, root.SetDexPoolConfig(stable))
+	require.NoError(t, root.HandleRemoteDexBatch(&lib.DexBatch{Committee: nestedId, PoolSize: poolAmount}, nestedId))
+	rootLocked, err := root.GetDexBatch(nestedId, true)
+	require.NoError(t, err)
+	require.True(t, rootLocked.PoolConfig.Equals(stable))
+	// an order that only meets its limit on the stable swap curve
+	require.NoError(t, nested.HandleMessageDexLimitOrder(&MessageDexLimitOrder{
+		ChainId: rootId, AmountForSale: 10_000, RequestedAmount: 9_900, Address: account.Bytes(), OrderId: []byte{1},
+	}))
+	// the nested chain locks the order with the root chain's config it received
+	require.NoError(t, nested.HandleRemoteDexBatch(rootLocked, rootId))
+	nestedLocked, err := nested.GetDexBatch(rootId, true)
+	require.NoError(t, err)
+	require.True(t, nestedLocked.PoolConfig.Equals(stable))
+	// governance switches back to the constant product curve while the batch is in flight
+	require.NoError(t, root.SetDexPoolConfig(lib.DefaultDexPoolConfig(nestedId)))
+	// the root chain still prices the in flight batch with the config it carries
+	require.NoError(t, root.HandleRemoteDexBatch(nestedLocked, nestedId))
+	reply, err := root.GetDexBatch(nestedId, true)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{stable.ComputeDY(poolAmount, poolAmount, 10_000)}, reply.Receipts)
+	// and the next batch carries the new config
+	require.True(t, reply.PoolConfig.Equals(lib.DefaultDexPoolConfig(nestedId)))
+}
+
+func TestDexPriceOracle(t *testing.T) {
+	const chainId = uint64(2)
+	sm := newTestStateMachine(t)
+	// rotate a batch at the given height and commit the state at it
+	rotateAt := func(height, counterPoolSize uint64) {
+		sm.height = height
+		require.NoError(t, sm.RotateDexBatches(nil, 1_000_000, counterPoolSize, chainId, nil, nil, nil))
+		_, err := sm.store.(lib.StoreI).Commit()
+		require.NoError(t, err)
+	}
+	// no observations yet
+	_, err := sm.GetDexTWAP(chainId, 1)
+	require.ErrorContains(t, err, lib.ErrInvalidDexTWAPWindow().Error())
+	// price 1.0 from height 2, 2.0 from height 3 (a single batch spike) and back to 1.0 from height 4
+	rotateAt(2, 1_000_000)
+	rotateAt(3, 500_000)
+	rotateAt(4, 1_000_000)
+	for h := uint64(5); h <= 11; h++ {
+		sm.height = h
+		_, err = sm.store.(lib.StoreI).Commit()
+		require.NoError(t, err)
+	}
+	accumulator, err := sm.GetDexPriceAccumulator(chainId)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, accumulator.LastHeight)
+	require.EqualValues(t, 1_000_000, accumulator.LastE6Price)
+	// the spike only moves the twap by its share of the window: (1 + 2 + 1 * 8) / 10 = 1.1
+	sm.height = 12
+	twap, err := sm.GetDexTWAP(chainId, 2)
+	require.NoError(t, err)
+	require.EqualExportedValues(t, &lib.DexTWAP{
+		LocalChainId:      sm.Config.ChainId,
+		RemoteChainId:     chainId,
+		StartHeight:       2,
+		EndHeight:         12,
+		E6ScaledTwap:      1_100_000,
+		E6ScaledSpotPrice: 1_000_000,
+	}, twap)
+	// a window after the spike is the spot price
+	twap, err = sm.GetDexTWAP(chainId, 4)
+	require.NoError(t, err)
+	require.EqualValues(t, 1_000_000, twap.E6ScaledTwap)
+	// the window can't start before the first observation or end before it starts
+	for _, start := range []uint64{0, 1, 12, 13} {
+		_, err = sm.GetDexTWAP(chainId, start)
+		require.ErrorContains(t, err, lib.ErrInvalidDexTWAPWindow().Error())
+	}
+	// a batch without liquidity on one side keeps the last price in effect
+	require.NoError(t, sm.RotateDexBatches(nil, 1_000_000, 0, chainId, nil, nil, nil))
+	accumulator, err = sm.GetDexPriceAccumulator(chainId)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, accumulator.LastHeight)
+	// but a price that can't be represented fails the rotation instead of silently freezing the twap
+	require.ErrorContains(t, sm.RotateDexBatches(nil, math.MaxUint64, 1, chainId, nil, nil, nil), ErrInvalidLiquidityPool().Error())
+	// plugins may read but not write the accumulator
+	resp, err := sm.StateRead(&lib.PluginStateReadRequest{Keys: []*lib.PluginKeyRead{{QueryId: 1, Key: KeyForDexPriceAccumulator(chainId)}}})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	require.Len(t, resp.Results[0].Entries, 1)
+	require.Panics(t, func() {
+		_, _ = sm.StateWrite(&lib.PluginStateWriteRequest{Sets: []*lib.PluginSetOp{{Key: KeyForDexPriceAccumulator(chainId), Value: []byte{1}}}})
+	})
+}
+
+func TestDexPartialFillRestingOrder(t *testing.T) {
+	const chain1Id, chain2Id, poolAmount = uint64(1), uint64(2), uint64(10_000)
+	// initialize the chains and pools
+	chain1, chain2 := newTestStateMachine(t), newTestStateMachine(t)
+	chain2.Config.ChainId = chain2Id
+	account := newTestAddress(t, 0)
+	require.NoError(t, chain2.AccountAdd(account, 2_000))
+	require.NoError(t, chain1.SetPool(&Pool{Id: chain2Id + LiquidityPoolAddend, Amount: poolAmount}))
+	require.NoError(t, chain2.SetPool(&Pool{Id: chain1Id + LiquidityPoolAddend, Amount: poolAmount}))
+	// an expiry height at or below the current height is rejected
+	require.ErrorContains(t, chain2.HandleMessageDexLimitOrder(&MessageDexLimitOrder{
+		ChainId: chain1Id, AmountForSale: 2_000, RequestedAmount: 1_900, Address: account.Bytes(), ExpiryHeight: chain2.Height(),
+	}), lib.ErrInvalidDexOrderExpiry().Error())
+	// sell 2000 at a limit price of 0.95, which only fills in part against a 10k:10k pool
+	require.NoError(t, chain2.HandleMessageDexLimitOrder(&MessageDexLimitOrder{
+		ChainId: chain1Id, AmountForSale: 2_000, RequestedAmount: 1_900, Address: account.Bytes(), OrderId: []byte{1},
+		ExpiryHeight: chain2.Height() + 1, PartialFill: true,
+	}))
+	// chain2 locks the order, chain1 fills it in part
+	require.NoError(t, chain2.HandleRemoteDexBatch(&lib.DexBatch{Committee: chain2Id, PoolSize: poolAmount}, chain1Id))
+	locked, err := chain2.GetDexBatch(chain1Id, true)
+	require.NoError(t, err)
+	require.NoError(t, chain1.HandleRemoteDexBatch(locked, chain2Id))
+	reply, err := chain1.GetDexBatch(chain2Id, true)
+	require.NoError(t, err)
+	require.Len(t, reply.Fills, 1)
+	sold, bought := reply.Fills[0], reply.Receipts[0]
+	require.NotZero(t, sold)
+	require.Less(t, sold, uint64(2_000))
+	require.GreaterOrEqual(t, bought*2_000, sold*1_900, "the fill must respect the limit price")
+	require.Equal(t, bought, getAccountBalance(t, &chain1, account))
+	// chain2 applies the receipt: the sold part moves to the liquidity pool and the rest rests in the next locked batch
+	chain2.events.Events = nil
+	require.NoError(t, chain2.HandleRemoteDexBatch(reply, chain1Id))
+	require.Equal(t, poolAmount+sold, getPoolBalance(t, &chain2, chain1Id+LiquidityPoolAddend))
+	require.Equal(t, 2_000-sold, getPoolBalance(t, &chain2, chain1Id+HoldingPoolAddend))
+	swap := chain2.events.Events[0].GetDexSwap()
+	require.Equal(t, sold, swap.SoldAmount)
+	require.Equal(t, sold, swap.FilledAmount)
+	require.Equal(t, 2_000-sold, swap.RemainingAmount)
+	page, err := chain2.GetDexOrdersPaginated(0, account, lib.PageParams{PageNumber: 1, PerPage: 10})
+	require.NoError(t, err)
+	open := *page.Results.(*DexOrderPage)
+	require.Len(t, open, 1)
+	require.True(t, open[0].Locked)
+	require.Equal(t, chain1Id, open[0].ChainId)
+	require.Equal(t, 2_000-sold, open[0].Order.AmountForSale)
+	require.Equal(t, sold, open[0].Order.FilledAmount)
+	require.Equal(t, []byte{1}, []byte(open[0].Order.OrderId))
+	// the remainder can't fill at the moved price, and the order expires before it rests again
+	locked, err = chain2.GetDexBatch(chain1Id, true)
+	require.NoError(t, err)
+	require.NoError(t, chain1.HandleRemoteDexBatch(locked, chain2Id))
+	reply, err = chain1.GetDexBatch(chain2Id, true)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0}, reply.Receipts)
+	chain2.height++
+	require.NoError(t, chain2.HandleRemoteDexBatch(reply, chain1Id))
+	require.Zero(t, getPoolBalance(t, &chain2, chain1Id+HoldingPoolAddend))
+	require.Equal(t, 2_000-sold, getAccountBalance(t, &chain2, account))
+	page, err = chain2.GetDexOrdersPaginated(chain1Id, nil, lib.PageParams{PageNumber: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Zero(t, page.TotalCount)
+}
+
+func TestDexRoutedSwap(t *testing.T) {
+	const rootId, chainAId, chainBId, poolAmount = uint64(1), uint64(2), uint64(3), uint64(10_000)
+	// initialize the chains and pools: the root chain pairs with both A and B
+	root, chainA, chainB := newTestStateMachine(t), newTestStateMachine(t), newTestStateMachine(t)
+	chainA.Config.ChainId, chainB.Config.ChainId = chainAId, chainBId
+	require.NoError(t, root.SetPool(&Pool{Id: chainAId + LiquidityPoolAddend, Amount: poolAmount}))
+	require.NoError(t, root.SetPool(&Pool{Id: chainBId + LiquidityPoolAddend, Amount: poolAmount}))
+	require.NoError(t, chainA.SetPool(&Pool{Id: rootId + LiquidityPoolAddend, Amount: poolAmount}))
+	require.NoError(t, chainB.SetPool(&Pool{Id: rootId + LiquidityPoolAddend, Amount: poolAmount}))
+	alice, bob := newTestAddress(t, 0), newTestAddress(t, 1)
+	require.NoError(t, chainA.AccountAdd(alice, 1_000))
+	require.NoError(t, chainA.AccountAdd(bob, 500))
+	// exchange (empty) batches so the root chain mirrors the pools of A and B
+	exchange := func(nested *StateMachine, nestedId uint64) {
+		locked, err := nested.GetDexBatch(rootId, true)
+		require.NoError(t, err)
+		require.NoError(t, root.HandleRemoteDexBatch(locked, nestedId))
+		reply, err := root.GetDexBatch(nestedId, true)
+		require.NoError(t, err)
+		require.NoError(t, nested.HandleRemoteDexBatch(reply, rootId))
+	}
+	require.NoError(t, chainA.HandleRemoteDexBatch(&lib.DexBatch{Committee: chainAId, PoolSize: poolAmount}, rootId))
+	require.NoError(t, chainB.HandleRemoteDexBatch(&lib.DexBatch{Committee: chainBId, PoolSize: poolAmount}, rootId))
+	exchange(&chainB, chainBId)
+	// a route must start on the local chain and go through the root chain
+	require.ErrorContains(t, chainA.HandleMessageDexRoutedSwap(&MessageDexRoutedSwap{
+		Path: []uint64{chainBId, rootId, chainAId}, AmountForSale: 1_000, MinimumOutput: 1, Address: alice.Bytes(),
+	}), lib.ErrInvalidDexRoute().Error())
+	require.ErrorContains(t, chainA.HandleMessageDexRoutedSwap(&MessageDexRoutedSwap{
+		Path: []uint64{chainAId, chainBId, rootId}, AmountForSale: 1_000, MinimumOutput: 1, Address: alice.Bytes(),
+	}), lib.ErrInvalidDexRoute().Error())
+	// alice's route meets her minimum output, bob's can't
+	require.NoError(t, chainA.HandleMessageDexRoutedSwap(&MessageDexRoutedSwap{
+		Path: []uint64{chainAId, rootId, chainBId}, AmountForSale: 1_000, MinimumOutput: 800, Address: alice.Bytes(), OrderId: []byte{1},
+	}))
+	require.NoError(t, chainA.HandleMessageDexRoutedSwap(&MessageDexRoutedSwap{
+		Path: []uint64{chainAId, rootId, chainBId}, AmountForSale: 500, MinimumOutput: 500, Address: bob.Bytes(), OrderId: []byte{2},
+	}))
+	exchange(&chainA, chainAId)
+	// a nested chain can't quote the pools it isn't part of, the root chain can
+	_, err := chainA.QuoteDexRoute(1_000, []uint64{chainAId, rootId, chainBId})
+	require.ErrorContains(t, err, lib.ErrInvalidDexRoute().Error())
+	quote, err := root.QuoteDexRoute(1_000, []uint64{chainAId, rootId, chainBId})
+	require.NoError(t, err)
+	require.Len(t, quote.LegOutputs, 2)
+	require.Equal(t, quote.LegOutputs[1], quote.AmountOut)
+	require.GreaterOrEqual(t, quote.AmountOut, uint64(800))
+	// the root chain executes the first leg and forwards alice's proceeds to B's next batch
+	locked, err := chainA.GetDexBatch(rootId, true)
+	require.NoError(t, err)
+	require.Len(t, locked.Orders, 2)
+	require.Equal(t, []uint64{chainBId}, locked.Orders[0].Route)
+	require.NoError(t, root.HandleRemoteDexBatch(locked, chainAId))
+	reply, err := root.GetDexBatch(chainAId, true)
+	require.NoError(t, err)
+	require.Zero(t, reply.Receipts[1])
+	proceeds := reply.Receipts[0]
+	require.NotZero(t, proceeds)
+	require.Zero(t, getAccountBalance(t, &root, alice))
+	require.Equal(t, proceeds, getPoolBalance(t, &root, chainBId+HoldingPoolAddend))
+	next, err := root.GetDexBatch(chainBId, false)
+	require.NoError(t, err)
+	require.Len(t, next.Orders, 1)
+	require.Equal(t, proceeds, next.Orders[0].AmountForSale)
+	require.Equal(t, uint64(800), next.Orders[0].RequestedAmount)
+	require.Empty(t, next.Orders[0].Route)
+	// chain A applies the receipts: alice's tokens move to the pool, bob is refunded atomically
+	require.NoError(t, chainA.HandleRemoteDexBatch(reply, rootId))
+	require.Equal(t, poolAmount+1_000, getPoolBalance(t, &chainA, rootId+LiquidityPoolAddend))
+	require.Equal(t, uint64(500), getAccountBalance(t, &chainA, bob))
+	require.Zero(t, getAccountBalance(t, &chainA, alice))
+	// the root chain locks the forwarded order for B, which executes the last leg
+	exchange(&chainB, chainBId)
+	reply, err = root.GetDexBatch(chainBId, true)
+	require.NoError(t, err)
+	require.Len(t, reply.Orders, 1)
+	require.Equal(t, quote.AmountOut, getAccountBalance(t, &chainB, alice))
+}
+
+func TestDexRoutedSwapRefund(t *testing.T) {
+	const rootId, chainAId, chainBId, poolAmount = uint64(1), uint64(2), uint64(3), uint64(10_000)
+	// initialize the chains and pools: the root chain pairs with both A and B
+	root, chainA, chainB := newTestStateMachine(t), newTestStateMachine(t), newTestStateMachine(t)
+	chainA.Config.ChainId, chainB.Config.ChainId = chainAId, chainBId
+	require.NoError(t, root.SetPool(&Pool{Id: chainAId + LiquidityPoolAddend, Amount: poolAmount}))
+	require.NoError(t, root.SetPool(&Pool{Id: chainBId + LiquidityPoolAddend, Amount: poolAmount}))
+	require.NoError(t, chainA.SetPool(&Pool{Id: rootId + LiquidityPoolAddend, Amount: poolAmount}))
+	require.NoError(t, chainB.SetPool(&Pool{Id: rootId + LiquidityPoolAddend, Amount: poolAmount}))
+	alice := newTestAddress(t, 0)
+	require.NoError(t, chainA.AccountAdd(alice, 1_000))
+	// exchange batches between the root chain and a nested chain, returning the root chain's batch
+	exchange := func(nested *StateMachine, nestedId uint64) *lib.DexBatch {
+		locked, err := nested.GetDexBatch(rootId, true)
+		require.NoError(t, err)
+		require.NoError(t, root.HandleRemoteDexBatch(locked, nestedId))
+		reply, err := root.GetDexBatch(nestedId, true)
+		require.NoError(t, err)
+		require.NoError(t, nested.HandleRemoteDexBatch(reply, rootId))
+		return reply
+	}
+	require.NoError(t, chainA.HandleRemoteDexBatch(&lib.DexBatch{Committee: chainAId, PoolSize: poolAmount}, rootId))
+	require.NoError(t, chainB.HandleRemoteDexBatch(&lib.DexBatch{Committee: chainBId, PoolSize: poolAmount}, rootId))
+	exchange(&chainB, chainBId)
+	// alice routes A -> root -> B and the root chain executes the first leg
+	require.NoError(t, chainA.HandleMessageDexRoutedSwap(&MessageDexRoutedSwap{
+		Path: []uint64{chainAId, rootId, chainBId}, AmountForSale: 1_000, MinimumOutput: 800, Address: alice.Bytes(), OrderId: []byte{1},
+	}))
+	exchange(&chainA, chainAId)
+	proceeds := exchange(&chainA, chainAId).Receipts[0]
+	require.NotZero(t, proceeds)
+	require.Equal(t, poolAmount-proceeds, getPoolBalance(t, &root, chainAId+LiquidityPoolAddend))
+	require.Equal(t, poolAmount+1_000, getPoolBalance(t, &chainA, rootId+LiquidityPoolAddend))
+	// the root chain locks the forwarded order for B, but B's pool moves before B executes it
+	locked, err := chainB.GetDexBatch(rootId, true)
+	require.NoError(t, err)
+	require.NoError(t, root.HandleRemoteDexBatch(locked, chainBId))
+	reply, err := root.GetDexBatch(chainBId, true)
+	require.NoError(t, err)
+	require.Len(t, reply.Orders, 1)
+	require.Equal(t, chainAId, reply.Orders[0].OriginChainId)
+	require.NoError(t, chainB.PoolSub(rootId+LiquidityPoolAddend, poolAmount/2))
+	require.NoError(t, chainB.HandleRemoteDexBatch(reply, rootId))
+	require.Zero(t, getAccountBalance(t, &chainB, alice))
+	// the root chain doesn't pay out the intermediate root tokens, it escrows them to swap back to A's asset
+	exchange(&chainB, chainBId)
+	require.Zero(t, getAccountBalance(t, &root, alice))
+	require.Zero(t, getPoolBalance(t, &root, chainBId+HoldingPoolAddend))
+	require.Equal(t, proceeds, getPoolBalance(t, &root, chainAId+HoldingPoolAddend))
+	next, err := root.GetDexBatch(chainAId, false)
+	require.NoError(t, err)
+	require.Len(t, next.Orders, 1)
+	require.EqualExportedValues(t, &lib.DexLimitOrder{AmountForSale: proceeds, Address: alice.Bytes(), OrderId: []byte{1}}, next.Orders[0])
+	// A sells the proceeds back through its pool at the current price, so the fee and the price move stay with alice
+	exchange(&chainA, chainAId)
+	refund := getAccountBalance(t, &chainA, alice)
+	require.NotZero(t, refund)
+	require.Less(t, refund, uint64(1_000))
+	require.Equal(t, poolAmount+1_000-refund, getPoolBalance(t, &chainA, rootId+LiquidityPoolAddend))
+	// and the root chain returns the proceeds to its pool once A receipts the batch
+	reply = exchange(&chainA, chainAId)
+	require.Zero(t, getPoolBalance(t, &root, chainAId+HoldingPoolAddend))
+	require.Equal(t, poolAmount, getPoolBalance(t, &root, chainAId+LiquidityPoolAddend))
+	require.Zero(t, getAccountBalance(t, &root, alice))
+	// both chains still mirror each other's pool
+	require.Equal(t, poolAmount, reply.PoolSize)
+	require.Equal(t, poolAmount+1_000-refund, reply.CounterPoolSize)
+}
+
+func TestMessageDexRoutedSwapCheck(t *testing.T) {
+	address := newTestAddress(t).Bytes()
+	tests := []struct {
+		name  string
+		path  []uint64
+		error string
+	}{
+		{name: "single hop", path: []uint64{2, 1}},
+		{name: "multi hop", path: []uint64{2, 1, 3}},
+		{name: "too short", path: []uint64{2}, error: "route"},
+		{name: "too long", path: []uint64{2, 1, 3, 4}, error: "route"},
+		{name: "revisits a chain", path: []uint64{2, 1, 2}, error: "route"},
+		{name: "invalid chain id", path: []uint64{2, MaxChainId + 1}, error: "chain"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := (&MessageDexRoutedSwap{Path: test.path, AmountForSale: 1, MinimumOutput: 1, Address: address}).Check()
+			if test.error == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, test.error)
+		})
+	}
+}
+
+func TestDexPosition(t *testing.T) {
+	sm := newTestStateMachine(t)
+	chainId := uint64(2)
+	user := newTestAddress(t, 2)
+	// seed the pools
+	require.NoError(t, sm.SetPool(&Pool{Id: chainId + LiquidityPoolAddend, Amount: 20_000}))
+	require.NoError(t, sm.SetPool(&Pool{Id: chainId + HoldingPoolAddend, Amount: 4_000}))
+	x, y := uint64(20_000), uint64(20_000)
+	// deposit the local asset
+	require.NoError(t, sm.HandleBatchDeposit(&lib.DexBatch{Deposits: []*lib.DexLiquidityDeposit{
+		{Amount: 4_000, Address: user.Bytes(), OrderId: []byte{1}},
+	}}, chainId, &x, &y, true, nil))
+	// deposit the counter asset
+	require.NoError(t, sm.HandleBatchDeposit(&lib.DexBatch{Deposits: []*lib.DexLiquidityDeposit{
+		{Amount: 2_000, Address: user.Bytes(), OrderId: []byte{2}},
+	}}, chainId, &y, &x, false, nil))
+	// validate the cost basis
+	basis, err := sm.GetDexPositionBasis(chainId, user.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, uint64(4_000), basis.LocalDeposited)
+	require.Equal(t, uint64(2_000), basis.RemoteDeposited)
+	require.NotZero(t, basis.Liquidity)
+	// lock a batch with the pool sizes
+	require.NoError(t, sm.SetDexBatch(KeyForLockedBatch(chainId), &lib.DexBatch{Committee: chainId, PoolSize: x, CounterPoolSize: y}))
+	position, err := sm.GetDexPosition(chainId, user.Bytes())
+	require.NoError(t, err)
+	pool, err := sm.GetPool(chainId + LiquidityPoolAddend)
+	require.NoError(t, err)
+	points, err := pool.GetPointsFor(user.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, points, position.Points)
+	require.Equal(t, lib.SafeMulDiv(x, points, pool.TotalPoolPoints), position.LocalAmount)
+	require.Equal(t, lib.SafeMulDiv(y, points, pool.TotalPoolPoints), position.RemoteAmount)
+	// no swaps means no fees (within rounding)
+	require.LessOrEqual(t, position.LocalFees, uint64(1))
+	require.LessOrEqual(t, position.RemoteFees, uint64(1))
+	// values are in the local asset at the current price
+	require.Equal(t, 4_000+lib.SafeMulDiv(2_000, x, y), position.HoldValue)
+	require.Equal(t, position.LocalAmount+lib.SafeMulDiv(position.RemoteAmount, x, y), position.PositionValue)
+	feeValue := position.LocalFees + lib.SafeMulDiv(position.RemoteFees, x, y)
+	require.Equal(t, int64(position.HoldValue)-int64(position.PositionValue-feeValue), position.ImpermanentLoss)
+	// fees grow both sides of the pool without changing the price
+	require.NoError(t, sm.SetDexBatch(KeyForLockedBatch(chainId), &lib.DexBatch{Committee: chainId, PoolSize: x * 11 / 10, CounterPoolSize: y * 11 / 10}))
+	withFees, err := sm.GetDexPosition(chainId, user.Bytes())
+	require.NoError(t, err)
+	require.InDelta(t, position.LocalAmount/10, withFees.LocalFees, 2)
+	require.InDelta(t, position.RemoteAmount/10, withFees.RemoteFees, 2)
+	require.InDelta(t, position.ImpermanentLoss, withFees.ImpermanentLoss, 4)
+	// the positions of the provider
+	positions, err := sm.GetDexPositions(user.Bytes())
+	require.NoError(t, err)
+	require.Len(t, positions, 1)
+	positions, err = sm.GetDexPositions(newTestAddressBytes(t, 3))
+	require.NoError(t, err)
+	require.Empty(t, positions)
+	// withdrawing half the points halves the cost basis
+	require.NoError(t, sm.HandleBatchWithdraw(&lib.DexBatch{Withdrawals: []*lib.DexLiquidityWithdraw{
+		{Percent: 50, Address: user.Bytes(), OrderId: []byte{3}},
+	}}, chainId, &x, &y, true))
+	halved, err := sm.GetDexPositionBasis(chainId, user.Bytes())
+	require.NoError(t, err)
+	require.InDelta(t, basis.LocalDeposited/2, halved.LocalDeposited, 1)
+	require.InDelta(t, basis.RemoteDeposited/2, halved.RemoteDeposited, 1)
+	require.InDelta(t, basis.Liquidity/2, halved.Liquidity, 1)
+	// withdrawing everything removes the cost basis
+	require.NoError(t, sm.HandleBatchWithdraw(&lib.DexBatch{Withdrawals: []*lib.DexLiquidityWithdraw{
+		{Percent: 100, Address: user.Bytes(), OrderId: []byte{4}},
+	}}, chainId, &x, &y, true))
+	removed, err := sm.GetDexPositionBasis(chainId, user.Bytes())
+	require.NoError(t, err)
+	require.Nil(t, removed)
+}
+
+func TestDexLiquiditySlippageProtection(t *testing.T) {
+	sm := newTestStateMachine(t)
+	chainId := uint64(2)
+	protected, unprotected := newTestAddress(t, 2), newTestAddress(t, 3)
+	// seed the pools
+	require.NoError(t, sm.SetPool(&Pool{Id: chainId + LiquidityPoolAddend, Amount: 20_000}))
+	require.NoError(t, sm.SetPool(&Pool{Id: chainId + HoldingPoolAddend, Amount: 8_000}))
+	x, y := uint64(20_000), uint64(20_000)
+	// a 4,000 deposit into a 20,000 pool (with the 4,000 unprotected deposit) mints well under 4,000 points
+	require.NoError(t, sm.HandleBatchDeposit(&lib.DexBatch{Deposits: []*lib.DexLiquidityDeposit{
+		{Amount: 4_000, Address: protected.Bytes(), OrderId: []byte{1}, MinPoints: 4_000},
+		{Amount: 4_000, Address: unprotected.Bytes(), OrderId: []byte{2}},
+	}}, chainId, &x, &y, true, nil))
+	// the protected deposit is refunded from the holding pool
+	account, err := sm.GetAccount(protected)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4_000), account.Amount)
+	holdingPool, err := sm.GetPool(chainId + HoldingPoolAddend)
+	require.NoError(t, err)
+	require.Zero(t, holdingPool.Amount)
+	// only the unprotected deposit minted points (priced as if alone in the batch)
+	pool, err := sm.GetPool(chainId + LiquidityPoolAddend)
+	require.NoError(t, err)
+	_, err = pool.GetPointsFor(protected.Bytes())
+	require.Error(t, err)
+	points, err := pool.GetPointsFor(unprotected.Bytes())
+	require.NoError(t, err)
+	expected, err := liquidityDepositPoints(nil, 20_000, 20_000, 20_000, 4_000)
+	require.NoError(t, err)
+	require.Equal(t, expected, points)
+	require.Equal(t, uint64(24_000), x)
+	require.Equal(t, uint64(24_000), pool.Amount)
+	// the counter chain reaches the same outcome without moving funds
+	remote := newTestStateMachine(t)
+	require.NoError(t, remote.SetPool(&Pool{Id: chainId + LiquidityPoolAddend, Amount: 20_000}))
+	rx, ry := uint64(20_000), uint64(20_000)
+	require.NoError(t, remote.HandleBatchDeposit(&lib.DexBatch{Deposits: []*lib.DexLiquidityDeposit{
+		{Amount: 4_000, Address: protected.Bytes(), OrderId: []byte{1}, MinPoints: 4_000},
+		{Amount: 4_000, Address: unprotected.Bytes(), OrderId: []byte{2}},
+	}}, chainId, &rx, &ry, false, nil))
+	remotePool, err := remote.GetPool(chainId + LiquidityPoolAddend)
+	require.NoError(t, err)
+	require.Equal(t, pool.Points, remotePool.Points)
+	require.Equal(t, pool.TotalPoolPoints, remotePool.TotalPoolPoints)
+	// a withdrawal asking for more than its share keeps its points; the rest of the batch is paid
+	require.NoError(t, sm.HandleBatchWithdraw(&lib.DexBatch{Withdrawals: []*lib.DexLiquidityWithdraw{
+		{Percent: 100, Address: unprotected.Bytes(), OrderId: []byte{3}, MinAmount: 1, MinCounterAmount: 20_000},
+		{Percent: 10, Address: deadAddr.Bytes(), OrderId: []byte{4}},
+	}}, chainId, &x, &y, true))
+	pool, err = sm.GetPool(chainId + LiquidityPoolAddend)
+	require.NoError(t, err)
+	kept, err := pool.GetPointsFor(unprotected.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, points, kept)
+	account, err = sm.GetAccount(unprotected)
+	require.NoError(t, err)
+	require.Zero(t, account.Amount)
+	deadAccount, err := sm.GetAccount(deadAddr)
+	require.NoError(t, err)
+	require.NotZero(t, deadAccount.Amount)
+	// within its minimums the withdrawal is paid
+	require.NoError(t, sm.HandleBatchWithdraw(&lib.DexBatch{Withdrawals: []*lib.DexLiquidityWithdraw{
+		{Percent: 100, Address: unprotected.Bytes(), OrderId: []byte{5}, MinAmount: 1, MinCounterAmount: 1},
+	}}, chainId, &x, &y, true))
+	account, err = sm.GetAccount(unprotected)
+	require.NoError(t, err)
+	require.NotZero(t, account.Amount)
+}
+
+func TestDexBatchStall(t *testing.T) {
+	const chainId = uint64(2)
+	sm := newTestStateMachine(t)
+	require.NoError(t, sm.SetPool(&Pool{Id: chainId + LiquidityPoolAddend, Amount: 1000}))
+	require.NoError(t, sm.SetPool(&Pool{Id: chainId + HoldingPoolAddend, Amount: 10}))
+	locked := &lib.DexBatch{Committee: chainId, LockedHeight: 1, Orders: []*lib.DexLimitOrder{
+		{AmountForSale: 10, RequestedAmount: 1, Address: newTestAddressBytes(t, 1), OrderId: []byte{1}},
+	}}
+	require.NoError(t, sm.SetDexBatch(KeyForLockedBatch(chainId), locked))
+	lockedHash := locked.Hash()
+	// the counter chain hasn't receipted the locked batch
+	mirror := uint64(1000)
+	skipped, err := sm.HandleReceiptsForOurLockedBatch(&lib.DexBatch{Committee: chainId, ReceiptHash: []byte{0xAA}}, &mirror, chainId)
+	require.NoError(t, err)
+	require.True(t, skipped)
+	skipped, err = sm.HandleReceiptsForOurLockedBatch(&lib.DexBatch{Committee: chainId, ReceiptHash: []byte{0xAA}}, &mirror, chainId)
+	require.NoError(t, err)
+	require.True(t, skipped)
+	stall, err := sm.GetDexBatchStall(chainId)
+	require.NoError(t, err)
+	require.EqualExportedValues(t, &lib.DexBatchStall{
+		ChainId:             chainId,
+		Reason:              lib.DexStallReason_DEX_STALL_RECEIPT_HASH_MISMATCH,
+		SinceHeight:         2,
+		LastHeight:          2,
+		Count:               2,
+		ExpectedReceiptHash: lockedHash,
+		ReceivedReceiptHash: []byte{0xAA},
+		ExpectedReceipts:    1,
+	}, stall)
+	// the counter chain receipted the locked batch with the wrong number of receipts
+	sm.height = 7
+	skipped, err = sm.HandleReceiptsForOurLockedBatch(&lib.DexBatch{Committee: chainId, ReceiptHash: lockedHash}, &mirror, chainId)
+	require.NoError(t, err)
+	require.True(t, skipped)
+	status, err := sm.GetDexBatchStatus(chainId)
+	require.NoError(t, err)
+	require.Equal(t, lib.DexStuckCounter, status.Stuck)
+	require.Equal(t, lib.DexStallReason_DEX_STALL_RECEIPT_COUNT_MISMATCH, status.Stall.Reason)
+	require.EqualValues(t, 7, status.Stall.SinceHeight)
+	require.EqualValues(t, 1, status.Stall.Count)
+	require.EqualValues(t, 6, status.BlocksLocked)
+	require.EqualValues(t, 1, status.LockedOrders)
+	require.Equal(t, lockedHash, []byte(status.LockedHash))
+	require.Zero(t, status.LivenessFallbackHeight) // the root chain doesn't fall back
+	// the receipts are processed (the order failed) and the stall is cleared
+	skipped, err = sm.HandleReceiptsForOurLockedBatch(&lib.DexBatch{Committee: chainId, ReceiptHash: lockedHash, Receipts: []uint64{0}}, &mirror, chainId)
+	require.NoError(t, err)
+	require.False(t, skipped)
+	stall, err = sm.GetDexBatchStall(chainId)
+	require.NoError(t, err)
+	require.Nil(t, stall)
+	status, err = sm.GetDexBatchStatus(chainId)
+	require.NoError(t, err)
+	require.Equal(t, lib.DexStuckNone, status.Stuck)
+	require.Zero(t, status.LockedHeight)
+}
+
+func TestDexBatchStallMissingCounterBatch(t *testing.T) {
+	sm := newTestStateMachine(t)
+	rootChainId, err := sm.GetRootChainId()
+	require.NoError(t, err)
+	sm.Config.ChainId = rootChainId + 1 // nested chain
+	require.NoError(t, sm.SetPool(&Pool{Id: rootChainId + LiquidityPoolAddend, Amount: 1000}))
+	require.NoError(t, sm.SetDexBatch(KeyForLockedBatch(rootChainId), &lib.DexBatch{Committee: rootChainId, LockedHeight: 1, Orders: []*lib.DexLimitOrder{
+		{AmountForSale: 10, RequestedAmount: 1, Address: newTestAddressBytes(t, 1), OrderId: []byte{1}},
+	}}))
+	// the governance param sets the liveness fallback window
+	require.Error(t, sm.UpdateParam(ParamSpaceVal, ParamDexLivenessFallbackBlocks, &lib.UInt64Wrapper{Value: lib.TriggerModuloBlocks - 1}))
+	require.NoError(t, sm.UpdateParam(ParamSpaceVal, ParamDexLivenessFallbackBlocks, &lib.UInt64Wrapper{Value: 12}))
+	// the certificate didn't carry the root chain's batch
+	require.NoError(t, sm.HandleDexBatch(rootChainId, &lib.CertificateResult{}, true))
+	status, err := sm.GetDexBatchStatus(rootChainId)
+	require.NoError(t, err)
+	require.Equal(t, lib.DexStuckLocal, status.Stuck)
+	require.Equal(t, lib.DexStallReason_DEX_STALL_MISSING_COUNTER_BATCH, status.Stall.Reason)
+	require.EqualValues(t, 12, status.LivenessFallbackBlocks)
+	// the fallback fires at the first trigger block past the window
+	require.EqualValues(t, 16, status.LivenessFallbackHeight)
+	statuses, err := sm.GetDexBatchStatuses()
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	// the liveness fallback drops the locked batch and the stall
+	require.NoError(t, sm.HandleLivenessFallback(rootChainId, &lib.DexBatch{}, &lib.DexBatch{}))
+	stall, err := sm.GetDexBatchStall(rootChainId)
+	require.NoError(t, err)
+	require.Nil(t, stall)
+}
@@ -302,3 +302,39 @@ func ErrStakeBelowMininum() lib.ErrorI {
 func ErrRemotePoolSizeDebit() lib.ErrorI {
 	return lib.NewError(lib.CodeRemotePoolSizeDebit, lib.StateMachineModule, "remote pool size debit")
 }
+
+func ErrMultisigAccountExists() lib.ErrorI {
+	return lib.NewError(lib.CodeMultisigAccountExists, lib.StateMachineModule, "multisig account already exists")
+}
+
+func ErrMultisigAccountNotFound() lib.ErrorI {
+	return lib.NewError(lib.CodeMultisigAccountNotFound, lib.StateMachineModule, "multisig account not found")
+}
+
+func ErrInvalidMultisigThreshold() lib.ErrorI {
+	return lib.NewError(lib.CodeInvalidMultisigThreshold, lib.StateMachineModule, "multisig threshold must be between 1 and the number of members")
+}
+
+func ErrInvalidMultisigMembers() lib.ErrorI {
+	return lib.NewError(lib.CodeInvalidMultisigMembers, lib.StateMachineModule, "multisig members must be unique bls public keys")
+}
+
+func ErrProposalNotFound() lib.ErrorI {
+	return lib.NewError(lib.CodeProposalNotFound, lib.StateMachineModule, "proposal not found")
+}
+
+func ErrProposalNotVoting() lib.ErrorI {
+	return lib.NewError(lib.CodeProposalNotVoting, lib.StateMachineModule, "proposal is not open for voting")
+}
+
+func ErrInvalidProposal() lib.ErrorI {
+	return lib.NewError(lib.CodeInvalidProposal, lib.StateMachineModule, "proposal must be a change parameter, dao transfer or dex pool config message")
+}
+
+func ErrDepositBelowMinimum() lib.ErrorI {
+	return lib.NewError(lib.CodeDepositBelowMinimum, lib.StateMachineModule, "proposal deposit is below the minimum")
+}
+
+func ErrProposalsDisabled() lib.ErrorI {
+	return lib.NewError(lib.CodeProposalsDisabled, lib.StateMachineModule, "on-chain proposals are disabled")
+}
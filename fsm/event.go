@@ -60,13 +60,16 @@ func (s *StateMachine) EventOrderBookReset(order *lib.SellOrder) lib.ErrorI {
 }
 
 // EventDexSwap() adds an AMM token swap event to the indexer
-func (s *StateMachine) EventDexSwap(address, orderId []byte, soldAmount, boughtAmount, chainId uint64, localOrigin, success bool) lib.ErrorI {
+// filledAmount is the amount of the order sold so far and remainingAmount the amount left unsold (fill progress)
+func (s *StateMachine) EventDexSwap(address, orderId []byte, soldAmount, boughtAmount, filledAmount, remainingAmount, chainId uint64, localOrigin, success bool) lib.ErrorI {
 	return s.addEvent(lib.EventTypeDexSwap, &lib.EventDexSwap{
-		SoldAmount:   soldAmount,
-		BoughtAmount: boughtAmount,
-		LocalOrigin:  localOrigin,
-		Success:      success,
-		OrderId:      orderId,
+		SoldAmount:      soldAmount,
+		BoughtAmount:    boughtAmount,
+		LocalOrigin:     localOrigin,
+		Success:         success,
+		OrderId:         orderId,
+		FilledAmount:    filledAmount,
+		RemainingAmount: remainingAmount,
 	}, address, chainId)
 }
 
@@ -91,6 +94,26 @@ func (s *StateMachine) EventDexLiquidityWithdraw(address, orderId []byte, localA
 	}, address, chainId)
 }
 
+// EventProposalSubmit() adds an on-chain governance proposal submitted event
+func (s *StateMachine) EventProposalSubmit(proposal *Proposal) lib.ErrorI {
+	return s.addEvent(lib.EventTypeProposalSubmit, &lib.EventProposalSubmit{
+		ProposalId:      proposal.Id,
+		Deposit:         proposal.Deposit,
+		VotingEndHeight: proposal.VotingEndHeight,
+	}, proposal.Proposer)
+}
+
+// EventProposalTally() adds an on-chain governance proposal tallied event
+func (s *StateMachine) EventProposalTally(proposal *Proposal) lib.ErrorI {
+	return s.addEvent(lib.EventTypeProposalTally, &lib.EventProposalTally{
+		ProposalId:       proposal.Id,
+		Status:           proposal.Status.String(),
+		ApproveVotes:     proposal.ApproveVotes,
+		RejectVotes:      proposal.RejectVotes,
+		TotalVotingPower: proposal.TotalVotingPower,
+	}, proposal.Proposer)
+}
+
 // addEvent() is a helper function that creates an event with common fields set and adds it to the tracker
 func (s *StateMachine) addEvent(eventType lib.EventType, msg interface{}, address []byte, chainId ...uint64) lib.ErrorI {
 	e := &lib.Event{
@@ -124,6 +147,10 @@ func (s *StateMachine) addEvent(eventType lib.EventType, msg interface{}, addres
 		e.Msg = &lib.Event_OrderBookLock{OrderBookLock: msg.(*lib.EventOrderBookLock)}
 	case lib.EventTypeOrderBookReset:
 		e.Msg = &lib.Event_OrderBookReset{OrderBookReset: msg.(*lib.EventOrderBookReset)}
+	case lib.EventTypeProposalSubmit:
+		e.Msg = &lib.Event_ProposalSubmit{ProposalSubmit: msg.(*lib.EventProposalSubmit)}
+	case lib.EventTypeProposalTally:
+		e.Msg = &lib.Event_ProposalTally{ProposalTally: msg.(*lib.EventProposalTally)}
 	}
 
 	// optionally set chainId if provided
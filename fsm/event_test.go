@@ -301,7 +301,7 @@ func TestEventDexSwap(t *testing.T) {
 			// create a state machine instance with default parameters
 			sm := newTestStateMachine(t)
 			// execute the function call
-			err := sm.EventDexSwap(test.address, test.orderId, test.soldAmount, test.boughtAmount, test.chainId, test.inbound, test.success)
+			err := sm.EventDexSwap(test.address, test.orderId, test.soldAmount, test.boughtAmount, test.soldAmount, 0, test.chainId, test.inbound, test.success)
 			// validate the expected error
 			require.Equal(t, test.error != "", err != nil, err)
 			if err != nil {
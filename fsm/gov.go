@@ -1,6 +1,8 @@
 package fsm
 
 import (
+	"encoding/json"
+
 	"github.com/canopy-network/canopy/lib"
 	"github.com/canopy-network/canopy/lib/crypto"
 	"google.golang.org/protobuf/proto"
@@ -47,6 +49,286 @@ func (s *StateMachine) ApproveProposal(msg GovProposal) lib.ErrorI {
 	}
 }
 
+// ExecuteGovProposal() applies an approved governance message (MessageChangeParameter, MessageDAOTransfer or MessageDexPoolConfig)
+func (s *StateMachine) ExecuteGovProposal(msg proto.Message) lib.ErrorI {
+	switch x := msg.(type) {
+	case *MessageChangeParameter:
+		// extract the value from the proto packed 'any'
+		protoMsg, err := lib.FromAny(x.ParameterValue)
+		if err != nil {
+			return err
+		}
+		// update the parameter
+		return s.UpdateParam(x.ParameterSpace, x.ParameterKey, protoMsg)
+	case *MessageDAOTransfer:
+		// optionally mint the transfer amount into the DAO pool before distributing the grant
+		if x.Mint {
+			if err := s.MintToPool(lib.DAOPoolID, x.Amount); err != nil {
+				return err
+			}
+		}
+		// remove from DAO fund
+		if err := s.PoolSub(lib.DAOPoolID, x.Amount); err != nil {
+			return err
+		}
+		// add to account
+		return s.AccountAdd(crypto.NewAddressFromBytes(x.Address), x.Amount)
+	case *MessageDexPoolConfig:
+		// the root chain's governance chooses the pool config, nested chains adopt it from the root chain's dex batches
+		ownRoot, err := s.LoadIsOwnRoot()
+		if err != nil {
+			return err
+		}
+		if !ownRoot {
+			return lib.ErrInvalidDexPoolConfig()
+		}
+		return s.SetDexPoolConfig(x.PoolConfig())
+	default:
+		return ErrInvalidProposal()
+	}
+}
+
+// ON-CHAIN PROPOSAL CODE BELOW
+
+/*
+	On-chain proposals:
+	- A proposer escrows a deposit in the GovDepositPool with a MessageSubmitProposal
+	- Validators and delegators vote with a MessageVote until the voting end height
+	- At the end block of the voting end height the votes are weighted by the voter's stake and tallied
+	- If quorum and threshold are met the proposal is executed, otherwise it's rejected
+	- The deposit is refunded unless quorum wasn't met, in which case it's forfeited to the DAO
+*/
+
+// GetProposal() retrieves an on-chain proposal by its id
+func (s *StateMachine) GetProposal(id []byte) (*Proposal, lib.ErrorI) {
+	bz, err := s.Get(KeyForProposal(id))
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, ErrProposalNotFound()
+	}
+	proposal := new(Proposal)
+	if err = lib.Unmarshal(bz, proposal); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// GetProposals() retrieves all on-chain proposals
+func (s *StateMachine) GetProposals() (proposals []*Proposal, err lib.ErrorI) {
+	err = s.IterateAndExecute(ProposalsPrefix(), func(_, value []byte) lib.ErrorI {
+		proposal := new(Proposal)
+		if e := lib.Unmarshal(value, proposal); e != nil {
+			return e
+		}
+		proposals = append(proposals, proposal)
+		return nil
+	})
+	return
+}
+
+// SetProposal() inserts or updates an on-chain proposal in state
+func (s *StateMachine) SetProposal(proposal *Proposal) lib.ErrorI {
+	bz, err := lib.Marshal(proposal)
+	if err != nil {
+		return err
+	}
+	return s.Set(KeyForProposal(proposal.Id), bz)
+}
+
+// SetProposalVote() records a vote on an open proposal, replacing any previous vote from the same voter
+func (s *StateMachine) SetProposalVote(id []byte, voter crypto.AddressI, approve bool) lib.ErrorI {
+	bz, err := lib.Marshal(&ProposalVote{Approve: approve})
+	if err != nil {
+		return err
+	}
+	return s.Set(KeyForProposalVote(id, voter), bz)
+}
+
+// TallyProposals() tallies and finalizes every proposal whose voting period ends at this height
+func (s *StateMachine) TallyProposals() lib.ErrorI {
+	// collect the ids of proposals ending this block
+	var ids, toDelete [][]byte
+	if err := s.IterateAndExecute(ProposalEndPrefix(s.Height()), func(key, value []byte) lib.ErrorI {
+		ids, toDelete = append(ids, value), append(toDelete, key)
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := s.TallyProposal(id); err != nil {
+			return err
+		}
+	}
+	// remove the ended proposals from the voting index
+	return s.DeleteAll(toDelete)
+}
+
+// TallyProposal() weighs the votes on a proposal by stake, executes it if passed and settles the deposit
+func (s *StateMachine) TallyProposal(id []byte) lib.ErrorI {
+	proposal, err := s.GetProposal(id)
+	if err != nil {
+		return err
+	}
+	params, err := s.GetParamsGov()
+	if err != nil {
+		return err
+	}
+	// the total voting power is all stake (validators and delegators) at the time of the tally
+	supply, err := s.GetSupply()
+	if err != nil {
+		return err
+	}
+	proposal.TotalVotingPower = supply.Staked
+	// weigh each vote by the current stake of the voter
+	var toDelete [][]byte
+	if err = s.IterateAndExecute(ProposalVotesPrefix(id), func(key, value []byte) lib.ErrorI {
+		toDelete = append(toDelete, key)
+		voter, e := AddressFromKey(key)
+		if e != nil {
+			return e
+		}
+		vote := new(ProposalVote)
+		if e = lib.Unmarshal(value, vote); e != nil {
+			return e
+		}
+		// voters who fully unstaked since voting carry no weight
+		validator, e := s.GetValidator(voter)
+		if e != nil {
+			if e.Code() == lib.CodeValidatorNotExists {
+				return nil
+			}
+			// any other error (ex. a store or decode failure) must not silently change the outcome
+			return e
+		}
+		if vote.Approve {
+			proposal.ApproveVotes += validator.StakedAmount
+		} else {
+			proposal.RejectVotes += validator.StakedAmount
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	// the votes are no longer needed once the totals are saved in the proposal
+	if err = s.DeleteAll(toDelete); err != nil {
+		return err
+	}
+	// determine the outcome
+	voted := proposal.ApproveVotes + proposal.RejectVotes
+	switch {
+	case voted == 0 || voted*100 < params.ProposalQuorumPercentage*proposal.TotalVotingPower:
+		proposal.Status = ProposalStatus_PROPOSAL_NO_QUORUM
+	case proposal.ApproveVotes*100 <= params.ProposalThresholdPercentage*voted:
+		proposal.Status = ProposalStatus_PROPOSAL_REJECTED
+	default:
+		proposal.Status = ProposalStatus_PROPOSAL_PASSED
+		if e := s.executeProposal(proposal); e != nil {
+			s.log.Warnf("Executing passed proposal %x failed with err: %s", proposal.Id, e.Error())
+			proposal.Status = ProposalStatus_PROPOSAL_FAILED
+		}
+	}
+	// settle the deposit: forfeited to the DAO without quorum, otherwise refunded
+	if err = s.PoolSub(lib.GovDepositPoolID, proposal.Deposit); err != nil {
+		return err
+	}
+	if proposal.Status == ProposalStatus_PROPOSAL_NO_QUORUM {
+		err = s.PoolAdd(lib.DAOPoolID, proposal.Deposit)
+	} else {
+		err = s.AccountAdd(crypto.NewAddressFromBytes(proposal.Proposer), proposal.Deposit)
+	}
+	if err != nil {
+		return err
+	}
+	if err = s.SetProposal(proposal); err != nil {
+		return err
+	}
+	return s.EventProposalTally(proposal)
+}
+
+// executeProposal() executes a passed proposal in a 'database transaction' so a failed execution leaves no partial writes or events
+func (s *StateMachine) executeProposal(proposal *Proposal) lib.ErrorI {
+	currentStore := s.Store()
+	txn, err := s.TxnWrap()
+	if err != nil {
+		return err
+	}
+	defer s.SetStore(currentStore)
+	// save the event count to drop the events of a failed execution
+	eventCount := s.events.Len()
+	msg, err := lib.FromAny(proposal.Msg)
+	if err == nil {
+		err = s.ExecuteGovProposal(msg)
+	}
+	if err != nil {
+		// discard the writes, cached writes and events of the failed execution
+		txn.Discard()
+		s.ResetCaches()
+		s.events.Truncate(eventCount)
+		return err
+	}
+	return txn.Flush()
+}
+
+// MarshalJSON() is the json.Marshaller implementation for Proposal
+func (x *Proposal) MarshalJSON() ([]byte, error) {
+	msg, err := govProposalToJSON(x.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonProposal{
+		Id:               x.Id,
+		Proposer:         x.Proposer,
+		Deposit:          x.Deposit,
+		Msg:              msg,
+		SubmitHeight:     x.SubmitHeight,
+		VotingEndHeight:  x.VotingEndHeight,
+		Status:           x.Status.String(),
+		ApproveVotes:     x.ApproveVotes,
+		RejectVotes:      x.RejectVotes,
+		TotalVotingPower: x.TotalVotingPower,
+	})
+}
+
+// UnmarshalJSON() is the json.Unmarshaler implementation for Proposal
+func (x *Proposal) UnmarshalJSON(b []byte) (err error) {
+	var j jsonProposal
+	if err = json.Unmarshal(b, &j); err != nil {
+		return
+	}
+	msg, err := govProposalFromJSON(j.Msg)
+	if err != nil {
+		return
+	}
+	*x = Proposal{
+		Id:               j.Id,
+		Proposer:         j.Proposer,
+		Deposit:          j.Deposit,
+		Msg:              msg,
+		SubmitHeight:     j.SubmitHeight,
+		VotingEndHeight:  j.VotingEndHeight,
+		Status:           ProposalStatus(ProposalStatus_value[j.Status]),
+		ApproveVotes:     j.ApproveVotes,
+		RejectVotes:      j.RejectVotes,
+		TotalVotingPower: j.TotalVotingPower,
+	}
+	return
+}
+
+type jsonProposal struct {
+	Id               lib.HexBytes     `json:"id"`
+	Proposer         lib.HexBytes     `json:"proposer"`
+	Deposit          uint64           `json:"deposit"`
+	Msg              *jsonGovProposal `json:"msg"`
+	SubmitHeight     uint64           `json:"submitHeight"`
+	VotingEndHeight  uint64           `json:"votingEndHeight"`
+	Status           string           `json:"status"`
+	ApproveVotes     uint64           `json:"approveVotes"`
+	RejectVotes      uint64           `json:"rejectVotes"`
+	TotalVotingPower uint64           `json:"totalVotingPower"`
+}
+
 // PARAMETER CODE BELOW
 
 // UpdateParam() updates a governance parameter keyed by space and name
@@ -9,6 +9,7 @@ package fsm
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -92,6 +93,67 @@ func (GovProposalVoteConfig) EnumDescriptor() ([]byte, []int) {
 	return file_gov_proto_rawDescGZIP(), []int{0}
 }
 
+// ProposalStatus is the lifecycle stage of an on-chain governance proposal
+type ProposalStatus int32
+
+const (
+	// PROPOSAL_VOTING: the proposal is open for votes
+	ProposalStatus_PROPOSAL_VOTING ProposalStatus = 0
+	// PROPOSAL_PASSED: the proposal reached quorum and threshold and was executed
+	ProposalStatus_PROPOSAL_PASSED ProposalStatus = 1
+	// PROPOSAL_REJECTED: the proposal reached quorum but not the approval threshold
+	ProposalStatus_PROPOSAL_REJECTED ProposalStatus = 2
+	// PROPOSAL_NO_QUORUM: not enough stake voted; the deposit is forfeited to the DAO
+	ProposalStatus_PROPOSAL_NO_QUORUM ProposalStatus = 3
+	// PROPOSAL_FAILED: the proposal passed but executing it returned an error
+	ProposalStatus_PROPOSAL_FAILED ProposalStatus = 4
+)
+
+// Enum value maps for ProposalStatus.
+var (
+	ProposalStatus_name = map[int32]string{
+		0: "PROPOSAL_VOTING",
+		1: "PROPOSAL_PASSED",
+		2: "PROPOSAL_REJECTED",
+		3: "PROPOSAL_NO_QUORUM",
+		4: "PROPOSAL_FAILED",
+	}
+	ProposalStatus_value = map[string]int32{
+		"PROPOSAL_VOTING":    0,
+		"PROPOSAL_PASSED":    1,
+		"PROPOSAL_REJECTED":  2,
+		"PROPOSAL_NO_QUORUM": 3,
+		"PROPOSAL_FAILED":    4,
+	}
+)
+
+func (x ProposalStatus) Enum() *ProposalStatus {
+	p := new(ProposalStatus)
+	*p = x
+	return p
+}
+
+func (x ProposalStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProposalStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_gov_proto_enumTypes[1].Descriptor()
+}
+
+func (ProposalStatus) Type() protoreflect.EnumType {
+	return &file_gov_proto_enumTypes[1]
+}
+
+func (x ProposalStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProposalStatus.Descriptor instead.
+func (ProposalStatus) EnumDescriptor() ([]byte, []int) {
+	return file_gov_proto_rawDescGZIP(), []int{1}
+}
+
 // Params are are configurable settings in a blockchain or decentralized network that control various aspects of the
 // system's operation and rules, such as transaction fees, block sizes, and validator behaviors. These parameters can be
 // adjusted through validator consensus to adapt the network as needs change.
@@ -241,8 +303,14 @@ type ConsensusParams struct {
 	Retired uint64 `protobuf:"varint,4,opt,name=retired,proto3" json:"retired,omitempty"`
 	// reset_committee: clears committee data for the provided committee id
 	ResetCommittee uint64 `protobuf:"varint,5,opt,name=reset_committee,json=resetCommittee,proto3" json:"resetCommittee"` // @gotags: json:"resetCommittee"
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// min_phase_timeout_ms: the lower bound (in milliseconds) of any phase timeout chosen by a node running adaptive
+	// consensus timeouts; 0 is the protocol default of 500ms
+	MinPhaseTimeoutMs uint64 `protobuf:"varint,6,opt,name=min_phase_timeout_ms,json=minPhaseTimeoutMs,proto3" json:"minPhaseTimeoutMS"` // @gotags: json:"minPhaseTimeoutMS"
+	// max_phase_timeout_ms: the upper bound (in milliseconds) of any phase timeout chosen by a node running adaptive
+	// consensus timeouts; 0 is the protocol default of 10s
+	MaxPhaseTimeoutMs uint64 `protobuf:"varint,7,opt,name=max_phase_timeout_ms,json=maxPhaseTimeoutMs,proto3" json:"maxPhaseTimeoutMS"` // @gotags: json:"maxPhaseTimeoutMS"
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ConsensusParams) Reset() {
@@ -310,6 +378,20 @@ func (x *ConsensusParams) GetResetCommittee() uint64 {
 	return 0
 }
 
+func (x *ConsensusParams) GetMinPhaseTimeoutMs() uint64 {
+	if x != nil {
+		return x.MinPhaseTimeoutMs
+	}
+	return 0
+}
+
+func (x *ConsensusParams) GetMaxPhaseTimeoutMs() uint64 {
+	if x != nil {
+		return x.MaxPhaseTimeoutMs
+	}
+	return 0
+}
+
 // ValidatorParams is the parameter space that defines the rules and criteria for validators in the blockchain
 type ValidatorParams struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -366,8 +448,12 @@ type ValidatorParams struct {
 	MinimumStakeForDelegates uint64 `protobuf:"varint,18,opt,name=minimum_stake_for_delegates,json=minimumStakeForDelegates,proto3" json:"minimumStakeForDelegates"` // @gotags: json:"minimumStakeForDelegates"
 	// maximum_delegates_per_committee: is the maximum number of delegates that can be chose as lottery winners
 	MaximumDelegatesPerCommittee uint64 `protobuf:"varint,19,opt,name=maximum_delegates_per_committee,json=maximumDelegatesPerCommittee,proto3" json:"maximumDelegatesPerCommittee"` // @gotags: json:"maximumDelegatesPerCommittee"
-	unknownFields                protoimpl.UnknownFields
-	sizeCache                    protoimpl.SizeCache
+	// dex_liveness_fallback_blocks: the number of blocks a nested chain's locked dex batch may wait on receipts before the
+	// nested chain falls back (refunding its locked batch and mirroring the root chain's liquidity points); 0 is the
+	// protocol default of 60 blocks
+	DexLivenessFallbackBlocks uint64 `protobuf:"varint,20,opt,name=dex_liveness_fallback_blocks,json=dexLivenessFallbackBlocks,proto3" json:"dexLivenessFallbackBlocks"` // @gotags: json:"dexLivenessFallbackBlocks"
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
 }
 
 func (x *ValidatorParams) Reset() {
@@ -533,6 +619,13 @@ func (x *ValidatorParams) GetMaximumDelegatesPerCommittee() uint64 {
 	return 0
 }
 
+func (x *ValidatorParams) GetDexLivenessFallbackBlocks() uint64 {
+	if x != nil {
+		return x.DexLivenessFallbackBlocks
+	}
+	return 0
+}
+
 // FeeParams is the parameter space that defines various amounts for transaction fees
 type FeeParams struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -568,8 +661,16 @@ type FeeParams struct {
 	DexLiquidityDepositFee uint64 `protobuf:"varint,15,opt,name=dex_liquidity_deposit_fee,json=dexLiquidityDepositFee,proto3" json:"dexLiquidityDeposit"` // @gotags: json:"dexLiquidityDeposit"
 	// dex_liquidity_withdraw: is the fee amount (in uCNPY) for Message Dex Liquidity Withdraw
 	DexLiquidityWithdrawFee uint64 `protobuf:"varint,16,opt,name=dex_liquidity_withdraw_fee,json=dexLiquidityWithdrawFee,proto3" json:"dexLiquidityWithdraw"` // @gotags: json:"dexLiquidityWithdraw"
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+	// create_multisig_account_fee: is the fee amount (in uCNPY) for Message Create Multisig Account
+	CreateMultisigAccountFee uint64 `protobuf:"varint,17,opt,name=create_multisig_account_fee,json=createMultisigAccountFee,proto3" json:"createMultisigAccountFee"` // @gotags: json:"createMultisigAccountFee"
+	// submit_proposal_fee: is the fee amount (in uCNPY) for Message Submit Proposal
+	SubmitProposalFee uint64 `protobuf:"varint,18,opt,name=submit_proposal_fee,json=submitProposalFee,proto3" json:"submitProposalFee"` // @gotags: json:"submitProposalFee"
+	// vote_fee: is the fee amount (in uCNPY) for Message Vote
+	VoteFee uint64 `protobuf:"varint,19,opt,name=vote_fee,json=voteFee,proto3" json:"voteFee"` // @gotags: json:"voteFee"
+	// dex_routed_swap_fee: is the fee amount (in uCNPY) for Message Dex Routed Swap
+	DexRoutedSwapFee uint64 `protobuf:"varint,20,opt,name=dex_routed_swap_fee,json=dexRoutedSwapFee,proto3" json:"dexRoutedSwapFee"` // @gotags: json:"dexRoutedSwapFee"
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *FeeParams) Reset() {
@@ -714,14 +815,50 @@ func (x *FeeParams) GetDexLiquidityWithdrawFee() uint64 {
 	return 0
 }
 
+func (x *FeeParams) GetCreateMultisigAccountFee() uint64 {
+	if x != nil {
+		return x.CreateMultisigAccountFee
+	}
+	return 0
+}
+
+func (x *FeeParams) GetSubmitProposalFee() uint64 {
+	if x != nil {
+		return x.SubmitProposalFee
+	}
+	return 0
+}
+
+func (x *FeeParams) GetVoteFee() uint64 {
+	if x != nil {
+		return x.VoteFee
+	}
+	return 0
+}
+
+func (x *FeeParams) GetDexRoutedSwapFee() uint64 {
+	if x != nil {
+		return x.DexRoutedSwapFee
+	}
+	return 0
+}
+
 // GovernanceParams is the parameter space that define the rules that enable decentralized and autonomous
 // governing of the network
 type GovernanceParams struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// dao_reward_percent: is the percent of the block reward that is sent to the DAO
 	DaoRewardPercentage uint64 `protobuf:"varint,1,opt,name=dao_reward_percentage,json=daoRewardPercentage,proto3" json:"daoRewardPercentage"` // @gotags: json:"daoRewardPercentage"
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// proposal_min_deposit: is the minimum amount (in uCNPY) escrowed when submitting an on-chain proposal
+	ProposalMinDeposit uint64 `protobuf:"varint,2,opt,name=proposal_min_deposit,json=proposalMinDeposit,proto3" json:"proposalMinDeposit"` // @gotags: json:"proposalMinDeposit"
+	// proposal_voting_period: is the number of blocks an on-chain proposal is open for voting
+	ProposalVotingPeriod uint64 `protobuf:"varint,3,opt,name=proposal_voting_period,json=proposalVotingPeriod,proto3" json:"proposalVotingPeriod"` // @gotags: json:"proposalVotingPeriod"
+	// proposal_quorum_percentage: is the percent of the total stake that must vote for the result to be valid
+	ProposalQuorumPercentage uint64 `protobuf:"varint,4,opt,name=proposal_quorum_percentage,json=proposalQuorumPercentage,proto3" json:"proposalQuorumPercentage"` // @gotags: json:"proposalQuorumPercentage"
+	// proposal_threshold_percentage: is the percent of the voting stake that must approve for a proposal to pass
+	ProposalThresholdPercentage uint64 `protobuf:"varint,5,opt,name=proposal_threshold_percentage,json=proposalThresholdPercentage,proto3" json:"proposalThresholdPercentage"` // @gotags: json:"proposalThresholdPercentage"
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
 }
 
 func (x *GovernanceParams) Reset() {
@@ -761,11 +898,212 @@ func (x *GovernanceParams) GetDaoRewardPercentage() uint64 {
 	return 0
 }
 
+func (x *GovernanceParams) GetProposalMinDeposit() uint64 {
+	if x != nil {
+		return x.ProposalMinDeposit
+	}
+	return 0
+}
+
+func (x *GovernanceParams) GetProposalVotingPeriod() uint64 {
+	if x != nil {
+		return x.ProposalVotingPeriod
+	}
+	return 0
+}
+
+func (x *GovernanceParams) GetProposalQuorumPercentage() uint64 {
+	if x != nil {
+		return x.ProposalQuorumPercentage
+	}
+	return 0
+}
+
+func (x *GovernanceParams) GetProposalThresholdPercentage() uint64 {
+	if x != nil {
+		return x.ProposalThresholdPercentage
+	}
+	return 0
+}
+
+// Proposal is an on-chain governance proposal decided by stake weighted voting of validators and delegators
+type Proposal struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id: the unique identifier of the proposal (the first 20 bytes of the submitting transaction hash)
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// proposer: the address that submitted the proposal and is refunded the deposit
+	Proposer []byte `protobuf:"bytes,2,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	// deposit: the amount (in uCNPY) escrowed in the governance deposit pool until the proposal is tallied
+	Deposit uint64 `protobuf:"varint,3,opt,name=deposit,proto3" json:"deposit,omitempty"`
+	// msg: the governance message executed if the proposal passes (MessageChangeParameter or MessageDAOTransfer)
+	Msg *anypb.Any `protobuf:"bytes,4,opt,name=msg,proto3" json:"msg,omitempty"`
+	// submit_height: the block height the proposal was submitted
+	SubmitHeight uint64 `protobuf:"varint,5,opt,name=submit_height,json=submitHeight,proto3" json:"submitHeight"` // @gotags: json:"submitHeight"
+	// voting_end_height: the last block height votes are accepted and the height the proposal is tallied
+	VotingEndHeight uint64 `protobuf:"varint,6,opt,name=voting_end_height,json=votingEndHeight,proto3" json:"votingEndHeight"` // @gotags: json:"votingEndHeight"
+	// status: the lifecycle stage of the proposal
+	Status ProposalStatus `protobuf:"varint,7,opt,name=status,proto3,enum=types.ProposalStatus" json:"status,omitempty"`
+	// approve_votes: the total stake that voted to approve (set when tallied)
+	ApproveVotes uint64 `protobuf:"varint,8,opt,name=approve_votes,json=approveVotes,proto3" json:"approveVotes"` // @gotags: json:"approveVotes"
+	// reject_votes: the total stake that voted to reject (set when tallied)
+	RejectVotes uint64 `protobuf:"varint,9,opt,name=reject_votes,json=rejectVotes,proto3" json:"rejectVotes"` // @gotags: json:"rejectVotes"
+	// total_voting_power: the total stake eligible to vote (set when tallied)
+	TotalVotingPower uint64 `protobuf:"varint,10,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"totalVotingPower"` // @gotags: json:"totalVotingPower"
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Proposal) Reset() {
+	*x = Proposal{}
+	mi := &file_gov_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Proposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proposal) ProtoMessage() {}
+
+func (x *Proposal) ProtoReflect() protoreflect.Message {
+	mi := &file_gov_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proposal.ProtoReflect.Descriptor instead.
+func (*Proposal) Descriptor() ([]byte, []int) {
+	return file_gov_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Proposal) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Proposal) GetProposer() []byte {
+	if x != nil {
+		return x.Proposer
+	}
+	return nil
+}
+
+func (x *Proposal) GetDeposit() uint64 {
+	if x != nil {
+		return x.Deposit
+	}
+	return 0
+}
+
+func (x *Proposal) GetMsg() *anypb.Any {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *Proposal) GetSubmitHeight() uint64 {
+	if x != nil {
+		return x.SubmitHeight
+	}
+	return 0
+}
+
+func (x *Proposal) GetVotingEndHeight() uint64 {
+	if x != nil {
+		return x.VotingEndHeight
+	}
+	return 0
+}
+
+func (x *Proposal) GetStatus() ProposalStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ProposalStatus_PROPOSAL_VOTING
+}
+
+func (x *Proposal) GetApproveVotes() uint64 {
+	if x != nil {
+		return x.ApproveVotes
+	}
+	return 0
+}
+
+func (x *Proposal) GetRejectVotes() uint64 {
+	if x != nil {
+		return x.RejectVotes
+	}
+	return 0
+}
+
+func (x *Proposal) GetTotalVotingPower() uint64 {
+	if x != nil {
+		return x.TotalVotingPower
+	}
+	return 0
+}
+
+// ProposalVote is a validator's or delegator's vote on an on-chain governance proposal
+type ProposalVote struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// approve: true to vote for the proposal, false to vote against
+	Approve       bool `protobuf:"varint,1,opt,name=approve,proto3" json:"approve,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProposalVote) Reset() {
+	*x = ProposalVote{}
+	mi := &file_gov_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProposalVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposalVote) ProtoMessage() {}
+
+func (x *ProposalVote) ProtoReflect() protoreflect.Message {
+	mi := &file_gov_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposalVote.ProtoReflect.Descriptor instead.
+func (*ProposalVote) Descriptor() ([]byte, []int) {
+	return file_gov_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProposalVote) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
 var File_gov_proto protoreflect.FileDescriptor
 
 const file_gov_proto_rawDesc = "" +
 	"\n" +
-	"\tgov.proto\x12\x05types\"\xd1\x01\n" +
+	"\tgov.proto\x12\x05types\x1a\x19google/protobuf/any.proto\"\xd1\x01\n" +
 	"\x06Params\x124\n" +
 	"\tConsensus\x18\x01 \x01(\v2\x16.types.ConsensusParamsR\tConsensus\x124\n" +
 	"\tValidator\x18\x02 \x01(\v2\x16.types.ValidatorParamsR\tValidator\x12\"\n" +
@@ -775,14 +1113,16 @@ const file_gov_proto_rawDesc = "" +
 	"Governance\"C\n" +
 	"\x0fProtocolVersion\x12\x16\n" +
 	"\x06height\x18\x01 \x01(\x04R\x06height\x12\x18\n" +
-	"\aversion\x18\x02 \x01(\x04R\aversion\"\xc2\x01\n" +
+	"\aversion\x18\x02 \x01(\x04R\aversion\"\xa4\x02\n" +
 	"\x0fConsensusParams\x12\x1d\n" +
 	"\n" +
 	"block_size\x18\x01 \x01(\x04R\tblockSize\x12)\n" +
 	"\x10protocol_version\x18\x02 \x01(\tR\x0fprotocolVersion\x12\"\n" +
 	"\rroot_chain_id\x18\x03 \x01(\x04R\vrootChainId\x12\x18\n" +
 	"\aretired\x18\x04 \x01(\x04R\aretired\x12'\n" +
-	"\x0freset_committee\x18\x05 \x01(\x04R\x0eresetCommittee\"\xa0\b\n" +
+	"\x0freset_committee\x18\x05 \x01(\x04R\x0eresetCommittee\x12/\n" +
+	"\x14min_phase_timeout_ms\x18\x06 \x01(\x04R\x11minPhaseTimeoutMs\x12/\n" +
+	"\x14max_phase_timeout_ms\x18\a \x01(\x04R\x11maxPhaseTimeoutMs\"\xe1\b\n" +
 	"\x0fValidatorParams\x12)\n" +
 	"\x10unstaking_blocks\x18\x01 \x01(\x04R\x0funstakingBlocks\x12(\n" +
 	"\x10max_pause_blocks\x18\x02 \x01(\x04R\x0emaxPauseBlocks\x12?\n" +
@@ -804,7 +1144,8 @@ const file_gov_proto_rawDesc = "" +
 	"\x19lock_order_fee_multiplier\x18\x10 \x01(\x04R\x16lockOrderFeeMultiplier\x12?\n" +
 	"\x1cminimum_stake_for_validators\x18\x11 \x01(\x04R\x19minimumStakeForValidators\x12=\n" +
 	"\x1bminimum_stake_for_delegates\x18\x12 \x01(\x04R\x18minimumStakeForDelegates\x12E\n" +
-	"\x1fmaximum_delegates_per_committee\x18\x13 \x01(\x04R\x1cmaximumDelegatesPerCommittee\"\x9e\x05\n" +
+	"\x1fmaximum_delegates_per_committee\x18\x13 \x01(\x04R\x1cmaximumDelegatesPerCommittee\x12?\n" +
+	"\x1cdex_liveness_fallback_blocks\x18\x14 \x01(\x04R\x19dexLivenessFallbackBlocks\"\xd7\x06\n" +
 	"\tFeeParams\x12\x19\n" +
 	"\bsend_fee\x18\x01 \x01(\x04R\asendFee\x12\x1b\n" +
 	"\tstake_fee\x18\x02 \x01(\x04R\bstakeFee\x12$\n" +
@@ -825,15 +1166,43 @@ const file_gov_proto_rawDesc = "" +
 	"\x10delete_order_fee\x18\r \x01(\x04R\x0edeleteOrderFee\x12-\n" +
 	"\x13dex_limit_order_fee\x18\x0e \x01(\x04R\x10dexLimitOrderFee\x129\n" +
 	"\x19dex_liquidity_deposit_fee\x18\x0f \x01(\x04R\x16dexLiquidityDepositFee\x12;\n" +
-	"\x1adex_liquidity_withdraw_fee\x18\x10 \x01(\x04R\x17dexLiquidityWithdrawFee\"F\n" +
+	"\x1adex_liquidity_withdraw_fee\x18\x10 \x01(\x04R\x17dexLiquidityWithdrawFee\x12=\n" +
+	"\x1bcreate_multisig_account_fee\x18\x11 \x01(\x04R\x18createMultisigAccountFee\x12.\n" +
+	"\x13submit_proposal_fee\x18\x12 \x01(\x04R\x11submitProposalFee\x12\x19\n" +
+	"\bvote_fee\x18\x13 \x01(\x04R\avoteFee\x12-\n" +
+	"\x13dex_routed_swap_fee\x18\x14 \x01(\x04R\x10dexRoutedSwapFee\"\xb0\x02\n" +
 	"\x10GovernanceParams\x122\n" +
-	"\x15dao_reward_percentage\x18\x01 \x01(\x04R\x13daoRewardPercentage*I\n" +
+	"\x15dao_reward_percentage\x18\x01 \x01(\x04R\x13daoRewardPercentage\x120\n" +
+	"\x14proposal_min_deposit\x18\x02 \x01(\x04R\x12proposalMinDeposit\x124\n" +
+	"\x16proposal_voting_period\x18\x03 \x01(\x04R\x14proposalVotingPeriod\x12<\n" +
+	"\x1aproposal_quorum_percentage\x18\x04 \x01(\x04R\x18proposalQuorumPercentage\x12B\n" +
+	"\x1dproposal_threshold_percentage\x18\x05 \x01(\x04R\x1bproposalThresholdPercentage\"\xee\x02\n" +
+	"\bProposal\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x1a\n" +
+	"\bproposer\x18\x02 \x01(\fR\bproposer\x12\x18\n" +
+	"\adeposit\x18\x03 \x01(\x04R\adeposit\x12&\n" +
+	"\x03msg\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\x03msg\x12#\n" +
+	"\rsubmit_height\x18\x05 \x01(\x04R\fsubmitHeight\x12*\n" +
+	"\x11voting_end_height\x18\x06 \x01(\x04R\x0fvotingEndHeight\x12-\n" +
+	"\x06status\x18\a \x01(\x0e2\x15.types.ProposalStatusR\x06status\x12#\n" +
+	"\rapprove_votes\x18\b \x01(\x04R\fapproveVotes\x12!\n" +
+	"\freject_votes\x18\t \x01(\x04R\vrejectVotes\x12,\n" +
+	"\x12total_voting_power\x18\n" +
+	" \x01(\x04R\x10totalVotingPower\"(\n" +
+	"\fProposalVote\x12\x18\n" +
+	"\aapprove\x18\x01 \x01(\bR\aapprove*I\n" +
 	"\x15GovProposalVoteConfig\x12\x0e\n" +
 	"\n" +
 	"ACCEPT_ALL\x10\x00\x12\x10\n" +
 	"\fAPPROVE_LIST\x10\x01\x12\x0e\n" +
 	"\n" +
-	"REJECT_ALL\x10\x02B&Z$github.com/canopy-network/canopy/fsmb\x06proto3"
+	"REJECT_ALL\x10\x02*~\n" +
+	"\x0eProposalStatus\x12\x13\n" +
+	"\x0fPROPOSAL_VOTING\x10\x00\x12\x13\n" +
+	"\x0fPROPOSAL_PASSED\x10\x01\x12\x15\n" +
+	"\x11PROPOSAL_REJECTED\x10\x02\x12\x16\n" +
+	"\x12PROPOSAL_NO_QUORUM\x10\x03\x12\x13\n" +
+	"\x0fPROPOSAL_FAILED\x10\x04B&Z$github.com/canopy-network/canopy/fsmb\x06proto3"
 
 var (
 	file_gov_proto_rawDescOnce sync.Once
@@ -847,27 +1216,33 @@ func file_gov_proto_rawDescGZIP() []byte {
 	return file_gov_proto_rawDescData
 }
 
-var file_gov_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_gov_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_gov_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_gov_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_gov_proto_goTypes = []any{
 	(GovProposalVoteConfig)(0), // 0: types.GovProposalVoteConfig
-	(*Params)(nil),             // 1: types.Params
-	(*ProtocolVersion)(nil),    // 2: types.ProtocolVersion
-	(*ConsensusParams)(nil),    // 3: types.ConsensusParams
-	(*ValidatorParams)(nil),    // 4: types.ValidatorParams
-	(*FeeParams)(nil),          // 5: types.FeeParams
-	(*GovernanceParams)(nil),   // 6: types.GovernanceParams
+	(ProposalStatus)(0),        // 1: types.ProposalStatus
+	(*Params)(nil),             // 2: types.Params
+	(*ProtocolVersion)(nil),    // 3: types.ProtocolVersion
+	(*ConsensusParams)(nil),    // 4: types.ConsensusParams
+	(*ValidatorParams)(nil),    // 5: types.ValidatorParams
+	(*FeeParams)(nil),          // 6: types.FeeParams
+	(*GovernanceParams)(nil),   // 7: types.GovernanceParams
+	(*Proposal)(nil),           // 8: types.Proposal
+	(*ProposalVote)(nil),       // 9: types.ProposalVote
+	(*anypb.Any)(nil),          // 10: google.protobuf.Any
 }
 var file_gov_proto_depIdxs = []int32{
-	3, // 0: types.Params.Consensus:type_name -> types.ConsensusParams
-	4, // 1: types.Params.Validator:type_name -> types.ValidatorParams
-	5, // 2: types.Params.Fee:type_name -> types.FeeParams
-	6, // 3: types.Params.Governance:type_name -> types.GovernanceParams
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	4,  // 0: types.Params.Consensus:type_name -> types.ConsensusParams
+	5,  // 1: types.Params.Validator:type_name -> types.ValidatorParams
+	6,  // 2: types.Params.Fee:type_name -> types.FeeParams
+	7,  // 3: types.Params.Governance:type_name -> types.GovernanceParams
+	10, // 4: types.Proposal.msg:type_name -> google.protobuf.Any
+	1,  // 5: types.Proposal.status:type_name -> types.ProposalStatus
+	6,  // [6:6] is the sub-list for method output_type
+	6,  // [6:6] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_gov_proto_init() }
@@ -880,8 +1255,8 @@ func file_gov_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gov_proto_rawDesc), len(file_gov_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
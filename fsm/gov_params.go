@@ -40,10 +40,12 @@ type ParamSpace interface {
 func DefaultParams() *Params {
 	return &Params{
 		Consensus: &ConsensusParams{
-			BlockSize:       uint64(units.MB),
-			ProtocolVersion: NewProtocolVersion(0, 1),
-			RootChainId:     1,
-			Retired:         0,
+			BlockSize:         uint64(units.MB),
+			ProtocolVersion:   NewProtocolVersion(0, 1),
+			RootChainId:       1,
+			Retired:           0,
+			MinPhaseTimeoutMs: lib.DefaultMinPhaseTimeoutMS,
+			MaxPhaseTimeoutMs: lib.DefaultMaxPhaseTimeoutMS,
 		},
 		Validator: &ValidatorParams{
 			UnstakingBlocks:                    2,
@@ -65,27 +67,36 @@ func DefaultParams() *Params {
 			MinimumStakeForValidators:          0,
 			MinimumStakeForDelegates:           0,
 			MaximumDelegatesPerCommittee:       0,
+			DexLivenessFallbackBlocks:          lib.LivenessFallbackBlocks,
 		},
 		Fee: &FeeParams{
-			SendFee:                 10000,
-			StakeFee:                10000,
-			EditStakeFee:            10000,
-			UnstakeFee:              10000,
-			PauseFee:                10000,
-			UnpauseFee:              10000,
-			ChangeParameterFee:      10000,
-			DaoTransferFee:          10000,
-			CertificateResultsFee:   0,
-			SubsidyFee:              10000,
-			CreateOrderFee:          10000,
-			EditOrderFee:            10000,
-			DeleteOrderFee:          10000,
-			DexLimitOrderFee:        0,
-			DexLiquidityDepositFee:  0,
-			DexLiquidityWithdrawFee: 0,
+			SendFee:                  10000,
+			StakeFee:                 10000,
+			EditStakeFee:             10000,
+			UnstakeFee:               10000,
+			PauseFee:                 10000,
+			UnpauseFee:               10000,
+			ChangeParameterFee:       10000,
+			DaoTransferFee:           10000,
+			CertificateResultsFee:    0,
+			SubsidyFee:               10000,
+			CreateOrderFee:           10000,
+			EditOrderFee:             10000,
+			DeleteOrderFee:           10000,
+			DexLimitOrderFee:         0,
+			DexLiquidityDepositFee:   0,
+			DexLiquidityWithdrawFee:  0,
+			CreateMultisigAccountFee: 10000,
+			SubmitProposalFee:        10000,
+			VoteFee:                  10000,
+			DexRoutedSwapFee:         0,
 		},
 		Governance: &GovernanceParams{
-			DaoRewardPercentage: 5,
+			DaoRewardPercentage:         5,
+			ProposalMinDeposit:          1000000000,
+			ProposalVotingPeriod:        4380,
+			ProposalQuorumPercentage:    33,
+			ProposalThresholdPercentage: 50,
 		},
 	}
 }
@@ -117,6 +128,12 @@ const (
 	ParamResetCommittee  = "resetCommittee"  // committee id to reset its committee data
 )
 
+// adaptive consensus timeout bounds
+const (
+	ParamMinPhaseTimeoutMS = "minPhaseTimeoutMS" // lower bound of an adaptive phase timeout
+	ParamMaxPhaseTimeoutMS = "maxPhaseTimeoutMS" // upper bound of an adaptive phase timeout
+)
+
 var _ ParamSpace = &ConsensusParams{}
 
 // Check() validates the consensus params
@@ -127,6 +144,9 @@ func (x *ConsensusParams) Check() lib.ErrorI {
 	if _, err := x.ParseProtocolVersion(); err != nil {
 		return err
 	}
+	if minMS, maxMS := x.PhaseTimeoutBounds(); minMS > maxMS {
+		return ErrInvalidParam(ParamMaxPhaseTimeoutMS)
+	}
 	return nil
 }
 
@@ -141,6 +161,10 @@ func (x *ConsensusParams) SetUint64(paramName string, value uint64) lib.ErrorI {
 		x.RootChainId = value
 	case ParamResetCommittee:
 		x.ResetCommittee = value
+	case ParamMinPhaseTimeoutMS:
+		x.MinPhaseTimeoutMs = value
+	case ParamMaxPhaseTimeoutMS:
+		x.MaxPhaseTimeoutMs = value
 	default:
 		return ErrUnknownParam()
 	}
@@ -172,6 +196,18 @@ func (x *ConsensusParams) SetString(paramName string, value string) lib.ErrorI {
 	return x.Check()
 }
 
+// PhaseTimeoutBounds() returns the bounds (in milliseconds) of adaptive phase timeouts, resolving unset bounds to the protocol defaults
+func (x *ConsensusParams) PhaseTimeoutBounds() (minMS, maxMS uint64) {
+	minMS, maxMS = x.MinPhaseTimeoutMs, x.MaxPhaseTimeoutMs
+	if minMS == 0 {
+		minMS = lib.DefaultMinPhaseTimeoutMS
+	}
+	if maxMS == 0 {
+		maxMS = lib.DefaultMaxPhaseTimeoutMS
+	}
+	return
+}
+
 // ParseProtocolVersion() validates the format of the Protocol version string and returns the ProtocolVersion object
 func (x *ConsensusParams) ParseProtocolVersion() (*ProtocolVersion, lib.ErrorI) {
 	return checkProtocolVersion(x.ProtocolVersion)
@@ -241,6 +277,7 @@ const (
 	ParamMinimumStakeForValidators          = "minimumStakeForValidators"          // minimum stake required to be a validator
 	ParamMinimumStakeForDelegates           = "minimumStakeForDelegates"           // minimum stake required to be a delegate
 	ParamMaximumDelegatesPerCommittee       = "maximumDelegatesPerCommittee"       // maximum number of delegates per committee
+	ParamDexLivenessFallbackBlocks          = "dexLivenessFallbackBlocks"          // blocks a nested chain's locked dex batch may wait on receipts before falling back
 )
 
 // Check() validates the Validator params
@@ -290,6 +327,10 @@ func (x *ValidatorParams) Check() lib.ErrorI {
 	if x.LockOrderFeeMultiplier == 0 {
 		return ErrInvalidParam(ParamLockOrderFeeMultiplier)
 	}
+	// the fallback is only evaluated on dex trigger blocks, so a shorter window would fire on the first one
+	if x.DexLivenessFallbackBlocks != 0 && x.DexLivenessFallbackBlocks < lib.TriggerModuloBlocks {
+		return ErrInvalidParam(ParamDexLivenessFallbackBlocks)
+	}
 	return nil
 }
 
@@ -334,6 +375,8 @@ func (x *ValidatorParams) SetUint64(paramName string, value uint64) lib.ErrorI {
 		x.MinimumStakeForDelegates = value
 	case ParamMaximumDelegatesPerCommittee:
 		x.MaximumDelegatesPerCommittee = value
+	case ParamDexLivenessFallbackBlocks:
+		x.DexLivenessFallbackBlocks = value
 	default:
 		return ErrUnknownParam()
 	}
@@ -350,22 +393,26 @@ func (x *ValidatorParams) SetString(_ string, _ string) lib.ErrorI {
 var _ ParamSpace = &FeeParams{}
 
 const (
-	ParamSendFee                 = "sendFee"                 // transaction fee for MessageSend
-	ParamStakeFee                = "stakeFee"                // transaction fee for MessageStake
-	ParamEditStakeFee            = "editStakeFee"            // transaction fee for MessageEditStake
-	ParamUnstakeFee              = "unstakeFee"              // transaction fee for MessageUnstake
-	ParamPauseFee                = "pauseFee"                // transaction fee for MessagePause
-	ParamUnpauseFee              = "unpauseFee"              // transaction fee for MessageUnpause
-	ParamChangeParameterFee      = "changeParameterFee"      // transaction fee for MessageChangeParameter
-	ParamDAOTransferFee          = "daoTransferFee"          // transaction fee for MessageDAOTransfer
-	ParamCertificateResultsFee   = "certificateResultsFee"   // transaction fee for MessageCertificateResults
-	ParamSubsidyFee              = "subsidyFee"              // transaction fee for MessageSubsidy
-	ParamCreateOrderFee          = "createOrderFee"          // transaction fee for MessageCreateOrder
-	ParamEditOrderFee            = "editOrderFee"            // transaction fee for MessageEditOrder
-	ParamDeleteOrderFee          = "deleteOrderFee"          // transaction fee for MessageDeleteOrder
-	ParamDexLimitOrderFee        = "dexLimitOrderFee"        // transaction fee for MessageDexLimitOrder
-	ParamDexLiquidityDepositFee  = "dexLiquidityDepositFee"  // transaction fee for MessageDexLiquidityDeposit
-	ParamDexLiquidityWithdrawFee = "dexLiquidityWithdrawFee" // transaction fee for MessageDexLiquidityWithdraw
+	ParamSendFee                  = "sendFee"                  // transaction fee for MessageSend
+	ParamStakeFee                 = "stakeFee"                 // transaction fee for MessageStake
+	ParamEditStakeFee             = "editStakeFee"             // transaction fee for MessageEditStake
+	ParamUnstakeFee               = "unstakeFee"               // transaction fee for MessageUnstake
+	ParamPauseFee                 = "pauseFee"                 // transaction fee for MessagePause
+	ParamUnpauseFee               = "unpauseFee"               // transaction fee for MessageUnpause
+	ParamChangeParameterFee       = "changeParameterFee"       // transaction fee for MessageChangeParameter
+	ParamDAOTransferFee           = "daoTransferFee"           // transaction fee for MessageDAOTransfer
+	ParamCertificateResultsFee    = "certificateResultsFee"    // transaction fee for MessageCertificateResults
+	ParamSubsidyFee               = "subsidyFee"               // transaction fee for MessageSubsidy
+	ParamCreateOrderFee           = "createOrderFee"           // transaction fee for MessageCreateOrder
+	ParamEditOrderFee             = "editOrderFee"             // transaction fee for MessageEditOrder
+	ParamDeleteOrderFee           = "deleteOrderFee"           // transaction fee for MessageDeleteOrder
+	ParamDexLimitOrderFee         = "dexLimitOrderFee"         // transaction fee for MessageDexLimitOrder
+	ParamDexLiquidityDepositFee   = "dexLiquidityDepositFee"   // transaction fee for MessageDexLiquidityDeposit
+	ParamDexLiquidityWithdrawFee  = "dexLiquidityWithdrawFee"  // transaction fee for MessageDexLiquidityWithdraw
+	ParamCreateMultisigAccountFee = "createMultisigAccountFee" // transaction fee for MessageCreateMultisigAccount
+	ParamSubmitProposalFee        = "submitProposalFee"        // transaction fee for MessageSubmitProposal
+	ParamVoteFee                  = "voteFee"                  // transaction fee for MessageVote
+	ParamDexRoutedSwapFee         = "dexRoutedSwapFee"         // transaction fee for MessageDexRoutedSwap
 )
 
 // Check() validates the Fee params
@@ -413,6 +460,14 @@ func (x *FeeParams) SetUint64(paramName string, value uint64) lib.ErrorI {
 		x.DexLiquidityDepositFee = value
 	case ParamDexLiquidityWithdrawFee:
 		x.DexLiquidityWithdrawFee = value
+	case ParamCreateMultisigAccountFee:
+		x.CreateMultisigAccountFee = value
+	case ParamSubmitProposalFee:
+		x.SubmitProposalFee = value
+	case ParamVoteFee:
+		x.VoteFee = value
+	case ParamDexRoutedSwapFee:
+		x.DexRoutedSwapFee = value
 	default:
 		return ErrUnknownParam()
 	}
@@ -422,7 +477,11 @@ func (x *FeeParams) SetUint64(paramName string, value uint64) lib.ErrorI {
 // governance param space
 
 const (
-	ParamDAORewardPercentage = "daoRewardPercentage" // percent of rewards the DAO fund receives
+	ParamDAORewardPercentage         = "daoRewardPercentage"         // percent of rewards the DAO fund receives
+	ParamProposalMinDeposit          = "proposalMinDeposit"          // minimum deposit escrowed with an on-chain proposal
+	ParamProposalVotingPeriod        = "proposalVotingPeriod"        // number of blocks an on-chain proposal is open for voting
+	ParamProposalQuorumPercentage    = "proposalQuorumPercentage"    // percent of the total stake that must vote on a proposal
+	ParamProposalThresholdPercentage = "proposalThresholdPercentage" // percent of the voting stake that must approve a proposal
 )
 
 var _ ParamSpace = &GovernanceParams{}
@@ -432,6 +491,12 @@ func (x *GovernanceParams) Check() lib.ErrorI {
 	if x.DaoRewardPercentage > 100 {
 		return ErrInvalidParam(ParamDAORewardPercentage)
 	}
+	if x.ProposalQuorumPercentage > 100 {
+		return ErrInvalidParam(ParamProposalQuorumPercentage)
+	}
+	if x.ProposalThresholdPercentage >= 100 {
+		return ErrInvalidParam(ParamProposalThresholdPercentage)
+	}
 	return nil
 }
 
@@ -440,6 +505,14 @@ func (x *GovernanceParams) SetUint64(paramName string, value uint64) lib.ErrorI
 	switch paramName {
 	case ParamDAORewardPercentage:
 		x.DaoRewardPercentage = value
+	case ParamProposalMinDeposit:
+		x.ProposalMinDeposit = value
+	case ParamProposalVotingPeriod:
+		x.ProposalVotingPeriod = value
+	case ParamProposalQuorumPercentage:
+		x.ProposalQuorumPercentage = value
+	case ParamProposalThresholdPercentage:
+		x.ProposalThresholdPercentage = value
 	default:
 		return ErrUnknownParam()
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/canopy-network/canopy/lib/crypto"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 func TestUpdateParam(t *testing.T) {
@@ -96,6 +97,25 @@ func TestUpdateParam(t *testing.T) {
 			},
 			error: "invalid protocol version",
 		},
+		{
+			name:   "consensus param phase timeout bound updated",
+			detail: "an update to the upper bound of adaptive phase timeouts under the consensus param space",
+			update: paramUpdate{
+				space: "cons",
+				name:  ParamMaxPhaseTimeoutMS,
+				value: &lib.UInt64Wrapper{Value: 20000},
+			},
+		},
+		{
+			name:   "consensus param phase timeout bounds inverted rejected",
+			detail: "the upper bound of adaptive phase timeouts must not be below the lower bound",
+			update: paramUpdate{
+				space: "cons",
+				name:  ParamMaxPhaseTimeoutMS,
+				value: &lib.UInt64Wrapper{Value: lib.DefaultMinPhaseTimeoutMS - 1},
+			},
+			error: "invalid param: maxPhaseTimeoutMS",
+		},
 		{
 			name:   "governance param updated",
 			detail: "an update to dao reward percentage under the governance param space",
@@ -145,6 +165,8 @@ func TestUpdateParam(t *testing.T) {
 				require.Equal(t, uint64Value.Value, got.Validator.MaxCommittees)
 			case ParamProtocolVersion: // consensus
 				require.Equal(t, stringValue.Value, got.Consensus.ProtocolVersion)
+			case ParamMaxPhaseTimeoutMS: // consensus
+				require.Equal(t, uint64Value.Value, got.Consensus.MaxPhaseTimeoutMs)
 			case ParamDAORewardPercentage: // gov
 				require.Equal(t, uint64Value.Value, got.Governance.DaoRewardPercentage)
 			case ParamCertificateResultsFee: // fee
@@ -576,6 +598,203 @@ func TestApproveProposal(t *testing.T) {
 	}
 }
 
+func TestHandleMessageVote(t *testing.T) {
+	tests := []struct {
+		name     string
+		detail   string
+		height   uint64
+		proposal *Proposal
+		voter    []byte
+		error    string
+	}{
+		{
+			name:   "proposal not found",
+			detail: "the proposal being voted on must exist",
+			height: 2,
+			voter:  newTestAddressBytes(t),
+			error:  "proposal not found",
+		},
+		{
+			name:     "not a validator",
+			detail:   "only stakers may vote",
+			height:   2,
+			proposal: &Proposal{Id: newTestAddressBytes(t, 2), VotingEndHeight: 10},
+			voter:    newTestAddressBytes(t, 1),
+			error:    "validator does not exist",
+		},
+		{
+			name:     "voting ended",
+			detail:   "votes after the voting end height are rejected",
+			height:   11,
+			proposal: &Proposal{Id: newTestAddressBytes(t, 2), VotingEndHeight: 10},
+			voter:    newTestAddressBytes(t),
+			error:    "proposal is not open for voting",
+		},
+		{
+			name:     "successful vote",
+			detail:   "a staker votes on an open proposal",
+			height:   10,
+			proposal: &Proposal{Id: newTestAddressBytes(t, 2), VotingEndHeight: 10},
+			voter:    newTestAddressBytes(t),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// create a state machine instance with default parameters
+			sm := newTestStateMachine(t)
+			sm.height = test.height
+			// preset a validator
+			require.NoError(t, sm.SetValidator(&Validator{Address: newTestAddressBytes(t), StakedAmount: 100}))
+			// preset the proposal
+			if test.proposal != nil {
+				require.NoError(t, sm.SetProposal(test.proposal))
+			}
+			msg := &MessageVote{Voter: test.voter, ProposalId: newTestAddressBytes(t, 2), Approve: true}
+			// execute the function call
+			err := sm.HandleMessageVote(msg)
+			// validate the expected error
+			require.Equal(t, test.error != "", err != nil, err)
+			if err != nil {
+				require.ErrorContains(t, err, test.error)
+				return
+			}
+			// validate the vote was recorded
+			bz, err := sm.Get(KeyForProposalVote(msg.ProposalId, crypto.NewAddress(test.voter)))
+			require.NoError(t, err)
+			vote := new(ProposalVote)
+			require.NoError(t, lib.Unmarshal(bz, vote))
+			require.True(t, vote.Approve)
+		})
+	}
+}
+
+func TestTallyProposal(t *testing.T) {
+	// pre-create the proposal messages
+	changeParam, err := lib.NewAny(&MessageChangeParameter{
+		ParameterSpace: ParamSpaceVal,
+		ParameterKey:   ParamUnstakingBlocks,
+		ParameterValue: func() *anypb.Any { a, _ := lib.NewAny(&lib.UInt64Wrapper{Value: 7}); return a }(),
+		Signer:         newTestAddressBytes(t),
+	})
+	require.NoError(t, err)
+	daoTransfer, err := lib.NewAny(&MessageDAOTransfer{Address: newTestAddressBytes(t, 3), Amount: 1_000_000})
+	require.NoError(t, err)
+	tests := []struct {
+		name     string
+		detail   string
+		msg      *anypb.Any
+		votes    map[int]bool // validator variation -> approve
+		expected ProposalStatus
+	}{
+		{
+			name:     "no quorum",
+			detail:   "less than the quorum percentage of stake voted",
+			msg:      changeParam,
+			votes:    map[int]bool{0: true},
+			expected: ProposalStatus_PROPOSAL_NO_QUORUM,
+		},
+		{
+			name:     "rejected",
+			detail:   "the approving stake didn't exceed the threshold",
+			msg:      changeParam,
+			votes:    map[int]bool{0: true, 1: false},
+			expected: ProposalStatus_PROPOSAL_REJECTED,
+		},
+		{
+			name:     "passed",
+			detail:   "the approving stake exceeded the threshold and the proposal executed",
+			msg:      changeParam,
+			votes:    map[int]bool{0: true, 1: true, 2: false},
+			expected: ProposalStatus_PROPOSAL_PASSED,
+		},
+		{
+			name:     "failed",
+			detail:   "the proposal passed but the dao pool can't cover the transfer",
+			msg:      daoTransfer,
+			votes:    map[int]bool{0: true, 1: true, 2: true},
+			expected: ProposalStatus_PROPOSAL_FAILED,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			supply, deposit, proposer := &Supply{}, uint64(100), newTestAddress(t, 4)
+			// create a state machine instance with default parameters
+			sm := newTestStateMachine(t)
+			// preset 4 validators with stake 100, 100, 100, 300
+			var validators []*Validator
+			for i, stake := range []uint64{100, 100, 100, 300} {
+				validators = append(validators, &Validator{Address: newTestAddressBytes(t, i), StakedAmount: stake})
+			}
+			require.NoError(t, sm.SetValidators(validators, supply))
+			require.NoError(t, sm.SetSupply(supply))
+			// preset the escrowed deposit and the proposal
+			require.NoError(t, sm.PoolAdd(lib.GovDepositPoolID, deposit))
+			proposal := &Proposal{Id: newTestAddressBytes(t, 5), Proposer: proposer.Bytes(), Deposit: deposit, Msg: test.msg}
+			require.NoError(t, sm.SetProposal(proposal))
+			// preset the votes
+			for i, approve := range test.votes {
+				require.NoError(t, sm.SetProposalVote(proposal.Id, newTestAddress(t, i), approve))
+			}
+			// execute the function call
+			require.NoError(t, sm.TallyProposal(proposal.Id))
+			// validate the status
+			got, err := sm.GetProposal(proposal.Id)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, got.Status)
+			require.EqualValues(t, 600, got.TotalVotingPower)
+			// validate the deposit was settled
+			escrow, err := sm.GetPoolBalance(lib.GovDepositPoolID)
+			require.NoError(t, err)
+			require.Zero(t, escrow)
+			refund, err := sm.GetAccountBalance(proposer)
+			require.NoError(t, err)
+			daoBalance, err := sm.GetPoolBalance(lib.DAOPoolID)
+			require.NoError(t, err)
+			if test.expected == ProposalStatus_PROPOSAL_NO_QUORUM {
+				require.Zero(t, refund)
+				require.Equal(t, deposit, daoBalance)
+			} else {
+				require.Equal(t, deposit, refund)
+				require.Zero(t, daoBalance)
+			}
+			// validate the execution outcome
+			valParams, err := sm.GetParamsVal()
+			require.NoError(t, err)
+			require.Equal(t, test.expected == ProposalStatus_PROPOSAL_PASSED, valParams.UnstakingBlocks == 7)
+			// validate the votes were pruned
+			it, err := sm.Iterator(ProposalVotesPrefix(proposal.Id))
+			require.NoError(t, err)
+			require.False(t, it.Valid())
+			it.Close()
+		})
+	}
+}
+
+func TestTallyProposalVoterErrors(t *testing.T) {
+	supply := &Supply{}
+	// create a state machine instance with default parameters
+	sm := newTestStateMachine(t)
+	// preset a validator and the proposal
+	require.NoError(t, sm.SetValidators([]*Validator{{Address: newTestAddressBytes(t), StakedAmount: 100}}, supply))
+	require.NoError(t, sm.SetSupply(supply))
+	daoTransfer, err := lib.NewAny(&MessageDAOTransfer{Address: newTestAddressBytes(t, 3), Amount: 1})
+	require.NoError(t, err)
+	proposal := &Proposal{Id: newTestAddressBytes(t, 5), Proposer: newTestAddressBytes(t, 4), Msg: daoTransfer}
+	require.NoError(t, sm.SetProposal(proposal))
+	// a voter that fully unstaked since voting carries no weight
+	require.NoError(t, sm.SetProposalVote(proposal.Id, newTestAddress(t), true))
+	require.NoError(t, sm.SetProposalVote(proposal.Id, newTestAddress(t, 1), true))
+	require.NoError(t, sm.TallyProposal(proposal.Id))
+	got, err := sm.GetProposal(proposal.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, got.ApproveVotes)
+	// a voter whose validator can't be read fails the tally rather than dropping the vote
+	require.NoError(t, sm.SetProposal(proposal))
+	require.NoError(t, sm.SetProposalVote(proposal.Id, newTestAddress(t), true))
+	require.NoError(t, sm.Set(KeyForValidator(newTestAddress(t)), []byte{0xff, 0xff}))
+	require.Error(t, sm.TallyProposal(proposal.Id))
+}
+
 func TestIsFeatureEnabled(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -11,6 +11,7 @@ import (
 var ReservedIDs = []uint64{
 	lib.UnknownChainId,
 	lib.DAOPoolID, // NOTE: DAOPoolId cannot be staked for as the max chain Id is the EscrowPoolAddend
+	lib.GovDepositPoolID,
 }
 
 // EscrowPoolAddend is used to translate a chainId into the id for the 'swap' escrow pool
@@ -43,8 +44,17 @@ var (
 	orderBookPrefix        = []byte{13} // store key prefix for 'sell orders' before they are bid on
 	retiredCommitteePrefix = []byte{14} // store key prefix for 'retired' (dead) committees
 	dexPrefix              = []byte{15} // store key prefix for 'dex' functionality
+	multisigPrefix         = []byte{16} // store key prefix for multisig account policies
+	proposalPrefix         = []byte{17} // store key prefix for on-chain governance proposals
+	proposalEndPrefix      = []byte{18} // store key prefix for open proposals by the height their voting ends
+	proposalVotePrefix     = []byte{19} // store key prefix for votes cast on open proposals
+	externalPaymentPrefix  = []byte{20} // store key prefix for external chain payments that closed a sell order
 	lockedBatchSegment = []byte{1}
 	nextBatchSement    = []byte{2}
+	poolConfigSegment  = []byte{3}
+	priceOracleSegment = []byte{4}
+	positionSegment    = []byte{5}
+	batchStallSegment  = []byte{6}
 )
 
 /*
@@ -74,6 +84,14 @@ func RetiredCommitteesPrefix() []byte   { return lib.JoinLenPrefix(retiredCommit
 func KeyForPool(n uint64) []byte        { return lib.JoinLenPrefix(poolPrefix, formatUint64(n)) }
 func KeyForNonSigner(a []byte) []byte   { return lib.JoinLenPrefix(nonSignerPrefix, a) }
 func OrderBookPrefix(cId uint64) []byte { return lib.JoinLenPrefix(orderBookPrefix, formatUint64(cId)) }
+func ProposalsPrefix() []byte           { return lib.JoinLenPrefix(proposalPrefix) }
+func ProposalEndPrefix(h uint64) []byte { return lib.JoinLenPrefix(proposalEndPrefix, formatUint64(h)) }
+func ProposalVotesPrefix(id []byte) []byte {
+	return lib.JoinLenPrefix(proposalVotePrefix, id)
+}
+func KeyForExternalPayment(chainId uint64, id []byte) []byte {
+	return lib.JoinLenPrefix(externalPaymentPrefix, formatUint64(chainId), id)
+}
 func KeyForOrder(chainId uint64, orderId []byte) []byte {
 	return append(OrderBookPrefix(chainId), lib.JoinLenPrefix(orderId)...)
 }
@@ -96,6 +114,18 @@ func KeyForRetiredCommittee(cId uint64) []byte {
 func KeyForAccount(addr crypto.AddressI) []byte {
 	return lib.JoinLenPrefix(accountPrefix, addr.Bytes())
 }
+func KeyForMultisigAccount(addr crypto.AddressI) []byte {
+	return lib.JoinLenPrefix(multisigPrefix, addr.Bytes())
+}
+func KeyForProposal(id []byte) []byte {
+	return lib.JoinLenPrefix(proposalPrefix, id)
+}
+func KeyForProposalEnd(h uint64, id []byte) []byte {
+	return append(ProposalEndPrefix(h), lib.JoinLenPrefix(id)...)
+}
+func KeyForProposalVote(id []byte, voter crypto.AddressI) []byte {
+	return append(ProposalVotesPrefix(id), lib.JoinLenPrefix(voter.Bytes())...)
+}
 func KeyForValidator(addr crypto.AddressI) []byte {
 	return lib.JoinLenPrefix(validatorPrefix, addr.Bytes())
 }
@@ -111,6 +141,22 @@ func KeyForNextBatch(chainId uint64) []byte {
 	return lib.JoinLenPrefix(dexPrefix, nextBatchSement, formatUint64(chainId))
 }
 
+func KeyForDexPoolConfig(chainId uint64) []byte {
+	return lib.JoinLenPrefix(dexPrefix, poolConfigSegment, formatUint64(chainId))
+}
+
+func KeyForDexPriceAccumulator(chainId uint64) []byte {
+	return lib.JoinLenPrefix(dexPrefix, priceOracleSegment, formatUint64(chainId))
+}
+
+func KeyForDexPositionBasis(chainId uint64, address []byte) []byte {
+	return lib.JoinLenPrefix(dexPrefix, positionSegment, formatUint64(chainId), address)
+}
+
+func KeyForDexBatchStall(chainId uint64) []byte {
+	return lib.JoinLenPrefix(dexPrefix, batchStallSegment, formatUint64(chainId))
+}
+
 func AddressFromKey(k []byte) (crypto.AddressI, lib.ErrorI) {
 	segments, err := decodeLengthPrefixedSafe(k)
 	if err != nil {
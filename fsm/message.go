@@ -2,6 +2,7 @@ package fsm
 
 import (
 	"bytes"
+	"slices"
 	"time"
 
 	"github.com/canopy-network/canopy/lib"
@@ -44,10 +45,18 @@ func (s *StateMachine) HandleMessage(msg lib.MessageI) lib.ErrorI {
 		return s.HandleMessageDeleteOrder(x)
 	case *MessageDexLimitOrder:
 		return s.HandleMessageDexLimitOrder(x)
+	case *MessageDexRoutedSwap:
+		return s.HandleMessageDexRoutedSwap(x)
 	case *MessageDexLiquidityDeposit:
 		return s.HandleMessageDexLiquidityDeposit(x)
 	case *MessageDexLiquidityWithdraw:
 		return s.HandleMessageDexLiquidityWithdraw(x)
+	case *MessageCreateMultisigAccount:
+		return s.HandleMessageCreateMultisigAccount(x)
+	case *MessageSubmitProposal:
+		return s.HandleMessageSubmitProposal(x)
+	case *MessageVote:
+		return s.HandleMessageVote(x)
 	default:
 		return ErrUnknownMessage(x)
 	}
@@ -305,13 +314,8 @@ func (s *StateMachine) HandleMessageChangeParameter(msg *MessageChangeParameter)
 	if err := s.ApproveProposal(msg); err != nil {
 		return ErrRejectProposal()
 	}
-	// extract the value from the proto packed 'any'
-	protoMsg, err := lib.FromAny(msg.ParameterValue)
-	if err != nil {
-		return err
-	}
 	// update the parameter
-	return s.UpdateParam(msg.ParameterSpace, msg.ParameterKey, protoMsg)
+	return s.ExecuteGovProposal(msg)
 }
 
 // HandleMessageDAOTransfer() is the proper handler for a `DAO-Transfer` message
@@ -320,18 +324,8 @@ func (s *StateMachine) HandleMessageDAOTransfer(msg *MessageDAOTransfer) lib.Err
 	if err := s.ApproveProposal(msg); err != nil {
 		return ErrRejectProposal()
 	}
-	// optionally mint the transfer amount into the DAO pool before distributing the grant
-	if msg.Mint {
-		if err := s.MintToPool(lib.DAOPoolID, msg.Amount); err != nil {
-			return err
-		}
-	}
-	// remove from DAO fund
-	if err := s.PoolSub(lib.DAOPoolID, msg.Amount); err != nil {
-		return err
-	}
-	// add to account
-	return s.AccountAdd(crypto.NewAddressFromBytes(msg.Address), msg.Amount)
+	// transfer from the DAO fund to the account
+	return s.ExecuteGovProposal(msg)
 }
 
 // HandleMessageCertificateResults() is the proper handler for a `CertificateResults` message
@@ -447,8 +441,8 @@ func (s *StateMachine) HandleMessageEditOrder(msg *MessageEditOrder) (err lib.Er
 	if err != nil {
 		return
 	}
-	// ensure the order isn't locked
-	if order.BuyerReceiveAddress != nil {
+	// ensure the order isn't locked (in whole or in part)
+	if order.BuyerReceiveAddress != nil || len(order.Locks) != 0 {
 		return lib.ErrOrderLocked()
 	}
 	// get the validator params from state
@@ -491,6 +485,7 @@ func (s *StateMachine) HandleMessageEditOrder(msg *MessageEditOrder) (err lib.Er
 		RequestedAmount:      msg.RequestedAmount,
 		SellerReceiveAddress: msg.SellerReceiveAddress,
 		SellersSendAddress:   order.SellersSendAddress,
+		FilledAmount:         order.FilledAmount,
 	}, msg.ChainId)
 }
 
@@ -500,7 +495,8 @@ func (s *StateMachine) HandleMessageDeleteOrder(msg *MessageDeleteOrder) (err li
 	if err != nil {
 		return
 	}
-	if order.BuyerReceiveAddress != nil {
+	// ensure the order isn't locked (in whole or in part)
+	if order.BuyerReceiveAddress != nil || len(order.Locks) != 0 {
 		return lib.ErrOrderLocked()
 	}
 	// subtract from the committee escrow pool
@@ -516,36 +512,74 @@ func (s *StateMachine) HandleMessageDeleteOrder(msg *MessageDeleteOrder) (err li
 
 // HandleMessageDexLimitOrder() is the proper handler for a `DexLimitOrder` message
 func (s *StateMachine) HandleMessageDexLimitOrder(msg *MessageDexLimitOrder) (err lib.ErrorI) {
+	return s.handleDexLimitOrder(msg.ChainId, &lib.DexLimitOrder{
+		AmountForSale:   msg.AmountForSale,
+		RequestedAmount: msg.RequestedAmount,
+		Address:         msg.Address,
+		OrderId:         msg.OrderId,
+		ExpiryHeight:    msg.ExpiryHeight,
+		PartialFill:     msg.PartialFill,
+	})
+}
+
+// HandleMessageDexRoutedSwap() is the proper handler for a `DexRoutedSwap` message
+// The order's first leg is sold to path[1]; a multi-hop route is forwarded by the root chain (see HandleDexBatchOrders)
+func (s *StateMachine) HandleMessageDexRoutedSwap(msg *MessageDexRoutedSwap) (err lib.ErrorI) {
+	// the route must start with the asset of this chain
+	if msg.Path[0] != s.Config.ChainId {
+		return lib.ErrInvalidDexRoute()
+	}
+	// a multi-hop route goes through the root chain, the only chain with a pool for every other chain
+	if len(msg.Path) > 2 {
+		rootChainId, e := s.GetRootChainId()
+		if e != nil {
+			return e
+		}
+		if msg.Path[1] != rootChainId {
+			return lib.ErrInvalidDexRoute()
+		}
+	}
+	// the requested amount of a routed order is the minimum output of the whole route
+	return s.handleDexLimitOrder(msg.Path[1], &lib.DexLimitOrder{
+		AmountForSale:   msg.AmountForSale,
+		RequestedAmount: msg.MinimumOutput,
+		Address:         msg.Address,
+		OrderId:         msg.OrderId,
+		Route:           slices.Clone(msg.Path[2:]),
+	})
+}
+
+// handleDexLimitOrder() escrows the amount for sale of an order and adds it to the next sell batch for the counter chain
+func (s *StateMachine) handleDexLimitOrder(chainId uint64, order *lib.DexLimitOrder) (err lib.ErrorI) {
 	// get the next sell batch
-	batch, err := s.GetDexBatch(msg.ChainId, false)
+	batch, err := s.GetDexBatch(chainId, false)
 	if err != nil {
 		return err
 	}
 	// ensure there's some liquidity in the pool
-	if batch.PoolSize == 0 || s.Config.ChainId == msg.ChainId {
+	if batch.PoolSize == 0 || s.Config.ChainId == chainId {
 		return ErrInvalidLiquidityPool()
 	}
 	// hard limit orders to 10K per batch to prevent unchecked state growth
 	if len(batch.Orders) >= lib.MaxOrdersPerDexBatch {
 		return ErrMaxDexBatchSize()
 	}
+	// a resting order must expire in the future
+	if order.ExpiryHeight != 0 && order.ExpiryHeight <= s.Height() {
+		return lib.ErrInvalidDexOrderExpiry()
+	}
 	// move funds from user
-	if err = s.AccountSub(crypto.NewAddress(msg.Address), msg.AmountForSale); err != nil {
+	if err = s.AccountSub(crypto.NewAddress(order.Address), order.AmountForSale); err != nil {
 		return err
 	}
 	// add funds to holding pool
-	if err = s.PoolAdd(msg.ChainId+HoldingPoolAddend, msg.AmountForSale); err != nil {
+	if err = s.PoolAdd(chainId+HoldingPoolAddend, order.AmountForSale); err != nil {
 		return err
 	}
 	// add the order to the batch
-	batch.Orders = append(batch.Orders, &lib.DexLimitOrder{
-		AmountForSale:   msg.AmountForSale,
-		RequestedAmount: msg.RequestedAmount,
-		Address:         msg.Address,
-		OrderId:         msg.OrderId,
-	})
+	batch.Orders = append(batch.Orders, order)
 	// update next sell batch
-	return s.SetDexBatch(KeyForNextBatch(msg.ChainId), batch)
+	return s.SetDexBatch(KeyForNextBatch(chainId), batch)
 }
 
 // HandleMessageDexLiquidityDeposit() is the proper handler for a `DexLiquidityDeposit` message
@@ -578,9 +612,10 @@ func (s *StateMachine) HandleMessageDexLiquidityDeposit(msg *MessageDexLiquidity
 	}
 	// add the deposit to the batch
 	batch.Deposits = append(batch.Deposits, &lib.DexLiquidityDeposit{
-		Address: msg.Address,
-		Amount:  msg.Amount,
-		OrderId: msg.OrderId,
+		Address:   msg.Address,
+		Amount:    msg.Amount,
+		OrderId:   msg.OrderId,
+		MinPoints: msg.MinPoints,
 	})
 	// update next sell batch
 	return s.SetDexBatch(KeyForNextBatch(msg.ChainId), batch)
@@ -612,14 +647,99 @@ func (s *StateMachine) HandleMessageDexLiquidityWithdraw(msg *MessageDexLiquidit
 	}
 	// add the withdrawal to the batch
 	batch.Withdrawals = append(batch.Withdrawals, &lib.DexLiquidityWithdraw{
-		Address: msg.Address,
-		Percent: msg.Percent,
-		OrderId: msg.OrderId,
+		Address:          msg.Address,
+		Percent:          msg.Percent,
+		OrderId:          msg.OrderId,
+		MinAmount:        msg.MinAmount,
+		MinCounterAmount: msg.MinCounterAmount,
 	})
 	// update next sell batch
 	return s.SetDexBatch(KeyForNextBatch(msg.ChainId), batch)
 }
 
+// HandleMessageCreateMultisigAccount() is the proper handler for a `CreateMultisigAccount` message
+func (s *StateMachine) HandleMessageCreateMultisigAccount(msg *MessageCreateMultisigAccount) lib.ErrorI {
+	// derive the multisig public key from the member keys and threshold
+	publicKey, err := NewMultisigPublicKey(msg.PublicKeys, msg.Threshold)
+	if err != nil {
+		return err
+	}
+	// the address is a function of the sorted member keys and the threshold (the member order doesn't change it)
+	address := publicKey.Address()
+	// ensure the policy isn't already registered
+	bz, err := s.Get(KeyForMultisigAccount(address))
+	if err != nil {
+		return err
+	}
+	if bz != nil {
+		return ErrMultisigAccountExists()
+	}
+	// register the multisig policy
+	return s.SetMultisigAccount(&MultisigAccount{
+		Address:    address.Bytes(),
+		PublicKeys: msg.PublicKeys,
+		Threshold:  msg.Threshold,
+	})
+}
+
+// HandleMessageSubmitProposal() is the proper handler for a `SubmitProposal` message
+func (s *StateMachine) HandleMessageSubmitProposal(msg *MessageSubmitProposal) lib.ErrorI {
+	params, err := s.GetParamsGov()
+	if err != nil {
+		return err
+	}
+	// a zero voting period disables on-chain proposals
+	if params.ProposalVotingPeriod == 0 {
+		return ErrProposalsDisabled()
+	}
+	// ensure the deposit covers the minimum
+	if msg.Deposit < params.ProposalMinDeposit {
+		return ErrDepositBelowMinimum()
+	}
+	// escrow the deposit until the proposal is tallied
+	if err = s.AccountSub(crypto.NewAddressFromBytes(msg.Proposer), msg.Deposit); err != nil {
+		return err
+	}
+	if err = s.PoolAdd(lib.GovDepositPoolID, msg.Deposit); err != nil {
+		return err
+	}
+	proposal := &Proposal{
+		Id:              msg.ProposalId,
+		Proposer:        msg.Proposer,
+		Deposit:         msg.Deposit,
+		Msg:             msg.Proposal,
+		SubmitHeight:    s.Height(),
+		VotingEndHeight: s.Height() + params.ProposalVotingPeriod,
+		Status:          ProposalStatus_PROPOSAL_VOTING,
+	}
+	if err = s.SetProposal(proposal); err != nil {
+		return err
+	}
+	// index the proposal by the height it's tallied
+	if err = s.Set(KeyForProposalEnd(proposal.VotingEndHeight, proposal.Id), proposal.Id); err != nil {
+		return err
+	}
+	return s.EventProposalSubmit(proposal)
+}
+
+// HandleMessageVote() is the proper handler for a `Vote` message
+func (s *StateMachine) HandleMessageVote(msg *MessageVote) lib.ErrorI {
+	proposal, err := s.GetProposal(msg.ProposalId)
+	if err != nil {
+		return err
+	}
+	// votes are accepted through the end block of the voting period
+	if proposal.Status != ProposalStatus_PROPOSAL_VOTING || s.Height() > proposal.VotingEndHeight {
+		return ErrProposalNotVoting()
+	}
+	// only validators and delegators may vote; the weight is applied at tally time
+	voter := crypto.NewAddressFromBytes(msg.Voter)
+	if _, err = s.GetValidator(voter); err != nil {
+		return err
+	}
+	return s.SetProposalVote(msg.ProposalId, voter, msg.Approve)
+}
+
 // GetFeeForMessageName() returns the associated cost for processing a specific type of message based on the name
 func (s *StateMachine) GetFeeForMessageName(name string) (fee uint64, err lib.ErrorI) {
 	// retrieve the fee parameters from the state
@@ -657,10 +777,18 @@ func (s *StateMachine) GetFeeForMessageName(name string) (fee uint64, err lib.Er
 		return feeParams.DeleteOrderFee, nil
 	case MessageDexLimitOrderName:
 		return feeParams.DexLimitOrderFee, nil
+	case MessageDexRoutedSwapName:
+		return feeParams.DexRoutedSwapFee, nil
 	case MessageDexLiquidityDepositName:
 		return feeParams.DexLiquidityDepositFee, nil
 	case MessageDexLiquidityWithdrawName:
 		return feeParams.DexLiquidityWithdrawFee, nil
+	case MessageCreateMultisigAccountName:
+		return feeParams.CreateMultisigAccountFee, nil
+	case MessageSubmitProposalName:
+		return feeParams.SubmitProposalFee, nil
+	case MessageVoteName:
+		return feeParams.VoteFee, nil
 	default:
 		return 0, lib.ErrUnknownMessageName(name)
 	}
@@ -714,10 +842,18 @@ func (s *StateMachine) GetAuthorizedSignersFor(msg lib.MessageI) (signers [][]by
 		return [][]byte{order.SellersSendAddress}, nil
 	case *MessageDexLimitOrder:
 		return [][]byte{x.Address}, nil
+	case *MessageDexRoutedSwap:
+		return [][]byte{x.Address}, nil
 	case *MessageDexLiquidityDeposit:
 		return [][]byte{x.Address}, nil
 	case *MessageDexLiquidityWithdraw:
 		return [][]byte{x.Address}, nil
+	case *MessageCreateMultisigAccount:
+		return [][]byte{x.Address}, nil
+	case *MessageSubmitProposal:
+		return [][]byte{x.Proposer}, nil
+	case *MessageVote:
+		return s.GetAuthorizedSignersForValidator(x.Voter)
 	default:
 		return nil, ErrUnknownMessage(x)
 	}
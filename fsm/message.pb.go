@@ -1081,7 +1081,11 @@ type MessageDexLimitOrder struct {
 	// sellers_send_address: the address the seller is selling and signing from
 	Address []byte `protobuf:"bytes,4,opt,name=address,proto3" json:"sellersSendAddress"` // @gotags: json:"sellersSendAddress"
 	// OrderId: auto-populated by the state machine to assign the unique bytes to the order
-	OrderId       []byte `protobuf:"bytes,5,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	OrderId []byte `protobuf:"bytes,5,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	// expiry_height: the height the order stops resting in the dex batches at (0 = single batch)
+	ExpiryHeight uint64 `protobuf:"varint,6,opt,name=expiry_height,json=expiryHeight,proto3" json:"expiryHeight"` // @gotags: json:"expiryHeight"
+	// partial_fill: allows the order to be filled in part at the limit price
+	PartialFill   bool `protobuf:"varint,7,opt,name=partial_fill,json=partialFill,proto3" json:"partialFill"` // @gotags: json:"partialFill"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1151,6 +1155,104 @@ func (x *MessageDexLimitOrder) GetOrderId() []byte {
 	return nil
 }
 
+func (x *MessageDexLimitOrder) GetExpiryHeight() uint64 {
+	if x != nil {
+		return x.ExpiryHeight
+	}
+	return 0
+}
+
+func (x *MessageDexLimitOrder) GetPartialFill() bool {
+	if x != nil {
+		return x.PartialFill
+	}
+	return false
+}
+
+// MessageDexRoutedSwap: swaps the local asset for the asset of another chain along a path of dex pools
+// (ex. A -> root -> B) in a single order; if a leg can't meet the minimum output the origin asset is refunded on this chain
+// (a failed forwarded leg is swapped back to the origin asset at the current price)
+type MessageDexRoutedSwap struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// path: the chains swapped through, starting with this chain and ending with the chain of the asset received
+	Path []uint64 `protobuf:"varint,1,rep,packed,name=path,proto3" json:"path"` // @gotags: json:"path"
+	// amount_for_sale: the amount of asset listed for sale, transferred to escrow
+	AmountForSale uint64 `protobuf:"varint,2,opt,name=amount_for_sale,json=amountForSale,proto3" json:"amountForSale"` // @gotags: json:"amountForSale"
+	// minimum_output: the minimum amount of the last chain's asset the seller is willing to receive
+	MinimumOutput uint64 `protobuf:"varint,3,opt,name=minimum_output,json=minimumOutput,proto3" json:"minimumOutput"` // @gotags: json:"minimumOutput"
+	// address: the address the seller is selling and signing from (and receiving to on every chain)
+	Address []byte `protobuf:"bytes,4,opt,name=address,proto3" json:"address"` // @gotags: json:"address"
+	// OrderId: auto-populated by the state machine to assign the unique bytes to the order
+	OrderId       []byte `protobuf:"bytes,5,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageDexRoutedSwap) Reset() {
+	*x = MessageDexRoutedSwap{}
+	mi := &file_message_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageDexRoutedSwap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageDexRoutedSwap) ProtoMessage() {}
+
+func (x *MessageDexRoutedSwap) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageDexRoutedSwap.ProtoReflect.Descriptor instead.
+func (*MessageDexRoutedSwap) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MessageDexRoutedSwap) GetPath() []uint64 {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *MessageDexRoutedSwap) GetAmountForSale() uint64 {
+	if x != nil {
+		return x.AmountForSale
+	}
+	return 0
+}
+
+func (x *MessageDexRoutedSwap) GetMinimumOutput() uint64 {
+	if x != nil {
+		return x.MinimumOutput
+	}
+	return 0
+}
+
+func (x *MessageDexRoutedSwap) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *MessageDexRoutedSwap) GetOrderId() []byte {
+	if x != nil {
+		return x.OrderId
+	}
+	return nil
+}
+
 // MessageDexLiquidityDeposit: deposits tokens to the liquidity pool in exchange for liquidity points
 type MessageDexLiquidityDeposit struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1161,14 +1263,17 @@ type MessageDexLiquidityDeposit struct {
 	// sellers_send_address: the address the seller is selling and signing from
 	Address []byte `protobuf:"bytes,3,opt,name=address,proto3" json:"sellersSendAddress"` // @gotags: json:"sellersSendAddress"
 	// OrderId: auto-populated by the state machine to assign the unique bytes to the order
-	OrderId       []byte `protobuf:"bytes,4,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	OrderId []byte `protobuf:"bytes,4,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	// min_points: slippage protection; the minimum liquidity points the deposit must mint when the counter chain processes
+	// the batch, otherwise the deposit is refunded (0 = no minimum)
+	MinPoints     uint64 `protobuf:"varint,5,opt,name=min_points,json=minPoints,proto3" json:"minPoints"` // @gotags: json:"minPoints"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MessageDexLiquidityDeposit) Reset() {
 	*x = MessageDexLiquidityDeposit{}
-	mi := &file_message_proto_msgTypes[14]
+	mi := &file_message_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1180,7 +1285,7 @@ func (x *MessageDexLiquidityDeposit) String() string {
 func (*MessageDexLiquidityDeposit) ProtoMessage() {}
 
 func (x *MessageDexLiquidityDeposit) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[14]
+	mi := &file_message_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1193,7 +1298,7 @@ func (x *MessageDexLiquidityDeposit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageDexLiquidityDeposit.ProtoReflect.Descriptor instead.
 func (*MessageDexLiquidityDeposit) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{14}
+	return file_message_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *MessageDexLiquidityDeposit) GetChainId() uint64 {
@@ -1224,6 +1329,13 @@ func (x *MessageDexLiquidityDeposit) GetOrderId() []byte {
 	return nil
 }
 
+func (x *MessageDexLiquidityDeposit) GetMinPoints() uint64 {
+	if x != nil {
+		return x.MinPoints
+	}
+	return 0
+}
+
 // MessageDexLiquidityWithdraw: withdraw tokens from both liquidity pools in exchange for burning liquidity points
 type MessageDexLiquidityWithdraw struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1234,14 +1346,20 @@ type MessageDexLiquidityWithdraw struct {
 	// address: the address the LP is signing from
 	Address []byte `protobuf:"bytes,3,opt,name=address,proto3" json:"address"` // @gotags: json:"address"
 	// OrderId: auto-populated by the state machine to assign the unique bytes to the order
-	OrderId       []byte `protobuf:"bytes,4,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	OrderId []byte `protobuf:"bytes,4,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	// min_amount: slippage protection; the minimum amount of this chain's asset to receive, otherwise the withdrawal is
+	// cancelled and the points are kept (0 = no minimum)
+	MinAmount uint64 `protobuf:"varint,5,opt,name=min_amount,json=minAmount,proto3" json:"minAmount"` // @gotags: json:"minAmount"
+	// min_counter_amount: slippage protection; the minimum amount of the counter asset to receive, otherwise the withdrawal
+	// is cancelled and the points are kept (0 = no minimum)
+	MinCounterAmount uint64 `protobuf:"varint,6,opt,name=min_counter_amount,json=minCounterAmount,proto3" json:"minCounterAmount"` // @gotags: json:"minCounterAmount"
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *MessageDexLiquidityWithdraw) Reset() {
 	*x = MessageDexLiquidityWithdraw{}
-	mi := &file_message_proto_msgTypes[15]
+	mi := &file_message_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1253,7 +1371,7 @@ func (x *MessageDexLiquidityWithdraw) String() string {
 func (*MessageDexLiquidityWithdraw) ProtoMessage() {}
 
 func (x *MessageDexLiquidityWithdraw) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[15]
+	mi := &file_message_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1266,7 +1384,7 @@ func (x *MessageDexLiquidityWithdraw) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageDexLiquidityWithdraw.ProtoReflect.Descriptor instead.
 func (*MessageDexLiquidityWithdraw) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{15}
+	return file_message_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *MessageDexLiquidityWithdraw) GetChainId() uint64 {
@@ -1297,11 +1415,304 @@ func (x *MessageDexLiquidityWithdraw) GetOrderId() []byte {
 	return nil
 }
 
+func (x *MessageDexLiquidityWithdraw) GetMinAmount() uint64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *MessageDexLiquidityWithdraw) GetMinCounterAmount() uint64 {
+	if x != nil {
+		return x.MinCounterAmount
+	}
+	return 0
+}
+
+// MessageCreateMultisigAccount: registers a threshold multisig account controlled by a set of BLS keys
+// The account address is derived from the member keys and the threshold, so only an aggregated signature of at least
+// 'threshold' members is able to sign for it
+type MessageCreateMultisigAccount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// address: the address creating the multisig account and paying the fee
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// public_keys: the BLS public keys of the members; the order defines the signer indices of the aggregated signature
+	PublicKeys [][]byte `protobuf:"bytes,2,rep,name=public_keys,json=publicKeys,proto3" json:"publicKeys"` // @gotags: json:"publicKeys"
+	// threshold: the minimum number of member signatures required to authorize a transaction
+	Threshold     uint32 `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageCreateMultisigAccount) Reset() {
+	*x = MessageCreateMultisigAccount{}
+	mi := &file_message_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageCreateMultisigAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageCreateMultisigAccount) ProtoMessage() {}
+
+func (x *MessageCreateMultisigAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageCreateMultisigAccount.ProtoReflect.Descriptor instead.
+func (*MessageCreateMultisigAccount) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MessageCreateMultisigAccount) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *MessageCreateMultisigAccount) GetPublicKeys() [][]byte {
+	if x != nil {
+		return x.PublicKeys
+	}
+	return nil
+}
+
+func (x *MessageCreateMultisigAccount) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+// MessageSubmitProposal: submits an on-chain governance proposal that validators and delegators vote on by stake
+// The deposit is escrowed until the voting period ends and is refunded unless the proposal fails to reach quorum
+type MessageSubmitProposal struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// proposer: the address submitting the proposal and paying the deposit
+	Proposer []byte `protobuf:"bytes,1,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	// deposit: the amount (in uCNPY) escrowed with the proposal; must be at least the proposal minimum deposit
+	Deposit uint64 `protobuf:"varint,2,opt,name=deposit,proto3" json:"deposit,omitempty"`
+	// proposal: the governance message to execute if the proposal passes (MessageChangeParameter or MessageDAOTransfer)
+	Proposal *anypb.Any `protobuf:"bytes,3,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	// proposal_id: internal use only, populated with the first 20 bytes of the transaction hash
+	ProposalId    []byte `protobuf:"bytes,4,opt,name=proposal_id,json=proposalId,proto3" json:"proposalId"` // @gotags: json:"proposalId"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageSubmitProposal) Reset() {
+	*x = MessageSubmitProposal{}
+	mi := &file_message_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageSubmitProposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageSubmitProposal) ProtoMessage() {}
+
+func (x *MessageSubmitProposal) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageSubmitProposal.ProtoReflect.Descriptor instead.
+func (*MessageSubmitProposal) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MessageSubmitProposal) GetProposer() []byte {
+	if x != nil {
+		return x.Proposer
+	}
+	return nil
+}
+
+func (x *MessageSubmitProposal) GetDeposit() uint64 {
+	if x != nil {
+		return x.Deposit
+	}
+	return 0
+}
+
+func (x *MessageSubmitProposal) GetProposal() *anypb.Any {
+	if x != nil {
+		return x.Proposal
+	}
+	return nil
+}
+
+func (x *MessageSubmitProposal) GetProposalId() []byte {
+	if x != nil {
+		return x.ProposalId
+	}
+	return nil
+}
+
+// MessageVote: casts a stake weighted vote on an open on-chain governance proposal
+// A later vote from the same voter replaces the earlier one; the weight is the voter's stake when the proposal is tallied
+type MessageVote struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// voter: the address of the validator or delegator voting
+	Voter []byte `protobuf:"bytes,1,opt,name=voter,proto3" json:"voter,omitempty"`
+	// proposal_id: the unique identifier of the proposal
+	ProposalId []byte `protobuf:"bytes,2,opt,name=proposal_id,json=proposalId,proto3" json:"proposalId"` // @gotags: json:"proposalId"
+	// approve: true to vote for the proposal, false to vote against
+	Approve       bool `protobuf:"varint,3,opt,name=approve,proto3" json:"approve,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageVote) Reset() {
+	*x = MessageVote{}
+	mi := &file_message_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageVote) ProtoMessage() {}
+
+func (x *MessageVote) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageVote.ProtoReflect.Descriptor instead.
+func (*MessageVote) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MessageVote) GetVoter() []byte {
+	if x != nil {
+		return x.Voter
+	}
+	return nil
+}
+
+func (x *MessageVote) GetProposalId() []byte {
+	if x != nil {
+		return x.ProposalId
+	}
+	return nil
+}
+
+func (x *MessageVote) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+// MessageDexPoolConfig is a governance message that sets the curve and fee tier of the liquidity pool for a counter chain
+// NOTE: it's only executable as the proposal of an on-chain governance proposal (MessageSubmitProposal)
+type MessageDexPoolConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// chain_id: the id of the counter chain the liquidity pool trades against
+	ChainId uint64 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chainId"` // @gotags: json:"chainId"
+	// curve: the invariant swaps and liquidity deposits are priced against
+	Curve lib.DexCurve `protobuf:"varint,2,opt,name=curve,proto3,enum=types.DexCurve" json:"curve,omitempty"`
+	// amplification: the stable swap amplification coefficient (stable swap only)
+	Amplification uint64 `protobuf:"varint,3,opt,name=amplification,proto3" json:"amplification,omitempty"`
+	// fee_basis_points: the taker fee tier charged on the amount sold, in 1/10000ths
+	FeeBasisPoints uint64 `protobuf:"varint,4,opt,name=fee_basis_points,json=feeBasisPoints,proto3" json:"feeBasisPoints"` // @gotags: json:"feeBasisPoints"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MessageDexPoolConfig) Reset() {
+	*x = MessageDexPoolConfig{}
+	mi := &file_message_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageDexPoolConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageDexPoolConfig) ProtoMessage() {}
+
+func (x *MessageDexPoolConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageDexPoolConfig.ProtoReflect.Descriptor instead.
+func (*MessageDexPoolConfig) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MessageDexPoolConfig) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *MessageDexPoolConfig) GetCurve() lib.DexCurve {
+	if x != nil {
+		return x.Curve
+	}
+	return lib.DexCurve(0)
+}
+
+func (x *MessageDexPoolConfig) GetAmplification() uint64 {
+	if x != nil {
+		return x.Amplification
+	}
+	return 0
+}
+
+func (x *MessageDexPoolConfig) GetFeeBasisPoints() uint64 {
+	if x != nil {
+		return x.FeeBasisPoints
+	}
+	return 0
+}
+
 var File_message_proto protoreflect.FileDescriptor
 
 const file_message_proto_rawDesc = "" +
 	"\n" +
-	"\rmessage.proto\x12\x05types\x1a\x19google/protobuf/any.proto\x1a\x11certificate.proto\"\xf9\x01\n" +
+	"\rmessage.proto\x12\x05types\x1a\x19google/protobuf/any.proto\x1a\x11certificate.proto\x1a\tdex.proto\"\xf9\x01\n" +
 	"\vMessageSend\x12!\n" +
 	"\ffrom_address\x18\x01 \x01(\fR\vfromAddress\x12\x1d\n" +
 	"\n" +
@@ -1381,23 +1792,57 @@ const file_message_proto_rawDesc = "" +
 	"\x14SellerReceiveAddress\x18\x06 \x01(\fR\x14SellerReceiveAddress\"H\n" +
 	"\x12MessageDeleteOrder\x12\x18\n" +
 	"\aOrderId\x18\x01 \x01(\fR\aOrderId\x12\x18\n" +
-	"\aChainId\x18\x02 \x01(\x04R\aChainId\"\xb8\x01\n" +
+	"\aChainId\x18\x02 \x01(\x04R\aChainId\"\x80\x02\n" +
 	"\x14MessageDexLimitOrder\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12&\n" +
 	"\x0famount_for_sale\x18\x02 \x01(\x04R\ramountForSale\x12)\n" +
 	"\x10requested_amount\x18\x03 \x01(\x04R\x0frequestedAmount\x12\x18\n" +
 	"\aaddress\x18\x04 \x01(\fR\aaddress\x12\x18\n" +
-	"\aOrderId\x18\x05 \x01(\fR\aOrderId\"\x83\x01\n" +
+	"\aOrderId\x18\x05 \x01(\fR\aOrderId\x12#\n" +
+	"\rexpiry_height\x18\x06 \x01(\x04R\fexpiryHeight\x12!\n" +
+	"\fpartial_fill\x18\a \x01(\bR\vpartialFill\"\xad\x01\n" +
+	"\x14MessageDexRoutedSwap\x12\x12\n" +
+	"\x04path\x18\x01 \x03(\x04R\x04path\x12&\n" +
+	"\x0famount_for_sale\x18\x02 \x01(\x04R\ramountForSale\x12%\n" +
+	"\x0eminimum_output\x18\x03 \x01(\x04R\rminimumOutput\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\fR\aaddress\x12\x18\n" +
+	"\aOrderId\x18\x05 \x01(\fR\aOrderId\"\xa2\x01\n" +
 	"\x1aMessageDexLiquidityDeposit\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x04R\x06amount\x12\x18\n" +
 	"\aaddress\x18\x03 \x01(\fR\aaddress\x12\x18\n" +
-	"\aOrderId\x18\x04 \x01(\fR\aOrderId\"\x86\x01\n" +
+	"\aOrderId\x18\x04 \x01(\fR\aOrderId\x12\x1d\n" +
+	"\n" +
+	"min_points\x18\x05 \x01(\x04R\tminPoints\"\xd3\x01\n" +
 	"\x1bMessageDexLiquidityWithdraw\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12\x18\n" +
 	"\apercent\x18\x02 \x01(\x04R\apercent\x12\x18\n" +
 	"\aaddress\x18\x03 \x01(\fR\aaddress\x12\x18\n" +
-	"\aOrderId\x18\x04 \x01(\fR\aOrderIdB&Z$github.com/canopy-network/canopy/fsmb\x06proto3"
+	"\aOrderId\x18\x04 \x01(\fR\aOrderId\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\x05 \x01(\x04R\tminAmount\x12,\n" +
+	"\x12min_counter_amount\x18\x06 \x01(\x04R\x10minCounterAmount\"w\n" +
+	"\x1cMessageCreateMultisigAccount\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x1f\n" +
+	"\vpublic_keys\x18\x02 \x03(\fR\n" +
+	"publicKeys\x12\x1c\n" +
+	"\tthreshold\x18\x03 \x01(\rR\tthreshold\"\xa0\x01\n" +
+	"\x15MessageSubmitProposal\x12\x1a\n" +
+	"\bproposer\x18\x01 \x01(\fR\bproposer\x12\x18\n" +
+	"\adeposit\x18\x02 \x01(\x04R\adeposit\x120\n" +
+	"\bproposal\x18\x03 \x01(\v2\x14.google.protobuf.AnyR\bproposal\x12\x1f\n" +
+	"\vproposal_id\x18\x04 \x01(\fR\n" +
+	"proposalId\"^\n" +
+	"\vMessageVote\x12\x14\n" +
+	"\x05voter\x18\x01 \x01(\fR\x05voter\x12\x1f\n" +
+	"\vproposal_id\x18\x02 \x01(\fR\n" +
+	"proposalId\x12\x18\n" +
+	"\aapprove\x18\x03 \x01(\bR\aapprove\"\xa8\x01\n" +
+	"\x14MessageDexPoolConfig\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12%\n" +
+	"\x05curve\x18\x02 \x01(\x0e2\x0f.types.DexCurveR\x05curve\x12$\n" +
+	"\ramplification\x18\x03 \x01(\x04R\ramplification\x12(\n" +
+	"\x10fee_basis_points\x18\x04 \x01(\x04R\x0efeeBasisPointsB&Z$github.com/canopy-network/canopy/fsmb\x06proto3"
 
 var (
 	file_message_proto_rawDescOnce sync.Once
@@ -1411,35 +1856,43 @@ func file_message_proto_rawDescGZIP() []byte {
 	return file_message_proto_rawDescData
 }
 
-var file_message_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_message_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_message_proto_goTypes = []any{
-	(*MessageSend)(nil),                 // 0: types.MessageSend
-	(*MessageStake)(nil),                // 1: types.MessageStake
-	(*MessageEditStake)(nil),            // 2: types.MessageEditStake
-	(*MessageUnstake)(nil),              // 3: types.MessageUnstake
-	(*MessagePause)(nil),                // 4: types.MessagePause
-	(*MessageUnpause)(nil),              // 5: types.MessageUnpause
-	(*MessageChangeParameter)(nil),      // 6: types.MessageChangeParameter
-	(*MessageDAOTransfer)(nil),          // 7: types.MessageDAOTransfer
-	(*MessageCertificateResults)(nil),   // 8: types.MessageCertificateResults
-	(*MessageSubsidy)(nil),              // 9: types.MessageSubsidy
-	(*MessageCreateOrder)(nil),          // 10: types.MessageCreateOrder
-	(*MessageEditOrder)(nil),            // 11: types.MessageEditOrder
-	(*MessageDeleteOrder)(nil),          // 12: types.MessageDeleteOrder
-	(*MessageDexLimitOrder)(nil),        // 13: types.MessageDexLimitOrder
-	(*MessageDexLiquidityDeposit)(nil),  // 14: types.MessageDexLiquidityDeposit
-	(*MessageDexLiquidityWithdraw)(nil), // 15: types.MessageDexLiquidityWithdraw
-	(*anypb.Any)(nil),                   // 16: google.protobuf.Any
-	(*lib.QuorumCertificate)(nil),       // 17: types.QuorumCertificate
+	(*MessageSend)(nil),                  // 0: types.MessageSend
+	(*MessageStake)(nil),                 // 1: types.MessageStake
+	(*MessageEditStake)(nil),             // 2: types.MessageEditStake
+	(*MessageUnstake)(nil),               // 3: types.MessageUnstake
+	(*MessagePause)(nil),                 // 4: types.MessagePause
+	(*MessageUnpause)(nil),               // 5: types.MessageUnpause
+	(*MessageChangeParameter)(nil),       // 6: types.MessageChangeParameter
+	(*MessageDAOTransfer)(nil),           // 7: types.MessageDAOTransfer
+	(*MessageCertificateResults)(nil),    // 8: types.MessageCertificateResults
+	(*MessageSubsidy)(nil),               // 9: types.MessageSubsidy
+	(*MessageCreateOrder)(nil),           // 10: types.MessageCreateOrder
+	(*MessageEditOrder)(nil),             // 11: types.MessageEditOrder
+	(*MessageDeleteOrder)(nil),           // 12: types.MessageDeleteOrder
+	(*MessageDexLimitOrder)(nil),         // 13: types.MessageDexLimitOrder
+	(*MessageDexRoutedSwap)(nil),         // 14: types.MessageDexRoutedSwap
+	(*MessageDexLiquidityDeposit)(nil),   // 15: types.MessageDexLiquidityDeposit
+	(*MessageDexLiquidityWithdraw)(nil),  // 16: types.MessageDexLiquidityWithdraw
+	(*MessageCreateMultisigAccount)(nil), // 17: types.MessageCreateMultisigAccount
+	(*MessageSubmitProposal)(nil),        // 18: types.MessageSubmitProposal
+	(*MessageVote)(nil),                  // 19: types.MessageVote
+	(*MessageDexPoolConfig)(nil),         // 20: types.MessageDexPoolConfig
+	(*anypb.Any)(nil),                    // 21: google.protobuf.Any
+	(*lib.QuorumCertificate)(nil),        // 22: types.QuorumCertificate
+	(lib.DexCurve)(0),                    // 23: types.DexCurve
 }
 var file_message_proto_depIdxs = []int32{
-	16, // 0: types.MessageChangeParameter.parameter_value:type_name -> google.protobuf.Any
-	17, // 1: types.MessageCertificateResults.qc:type_name -> types.QuorumCertificate
-	2,  // [2:2] is the sub-list for method output_type
-	2,  // [2:2] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	21, // 0: types.MessageChangeParameter.parameter_value:type_name -> google.protobuf.Any
+	22, // 1: types.MessageCertificateResults.qc:type_name -> types.QuorumCertificate
+	21, // 2: types.MessageSubmitProposal.proposal:type_name -> google.protobuf.Any
+	23, // 3: types.MessageDexPoolConfig.curve:type_name -> types.DexCurve
+	4,  // [4:4] is the sub-list for method output_type
+	4,  // [4:4] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_message_proto_init() }
@@ -1453,7 +1906,7 @@ func file_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_proto_rawDesc), len(file_message_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
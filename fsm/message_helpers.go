@@ -6,27 +6,33 @@ import (
 	"github.com/canopy-network/canopy/lib"
 	"github.com/canopy-network/canopy/lib/crypto"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 	"slices"
 )
 
 const (
 	// Names for each Transaction Message (payload) type
-	MessageSendName                 = "send"
-	MessageStakeName                = "stake"
-	MessageUnstakeName              = "unstake"
-	MessageEditStakeName            = "editStake"
-	MessagePauseName                = "pause"
-	MessageUnpauseName              = "unpause"
-	MessageChangeParameterName      = "changeParameter"
-	MessageDAOTransferName          = "daoTransfer"
-	MessageCertificateResultsName   = "certificateResults"
-	MessageSubsidyName              = "subsidy"
-	MessageCreateOrderName          = "createOrder"
-	MessageEditOrderName            = "editOrder"
-	MessageDeleteOrderName          = "deleteOrder"
-	MessageDexLimitOrderName        = "dexLimitOrder"
-	MessageDexLiquidityDepositName  = "dexLiquidityDeposit"
-	MessageDexLiquidityWithdrawName = "dexLiquidityWithdraw"
+	MessageSendName                  = "send"
+	MessageStakeName                 = "stake"
+	MessageUnstakeName               = "unstake"
+	MessageEditStakeName             = "editStake"
+	MessagePauseName                 = "pause"
+	MessageUnpauseName               = "unpause"
+	MessageChangeParameterName       = "changeParameter"
+	MessageDAOTransferName           = "daoTransfer"
+	MessageCertificateResultsName    = "certificateResults"
+	MessageSubsidyName               = "subsidy"
+	MessageCreateOrderName           = "createOrder"
+	MessageEditOrderName             = "editOrder"
+	MessageDeleteOrderName           = "deleteOrder"
+	MessageDexLimitOrderName         = "dexLimitOrder"
+	MessageDexLiquidityDepositName   = "dexLiquidityDeposit"
+	MessageDexLiquidityWithdrawName  = "dexLiquidityWithdraw"
+	MessageCreateMultisigAccountName = "createMultisigAccount"
+	MessageSubmitProposalName        = "submitProposal"
+	MessageVoteName                  = "vote"
+	MessageDexPoolConfigName         = "dexPoolConfig"
+	MessageDexRoutedSwapName         = "dexRoutedSwap"
 )
 
 func init() {
@@ -47,6 +53,11 @@ func init() {
 	lib.RegisteredMessages[MessageDexLimitOrderName] = new(MessageDexLimitOrder)
 	lib.RegisteredMessages[MessageDexLiquidityDepositName] = new(MessageDexLiquidityDeposit)
 	lib.RegisteredMessages[MessageDexLiquidityWithdrawName] = new(MessageDexLiquidityWithdraw)
+	lib.RegisteredMessages[MessageCreateMultisigAccountName] = new(MessageCreateMultisigAccount)
+	lib.RegisteredMessages[MessageSubmitProposalName] = new(MessageSubmitProposal)
+	lib.RegisteredMessages[MessageVoteName] = new(MessageVote)
+	lib.RegisteredMessages[MessageDexPoolConfigName] = new(MessageDexPoolConfig)
+	lib.RegisteredMessages[MessageDexRoutedSwapName] = new(MessageDexRoutedSwap)
 }
 
 var _ lib.MessageI = &MessageSend{} // interface enforcement
@@ -141,6 +152,7 @@ func (x *MessageStake) Check() lib.ErrorI {
 func (x *MessageStake) Name() string      { return MessageStakeName }
 func (x *MessageStake) New() lib.MessageI { return new(MessageStake) }
 func (x *MessageStake) Recipient() []byte { return nil }
+func (x *MessageStake) MempoolLane() int  { return lib.MempoolLaneValidator }
 
 // MarshalJSON() is the json.Marshaller implementation for MessageStake
 func (x MessageStake) MarshalJSON() ([]byte, error) {
@@ -205,6 +217,7 @@ func (x *MessageEditStake) Check() lib.ErrorI {
 func (x *MessageEditStake) Name() string      { return MessageEditStakeName }
 func (x *MessageEditStake) New() lib.MessageI { return new(MessageEditStake) }
 func (x *MessageEditStake) Recipient() []byte { return nil }
+func (x *MessageEditStake) MempoolLane() int  { return lib.MempoolLaneValidator }
 
 // MarshalJSON() is the json.Marshaller implementation for MessageEditStake
 func (x MessageEditStake) MarshalJSON() ([]byte, error) {
@@ -251,6 +264,7 @@ func (x *MessageUnstake) Check() lib.ErrorI { return checkAddress(x.Address) }
 func (x *MessageUnstake) Name() string      { return MessageUnstakeName }
 func (x *MessageUnstake) New() lib.MessageI { return new(MessageUnstake) }
 func (x *MessageUnstake) Recipient() []byte { return nil }
+func (x *MessageUnstake) MempoolLane() int  { return lib.MempoolLaneValidator }
 
 // MarshalJSON() is the json.Marshaller implementation for MessageUnstake
 func (x MessageUnstake) MarshalJSON() ([]byte, error) {
@@ -278,6 +292,7 @@ func (x *MessagePause) Check() lib.ErrorI { return checkAddress(x.Address) }
 func (x *MessagePause) Name() string      { return MessagePauseName }
 func (x *MessagePause) New() lib.MessageI { return new(MessagePause) }
 func (x *MessagePause) Recipient() []byte { return nil }
+func (x *MessagePause) MempoolLane() int  { return lib.MempoolLaneValidator }
 
 // MarshalJSON() is the json.Marshaller implementation for MessagePause
 func (x MessagePause) MarshalJSON() ([]byte, error) {
@@ -301,6 +316,7 @@ func (x *MessageUnpause) Check() lib.ErrorI { return checkAddress(x.Address) }
 func (x *MessageUnpause) Name() string      { return MessageUnpauseName }
 func (x *MessageUnpause) New() lib.MessageI { return new(MessageUnpause) }
 func (x *MessageUnpause) Recipient() []byte { return nil }
+func (x *MessageUnpause) MempoolLane() int  { return lib.MempoolLaneValidator }
 
 // MarshalJSON() is the json.Marshaller implementation for MessageUnpause
 func (x MessageUnpause) MarshalJSON() ([]byte, error) {
@@ -507,6 +523,7 @@ func (x *MessageCertificateResults) Check() lib.ErrorI {
 func (x *MessageCertificateResults) Name() string      { return MessageCertificateResultsName }
 func (x *MessageCertificateResults) New() lib.MessageI { return new(MessageCertificateResults) }
 func (x *MessageCertificateResults) Recipient() []byte { return nil }
+func (x *MessageCertificateResults) MempoolLane() int  { return lib.MempoolLaneCertificate }
 
 // MarshalJSON() is the json.Marshaller implementation for MessageProposal
 func (x MessageCertificateResults) MarshalJSON() ([]byte, error) {
@@ -767,6 +784,8 @@ func (x *MessageDexLimitOrder) MarshalJSON() ([]byte, error) {
 		AmountForSale:      x.AmountForSale,
 		RequestedAmount:    x.RequestedAmount,
 		SellersSendAddress: x.Address,
+		ExpiryHeight:       x.ExpiryHeight,
+		PartialFill:        x.PartialFill,
 	})
 }
 
@@ -781,6 +800,8 @@ func (x *MessageDexLimitOrder) UnmarshalJSON(b []byte) (err error) {
 		AmountForSale:   j.AmountForSale,
 		RequestedAmount: j.RequestedAmount,
 		Address:         j.SellersSendAddress,
+		ExpiryHeight:    j.ExpiryHeight,
+		PartialFill:     j.PartialFill,
 	}
 	return
 }
@@ -790,6 +811,60 @@ type jsonMessageDexLimitOrder struct {
 	AmountForSale      uint64       `json:"amountForSale"`
 	RequestedAmount    uint64       `json:"requestedAmount"`
 	SellersSendAddress lib.HexBytes `json:"sellerReceiveAddress"`
+	ExpiryHeight       uint64       `json:"expiryHeight,omitempty"`
+	PartialFill        bool         `json:"partialFill,omitempty"`
+}
+
+var _ lib.MessageI = &MessageDexRoutedSwap{} // interface enforcement
+
+func (x *MessageDexRoutedSwap) New() lib.MessageI { return new(MessageDexRoutedSwap) }
+func (x *MessageDexRoutedSwap) Name() string      { return MessageDexRoutedSwapName }
+func (x *MessageDexRoutedSwap) Recipient() []byte { return nil }
+
+// Check() validates the Message structure
+func (x *MessageDexRoutedSwap) Check() lib.ErrorI {
+	if err := checkAddress(x.Address); err != nil {
+		return err
+	}
+	if err := checkAmount(x.AmountForSale); err != nil {
+		return err
+	}
+	if err := checkAmount(x.MinimumOutput); err != nil {
+		return err
+	}
+	return checkDexPath(x.Path)
+}
+
+// MarshalJSON() is the json.Marshaller implementation for MessageDexRoutedSwap
+func (x *MessageDexRoutedSwap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMessageDexRoutedSwap{
+		Path:          x.Path,
+		AmountForSale: x.AmountForSale,
+		MinimumOutput: x.MinimumOutput,
+		Address:       x.Address,
+	})
+}
+
+// UnmarshalJSON() is the json.Unmarshaler implementation for MessageDexRoutedSwap
+func (x *MessageDexRoutedSwap) UnmarshalJSON(b []byte) (err error) {
+	var j jsonMessageDexRoutedSwap
+	if err = json.Unmarshal(b, &j); err != nil {
+		return
+	}
+	*x = MessageDexRoutedSwap{
+		Path:          j.Path,
+		AmountForSale: j.AmountForSale,
+		MinimumOutput: j.MinimumOutput,
+		Address:       j.Address,
+	}
+	return
+}
+
+type jsonMessageDexRoutedSwap struct {
+	Path          []uint64     `json:"path"`
+	AmountForSale uint64       `json:"amountForSale"`
+	MinimumOutput uint64       `json:"minimumOutput"`
+	Address       lib.HexBytes `json:"address"`
 }
 
 var _ lib.MessageI = &MessageDexLiquidityDeposit{} // interface enforcement
@@ -812,9 +887,10 @@ func (x *MessageDexLiquidityDeposit) Check() lib.ErrorI {
 // MarshalJSON() is the json.Marshaller implementation for MessageEditOrder
 func (x *MessageDexLiquidityDeposit) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jsonMessageDexLiquidityDeposit{
-		ChainId: x.ChainId,
-		Amount:  x.Amount,
-		Address: x.Address,
+		ChainId:   x.ChainId,
+		Amount:    x.Amount,
+		Address:   x.Address,
+		MinPoints: x.MinPoints,
 	})
 }
 
@@ -825,17 +901,19 @@ func (x *MessageDexLiquidityDeposit) UnmarshalJSON(b []byte) (err error) {
 		return
 	}
 	*x = MessageDexLiquidityDeposit{
-		ChainId: j.ChainId,
-		Amount:  j.Amount,
-		Address: j.Address,
+		ChainId:   j.ChainId,
+		Amount:    j.Amount,
+		Address:   j.Address,
+		MinPoints: j.MinPoints,
 	}
 	return
 }
 
 type jsonMessageDexLiquidityDeposit struct {
-	ChainId uint64       `json:"chainID"`
-	Amount  uint64       `json:"amount"`
-	Address lib.HexBytes `json:"address"`
+	ChainId   uint64       `json:"chainID"`
+	Amount    uint64       `json:"amount"`
+	Address   lib.HexBytes `json:"address"`
+	MinPoints uint64       `json:"minPoints"`
 }
 
 var _ lib.MessageI = &MessageDexLiquidityWithdraw{} // interface enforcement
@@ -858,9 +936,11 @@ func (x *MessageDexLiquidityWithdraw) Check() lib.ErrorI {
 // MarshalJSON() is the json.Marshaller implementation for MessageEditOrder
 func (x *MessageDexLiquidityWithdraw) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jsonMessageDexLiquidityWithdraw{
-		ChainId: x.ChainId,
-		Percent: x.Percent,
-		Address: x.Address,
+		ChainId:          x.ChainId,
+		Percent:          x.Percent,
+		Address:          x.Address,
+		MinAmount:        x.MinAmount,
+		MinCounterAmount: x.MinCounterAmount,
 	})
 }
 
@@ -871,17 +951,267 @@ func (x *MessageDexLiquidityWithdraw) UnmarshalJSON(b []byte) (err error) {
 		return
 	}
 	*x = MessageDexLiquidityWithdraw{
-		ChainId: j.ChainId,
-		Percent: j.Percent,
-		Address: j.Address,
+		ChainId:          j.ChainId,
+		Percent:          j.Percent,
+		Address:          j.Address,
+		MinAmount:        j.MinAmount,
+		MinCounterAmount: j.MinCounterAmount,
 	}
 	return
 }
 
 type jsonMessageDexLiquidityWithdraw struct {
-	ChainId uint64       `json:"chainID"`
-	Percent uint64       `json:"percent"`
-	Address lib.HexBytes `json:"address"`
+	ChainId          uint64       `json:"chainID"`
+	Percent          uint64       `json:"percent"`
+	Address          lib.HexBytes `json:"address"`
+	MinAmount        uint64       `json:"minAmount"`
+	MinCounterAmount uint64       `json:"minCounterAmount"`
+}
+
+var _ lib.MessageI = &MessageCreateMultisigAccount{} // interface enforcement
+
+func (x *MessageCreateMultisigAccount) New() lib.MessageI { return new(MessageCreateMultisigAccount) }
+func (x *MessageCreateMultisigAccount) Name() string      { return MessageCreateMultisigAccountName }
+func (x *MessageCreateMultisigAccount) Recipient() []byte { return nil }
+
+// Check() validates the Message structure
+func (x *MessageCreateMultisigAccount) Check() lib.ErrorI {
+	if err := checkAddress(x.Address); err != nil {
+		return err
+	}
+	return checkMultisigPolicy(x.PublicKeys, x.Threshold)
+}
+
+// MarshalJSON() is the json.Marshaller implementation for MessageCreateMultisigAccount
+func (x *MessageCreateMultisigAccount) MarshalJSON() ([]byte, error) {
+	publicKeys := make([]lib.HexBytes, 0, len(x.PublicKeys))
+	for _, pk := range x.PublicKeys {
+		publicKeys = append(publicKeys, pk)
+	}
+	return json.Marshal(jsonMessageCreateMultisigAccount{
+		Address:    x.Address,
+		PublicKeys: publicKeys,
+		Threshold:  x.Threshold,
+	})
+}
+
+// UnmarshalJSON() is the json.Unmarshaler implementation for MessageCreateMultisigAccount
+func (x *MessageCreateMultisigAccount) UnmarshalJSON(b []byte) (err error) {
+	var j jsonMessageCreateMultisigAccount
+	if err = json.Unmarshal(b, &j); err != nil {
+		return
+	}
+	*x = MessageCreateMultisigAccount{
+		Address:   j.Address,
+		Threshold: j.Threshold,
+	}
+	for _, pk := range j.PublicKeys {
+		x.PublicKeys = append(x.PublicKeys, pk)
+	}
+	return
+}
+
+type jsonMessageCreateMultisigAccount struct {
+	Address    lib.HexBytes   `json:"address"`
+	PublicKeys []lib.HexBytes `json:"publicKeys"`
+	Threshold  uint32         `json:"threshold"`
+}
+
+var _ lib.MessageI = &MessageSubmitProposal{} // interface enforcement
+
+func (x *MessageSubmitProposal) New() lib.MessageI { return new(MessageSubmitProposal) }
+func (x *MessageSubmitProposal) Name() string      { return MessageSubmitProposalName }
+func (x *MessageSubmitProposal) Recipient() []byte { return nil }
+
+// Check() validates the Message structure
+func (x *MessageSubmitProposal) Check() lib.ErrorI {
+	if err := checkAddress(x.Proposer); err != nil {
+		return err
+	}
+	if err := checkAmount(x.Deposit); err != nil {
+		return err
+	}
+	if err := ensureEmpty(x.ProposalId); err != nil {
+		return err
+	}
+	return checkGovProposal(x.Proposal)
+}
+
+// MarshalJSON() is the json.Marshaller implementation for MessageSubmitProposal
+func (x *MessageSubmitProposal) MarshalJSON() ([]byte, error) {
+	proposal, err := govProposalToJSON(x.Proposal)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonMessageSubmitProposal{
+		Proposer:   x.Proposer,
+		Deposit:    x.Deposit,
+		Proposal:   proposal,
+		ProposalId: x.ProposalId,
+	})
+}
+
+// UnmarshalJSON() is the json.Unmarshaler implementation for MessageSubmitProposal
+func (x *MessageSubmitProposal) UnmarshalJSON(b []byte) (err error) {
+	var j jsonMessageSubmitProposal
+	if err = json.Unmarshal(b, &j); err != nil {
+		return
+	}
+	proposal, err := govProposalFromJSON(j.Proposal)
+	if err != nil {
+		return
+	}
+	*x = MessageSubmitProposal{
+		Proposer:   j.Proposer,
+		Deposit:    j.Deposit,
+		Proposal:   proposal,
+		ProposalId: j.ProposalId,
+	}
+	return
+}
+
+type jsonMessageSubmitProposal struct {
+	Proposer   lib.HexBytes     `json:"proposer"`
+	Deposit    uint64           `json:"deposit"`
+	Proposal   *jsonGovProposal `json:"proposal"`
+	ProposalId lib.HexBytes     `json:"proposalId,omitempty"`
+}
+
+// jsonGovProposal is the typed json form of a governance message wrapped in an on-chain proposal
+type jsonGovProposal struct {
+	Type string          `json:"type"`
+	Msg  json.RawMessage `json:"msg"`
+}
+
+// govProposalToJSON() converts a proto any governance message into its typed json form
+func govProposalToJSON(a *anypb.Any) (*jsonGovProposal, error) {
+	if a == nil {
+		return nil, nil
+	}
+	p, err := lib.FromAny(a)
+	if err != nil {
+		return nil, err
+	}
+	msg, ok := p.(lib.MessageI)
+	if !ok {
+		return nil, ErrInvalidProposal()
+	}
+	bz, e := json.Marshal(msg)
+	if e != nil {
+		return nil, e
+	}
+	return &jsonGovProposal{Type: msg.Name(), Msg: bz}, nil
+}
+
+// govProposalFromJSON() converts a typed json governance message into a proto any
+func govProposalFromJSON(j *jsonGovProposal) (*anypb.Any, error) {
+	if j == nil {
+		return nil, nil
+	}
+	registered, found := lib.RegisteredMessages[j.Type]
+	if !found {
+		return nil, lib.ErrUnknownMessageName(j.Type)
+	}
+	msg := registered.New()
+	if err := json.Unmarshal(j.Msg, msg); err != nil {
+		return nil, err
+	}
+	return lib.NewAny(msg)
+}
+
+var _ lib.MessageI = &MessageVote{} // interface enforcement
+
+func (x *MessageVote) New() lib.MessageI { return new(MessageVote) }
+func (x *MessageVote) Name() string      { return MessageVoteName }
+func (x *MessageVote) Recipient() []byte { return nil }
+
+// Check() validates the Message structure
+func (x *MessageVote) Check() lib.ErrorI {
+	if err := checkAddress(x.Voter); err != nil {
+		return err
+	}
+	return checkProposalId(x.ProposalId)
+}
+
+// MarshalJSON() is the json.Marshaller implementation for MessageVote
+func (x *MessageVote) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMessageVote{
+		Voter:      x.Voter,
+		ProposalId: x.ProposalId,
+		Approve:    x.Approve,
+	})
+}
+
+// UnmarshalJSON() is the json.Unmarshaler implementation for MessageVote
+func (x *MessageVote) UnmarshalJSON(b []byte) (err error) {
+	var j jsonMessageVote
+	if err = json.Unmarshal(b, &j); err != nil {
+		return
+	}
+	*x = MessageVote{
+		Voter:      j.Voter,
+		ProposalId: j.ProposalId,
+		Approve:    j.Approve,
+	}
+	return
+}
+
+type jsonMessageVote struct {
+	Voter      lib.HexBytes `json:"voter"`
+	ProposalId lib.HexBytes `json:"proposalId"`
+	Approve    bool         `json:"approve"`
+}
+
+var _ lib.MessageI = &MessageDexPoolConfig{} // interface enforcement
+
+// NOTE: MessageDexPoolConfig is only executable as the proposal of a MessageSubmitProposal
+func (x *MessageDexPoolConfig) New() lib.MessageI { return new(MessageDexPoolConfig) }
+func (x *MessageDexPoolConfig) Name() string      { return MessageDexPoolConfigName }
+func (x *MessageDexPoolConfig) Recipient() []byte { return nil }
+
+// Check() validates the Message structure
+func (x *MessageDexPoolConfig) Check() lib.ErrorI {
+	if err := checkChainId(x.ChainId); err != nil {
+		return err
+	}
+	return x.PoolConfig().Check()
+}
+
+// MarshalJSON() is the json.Marshaller implementation for MessageDexPoolConfig
+func (x *MessageDexPoolConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMessageDexPoolConfig{
+		ChainId:        x.ChainId,
+		Curve:          x.Curve,
+		Amplification:  x.Amplification,
+		FeeBasisPoints: x.FeeBasisPoints,
+	})
+}
+
+// UnmarshalJSON() is the json.Unmarshaler implementation for MessageDexPoolConfig
+func (x *MessageDexPoolConfig) UnmarshalJSON(b []byte) (err error) {
+	var j jsonMessageDexPoolConfig
+	if err = json.Unmarshal(b, &j); err != nil {
+		return
+	}
+	*x = MessageDexPoolConfig{
+		ChainId:        j.ChainId,
+		Curve:          j.Curve,
+		Amplification:  j.Amplification,
+		FeeBasisPoints: j.FeeBasisPoints,
+	}
+	return
+}
+
+type jsonMessageDexPoolConfig struct {
+	ChainId        uint64       `json:"chainId"`
+	Curve          lib.DexCurve `json:"curve"`
+	Amplification  uint64       `json:"amplification"`
+	FeeBasisPoints uint64       `json:"feeBasisPoints"`
+}
+
+// PoolConfig() converts the message to the pool config it proposes
+func (x *MessageDexPoolConfig) PoolConfig() *lib.DexPoolConfig {
+	return &lib.DexPoolConfig{ChainId: x.ChainId, Curve: x.Curve, Amplification: x.Amplification, FeeBasisPoints: x.FeeBasisPoints}
 }
 
 func ensureEmpty(b []byte) lib.ErrorI {
@@ -994,6 +1324,22 @@ func checkCommittees(committees []uint64) lib.ErrorI {
 	return nil
 }
 
+// checkDexPath() validates a path of distinct chains swapped through, ex. A -> root -> B
+func checkDexPath(path []uint64) lib.ErrorI {
+	if len(path) < 2 || len(path) > 2+lib.MaxDexRouteHops {
+		return lib.ErrInvalidDexRoute()
+	}
+	for i, chainId := range path {
+		if err := checkChainId(chainId); err != nil {
+			return err
+		}
+		if slices.Contains(path[:i], chainId) {
+			return lib.ErrInvalidDexRoute()
+		}
+	}
+	return nil
+}
+
 func checkChainId(i uint64) lib.ErrorI {
 	if slices.Contains(ReservedIDs, i) {
 		return ErrInvalidChainId()
@@ -1017,16 +1363,56 @@ func checkStartEndHeight(proposal GovProposal) lib.ErrorI {
 	return nil
 }
 
+// checkGovProposal() validates the governance message wrapped in an on-chain proposal
+// NOTE: the start and end heights of the wrapped message are unused as the voting period is set by governance params
+func checkGovProposal(a *anypb.Any) lib.ErrorI {
+	if a == nil {
+		return ErrInvalidProposal()
+	}
+	msg, err := lib.FromAny(a)
+	if err != nil {
+		return err
+	}
+	switch x := msg.(type) {
+	case *MessageChangeParameter:
+		if x.ParameterKey == "" {
+			return ErrParamKeyEmpty()
+		}
+		if x.ParameterValue == nil {
+			return ErrParamValueEmpty()
+		}
+		return nil
+	case *MessageDAOTransfer:
+		if err = checkAddress(x.Address); err != nil {
+			return err
+		}
+		return checkAmount(x.Amount)
+	case *MessageDexPoolConfig:
+		return x.Check()
+	default:
+		return ErrInvalidProposal()
+	}
+}
+
+// checkProposalId() validates the identifier of an on-chain proposal
+func checkProposalId(id []byte) lib.ErrorI {
+	if len(id) != crypto.AddressSize {
+		return ErrInvalidProposal()
+	}
+	return nil
+}
+
 // checkOrders() validates the (swap) orders within the transaction
 func checkOrders(orders *lib.Orders) lib.ErrorI {
 	if orders != nil {
-		// ensure no duplicate lock orders
+		// ensure no duplicate lock orders (an order may be locked in parts, but once per buyer)
 		deDupe := lib.NewDeDuplicator[string]()
 		for _, lockOrder := range orders.LockOrders {
 			if lockOrder == nil {
 				return ErrInvalidLockOrder()
 			}
-			if found := deDupe.Found(lib.BytesToString(lockOrder.OrderId)); found {
+			lockId := &lib.OrderLockId{OrderId: lockOrder.OrderId, BuyerSendAddress: lockOrder.BuyerSendAddress}
+			if found := deDupe.Found(lockId.Key()); found {
 				return ErrDuplicateLockOrder()
 			}
 			if err := checkAddress(lockOrder.BuyerReceiveAddress); err != nil {
@@ -1050,6 +1436,20 @@ func checkOrders(orders *lib.Orders) lib.ErrorI {
 				return ErrInvalidCloseOrder()
 			}
 		}
+		// ensure no duplicate reset locks
+		deDupe = lib.NewDeDuplicator[string]()
+		for _, resetLock := range orders.ResetLocks {
+			if resetLock == nil || deDupe.Found(resetLock.Key()) {
+				return ErrInvalidCloseOrder()
+			}
+		}
+		// ensure no duplicate close locks
+		deDupe = lib.NewDeDuplicator[string]()
+		for _, closeLock := range orders.CloseLocks {
+			if closeLock == nil || deDupe.Found(closeLock.Key()) {
+				return ErrInvalidCloseOrder()
+			}
+		}
 	}
 	return nil
 }
@@ -451,6 +451,26 @@ func TestGetFeeForMessage(t *testing.T) {
 			detail: "evaluates the function for message delete order",
 			msg:    &MessageDeleteOrder{},
 		},
+		{
+			name:   "msg create multisig account",
+			detail: "evaluates the function for message create multisig account",
+			msg:    &MessageCreateMultisigAccount{},
+		},
+		{
+			name:   "msg submit proposal",
+			detail: "evaluates the function for message submit proposal",
+			msg:    &MessageSubmitProposal{},
+		},
+		{
+			name:   "msg vote",
+			detail: "evaluates the function for message vote",
+			msg:    &MessageVote{},
+		},
+		{
+			name:   "msg dex routed swap",
+			detail: "evaluates the function for message dex routed swap",
+			msg:    &MessageDexRoutedSwap{},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -488,6 +508,14 @@ func TestGetFeeForMessage(t *testing.T) {
 					return feeParams.EditOrderFee
 				case *MessageDeleteOrder:
 					return feeParams.DeleteOrderFee
+				case *MessageCreateMultisigAccount:
+					return feeParams.CreateMultisigAccountFee
+				case *MessageSubmitProposal:
+					return feeParams.SubmitProposalFee
+				case *MessageVote:
+					return feeParams.VoteFee
+				case *MessageDexRoutedSwap:
+					return feeParams.DexRoutedSwapFee
 				default:
 					panic("unknown msg")
 				}
@@ -579,6 +607,21 @@ func TestGetAuthorizedSignersFor(t *testing.T) {
 				},
 			},
 			expected: [][]byte{newTestAddressBytes(t)},
+		}, {
+			name:     "msg create multisig account",
+			detail:   "retrieves the authorized signers for message create multisig account",
+			msg:      &MessageCreateMultisigAccount{Address: newTestAddressBytes(t)},
+			expected: [][]byte{newTestAddressBytes(t)},
+		}, {
+			name:     "msg submit proposal",
+			detail:   "retrieves the authorized signers for message submit proposal",
+			msg:      &MessageSubmitProposal{Proposer: newTestAddressBytes(t)},
+			expected: [][]byte{newTestAddressBytes(t)},
+		}, {
+			name:     "msg vote",
+			detail:   "retrieves the authorized signers for message vote",
+			msg:      &MessageVote{Voter: newTestAddressBytes(t)},
+			expected: [][]byte{newTestAddressBytes(t), newTestAddressBytes(t, 1)},
 		},
 	}
 	for _, test := range tests {
@@ -2321,6 +2364,194 @@ func TestMessageSubsidy(t *testing.T) {
 	}
 }
 
+func TestHandleMessageCreateMultisigAccount(t *testing.T) {
+	// define the member keys
+	members := [][]byte{newTestPublicKeyBytes(t), newTestPublicKeyBytes(t, 1), newTestPublicKeyBytes(t, 2)}
+	tests := []struct {
+		name       string
+		detail     string
+		preset     bool
+		publicKeys [][]byte
+		threshold  uint32
+		error      string
+	}{
+		{
+			name:       "zero threshold",
+			detail:     "a threshold of zero would let any single member sign for the account",
+			publicKeys: members,
+			threshold:  0,
+			error:      "multisig threshold",
+		},
+		{
+			name:       "threshold above members",
+			detail:     "the threshold can't exceed the number of members",
+			publicKeys: members,
+			threshold:  4,
+			error:      "multisig threshold",
+		},
+		{
+			name:       "duplicate member",
+			detail:     "each member key must be unique",
+			publicKeys: [][]byte{members[0], members[0]},
+			threshold:  1,
+			error:      "multisig members",
+		},
+		{
+			name:       "already exists",
+			detail:     "the same members and threshold can't be registered twice",
+			preset:     true,
+			publicKeys: members,
+			threshold:  2,
+			error:      "multisig account already exists",
+		},
+		{
+			name:       "successful registration",
+			detail:     "the multisig policy is registered at the derived address",
+			publicKeys: members,
+			threshold:  2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// create a state machine instance with default parameters
+			sm := newTestStateMachine(t)
+			msg := &MessageCreateMultisigAccount{Address: newTestAddressBytes(t), PublicKeys: test.publicKeys, Threshold: test.threshold}
+			// preset the registration
+			if test.preset {
+				require.NoError(t, sm.HandleMessageCreateMultisigAccount(msg))
+			}
+			// execute the function
+			err := sm.HandleMessageCreateMultisigAccount(msg)
+			// validate the expected error
+			require.Equal(t, test.error != "", err != nil, err)
+			if err != nil {
+				require.ErrorContains(t, err, test.error)
+				return
+			}
+			// the address is derived from the members and threshold
+			publicKey, err := NewMultisigPublicKey(test.publicKeys, test.threshold)
+			require.NoError(t, err)
+			got, err := sm.GetMultisigAccount(publicKey.Address())
+			require.NoError(t, err)
+			// validate the registered policy preserves the member order
+			require.Equal(t, publicKey.Address().Bytes(), got.Address)
+			require.Equal(t, test.publicKeys, got.PublicKeys)
+			require.Equal(t, test.threshold, got.Threshold)
+		})
+	}
+}
+
+func TestMultisigAccountMemberOrder(t *testing.T) {
+	// create a state machine instance with default parameters
+	sm := newTestStateMachine(t)
+	// define the same members in two orders
+	members := [][]byte{newTestPublicKeyBytes(t), newTestPublicKeyBytes(t, 1), newTestPublicKeyBytes(t, 2)}
+	reordered := [][]byte{members[2], members[0], members[1]}
+	// the member order defines the signer indices, but the address is derived from the sorted keys
+	publicKey, err := NewMultisigPublicKey(members, 2)
+	require.NoError(t, err)
+	reorderedKey, err := NewMultisigPublicKey(reordered, 2)
+	require.NoError(t, err)
+	require.Equal(t, publicKey.Address().Bytes(), reorderedKey.Address().Bytes())
+	// so registering the same members in another order collides with the first registration
+	msg := &MessageCreateMultisigAccount{Address: newTestAddressBytes(t), PublicKeys: members, Threshold: 2}
+	require.NoError(t, sm.HandleMessageCreateMultisigAccount(msg))
+	msg.PublicKeys = reordered
+	require.ErrorContains(t, sm.HandleMessageCreateMultisigAccount(msg), "multisig account already exists")
+	// a different threshold is a different account
+	msg.Threshold = 3
+	require.NoError(t, sm.HandleMessageCreateMultisigAccount(msg))
+}
+
+func TestHandleMessageSubmitProposal(t *testing.T) {
+	// pre-create a dao transfer proposal
+	proposal, err := lib.NewAny(&MessageDAOTransfer{Address: newTestAddressBytes(t, 1), Amount: 100})
+	require.NoError(t, err)
+	tests := []struct {
+		name          string
+		detail        string
+		presetBalance uint64
+		votingPeriod  uint64
+		deposit       uint64
+		error         string
+	}{
+		{
+			name:          "proposals disabled",
+			detail:        "a zero voting period disables on-chain proposals",
+			presetBalance: 100,
+			votingPeriod:  0,
+			deposit:       100,
+			error:         "on-chain proposals are disabled",
+		},
+		{
+			name:          "deposit below minimum",
+			detail:        "the deposit must cover the minimum deposit param",
+			presetBalance: 100,
+			votingPeriod:  10,
+			deposit:       99,
+			error:         "proposal deposit is below the minimum",
+		},
+		{
+			name:          "insufficient funds",
+			detail:        "the proposer must be able to escrow the deposit",
+			presetBalance: 99,
+			votingPeriod:  10,
+			deposit:       100,
+			error:         "insufficient funds",
+		},
+		{
+			name:          "successful submission",
+			detail:        "the deposit is escrowed and the proposal is opened for voting",
+			presetBalance: 100,
+			votingPeriod:  10,
+			deposit:       100,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// create a state machine instance with default parameters
+			sm := newTestStateMachine(t)
+			// preset the governance params
+			require.NoError(t, sm.UpdateParam(ParamSpaceGov, ParamProposalMinDeposit, &lib.UInt64Wrapper{Value: 100}))
+			require.NoError(t, sm.UpdateParam(ParamSpaceGov, ParamProposalVotingPeriod, &lib.UInt64Wrapper{Value: test.votingPeriod}))
+			// preset the proposer balance
+			require.NoError(t, sm.AccountAdd(newTestAddress(t), test.presetBalance))
+			msg := &MessageSubmitProposal{
+				Proposer:   newTestAddressBytes(t),
+				Deposit:    test.deposit,
+				Proposal:   proposal,
+				ProposalId: newTestAddressBytes(t, 2),
+			}
+			// execute the function
+			err = sm.HandleMessageSubmitProposal(msg)
+			// validate the expected error
+			require.Equal(t, test.error != "", err != nil, err)
+			if err != nil {
+				require.ErrorContains(t, err, test.error)
+				return
+			}
+			// validate the deposit was escrowed
+			balance, err := sm.GetAccountBalance(newTestAddress(t))
+			require.NoError(t, err)
+			require.Equal(t, test.presetBalance-test.deposit, balance)
+			escrow, err := sm.GetPoolBalance(lib.GovDepositPoolID)
+			require.NoError(t, err)
+			require.Equal(t, test.deposit, escrow)
+			// validate the proposal is open for voting
+			got, err := sm.GetProposal(msg.ProposalId)
+			require.NoError(t, err)
+			require.Equal(t, ProposalStatus_PROPOSAL_VOTING, got.Status)
+			require.Equal(t, sm.Height()+test.votingPeriod, got.VotingEndHeight)
+			// validate a vote from a non staker is rejected
+			require.ErrorContains(t, sm.HandleMessageVote(&MessageVote{Voter: newTestAddressBytes(t, 1), ProposalId: msg.ProposalId}), "validator does not exist")
+			// validate the submit event was emitted
+			events := sm.events.Reset()
+			require.Len(t, events, 1)
+			require.Equal(t, string(lib.EventTypeProposalSubmit), events[0].EventType)
+		})
+	}
+}
+
 func TestMessageCreateOrder(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -163,9 +163,9 @@ func TestApplyBlock(t *testing.T) {
 				NumTxs:                1,
 				TotalTxs:              1,
 				TotalVdfIterations:    0,
-				Hash:                  []byte{0x5f, 0xe4, 0xb6, 0x5c, 0x5, 0xd3, 0x62, 0xb8, 0xac, 0xa0, 0x5a, 0x51, 0x88, 0xe3, 0xc9, 0x26, 0xb8, 0x67, 0xd8, 0xd9, 0x27, 0x6d, 0x32, 0xde, 0xdc, 0xd0, 0x70, 0xf3, 0x13, 0xcd, 0xba, 0xb4},
+				Hash:                  []byte{0x43, 0x4, 0xc1, 0xb, 0x28, 0x56, 0xe7, 0x8d, 0xeb, 0xfb, 0xf, 0x59, 0x9a, 0x4f, 0x9b, 0x2f, 0xf9, 0x2e, 0xfa, 0x50, 0xf8, 0x5c, 0xac, 0x24, 0xf7, 0x65, 0x64, 0xa0, 0x91, 0x96, 0x13, 0xec},
 				LastBlockHash:         []byte{0x26, 0x46, 0xe, 0xd3, 0x76, 0x17, 0x95, 0x7c, 0x96, 0xd9, 0xab, 0xf5, 0x94, 0xa1, 0xac, 0x86, 0x5a, 0x43, 0x11, 0x2, 0xfc, 0x38, 0x77, 0x71, 0xa8, 0xc7, 0x6d, 0xa0, 0x2e, 0x6f, 0x1, 0xe8},
-				StateRoot:             []byte{0x7, 0x9e, 0x61, 0x26, 0xe3, 0xa7, 0x92, 0xe3, 0x70, 0xf6, 0x84, 0x5b, 0xdd, 0x2e, 0x49, 0x1b, 0x6b, 0xb5, 0xa0, 0xdf, 0x7, 0x91, 0xc4, 0xa5, 0xb2, 0xa5, 0x36, 0xc7, 0x3a, 0x90, 0x20, 0x9f},
+				StateRoot:             []byte{0xaa, 0xbf, 0x0, 0x79, 0x23, 0xbb, 0xea, 0x71, 0x85, 0x59, 0x34, 0xff, 0xbe, 0xc5, 0xfe, 0xa2, 0x4d, 0xc7, 0x6a, 0x92, 0xa7, 0x3e, 0x6a, 0x9a, 0x73, 0x71, 0xd5, 0xeb, 0x6, 0x99, 0xa6, 0x53},
 				TransactionRoot:       []byte{0x7f, 0x1, 0x75, 0x98, 0x49, 0x5, 0x73, 0x43, 0xb7, 0xb7, 0xea, 0x6c, 0x55, 0x84, 0x91, 0xe7, 0x7d, 0x51, 0xf4, 0x8a, 0x3, 0x3a, 0xe6, 0x9e, 0x4, 0x6, 0x58, 0x8a, 0xfb, 0x63, 0xde, 0x25},
 				ValidatorRoot:         []byte{0x24, 0xa5, 0xf1, 0x5d, 0xdd, 0x13, 0xdd, 0x75, 0x33, 0x2a, 0xe4, 0xf6, 0x2b, 0x3f, 0xa, 0x8c, 0xdf, 0x90, 0x1d, 0x9f, 0xaa, 0xb0, 0x5d, 0xae, 0x7a, 0x47, 0xa7, 0x59, 0x98, 0x64, 0xb3, 0x7c},
 				NextValidatorRoot:     []byte{0x24, 0xa5, 0xf1, 0x5d, 0xdd, 0x13, 0xdd, 0x75, 0x33, 0x2a, 0xe4, 0xf6, 0x2b, 0x3f, 0xa, 0x8c, 0xdf, 0x90, 0x1d, 0x9f, 0xaa, 0xb0, 0x5d, 0xae, 0x7a, 0x47, 0xa7, 0x59, 0x98, 0x64, 0xb3, 0x7c},
@@ -16,6 +16,7 @@ import (
 // - 'buy' is an actor 'claiming / reserving' the sell order
 // - 'reset' is a 'claimed' order whose 'buyer' did not send the tokens to the seller before the deadline, thus the order is re-opened for sale
 // - 'close' is a 'claimed' order whose 'buyer' sent the tokens to the seller before the deadline, thus the order is 'closed' and the tokens are moved from escrow to the buyer
+// A 'buy' may reserve only a part of the order, in which case 'reset locks' and 'close locks' act on the part reserved by a single buyer
 func (s *StateMachine) HandleCommitteeSwaps(orders *lib.Orders, chainId uint64) {
 	if orders != nil {
 		// close and reset are mutually exclusive for the same order in one instruction set
@@ -46,6 +47,21 @@ func (s *StateMachine) HandleCommitteeSwaps(orders *lib.Orders, chainId uint64)
 				s.log.Warnf("ResetOrder failed (can happen due to asynchronicity): %s", err.Error())
 			}
 		}
+		// close and reset are mutually exclusive for the same partial lock in one instruction set
+		closeLockSet := make(map[string]struct{}, len(orders.CloseLocks))
+		for _, closeLock := range orders.CloseLocks {
+			closeLockSet[closeLock.Key()] = struct{}{}
+		}
+		// reset locks release the part of an order reserved by a buyer who didn't send before the deadline
+		for _, resetLock := range orders.ResetLocks {
+			if _, hasClose := closeLockSet[resetLock.Key()]; hasClose {
+				s.log.Warnf("ResetOrderLock skipped due to conflicting close instruction, id: %s", resetLock.Key())
+				continue
+			}
+			if err := s.ResetOrderLock(resetLock, chainId); err != nil {
+				s.log.Warnf("ResetOrderLock failed (can happen due to asynchronicity): %s", err.Error())
+			}
+		}
 		// close orders are a result of the committee witnessing the buyer sending the
 		// buy assets before the 'deadline height' of the 'buyer chain'
 		for _, closeOrderId := range orders.CloseOrders {
@@ -53,6 +69,19 @@ func (s *StateMachine) HandleCommitteeSwaps(orders *lib.Orders, chainId uint64)
 				s.log.Warnf("CloseOrder failed (can happen due to asynchronicity): %s", err.Error())
 			}
 		}
+		// close locks sell the part of an order reserved by a buyer who sent before the deadline
+		for _, closeLock := range orders.CloseLocks {
+			if err := s.CloseOrderLock(closeLock, chainId); err != nil {
+				s.log.Warnf("CloseOrderLock failed (can happen due to asynchronicity): %s", err.Error())
+			}
+		}
+		// external payments are the transfers witnessed outside of Canopy for the closes; recording them ensures the
+		// committee never counts the same transfer as the payment of another lock
+		for _, payment := range orders.ExternalPayments {
+			if err := s.SetExternalPayment(payment, chainId); err != nil {
+				s.log.Warnf("SetExternalPayment failed: %s", err.Error())
+			}
+		}
 	}
 	// exit
 	return
@@ -97,10 +126,17 @@ func (s *StateMachine) ParseCloseOrder(tx *lib.Transaction) (co *lib.CloseOrder,
 
 // ProcessRootChainOrderBook() processes the order book from the root-chain and cross-references blocks on this chain to determine
 // actions that warrant committee level changes to the root-chain order book like: LockOrder, ResetOrder and CloseOrder
-func (s *StateMachine) ProcessRootChainOrderBook(book *lib.OrderBook, proposalBlock *lib.BlockResult) (lockOrders []*lib.LockOrder, closedOrders, resetOrders [][]byte) {
+// (or ResetOrderLock and CloseOrderLock for the partial locks of an order)
+func (s *StateMachine) ProcessRootChainOrderBook(book *lib.OrderBook, proposalBlock *lib.BlockResult) (lockOrders []*lib.LockOrder, closedOrders, resetOrders [][]byte, closedLocks, resetLocks []*lib.OrderLockId) {
 	if book == nil || len(book.Orders) == 0 {
 		return
 	}
+	// get the validator params for the minimum partial lock size
+	valParams, err := s.GetParamsVal()
+	if err != nil {
+		s.log.Error(err.Error())
+		return
+	}
 	blocks := []*lib.BlockResult{proposalBlock}
 	// historical checking logic:
 	// don't do historical checking before block 16
@@ -123,15 +159,13 @@ func (s *StateMachine) ProcessRootChainOrderBook(book *lib.OrderBook, proposalBl
 	lockedOrders, closeOrders, coSends := s.ParseBlockForLockAndCloseOrders(blocks...)
 	// for each order in the book
 	for _, order := range book.Orders {
-		// if the order is not locked
-		if len(order.BuyerReceiveAddress) == 0 {
-			// attempt to get the lock order command from the map
-			lockOrder, found := lockedOrders[string(order.Id)]
-			if !found {
-				continue
-			}
-			// add to lock orders
-			lockOrders = append(lockOrders, lockOrder)
+		// if the whole order is not locked
+		if !order.Locked() {
+			// settle the partial locks of the order
+			closed, reset := s.processOrderLocks(order, coSends[string(order.Id)])
+			closedLocks, resetLocks = append(closedLocks, closed...), append(resetLocks, reset...)
+			// add the lock order commands that fit the unlocked amount of the order
+			lockOrders = append(lockOrders, selectLockOrders(order, lockedOrders[string(order.Id)], valParams.MinimumOrderSize)...)
 		} else {
 			// see if the 'locked' order is expired
 			if s.height > order.BuyerChainDeadline {
@@ -180,8 +214,71 @@ func (s *StateMachine) ProcessRootChainOrderBook(book *lib.OrderBook, proposalBl
 	return
 }
 
+// processOrderLocks() determines the partial locks of an order that expired (reset) or whose buyer sent the
+// requested amount to the seller (close)
+func (s *StateMachine) processOrderLocks(order *lib.SellOrder, sends []*MessageSend) (closed, reset []*lib.OrderLockId) {
+	// for each partial lock
+	for _, lock := range order.Locks {
+		id := &lib.OrderLockId{OrderId: order.Id, BuyerSendAddress: lock.BuyerSendAddress}
+		// see if the lock is expired
+		if s.height > lock.BuyerChainDeadline {
+			reset = append(reset, id)
+			continue
+		}
+		// pick the first close send candidate that pays the lock
+		for _, send := range sends {
+			// check the sent amount, payment sender and payment recipient
+			if send.Amount == lock.RequestedAmount && bytes.Equal(send.FromAddress, lock.BuyerSendAddress) &&
+				bytes.Equal(send.ToAddress, order.SellerReceiveAddress) {
+				closed = append(closed, id)
+				break
+			}
+		}
+	}
+	return
+}
+
+// selectLockOrders() selects the lock order commands (in parse order) that the order is able to accept
+func selectLockOrders(order *lib.SellOrder, candidates []*lib.LockOrder, minimumOrderSize uint64) (selected []*lib.LockOrder) {
+	available, locks := order.AvailableAmount(), len(order.Locks)
+	for _, lock := range candidates {
+		// a whole order lock is only accepted if no part of the order is reserved
+		if order.IsWholeLock(lock.Amount) {
+			if locks == 0 {
+				return append(selected, lock)
+			}
+			continue
+		}
+		// a buyer may hold a single lock per order
+		if order.GetLock(lock.BuyerSendAddress) != nil {
+			continue
+		}
+		// ensure the order is able to hold another lock
+		if locks >= lib.MaxLocksPerSellOrder {
+			return
+		}
+		// ensure the amount fits the unlocked amount of the order
+		if checkLockAmount(lock.Amount, available, minimumOrderSize) != nil {
+			continue
+		}
+		available, locks = available-lock.Amount, locks+1
+		selected = append(selected, lock)
+	}
+	return
+}
+
+// checkLockAmount() ensures a partial lock fits the unlocked amount of an order and isn't dust
+// NOTE: a partial lock below the minimum order size is only allowed if it takes all that's left
+func checkLockAmount(amount, available, minimumOrderSize uint64) lib.ErrorI {
+	if amount == 0 || amount > available || (amount < minimumOrderSize && amount != available) {
+		return lib.ErrInvalidLockAmount()
+	}
+	return nil
+}
+
 // ParseCloseOrders() parses the blocks for memo commands to execute specialized 'close order' functionality
-func (s *StateMachine) ParseBlockForLockAndCloseOrders(blocks ...*lib.BlockResult) (lockOrders map[string]*lib.LockOrder, closeOrders map[string]*lib.CloseOrder, coSends map[string][]*MessageSend) {
+// NOTE: lock orders are kept per order id in parse order (one per buyer send address) as an order may be locked in parts
+func (s *StateMachine) ParseBlockForLockAndCloseOrders(blocks ...*lib.BlockResult) (lockOrders map[string][]*lib.LockOrder, closeOrders map[string]*lib.CloseOrder, coSends map[string][]*MessageSend) {
 	// get the governance parameters from state
 	params, err := s.GetParams()
 	if err != nil {
@@ -191,7 +288,9 @@ func (s *StateMachine) ParseBlockForLockAndCloseOrders(blocks ...*lib.BlockResul
 	// calculate the minimum lock order fee
 	minFee := params.Fee.SendFee * params.Validator.LockOrderFeeMultiplier
 	// make the maps
-	lockOrders = make(map[string]*lib.LockOrder)
+	lockOrders = make(map[string][]*lib.LockOrder)
+	// track the buyers seen per order
+	lockBuyers := make(map[string]struct{})
 	closeOrders = make(map[string]*lib.CloseOrder)
 	coSends = make(map[string][]*MessageSend)
 	// for each block
@@ -216,9 +315,11 @@ func (s *StateMachine) ParseBlockForLockAndCloseOrders(blocks ...*lib.BlockResul
 			}
 			// parse the transaction for embedded 'lock orders'
 			if lockOrder, ok := s.ParseLockOrder(tx.Transaction, send.FromAddress, params.Validator.BuyDeadlineBlocks); ok {
-				// preserve first-seen order command (proposal block is parsed first)
-				if _, exists := lockOrders[string(lockOrder.OrderId)]; !exists {
-					lockOrders[string(lockOrder.OrderId)] = lockOrder
+				// preserve first-seen order command per buyer (proposal block is parsed first)
+				buyerKey := (&lib.OrderLockId{OrderId: lockOrder.OrderId, BuyerSendAddress: lockOrder.BuyerSendAddress}).Key()
+				if _, exists := lockBuyers[buyerKey]; !exists {
+					lockBuyers[buyerKey] = struct{}{}
+					lockOrders[string(lockOrder.OrderId)] = append(lockOrders[string(lockOrder.OrderId)], lockOrder)
 				}
 				// continue
 				continue
@@ -239,6 +340,7 @@ func (s *StateMachine) ParseBlockForLockAndCloseOrders(blocks ...*lib.BlockResul
 }
 
 // LockOrder() adds a recipient and a deadline height to an existing order and saves it to the state
+// NOTE: a lock of a part of the order is added to the order's partial locks instead
 func (s *StateMachine) LockOrder(lock *lib.LockOrder, chainId uint64) (err lib.ErrorI) {
 	// get the order from state
 	order, err := s.GetOrder(lock.OrderId, chainId)
@@ -249,10 +351,22 @@ func (s *StateMachine) LockOrder(lock *lib.LockOrder, chainId uint64) (err lib.E
 	if order.BuyerReceiveAddress != nil {
 		return lib.ErrOrderLocked()
 	}
+	// if locking a part of the order
+	if !order.IsWholeLock(lock.Amount) {
+		return s.lockOrderPart(order, lock, chainId)
+	}
+	// a whole order lock requires that no part of the order is reserved
+	if len(order.Locks) != 0 {
+		return lib.ErrOrderLocked()
+	}
 	// set the buyer's receive, send, and deadline height in the order
 	order.BuyerReceiveAddress = lock.BuyerReceiveAddress
 	order.BuyerSendAddress = lock.BuyerSendAddress
 	order.BuyerChainDeadline = lock.BuyerChainDeadline
+	// an external payment made before the lock can't pay for it
+	if order.LockTime, err = s.lastBlockTime(); err != nil {
+		return
+	}
 	// set the order book back in state
 	if err = s.SetOrder(order, chainId); err != nil {
 		return
@@ -261,6 +375,44 @@ func (s *StateMachine) LockOrder(lock *lib.LockOrder, chainId uint64) (err lib.E
 	return s.EventOrderBookLock(order)
 }
 
+// lockOrderPart() reserves a part of an existing order for a buyer at the order's price and saves it to the state
+func (s *StateMachine) lockOrderPart(order *lib.SellOrder, lock *lib.LockOrder, chainId uint64) (err lib.ErrorI) {
+	// ensure the buyer doesn't already hold a lock and the order is able to hold another
+	if order.GetLock(lock.BuyerSendAddress) != nil || len(order.Locks) >= lib.MaxLocksPerSellOrder {
+		return lib.ErrOrderLocked()
+	}
+	// get the validator params from state
+	valParams, err := s.GetParamsVal()
+	if err != nil {
+		return
+	}
+	// ensure the amount fits the unlocked amount of the order
+	if err = checkLockAmount(lock.Amount, order.AvailableAmount(), valParams.MinimumOrderSize); err != nil {
+		return
+	}
+	// an external payment made before the lock can't pay for it
+	lockTime, err := s.lastBlockTime()
+	if err != nil {
+		return
+	}
+	// fix the requested amount of the part at the order's price
+	partial := &lib.SellOrderLock{
+		BuyerSendAddress:    lock.BuyerSendAddress,
+		BuyerReceiveAddress: lock.BuyerReceiveAddress,
+		BuyerChainDeadline:  lock.BuyerChainDeadline,
+		Amount:              lock.Amount,
+		RequestedAmount:     order.LockRequestedAmount(lock.Amount),
+		LockTime:            lockTime,
+	}
+	order.Locks = append(order.Locks, partial)
+	// set the order back in state
+	if err = s.SetOrder(order, chainId); err != nil {
+		return
+	}
+	// emit order book lock event
+	return s.EventOrderBookLock(order.ForLock(partial))
+}
+
 // ResetOrder() removes the recipient and deadline height from an existing order and saves it to the state
 func (s *StateMachine) ResetOrder(orderId []byte, chainId uint64) (err lib.ErrorI) {
 	// get the order from state
@@ -289,36 +441,141 @@ func (s *StateMachine) CloseOrder(orderId []byte, chainId uint64) (err lib.Error
 	if order.BuyerReceiveAddress == nil {
 		return ErrInvalidLockOrder()
 	}
+	// move the tokens from escrow to the buyer
+	if err = s.releaseEscrow(order.BuyerReceiveAddress, order.AmountForSale, chainId); err != nil {
+		return
+	}
+	// add swap event
+	if err = s.EventOrderBookSwap(order); err != nil {
+		return
+	}
+	// delete the order
+	return s.DeleteOrder(orderId, chainId)
+}
+
+// ResetOrderLock() removes the partial lock of a buyer from an existing order and saves it to the state
+func (s *StateMachine) ResetOrderLock(id *lib.OrderLockId, chainId uint64) (err lib.ErrorI) {
+	// get the order from state
+	order, err := s.GetOrder(id.OrderId, chainId)
+	if err != nil {
+		return
+	}
+	// get the partial lock of the buyer
+	lock := order.GetLock(id.BuyerSendAddress)
+	if lock == nil {
+		return ErrInvalidLockOrder()
+	}
+	// emit order book reset event before resetting the lock (so we have access to order details)
+	if err = s.EventOrderBookReset(order.ForLock(lock)); err != nil {
+		return
+	}
+	// release the locked part of the order
+	order.RemoveLock(id.BuyerSendAddress)
+	// set the order back in state
+	return s.SetOrder(order, chainId)
+}
+
+// CloseOrderLock() sends the locked part of an order from escrow to the buyer and reduces the order to the remaining amounts
+// the order is deleted once nothing is left for sale
+func (s *StateMachine) CloseOrderLock(id *lib.OrderLockId, chainId uint64) (err lib.ErrorI) {
+	// get the order from state
+	order, err := s.GetOrder(id.OrderId, chainId)
+	if err != nil {
+		return
+	}
+	// get the partial lock of the buyer
+	lock := order.GetLock(id.BuyerSendAddress)
+	if lock == nil || lock.Amount > order.AmountForSale {
+		return ErrInvalidLockOrder()
+	}
+	// move the locked tokens from escrow to the buyer
+	if err = s.releaseEscrow(lock.BuyerReceiveAddress, lock.Amount, chainId); err != nil {
+		return
+	}
+	// add swap event
+	if err = s.EventOrderBookSwap(order.ForLock(lock)); err != nil {
+		return
+	}
+	// track the remaining amounts of the order
+	order.RemoveLock(id.BuyerSendAddress)
+	order.AmountForSale -= lock.Amount
+	order.FilledAmount += lock.Amount
+	// rounding up the requested amount of each lock may exhaust the requested amount before the amount for sale
+	if lock.RequestedAmount >= order.RequestedAmount {
+		order.RequestedAmount = 0
+	} else {
+		order.RequestedAmount -= lock.RequestedAmount
+	}
+	// delete the order if nothing is left for sale
+	if order.AmountForSale == 0 {
+		return s.DeleteOrder(id.OrderId, chainId)
+	}
+	// set the order back in state
+	return s.SetOrder(order, chainId)
+}
+
+// releaseEscrow() moves tokens from the escrow pool of a committee to the buyer's receive address
+func (s *StateMachine) releaseEscrow(buyerReceiveAddress []byte, amount, chainId uint64) (err lib.ErrorI) {
 	// preflight both legs so close is atomic with respect to expected validation failures
-	buyerAddress := crypto.NewAddress(order.BuyerReceiveAddress)
+	buyerAddress := crypto.NewAddress(buyerReceiveAddress)
 	buyerAccount, err := s.GetAccount(buyerAddress)
 	if err != nil {
 		return
 	}
-	if buyerAccount.Amount > math.MaxUint64-order.AmountForSale {
+	if buyerAccount.Amount > math.MaxUint64-amount {
 		return ErrInvalidAmount()
 	}
 	escrowPool, err := s.GetPool(chainId + EscrowPoolAddend)
 	if err != nil {
 		return
 	}
-	if escrowPool.Amount < order.AmountForSale {
+	if escrowPool.Amount < amount {
 		return ErrInsufficientFunds()
 	}
 	// remove the funds from the escrow pool
-	if err = s.PoolSub(chainId+EscrowPoolAddend, order.AmountForSale); err != nil {
+	if err = s.PoolSub(chainId+EscrowPoolAddend, amount); err != nil {
 		return
 	}
 	// send the funds to the recipient address
-	if err = s.AccountAdd(buyerAddress, order.AmountForSale); err != nil {
-		return
+	return s.AccountAdd(buyerAddress, amount)
+}
+
+// lastBlockTime() returns the time (unix micro) of the last block, the latest time known to the state machine
+func (s *StateMachine) lastBlockTime() (uint64, lib.ErrorI) {
+	// there's no block before genesis
+	if s.Height() <= 1 {
+		return 0, nil
 	}
-	// add swap event
-	if err = s.EventOrderBookSwap(order); err != nil {
-		return
+	return s.LoadBlockTime(s.Height() - 1)
+}
+
+// LoadBlockTime() returns the time (unix micro) of the indexed block at a height (0 if not indexed)
+func (s *StateMachine) LoadBlockTime(height uint64) (uint64, lib.ErrorI) {
+	// ensure the store is the proper type to allow indexer actions
+	store, ok := s.store.(lib.RIndexerI)
+	if !ok {
+		return 0, ErrWrongStoreType()
+	}
+	// get the header of the block from the indexer
+	block, err := store.GetBlockHeaderByHeight(height)
+	if err != nil || block == nil || block.BlockHeader == nil {
+		return 0, err
+	}
+	return block.BlockHeader.Time, nil
+}
+
+// SetExternalPayment() records an external chain payment that closed a sell order (or partial lock) of a committee
+func (s *StateMachine) SetExternalPayment(id []byte, chainId uint64) lib.ErrorI {
+	return s.Set(KeyForExternalPayment(chainId, id), externalPaymentPrefix)
+}
+
+// HasExternalPayment() returns true if the external chain payment already closed a sell order of the committee
+func (s *StateMachine) HasExternalPayment(id []byte, chainId uint64) (bool, lib.ErrorI) {
+	bz, err := s.Get(KeyForExternalPayment(chainId, id))
+	if err != nil {
+		return false, err
 	}
-	// delete the order
-	return s.DeleteOrder(orderId, chainId)
+	return len(bz) != 0, nil
 }
 
 // SetOrder() sets the sell order in state
@@ -486,6 +486,8 @@ func TestLockOrder(t *testing.T) {
 				err := sm.SetOrder(test.preset, lib.CanopyChainId)
 				require.NoError(t, err)
 			}
+			// index the last block, whose time the lock records
+			require.NoError(t, sm.store.(lib.StoreI).IndexBlock(&lib.BlockResult{BlockHeader: &lib.BlockHeader{Height: sm.Height() - 1, Time: 1_000_000}}))
 			// execute the function call
 			err := sm.LockOrder(test.order, lib.CanopyChainId)
 			// validate the expected error
@@ -500,6 +502,8 @@ func TestLockOrder(t *testing.T) {
 			// validate the update of the 'buy' fields
 			require.Equal(t, test.order.BuyerReceiveAddress, order.BuyerReceiveAddress)
 			require.Equal(t, test.order.BuyerChainDeadline, order.BuyerChainDeadline)
+			// a payment made before the last block can't pay the lock
+			require.EqualValues(t, 1_000_000, order.LockTime)
 		})
 	}
 }
@@ -854,7 +858,7 @@ func TestProcessRootChainOrderBookLockBindsBuyerSender(t *testing.T) {
 		},
 	}
 
-	lockOrders, closedOrders, resetOrders := sm.ProcessRootChainOrderBook(book, proposal)
+	lockOrders, closedOrders, resetOrders, _, _ := sm.ProcessRootChainOrderBook(book, proposal)
 	require.Len(t, lockOrders, 1)
 	require.Empty(t, closedOrders)
 	require.Empty(t, resetOrders)
@@ -924,7 +928,7 @@ func TestProcessRootChainOrderBookCloseRequiresSenderAndRecipientBinding(t *test
 				},
 			}
 
-			_, closedOrders, resetOrders := sm.ProcessRootChainOrderBook(book, proposal)
+			_, closedOrders, resetOrders, _, _ := sm.ProcessRootChainOrderBook(book, proposal)
 			require.Empty(t, resetOrders)
 			if test.expectClosed {
 				require.Len(t, closedOrders, 1)
@@ -974,7 +978,7 @@ func TestParseBlockForLockAndCloseOrdersPrefersProposalLockOverHistorical(t *tes
 	require.Len(t, lockOrders, 1)
 	require.Empty(t, closeOrders)
 	require.Empty(t, coSends)
-	got := lockOrders[string(orderID)]
+	got := lockOrders[string(orderID)][0]
 	require.Equal(t, proposalBuyer, got.BuyerSendAddress)
 	require.Equal(t, proposalReceive, got.BuyerReceiveAddress)
 }
@@ -1036,7 +1040,7 @@ func TestProcessRootChainOrderBookCloseUsesFirstValidCandidate(t *testing.T) {
 		},
 	}
 
-	_, closedOrders, resetOrders := sm.ProcessRootChainOrderBook(book, proposal)
+	_, closedOrders, resetOrders, _, _ := sm.ProcessRootChainOrderBook(book, proposal)
 	require.Empty(t, resetOrders)
 	require.Len(t, closedOrders, 1)
 	require.Equal(t, orderID, closedOrders[0])
@@ -1078,6 +1082,146 @@ func TestParseBlockForLockAndCloseOrdersPrefersProposalCloseOverHistorical(t *te
 	require.Equal(t, proposalRecipient, gotSends[0].ToAddress)
 }
 
+func TestPartialLockOrders(t *testing.T) {
+	sm := newTestStateMachine(t)
+	chainId := sm.Config.ChainId
+	orderId := newTestOrderId(t, 200)
+	buyerA, buyerB, buyerC := newTestAddressBytes(t, 1), newTestAddressBytes(t, 2), newTestAddressBytes(t, 3)
+	// preset a 3,000 for 1,000 order and its escrow
+	require.NoError(t, sm.SetOrder(&lib.SellOrder{
+		Id:                 orderId,
+		Committee:          chainId,
+		AmountForSale:      3_000_000_000,
+		RequestedAmount:    1_000_000_000,
+		SellersSendAddress: newTestAddressBytes(t, 7),
+	}, chainId))
+	require.NoError(t, sm.PoolAdd(chainId+EscrowPoolAddend, 3_000_000_000))
+	lock := func(buyer []byte, amount uint64) lib.ErrorI {
+		return sm.LockOrder(&lib.LockOrder{
+			OrderId:             orderId,
+			ChainId:             chainId,
+			BuyerSendAddress:    buyer,
+			BuyerReceiveAddress: buyer,
+			BuyerChainDeadline:  100,
+			Amount:              amount,
+		}, chainId)
+	}
+	// two buyers lock parts of the order
+	require.NoError(t, lock(buyerA, 1_000_000_000))
+	require.NoError(t, lock(buyerB, 1_500_000_000))
+	// a lock can't exceed the unlocked amount, be dust or be held twice by a buyer
+	require.ErrorContains(t, lock(buyerC, 1_000_000_000), "lock amount")
+	require.ErrorContains(t, lock(buyerC, 100_000_000), "lock amount")
+	require.ErrorContains(t, lock(buyerA, 500_000_000), "order locked")
+	// the whole order can't be locked while parts are locked
+	require.ErrorContains(t, lock(buyerC, 0), "order locked")
+	order, err := sm.GetOrder(orderId, chainId)
+	require.NoError(t, err)
+	require.Len(t, order.Locks, 2)
+	require.EqualValues(t, 500_000_000, order.AvailableAmount())
+	// the requested amount of a lock is rounded up at the order's price
+	require.EqualValues(t, 333_333_334, order.GetLock(buyerA).RequestedAmount)
+	require.EqualValues(t, 500_000_000, order.GetLock(buyerB).RequestedAmount)
+	// a partially locked order can't be edited or deleted
+	require.ErrorContains(t, sm.HandleMessageDeleteOrder(&MessageDeleteOrder{OrderId: orderId, ChainId: chainId}), "order locked")
+	// close the lock of buyer A
+	require.NoError(t, sm.CloseOrderLock(&lib.OrderLockId{OrderId: orderId, BuyerSendAddress: buyerA}, chainId))
+	balance, err := sm.GetAccountBalance(crypto.NewAddress(buyerA))
+	require.NoError(t, err)
+	require.EqualValues(t, 1_000_000_000, balance)
+	order, err = sm.GetOrder(orderId, chainId)
+	require.NoError(t, err)
+	require.EqualValues(t, 2_000_000_000, order.AmountForSale)
+	require.EqualValues(t, 666_666_666, order.RequestedAmount)
+	require.EqualValues(t, 1_000_000_000, order.FilledAmount)
+	require.Len(t, order.Locks, 1)
+	// reset the lock of buyer B
+	require.NoError(t, sm.ResetOrderLock(&lib.OrderLockId{OrderId: orderId, BuyerSendAddress: buyerB}, chainId))
+	require.ErrorContains(t, sm.CloseOrderLock(&lib.OrderLockId{OrderId: orderId, BuyerSendAddress: buyerB}, chainId), "lock order")
+	order, err = sm.GetOrder(orderId, chainId)
+	require.NoError(t, err)
+	require.Empty(t, order.Locks)
+	require.EqualValues(t, 2_000_000_000, order.AvailableAmount())
+	// buyer C closes the remainder in a single part
+	require.NoError(t, lock(buyerC, 1_000_000_000))
+	require.NoError(t, lock(buyerB, 1_000_000_000))
+	require.NoError(t, sm.CloseOrderLock(&lib.OrderLockId{OrderId: orderId, BuyerSendAddress: buyerC}, chainId))
+	require.NoError(t, sm.CloseOrderLock(&lib.OrderLockId{OrderId: orderId, BuyerSendAddress: buyerB}, chainId))
+	// the order is deleted once nothing is left for sale
+	_, err = sm.GetOrder(orderId, chainId)
+	require.ErrorContains(t, err, "not found")
+	escrow, err := sm.GetPoolBalance(chainId + EscrowPoolAddend)
+	require.NoError(t, err)
+	require.Zero(t, escrow)
+}
+
+func TestProcessRootChainOrderBookPartialLocks(t *testing.T) {
+	sm := newTestStateMachine(t)
+	chainId := sm.Config.ChainId
+	orderId := newTestOrderId(t, 201)
+	seller := newTestAddressBytes(t, 0)
+	buyerA, buyerB, buyerC, buyerD, buyerE := newTestAddressBytes(t, 1), newTestAddressBytes(t, 2),
+		newTestAddressBytes(t, 3), newTestAddressBytes(t, 4), newTestAddressBytes(t, 5)
+	// a 5,000 for 1,000 order with an active lock (A) and an expired lock (B)
+	order := &lib.SellOrder{
+		Id:                   orderId,
+		Committee:            chainId,
+		AmountForSale:        5_000_000_000,
+		RequestedAmount:      1_000_000_000,
+		SellerReceiveAddress: seller,
+		SellersSendAddress:   newTestAddressBytes(t, 6),
+		Locks: []*lib.SellOrderLock{
+			{BuyerSendAddress: buyerA, BuyerReceiveAddress: buyerA, BuyerChainDeadline: sm.Height() + 100, Amount: 1_000_000_000, RequestedAmount: 200_000_000},
+			{BuyerSendAddress: buyerB, BuyerReceiveAddress: buyerB, BuyerChainDeadline: sm.Height() - 1, Amount: 1_000_000_000, RequestedAmount: 200_000_000},
+		},
+	}
+	require.NoError(t, sm.SetOrder(order, chainId))
+	require.NoError(t, sm.PoolAdd(chainId+EscrowPoolAddend, order.AmountForSale))
+	lockMemo := func(amount uint64) string {
+		memo, err := lib.MarshalJSON(&lib.LockOrder{OrderId: orderId, ChainId: chainId, BuyerReceiveAddress: newTestAddressBytes(t, 7), Amount: amount})
+		require.NoError(t, err)
+		return string(memo)
+	}
+	closeMemo, err := lib.MarshalJSON(&lib.CloseOrder{OrderId: orderId, ChainId: chainId, CloseOrder: true})
+	require.NoError(t, err)
+	proposal := &lib.BlockResult{
+		BlockHeader: &lib.BlockHeader{Height: sm.Height()},
+		Transactions: []*lib.TxResult{
+			// buyer A pays for its lock
+			newTestSendTxResult(t, buyerA, seller, 200_000_000, 1_000_000, string(closeMemo), chainId),
+			// C fits, D exceeds what's left after C and E takes the rest (the reset of B isn't counted until applied)
+			newTestSendTxResult(t, buyerC, buyerC, 1, 1_000_000, lockMemo(2_000_000_000), chainId),
+			newTestSendTxResult(t, buyerD, buyerD, 1, 1_000_000, lockMemo(2_000_000_000), chainId),
+			newTestSendTxResult(t, buyerE, buyerE, 1, 1_000_000, lockMemo(1_000_000_000), chainId),
+		},
+	}
+	book := &lib.OrderBook{ChainId: chainId, Orders: []*lib.SellOrder{order}}
+	lockOrders, closedOrders, resetOrders, closedLocks, resetLocks := sm.ProcessRootChainOrderBook(book, proposal)
+	require.Empty(t, closedOrders)
+	require.Empty(t, resetOrders)
+	require.Len(t, lockOrders, 2)
+	require.Equal(t, buyerC, []byte(lockOrders[0].BuyerSendAddress))
+	require.Equal(t, buyerE, []byte(lockOrders[1].BuyerSendAddress))
+	require.Equal(t, []*lib.OrderLockId{{OrderId: orderId, BuyerSendAddress: buyerA}}, closedLocks)
+	require.Equal(t, []*lib.OrderLockId{{OrderId: orderId, BuyerSendAddress: buyerB}}, resetLocks)
+	// apply the committee instructions
+	orders := &lib.Orders{LockOrders: lockOrders, ResetLocks: resetLocks, CloseLocks: closedLocks}
+	require.NoError(t, orders.CheckBasic())
+	sm.HandleCommitteeSwaps(orders, chainId)
+	got, err := sm.GetOrder(orderId, chainId)
+	require.NoError(t, err)
+	require.EqualValues(t, 4_000_000_000, got.AmountForSale)
+	require.EqualValues(t, 800_000_000, got.RequestedAmount)
+	require.EqualValues(t, 1_000_000_000, got.FilledAmount)
+	require.Len(t, got.Locks, 2)
+	require.NotNil(t, got.GetLock(buyerC))
+	require.NotNil(t, got.GetLock(buyerE))
+	require.EqualValues(t, 1_000_000_000, got.AvailableAmount())
+	balance, err := sm.GetAccountBalance(crypto.NewAddress(buyerA))
+	require.NoError(t, err)
+	require.EqualValues(t, 1_000_000_000, balance)
+}
+
 func newTestSendTxResult(t *testing.T, from, to []byte, amount, fee uint64, memo string, chainID uint64) *lib.TxResult {
 	anyMsg, err := lib.NewAny(&MessageSend{
 		FromAddress: from,
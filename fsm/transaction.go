@@ -1,6 +1,7 @@
 package fsm
 
 import (
+	"bytes"
 	"github.com/canopy-network/canopy/lib"
 	"github.com/canopy-network/canopy/lib/crypto"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -82,7 +83,8 @@ func (s *StateMachine) ApplyTransaction(index uint64, transaction []byte, txHash
 			s.Metrics.ApplyTransactionStageTime.WithLabelValues("handle_message", messageType).Observe(time.Since(handleMessageStartTime).Seconds())
 		}
 	}
-	if result.tx.Memo == RLPV2Indicator {
+	// advance the nonce floor of the sender past the nonce protected transaction
+	if result.tx.IsNonceProtected() {
 		account, e := s.GetAccount(result.sender)
 		if e != nil {
 			return nil, nil, e
@@ -181,7 +183,7 @@ func (s *StateMachine) CheckTx(transaction []byte, txHash string, batchVerifier
 	if s.Metrics != nil {
 		s.Metrics.CheckTxSignatureTime.Observe(time.Since(signatureStartTime).Seconds())
 	}
-	if tx.Memo == RLPV2Indicator {
+	if tx.IsNonceProtected() {
 		account, e := s.GetAccount(sender)
 		if e != nil {
 			return nil, e
@@ -213,6 +215,161 @@ type CheckTxResult struct {
 	plugin    bool             // if the transaction is handled by the plugin
 }
 
+// SimulateTransaction() executes the transaction against a discarded 'database transaction' on top of the state machine's store
+// It's designed to be called on a TimeMachine() or Copy() of the latest state, reporting what the transaction would do without
+// persisting anything:
+// - the key-level state changes in the order they were first written
+// - the events emitted
+// - the fee charged against the minimum fee of the message type
+// - the error (code and module) the transaction would fail with
+// The transaction executes at the height of the state machine, which for the latest state is the next block (like the mempool)
+// An unsigned transaction (the signer's public key without a signature) is executed without verifying the signature,
+// while the signer's authorization is still checked against the public key
+func (s *StateMachine) SimulateTransaction(transaction []byte) (result *SimulationResult, err lib.ErrorI) {
+	txHash := crypto.HashString(transaction)
+	result = &SimulationResult{TxHash: txHash, Height: s.Height(), Changes: make([]*StateChange, 0), Events: make([]*lib.Event, 0)}
+	// verify the signature without a batch, unless the transaction is unsigned
+	var batchVerifier *crypto.BatchVerifier
+	if transaction, result.Unsigned, err = simulationSignature(transaction); err != nil {
+		result.Error = simulationError(err)
+		return result, nil
+	}
+	if result.Unsigned {
+		batchVerifier = crypto.NewBatchVerifier(true)
+	}
+	// wrap the store in a 'database transaction' that is always discarded
+	originalStore := s.Store()
+	txn, err := s.TxnWrap()
+	if err != nil {
+		return nil, err
+	}
+	recorder := newStateChangeRecorder(txn)
+	s.SetStore(recorder)
+	defer func() { txn.Discard(); s.SetStore(originalStore); s.ResetCaches(); s.events.Reset() }()
+	// validate the transaction
+	check, e := s.CheckTx(transaction, txHash, batchVerifier)
+	if e != nil {
+		result.Error = simulationError(e)
+		return
+	}
+	// calculate the fee breakdown
+	if check.msg != nil {
+		minimum, e := s.GetFeeForMessageName(check.msg.Name())
+		if e != nil {
+			return nil, e
+		}
+		result.Fee = &SimulationFee{MessageType: check.msg.Name(), Charged: check.tx.Fee, Minimum: minimum}
+	}
+	// apply the transaction; the signature was checked above so use a 'no-op' batch verifier
+	txResult, events, e := s.ApplyTransaction(0, transaction, txHash, crypto.NewBatchVerifier(true))
+	if e != nil {
+		result.Error = simulationError(e)
+		return
+	}
+	result.Result, result.Changes = txResult, recorder.changes
+	if events != nil {
+		result.Events = events
+	}
+	return
+}
+
+// simulationSignature() returns an unsigned transaction with a placeholder signature, so it passes the empty signature
+// checks and its signer can be authorized with a 'no-op' batch verifier; a signed transaction is returned as is
+// NOTE: an RLP transaction is verified from its RLP encoding, so it can only be simulated signed
+func simulationSignature(transaction []byte) (bz []byte, unsigned bool, err lib.ErrorI) {
+	tx := new(lib.Transaction)
+	if err = lib.Unmarshal(transaction, tx); err != nil {
+		return
+	}
+	if tx.Signature == nil || len(tx.Signature.PublicKey) == 0 || len(tx.Signature.Signature) != 0 {
+		return transaction, false, nil
+	}
+	if tx.Memo == RLPIndicator || tx.Memo == RLPV2Indicator {
+		return nil, false, ErrEmptySignature()
+	}
+	tx.Signature.Signature = []byte{0}
+	bz, err = lib.Marshal(tx)
+	return bz, true, err
+}
+
+// SimulationResult is the outcome of a transaction executed with SimulateTransaction()
+type SimulationResult struct {
+	TxHash   string         `json:"txHash"`             // the hash of the simulated transaction
+	Height   uint64         `json:"height"`             // the height of the block the transaction was executed in (the next block)
+	Unsigned bool           `json:"unsigned,omitempty"` // the transaction was executed without verifying its signature
+	Result   *lib.TxResult  `json:"result,omitempty"`   // the transaction result if it succeeds
+	Changes  []*StateChange `json:"changes"`            // the key-level state changes in the order they were first written
+	Events   []*lib.Event   `json:"events"`             // the events emitted
+	Fee      *SimulationFee `json:"fee,omitempty"`      // the fee breakdown (if the message is understood by the state machine)
+	Error    *lib.Error     `json:"error,omitempty"`    // the error the transaction fails with
+}
+
+// SimulationFee is the fee breakdown of a simulated transaction
+type SimulationFee struct {
+	MessageType string `json:"messageType"` // the name of the message type
+	Charged     uint64 `json:"charged"`     // the fee the transaction pays
+	Minimum     uint64 `json:"minimum"`     // the minimum fee for the message type according to the fee params
+}
+
+// StateChange is a single key-level write to the state
+type StateChange struct {
+	Key    lib.HexBytes `json:"key"`    // the state key
+	Before lib.HexBytes `json:"before"` // the value before the transaction (empty if the key didn't exist)
+	After  lib.HexBytes `json:"after"`  // the value after the transaction (empty if the key was deleted)
+}
+
+// stateChangeRecorder wraps a store, recording the key-level changes made through it
+type stateChangeRecorder struct {
+	lib.StoreI                         // the underlying store
+	changes    []*StateChange          // the changes in the order the keys were first written
+	byKey      map[string]*StateChange // the changes indexed by key
+}
+
+// newStateChangeRecorder() creates a recorder around a store
+func newStateChangeRecorder(store lib.StoreI) *stateChangeRecorder {
+	return &stateChangeRecorder{StoreI: store, byKey: make(map[string]*StateChange)}
+}
+
+// Set() records and executes a write
+func (r *stateChangeRecorder) Set(key, value []byte) lib.ErrorI {
+	if err := r.record(key, value); err != nil {
+		return err
+	}
+	return r.StoreI.Set(key, value)
+}
+
+// Delete() records and executes a delete
+func (r *stateChangeRecorder) Delete(key []byte) lib.ErrorI {
+	if err := r.record(key, nil); err != nil {
+		return err
+	}
+	return r.StoreI.Delete(key)
+}
+
+// record() saves the value of the key before its first write and the latest value after
+func (r *stateChangeRecorder) record(key, value []byte) lib.ErrorI {
+	change, found := r.byKey[string(key)]
+	if !found {
+		before, err := r.StoreI.Get(key)
+		if err != nil {
+			return err
+		}
+		change = &StateChange{Key: bytes.Clone(key), Before: bytes.Clone(before)}
+		r.byKey[string(key)] = change
+		r.changes = append(r.changes, change)
+	}
+	change.After = bytes.Clone(value)
+	return nil
+}
+
+// simulationError() converts an error to its serializable form
+func simulationError(err lib.ErrorI) *lib.Error {
+	if e, ok := err.(*lib.Error); ok {
+		return e
+	}
+	return lib.NewError(err.Code(), err.Module(), err.Error())
+}
+
 // CheckSignature() validates the signer and the digital signature associated with the transaction object
 func (s *StateMachine) CheckSignature(tx *lib.Transaction, authorizedSigners [][]byte, batchSigVerifier *crypto.BatchVerifier) (crypto.AddressI, lib.ErrorI) {
 	// validate the actual signature bytes
@@ -229,6 +386,12 @@ func (s *StateMachine) CheckSignature(tx *lib.Transaction, authorizedSigners [][
 	if e != nil {
 		return nil, ErrInvalidPublicKey(e)
 	}
+	// multisig keys may only sign for registered multisig accounts
+	if multiKey, isMultisig := publicKey.(*crypto.BLS12381MultiPublicKey); isMultisig {
+		if err = s.checkMultisigSigner(multiKey); err != nil {
+			return nil, err
+		}
+	}
 	// Legacy "RLP" was historically an ordinary memo for non-Ethereum keys.
 	// RLP.V2 is reserved and always requires an Ethereum key.
 	_, hasEthPubKey := publicKey.(*crypto.ETHSECP256K1PublicKey)
@@ -266,12 +429,26 @@ func (s *StateMachine) CheckSignature(tx *lib.Transaction, authorizedSigners [][
 	return nil, ErrUnauthorizedTx()
 }
 
+// checkMultisigSigner() ensures a BLS multi public key is the policy of a registered multisig account
+// NOTE: the multisig address commits to the member keys and the threshold, so a registered address pins both
+func (s *StateMachine) checkMultisigSigner(publicKey *crypto.BLS12381MultiPublicKey) lib.ErrorI {
+	// threshold 0 keys would be an 'open' account that any single member may sign for
+	if publicKey.Threshold() == 0 {
+		return ErrInvalidMultisigThreshold()
+	}
+	_, err := s.GetMultisigAccount(publicKey.Address())
+	return err
+}
+
 // CheckReplay() validates the timestamp of the transaction
-// Instead of using an increasing 'sequence number' Canopy uses timestamp + created block to act as a prune-friendly, replay attack / hash collision prevention mechanism
+// By default, instead of using an increasing 'sequence number' Canopy uses timestamp + created block to act as a prune-friendly, replay attack / hash collision prevention mechanism
 //   - Canopy searches the transaction indexer for the transaction using its hash to prevent 'replay attacks'
 //   - The timestamp protects against hash collisions as it injects 'micro-second level entropy'
 //     into the hash of the transaction, ensuring no transactions will 'accidentally collide'
 //   - The created block acceptance policy for transactions maintains an acceptable bound of 'time' to support database pruning
+//
+// Nonce protected transactions (RLP.V2 and native transactions signing a non-zero nonce) opt out of both in favor of the
+// sender's account nonce floor, which CheckTx() enforces once the signer is known
 func (s *StateMachine) CheckReplay(tx *lib.Transaction, txHash string) lib.ErrorI {
 	// ensure the right network
 	if uint64(s.NetworkID) != tx.NetworkId {
@@ -285,6 +462,11 @@ func (s *StateMachine) CheckReplay(tx *lib.Transaction, txHash string) lib.Error
 	if s.Height() < 2 {
 		return nil
 	}
+	// nonce protected transactions use the account nonce for replay protection, exempting them from the tx indexer
+	// lookup and the created height acceptance range
+	if tx.IsNonceProtected() {
+		return nil
+	}
 	// if checking the transaction hash
 	if txHash != "" {
 		// ensure the store can 'read the indexer'
@@ -329,10 +511,6 @@ func (s *StateMachine) CheckReplay(tx *lib.Transaction, txHash string) lib.Error
 			}
 		}
 	}
-	// RLP.V2 uses the account nonce for replay protection and a canonical CreatedHeight sentinel.
-	if tx.Memo == RLPV2Indicator {
-		return nil
-	}
 	// this gives the protocol a theoretically safe tx indexer prune height
 	maxHeight, minHeight := s.Height()+BlockAcceptanceRange, uint64(0)
 	// if height is after the BlockAcceptanceRange blocks
@@ -416,6 +594,10 @@ func (s *StateMachine) PopulateSpecialMessageFields(tx lib.TransactionI, signer
 			// populate the order id
 			hash, _ := tx.GetHash()
 			x.OrderId = hash[:20] // first 20 bytes of the transaction hash
+		case *MessageDexRoutedSwap:
+			// populate the order id
+			hash, _ := tx.GetHash()
+			x.OrderId = hash[:20] // first 20 bytes of the transaction hash
 		case *MessageDexLiquidityDeposit:
 			// populate the order id
 			hash, _ := tx.GetHash()
@@ -424,6 +606,10 @@ func (s *StateMachine) PopulateSpecialMessageFields(tx lib.TransactionI, signer
 			// populate the order id
 			hash, _ := tx.GetHash()
 			x.OrderId = hash[:20] // first 20 bytes of the transaction hash
+		case *MessageSubmitProposal:
+			// populate the proposal id
+			hash, _ := tx.GetHash()
+			x.ProposalId = hash[:20] // first 20 bytes of the transaction hash
 		}
 	}
 }
@@ -588,30 +774,78 @@ func NewDeleteOrderTx(from crypto.PrivateKeyI, orderId string, committeeId uint6
 }
 
 // NewDexLimitOrder() creates a DexLimitOrder object in the interface form of TransactionI
-func NewDexLimitOrder(from crypto.PrivateKeyI, amountForSale, requestedAmount, committeeId, networkId, chainId, fee, height uint64, memo string) (lib.TransactionI, lib.ErrorI) {
+// an expiry height rests the order in the dex batches until then, partial fill allows it to be filled in part
+func NewDexLimitOrder(from crypto.PrivateKeyI, amountForSale, requestedAmount, committeeId, expiryHeight uint64, partialFill bool, networkId, chainId, fee, height uint64, memo string) (lib.TransactionI, lib.ErrorI) {
 	return NewTransaction(from, &MessageDexLimitOrder{
 		ChainId:         committeeId,
 		AmountForSale:   amountForSale,
 		RequestedAmount: requestedAmount,
 		Address:         from.PublicKey().Address().Bytes(),
+		ExpiryHeight:    expiryHeight,
+		PartialFill:     partialFill,
+	}, networkId, chainId, fee, height, memo)
+}
+
+// NewDexRoutedSwap() creates a DexRoutedSwap object in the interface form of TransactionI
+// the path starts with this chain, ex. [A, root, B] swaps the asset of A for the asset of B through the root chain
+func NewDexRoutedSwap(from crypto.PrivateKeyI, amountForSale, minimumOutput uint64, path []uint64, networkId, chainId, fee, height uint64, memo string) (lib.TransactionI, lib.ErrorI) {
+	return NewTransaction(from, &MessageDexRoutedSwap{
+		Path:          path,
+		AmountForSale: amountForSale,
+		MinimumOutput: minimumOutput,
+		Address:       from.PublicKey().Address().Bytes(),
 	}, networkId, chainId, fee, height, memo)
 }
 
 // NewDexLiquidityDeposit() creates a DexLiquidityDeposit object in the interface form of TransactionI
-func NewDexLiquidityDeposit(from crypto.PrivateKeyI, amount, committeeId, networkId, chainId, fee, height uint64, memo string) (lib.TransactionI, lib.ErrorI) {
+func NewDexLiquidityDeposit(from crypto.PrivateKeyI, amount, minPoints, committeeId, networkId, chainId, fee, height uint64, memo string) (lib.TransactionI, lib.ErrorI) {
 	return NewTransaction(from, &MessageDexLiquidityDeposit{
-		ChainId: committeeId,
-		Amount:  amount,
-		Address: from.PublicKey().Address().Bytes(),
+		ChainId:   committeeId,
+		Amount:    amount,
+		Address:   from.PublicKey().Address().Bytes(),
+		MinPoints: minPoints,
 	}, networkId, chainId, fee, height, memo)
 }
 
 // NewDexLiquidityWithdraw() creates a DexLiquidityWithdrawal object in the interface form of TransactionI
-func NewDexLiquidityWithdraw(from crypto.PrivateKeyI, percent uint64, committeeId, networkId, chainId, fee, height uint64, memo string) (lib.TransactionI, lib.ErrorI) {
+func NewDexLiquidityWithdraw(from crypto.PrivateKeyI, percent, minAmount, minCounterAmount uint64, committeeId, networkId, chainId, fee, height uint64, memo string) (lib.TransactionI, lib.ErrorI) {
 	return NewTransaction(from, &MessageDexLiquidityWithdraw{
-		ChainId: committeeId,
-		Percent: percent,
-		Address: from.PublicKey().Address().Bytes(),
+		ChainId:          committeeId,
+		Percent:          percent,
+		Address:          from.PublicKey().Address().Bytes(),
+		MinAmount:        minAmount,
+		MinCounterAmount: minCounterAmount,
+	}, networkId, chainId, fee, height, memo)
+}
+
+// NewCreateMultisigAccountTx() creates a CreateMultisigAccount transaction that registers a threshold multisig policy
+func NewCreateMultisigAccountTx(from crypto.PrivateKeyI, publicKeys [][]byte, threshold uint32, networkId, chainId, fee, height uint64, memo string) (lib.TransactionI, lib.ErrorI) {
+	return NewTransaction(from, &MessageCreateMultisigAccount{
+		Address:    from.PublicKey().Address().Bytes(),
+		PublicKeys: publicKeys,
+		Threshold:  threshold,
+	}, networkId, chainId, fee, height, memo)
+}
+
+// NewSubmitProposalTx() creates a SubmitProposalTransaction object in the interface form of TransactionI
+func NewSubmitProposalTx(from crypto.PrivateKeyI, proposal lib.MessageI, deposit, networkId, chainId, fee, height uint64, memo string) (lib.TransactionI, lib.ErrorI) {
+	a, err := lib.NewAny(proposal)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransaction(from, &MessageSubmitProposal{
+		Proposer: from.PublicKey().Address().Bytes(),
+		Deposit:  deposit,
+		Proposal: a,
+	}, networkId, chainId, fee, height, memo)
+}
+
+// NewVoteTx() creates a VoteTransaction object in the interface form of TransactionI
+func NewVoteTx(from crypto.PrivateKeyI, voter crypto.AddressI, proposalId []byte, approve bool, networkId, chainId, fee, height uint64, memo string) (lib.TransactionI, lib.ErrorI) {
+	return NewTransaction(from, &MessageVote{
+		Voter:      voter.Bytes(),
+		ProposalId: proposalId,
+		Approve:    approve,
 	}, networkId, chainId, fee, height, memo)
 }
 
@@ -635,11 +869,21 @@ func NewCloseOrderTx(from crypto.PrivateKeyI, order lib.CloseOrder, buyersReceiv
 
 // NewTransaction() creates a Transaction object from a message in the interface form of TransactionI
 func NewTransaction(pk crypto.PrivateKeyI, msg lib.MessageI, networkId, chainId, fee, height uint64, memo string) (lib.TransactionI, lib.ErrorI) {
+	tx, err := NewUnsignedTransaction(msg, networkId, chainId, fee, height, memo)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.Sign(pk)
+}
+
+// NewUnsignedTransaction() creates a Transaction object from a message without signing it
+// This is used when the signature is produced elsewhere, like the aggregated signature of a multisig account
+func NewUnsignedTransaction(msg lib.MessageI, networkId, chainId, fee, height uint64, memo string) (*lib.Transaction, lib.ErrorI) {
 	a, err := lib.NewAny(msg)
 	if err != nil {
 		return nil, err
 	}
-	tx := &lib.Transaction{
+	return &lib.Transaction{
 		MessageType:   msg.Name(),
 		Msg:           a,
 		Signature:     nil,
@@ -649,6 +893,5 @@ func NewTransaction(pk crypto.PrivateKeyI, msg lib.MessageI, networkId, chainId,
 		Memo:          memo,
 		NetworkId:     networkId,
 		ChainId:       chainId,
-	}
-	return tx, tx.Sign(pk)
+	}, nil
 }
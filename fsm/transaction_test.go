@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	ethCrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"math"
 	"math/big"
@@ -216,6 +217,116 @@ func TestApplyTransactionVestingSend(t *testing.T) {
 	require.Zero(t, senderBal)
 }
 
+func TestSimulateTransaction(t *testing.T) {
+	const amount, fee = uint64(100), uint64(2)
+	kg := newTestKeyGroup(t)
+	recipient := newTestAddress(t, 1)
+	sendTx, e := NewSendTransaction(kg.PrivateKey, recipient, 50, 1, 1, fee, 1, "")
+	require.NoError(t, e)
+	// unsign() strips the signature of the send transaction, leaving the public key of the signer
+	unsign := func(publicKey []byte) lib.TransactionI {
+		tx := proto.Clone(sendTx).(*lib.Transaction)
+		tx.Signature = &lib.Signature{PublicKey: publicKey}
+		return tx
+	}
+	tests := []struct {
+		name         string
+		detail       string
+		presetSender uint64
+		tx           lib.TransactionI
+		expected     *Account
+		error        string
+		code         lib.ErrorCode
+	}{
+		{
+			name:         "insufficient funds",
+			detail:       "the error is returned with its code and module and no changes are reported",
+			presetSender: 10,
+			tx:           sendTx,
+			error:        "insufficient funds",
+			code:         ErrInsufficientFunds().Code(),
+		},
+		{
+			name:         "valid send",
+			detail:       "the key-level changes, events and fee are returned without modifying the state",
+			presetSender: amount,
+			tx:           sendTx,
+			expected:     &Account{Address: kg.Address.Bytes(), Amount: amount - 50 - fee},
+		},
+		{
+			name:         "unsigned send",
+			detail:       "an unsigned transaction executes without verifying the signature",
+			presetSender: amount,
+			tx:           unsign(kg.PublicKey.Bytes()),
+			expected:     &Account{Address: kg.Address.Bytes(), Amount: amount - 50 - fee},
+		},
+		{
+			name:         "unsigned send by an unauthorized signer",
+			detail:       "an unsigned transaction still needs the public key of an authorized signer",
+			presetSender: amount,
+			tx:           unsign(newTestPublicKey(t, 1).Bytes()),
+			error:        "unauthorized tx",
+			code:         ErrUnauthorizedTx().Code(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sm := newTestStateMachine(t)
+			s := sm.store.(lib.StoreI)
+			require.NoError(t, sm.UpdateParam("fee", ParamSendFee, &lib.UInt64Wrapper{Value: 1}))
+			require.NoError(t, sm.AccountAdd(kg.Address, test.presetSender))
+			require.NoError(t, s.IndexBlock(&lib.BlockResult{
+				BlockHeader: &lib.BlockHeader{Height: 1, Hash: crypto.Hash([]byte("block_hash")), Time: uint64(time.Now().UnixMicro())},
+			}))
+			tx, err := lib.Marshal(test.tx)
+			require.NoError(t, err)
+			// execute the function call
+			got, err := sm.SimulateTransaction(tx)
+			require.NoError(t, err)
+			require.Equal(t, crypto.HashString(tx), got.TxHash)
+			require.Equal(t, test.tx.GetSig().GetSignature() == nil, got.Unsigned)
+			// the transaction executes at the height of the next block
+			require.Equal(t, sm.Height(), got.Height)
+			// the state is never modified
+			sender, err := sm.GetAccount(kg.Address)
+			require.NoError(t, err)
+			require.Equal(t, test.presetSender, sender.Amount)
+			received, err := sm.GetAccount(recipient)
+			require.NoError(t, err)
+			require.Zero(t, received.Amount)
+			if test.error != "" {
+				require.NotNil(t, got.Error)
+				require.ErrorContains(t, got.Error, test.error)
+				require.Equal(t, test.code, got.Error.Code())
+				require.Equal(t, lib.StateMachineModule, got.Error.Module())
+				require.Nil(t, got.Result)
+				require.Empty(t, got.Changes)
+				return
+			}
+			require.Nil(t, got.Error)
+			require.Equal(t, &SimulationFee{MessageType: MessageSendName, Charged: fee, Minimum: 1}, got.Fee)
+			require.Equal(t, MessageSendName, got.Result.MessageType)
+			// the sender and recipient accounts are reported before and after the transaction
+			changes := make(map[string]*StateChange)
+			for _, change := range got.Changes {
+				changes[change.Key.String()] = change
+			}
+			senderChange := changes[lib.BytesToString(KeyForAccount(kg.Address))]
+			require.NotNil(t, senderChange)
+			before, after := new(Account), new(Account)
+			require.NoError(t, lib.Unmarshal(senderChange.Before, before))
+			require.NoError(t, lib.Unmarshal(senderChange.After, after))
+			require.Equal(t, test.presetSender, before.Amount)
+			require.EqualExportedValues(t, test.expected, after)
+			recipientChange := changes[lib.BytesToString(KeyForAccount(recipient))]
+			require.NotNil(t, recipientChange)
+			require.Empty(t, recipientChange.Before)
+			require.NoError(t, lib.Unmarshal(recipientChange.After, after))
+			require.Equal(t, uint64(50), after.Amount)
+		})
+	}
+}
+
 func TestCheckTx(t *testing.T) {
 	const amount = uint64(100)
 	// predefine a keygroup for signing the transaction
@@ -346,11 +457,11 @@ func TestCheckTxAcceptsSerializedMultiBLSSigner(t *testing.T) {
 	require.NoError(t, sm.UpdateParam("fee", ParamSendFee, &lib.UInt64Wrapper{Value: 1}))
 
 	signers := newTestKeyGroups(t, 3)
-	points := make([]kyber.Point, 0, len(signers))
+	points, publicKeys := make([]kyber.Point, 0, len(signers)), make([][]byte, 0, len(signers))
 	for _, kg := range signers {
 		point, err := crypto.BytesToBLS12381Point(kg.PublicKey.Bytes())
 		require.NoError(t, err)
-		points = append(points, point)
+		points, publicKeys = append(points, point), append(publicKeys, kg.PublicKey.Bytes())
 	}
 
 	multiKey, err := crypto.NewAccountAuthMultiBLSFromPoints(points, nil, 2)
@@ -388,6 +499,15 @@ func TestCheckTxAcceptsSerializedMultiBLSSigner(t *testing.T) {
 	txBytes, err := lib.Marshal(tx)
 	require.NoError(t, err)
 
+	// multisig keys can't sign until the account is registered
+	_, err = sm.CheckTx(txBytes, crypto.HashString(txBytes), nil)
+	require.ErrorContains(t, err, "multisig account not found")
+	require.NoError(t, sm.HandleMessageCreateMultisigAccount(&MessageCreateMultisigAccount{
+		Address:    newTestAddressBytes(t),
+		PublicKeys: publicKeys,
+		Threshold:  2,
+	}))
+
 	got, err := sm.CheckTx(txBytes, crypto.HashString(txBytes), nil)
 	require.NoError(t, err)
 	require.EqualExportedValues(t, tx, got.tx)
@@ -738,6 +858,41 @@ func TestAccountNonceReplayProtection(t *testing.T) {
 	require.ErrorContains(t, errI, "invalid tx nonce")
 }
 
+func TestNativeAccountNonceReplayProtection(t *testing.T) {
+	const fee = uint64(2)
+	kg := newTestKeyGroup(t)
+	sm := newTestStateMachine(t)
+	// the created height of the transactions is outside the acceptance range
+	sm.height = BlockAcceptanceRange + 10
+	require.NoError(t, sm.UpdateParam("fee", ParamSendFee, &lib.UInt64Wrapper{Value: 1}))
+	require.NoError(t, sm.AccountAdd(kg.Address, 1_000))
+	newTx := func(nonce uint64) []byte {
+		tx, err := NewSendTransaction(kg.PrivateKey, newTestAddress(t, 1), 50, 1, 1, fee, 1, "")
+		require.NoError(t, err)
+		tx.(*lib.Transaction).Nonce = nonce
+		require.NoError(t, tx.(*lib.Transaction).Sign(kg.PrivateKey))
+		bz, err := lib.Marshal(tx)
+		require.NoError(t, err)
+		return bz
+	}
+	// without a nonce the created height applies
+	legacy := newTx(0)
+	_, errI := sm.CheckTx(legacy, crypto.HashString(legacy), nil)
+	require.ErrorContains(t, errI, "invalid tx height")
+	// with a nonce the account nonce floor applies instead
+	sequenced := newTx(3)
+	_, _, errI = sm.ApplyTransaction(0, sequenced, crypto.HashString(sequenced), nil)
+	require.NoError(t, errI)
+	account, errI := sm.GetAccount(kg.Address)
+	require.NoError(t, errI)
+	require.EqualValues(t, 4, account.Nonce)
+	// both a replay of the applied nonce (3) and an unused lower nonce (2) are below the floor (4) and rejected
+	for _, tx := range [][]byte{sequenced, newTx(2)} {
+		_, errI = sm.CheckTx(tx, crypto.HashString(tx), nil)
+		require.Equal(t, lib.CodeInvalidTxNonce, errI.Code())
+	}
+}
+
 func TestFailedRLPV2TransactionDoesNotAdvanceNonce(t *testing.T) {
 	sm := newTestStateMachine(t)
 	tx, errI := RLPToCanopyTransactionV2(newTestRawEthereumTxWithNonce(t, 2))
@@ -788,12 +943,17 @@ func TestFeeOrderedSameNonceReplacementExecutesOnlyWinner(t *testing.T) {
 	changed, errI := mempool.AddTransactions(lowBytes, highBytes)
 	require.NoError(t, errI)
 	require.True(t, changed)
+	// the higher fee replaces the pending transaction with the same nonce
 	ordered := mempool.GetTransactions(math.MaxUint64)
-	require.Len(t, ordered, 2)
-	require.Equal(t, highBytes, ordered[0])
+	require.Equal(t, [][]byte{highBytes}, ordered)
+	// a lower fee can't replace it back
+	_, errI = mempool.AddTransactions(lowBytes)
+	require.Equal(t, lib.CodeUnderpricedReplacement, errI.Code())
+	require.Equal(t, [][]byte{highBytes}, mempool.GetTransactions(math.MaxUint64))
 
+	// a block from a peer carrying both only executes the first
 	results := new(lib.ApplyBlockResults)
-	require.NoError(t, sm.ApplyTransactions(context.Background(), ordered, results, false))
+	require.NoError(t, sm.ApplyTransactions(context.Background(), [][]byte{highBytes, lowBytes}, results, false))
 	require.Len(t, results.Results, 1)
 	require.Equal(t, highBytes, results.Txs[0])
 	require.Equal(t, highTx.Fee, results.Results[0].Transaction.Fee)
@@ -1084,3 +1244,12 @@ func TestCheckFee(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageMempoolLanes(t *testing.T) {
+	// certificate results and validator operations choose their priority lane, the rest use the default
+	require.Equal(t, lib.MempoolLaneCertificate, lib.MempoolLane(MessageCertificateResultsName))
+	for _, name := range []string{MessageStakeName, MessageEditStakeName, MessageUnstakeName, MessagePauseName, MessageUnpauseName} {
+		require.Equal(t, lib.MempoolLaneValidator, lib.MempoolLane(name), name)
+	}
+	require.Equal(t, lib.MempoolLaneDefault, lib.MempoolLane(MessageSendName))
+}
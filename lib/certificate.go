@@ -24,9 +24,18 @@ const (
 	MaxOrdersPerDexBatch    = 10_000
 	MaxReceipts             = MaxOrdersPerDexBatch
 	MaxLiquidityProviders   = 5_000
+	// MaxLocksPerCertificate caps the partial lock resets and closes a certificate result carries
+	MaxLocksPerCertificate = 10_000
+	// MaxExternalPayments caps the external payment ids a certificate result carries (one per close)
+	MaxExternalPayments = MaxOrdersPerDexBatch + MaxLocksPerCertificate
+	// MaxExternalPaymentIdSize caps the bytes of an external payment id (ex. an EVM tx hash and log index)
+	MaxExternalPaymentIdSize = 64
 	// MaxOrdersSettledPerBlock caps DEX orders settled per block so begin_block can't exceed the consensus
 	// round budget; orders beyond the cap are failed (receipt 0) and refunded to the seller on the origin chain
 	MaxOrdersSettledPerBlock = 250
+	// MaxDexRouteHops caps the chains a routed order continues to after its first leg; every pool pairs the root chain
+	// with one nested chain, so A -> root -> B is the longest route that doesn't revisit a chain
+	MaxDexRouteHops = 1
 )
 
 // MaxBlockHeaderSize is a consensus breaking change because it affects how the state machine
@@ -662,6 +671,10 @@ func (x *Orders) CheckBasic() (err ErrorI) {
 	if len(x.LockOrders) > MaxOrdersPerDexBatch || len(x.ResetOrders) > MaxOrdersPerDexBatch || len(x.CloseOrders) > MaxOrdersPerDexBatch {
 		return ErrTooManyDexOrders()
 	}
+	// enforce caps for the partial lock lists
+	if len(x.ResetLocks) > MaxLocksPerCertificate || len(x.CloseLocks) > MaxLocksPerCertificate {
+		return ErrTooManyDexOrders()
+	}
 	// for each lock order
 	for _, lock := range x.LockOrders {
 		// if the lock order is empty
@@ -704,6 +717,47 @@ func (x *Orders) CheckBasic() (err ErrorI) {
 			return ErrDuplicateCloseOrder()
 		}
 	}
+	// ensure no invalid or duplicate partial lock resets
+	deDuplicator = NewDeDuplicator[string]()
+	// for each reset lock
+	for _, reset := range x.ResetLocks {
+		// validate the lock id
+		if err = reset.CheckBasic(); err != nil {
+			return
+		}
+		// if a duplicate found
+		if deDuplicator.Found(reset.Key()) {
+			// exit with the duplicate reset order
+			return ErrDuplicateResetOrder()
+		}
+	}
+	// ensure no invalid or duplicate partial lock closes
+	deDuplicator = NewDeDuplicator[string]()
+	// for each close lock
+	for _, closeLock := range x.CloseLocks {
+		// validate the lock id
+		if err = closeLock.CheckBasic(); err != nil {
+			return
+		}
+		// if a duplicate found
+		if deDuplicator.Found(closeLock.Key()) {
+			// exit with the duplicate close order
+			return ErrDuplicateCloseOrder()
+		}
+	}
+	// ensure no invalid or duplicate external payments
+	if len(x.ExternalPayments) > MaxExternalPayments {
+		return ErrInvalidExternalPayment()
+	}
+	deDuplicator = NewDeDuplicator[string]()
+	// for each external payment
+	for _, payment := range x.ExternalPayments {
+		// if the id is empty, too large or a duplicate
+		if len(payment) == 0 || len(payment) > MaxExternalPaymentIdSize || deDuplicator.Found(BytesToString(payment)) {
+			// exit with the invalid payment error
+			return ErrInvalidExternalPayment()
+		}
+	}
 	// exit
 	return
 }
@@ -730,6 +784,16 @@ func (x *Orders) Equals(y *Orders) bool {
 		// exit with 'unequal'
 		return false
 	}
+	// if the partial lock lists are not equal
+	if !EqualOrderLockIds(x.CloseLocks, y.CloseLocks) || !EqualOrderLockIds(x.ResetLocks, y.ResetLocks) {
+		// exit with 'unequal'
+		return false
+	}
+	// if the external payments are not equal
+	if !EqualByteSlices(x.ExternalPayments, y.ExternalPayments) {
+		// exit with 'unequal'
+		return false
+	}
 	// if the lock orders lists are not equal size
 	if len(x.LockOrders) != len(y.LockOrders) {
 		// exit with 'unequal'
@@ -779,6 +843,11 @@ func (x *LockOrder) Equals(y *LockOrder) bool {
 		// exit with 'unequal'
 		return false
 	}
+	// if the locked amounts are not the same
+	if x.Amount != y.Amount {
+		// exit with 'unequal'
+		return false
+	}
 	// exit with the final equality check
 	return x.BuyerChainDeadline == y.BuyerChainDeadline
 }
@@ -796,6 +865,8 @@ type lockOrderJSON struct {
 	// buyer_chain_deadline: the 'counter asset' chain height at which the buyer must send the 'counter asset' by
 	// or the 'intent to buy' will be voided
 	BuyerChainDeadline uint64 `json:"buyerChainDeadline,omitempty"`
+	// amount: the amount of the sell order to lock; 0 locks the whole order
+	Amount uint64 `json:"amount,omitempty"`
 }
 
 // MarshalJSON() implements the json.Marshaller interface for LockOrder
@@ -807,6 +878,7 @@ func (x LockOrder) MarshalJSON() ([]byte, error) {
 		BuyersSendAddress:   x.BuyerSendAddress,
 		BuyerReceiveAddress: x.BuyerReceiveAddress,
 		BuyerChainDeadline:  x.BuyerChainDeadline,
+		Amount:              x.Amount,
 	})
 }
 
@@ -826,11 +898,77 @@ func (x *LockOrder) UnmarshalJSON(jsonBytes []byte) (err error) {
 		BuyerReceiveAddress: j.BuyerReceiveAddress,
 		BuyerSendAddress:    j.BuyersSendAddress,
 		BuyerChainDeadline:  j.BuyerChainDeadline,
+		Amount:              j.Amount,
 	}
 	// exit
 	return
 }
 
+// CheckBasic() performs stateless validation on an OrderLockId
+func (x *OrderLockId) CheckBasic() ErrorI {
+	// if the lock id is empty
+	if x == nil || len(x.OrderId) == 0 {
+		// exit with empty error
+		return ErrNilLockOrder()
+	}
+	// ensure the buyer send address (that identifies the lock) actually has some bytes
+	if len(x.BuyerSendAddress) == 0 {
+		// exit with address error
+		return ErrInvalidBuyerSendAddress()
+	}
+	// exit
+	return nil
+}
+
+// Key() returns the unique string key of the partial lock
+func (x *OrderLockId) Key() string {
+	return BytesToString(x.OrderId) + "/" + BytesToString(x.BuyerSendAddress)
+}
+
+// EqualOrderLockIds() compares two lists of OrderLockIds for equality
+func EqualOrderLockIds(x, y []*OrderLockId) bool {
+	// if the lists are not equal size
+	if len(x) != len(y) {
+		return false
+	}
+	// for each lock id
+	for i := range x {
+		// if either is empty or the ids are not the same
+		if x[i] == nil || y[i] == nil || x[i].Key() != y[i].Key() {
+			return x[i] == nil && y[i] == nil
+		}
+	}
+	return true
+}
+
+// orderLockIdJSON implements the json.Marshaller & json.Unmarshaler interfaces for OrderLockId
+type orderLockIdJSON struct {
+	// order_id: is the number id that is unique to this committee to identify the order
+	OrderId HexBytes `json:"orderId,omitempty"`
+	// buyer_send_address: the 'counter asset' address of the buyer who holds the lock
+	BuyerSendAddress HexBytes `json:"buyerSendAddress,omitempty"`
+}
+
+// MarshalJSON() implements the json.Marshaller interface for OrderLockId
+func (x *OrderLockId) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&orderLockIdJSON{OrderId: x.OrderId, BuyerSendAddress: x.BuyerSendAddress})
+}
+
+// UnmarshalJSON() implements the json.Unmarshaler interface for OrderLockId
+func (x *OrderLockId) UnmarshalJSON(jsonBytes []byte) (err error) {
+	// create a new json object reference to ensure a non nil result
+	j := new(orderLockIdJSON)
+	// populate the json object ref with json bytes
+	if err = json.Unmarshal(jsonBytes, j); err != nil {
+		// exit with error
+		return
+	}
+	// populate the underlying structure using the json object
+	*x = OrderLockId{OrderId: j.OrderId, BuyerSendAddress: j.BuyerSendAddress}
+	// exit
+	return
+}
+
 // closeOrderJSON implements the json.Marshaller & json.Unmarshaler interfaces for LockOrder
 type closeOrderJSON struct {
 	// order_id: is the number id that is unique to this committee to identify the order
@@ -908,11 +1046,19 @@ func (x *DexBatch) CheckBasic() (err ErrorI) {
 		if order == nil {
 			return ErrInvalidArgument()
 		}
+		// a routed order can't be partially filled and has a bounded route
+		if len(order.Route) != 0 && (len(order.Route) > MaxDexRouteHops || order.PartialFill || slices.Contains(order.Route, x.Committee)) {
+			return ErrInvalidDexRoute()
+		}
 	}
 	// ensure there's not too many receipts
 	if len(x.Receipts) > MaxReceipts {
 		return ErrTooManyDexReceipts()
 	}
+	// ensure the fills (if any) correspond to the receipts
+	if len(x.Fills) != 0 && len(x.Fills) != len(x.Receipts) {
+		return ErrInvalidDexBatchFills()
+	}
 	// ensure there's not too many liquidity providers
 	if len(x.PoolPoints) > MaxLiquidityProviders {
 		return ErrTooManyLiquidityProviders()
@@ -931,6 +1077,10 @@ func (x *DexBatch) CheckBasic() (err ErrorI) {
 		// exit with error
 		return ErrInvalidBlockHash()
 	}
+	// ensure the pool config is valid if set
+	if x.PoolConfig != nil {
+		return x.PoolConfig.Check()
+	}
 	// exit
 	return
 }
@@ -1018,11 +1168,21 @@ func (x *DexBatch) Equals(y *DexBatch) bool {
 		if !bytes.Equal(a.OrderId, b.OrderId) {
 			return false
 		}
+		if a.ExpiryHeight != b.ExpiryHeight || a.PartialFill != b.PartialFill || a.FilledAmount != b.FilledAmount {
+			return false
+		}
+		if !slices.Equal(a.Route, b.Route) {
+			return false
+		}
 	}
 	// ensure receipts equality
 	if !slices.Equal(x.Receipts, y.Receipts) {
 		return false
 	}
+	// ensure fills equality
+	if !slices.Equal(x.Fills, y.Fills) {
+		return false
+	}
 	// ensure pool points len equality
 	if len(x.PoolPoints) != len(y.PoolPoints) {
 		return false
@@ -1041,6 +1201,10 @@ func (x *DexBatch) Equals(y *DexBatch) bool {
 	if x.LivenessFallback != y.LivenessFallback {
 		return false
 	}
+	// ensure the pool config is equal
+	if !x.PoolConfig.Equals(y.PoolConfig) {
+		return false
+	}
 	// exit
 	return true
 }
@@ -361,9 +361,18 @@ type Orders struct {
 	ResetOrders [][]byte `protobuf:"bytes,2,rep,name=reset_orders,json=resetOrders,proto3" json:"resetOrders"` // @gotags: json:"resetOrders"
 	// close_orders: a list of orders where funds were sent,
 	// signaling Canopy to transfer escrowed tokens to the buyer's Canopy address
-	CloseOrders   [][]byte `protobuf:"bytes,3,rep,name=close_orders,json=closeOrders,proto3" json:"closeOrders"` // @gotags: json:"closeOrders"
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	CloseOrders [][]byte `protobuf:"bytes,3,rep,name=close_orders,json=closeOrders,proto3" json:"closeOrders"` // @gotags: json:"closeOrders"
+	// reset_locks: a list of partial locks where no funds were sent before the deadline,
+	// signaling to Canopy to release the locked part of the order
+	ResetLocks []*OrderLockId `protobuf:"bytes,4,rep,name=reset_locks,json=resetLocks,proto3" json:"resetLocks"` // @gotags: json:"resetLocks"
+	// close_locks: a list of partial locks where funds were sent,
+	// signaling Canopy to transfer the locked part of the escrowed tokens to the buyer's Canopy address
+	CloseLocks []*OrderLockId `protobuf:"bytes,5,rep,name=close_locks,json=closeLocks,proto3" json:"closeLocks"` // @gotags: json:"closeLocks"
+	// external_payments: the ids of the external chain payments witnessed for the close orders and close locks,
+	// recorded in state so a payment never closes a second lock
+	ExternalPayments [][]byte `protobuf:"bytes,6,rep,name=external_payments,json=externalPayments,proto3" json:"externalPayments"` // @gotags: json:"externalPayments"
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Orders) Reset() {
@@ -417,6 +426,82 @@ func (x *Orders) GetCloseOrders() [][]byte {
 	return nil
 }
 
+func (x *Orders) GetResetLocks() []*OrderLockId {
+	if x != nil {
+		return x.ResetLocks
+	}
+	return nil
+}
+
+func (x *Orders) GetCloseLocks() []*OrderLockId {
+	if x != nil {
+		return x.CloseLocks
+	}
+	return nil
+}
+
+func (x *Orders) GetExternalPayments() [][]byte {
+	if x != nil {
+		return x.ExternalPayments
+	}
+	return nil
+}
+
+// OrderLockId identifies a partial lock of a sell order; an order holds at most one lock per buyer send address
+type OrderLockId struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// order_id: is the number id that is unique to this committee to identify the order
+	OrderId []byte `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"orderID"` // @gotags: json:"orderID"
+	// buyer_send_address: the 'counter asset' address of the buyer who holds the lock
+	BuyerSendAddress []byte `protobuf:"bytes,2,opt,name=buyer_send_address,json=buyerSendAddress,proto3" json:"buyerSendAddress"` // @gotags: json:"buyerSendAddress"
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OrderLockId) Reset() {
+	*x = OrderLockId{}
+	mi := &file_certificate_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderLockId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderLockId) ProtoMessage() {}
+
+func (x *OrderLockId) ProtoReflect() protoreflect.Message {
+	mi := &file_certificate_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderLockId.ProtoReflect.Descriptor instead.
+func (*OrderLockId) Descriptor() ([]byte, []int) {
+	return file_certificate_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OrderLockId) GetOrderId() []byte {
+	if x != nil {
+		return x.OrderId
+	}
+	return nil
+}
+
+func (x *OrderLockId) GetBuyerSendAddress() []byte {
+	if x != nil {
+		return x.BuyerSendAddress
+	}
+	return nil
+}
+
 // LockOrder is a buyer expressing an intent to purchase an order, often referred to as 'claiming' the order
 type LockOrder struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -431,13 +516,15 @@ type LockOrder struct {
 	// buyer_chain_deadline: the 'counter asset' chain height at which the buyer must send the 'counter asset' by
 	// or the 'intent to buy' will be voided
 	BuyerChainDeadline uint64 `protobuf:"varint,5,opt,name=buyer_chain_deadline,json=buyerChainDeadline,proto3" json:"buyerChainDeadline"` // @gotags: json:"buyerChainDeadline"
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// amount: the amount of the sell order to lock; 0 locks the whole order
+	Amount        uint64 `protobuf:"varint,6,opt,name=amount,proto3" json:"amount"` // @gotags: json:"amount"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LockOrder) Reset() {
 	*x = LockOrder{}
-	mi := &file_certificate_proto_msgTypes[5]
+	mi := &file_certificate_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -449,7 +536,7 @@ func (x *LockOrder) String() string {
 func (*LockOrder) ProtoMessage() {}
 
 func (x *LockOrder) ProtoReflect() protoreflect.Message {
-	mi := &file_certificate_proto_msgTypes[5]
+	mi := &file_certificate_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,7 +549,7 @@ func (x *LockOrder) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LockOrder.ProtoReflect.Descriptor instead.
 func (*LockOrder) Descriptor() ([]byte, []int) {
-	return file_certificate_proto_rawDescGZIP(), []int{5}
+	return file_certificate_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *LockOrder) GetOrderId() []byte {
@@ -500,6 +587,13 @@ func (x *LockOrder) GetBuyerChainDeadline() uint64 {
 	return 0
 }
 
+func (x *LockOrder) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
 // CloseOrder is a buyer completing the purchase of an order, often referred to as 'buying' the order
 type CloseOrder struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -515,7 +609,7 @@ type CloseOrder struct {
 
 func (x *CloseOrder) Reset() {
 	*x = CloseOrder{}
-	mi := &file_certificate_proto_msgTypes[6]
+	mi := &file_certificate_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -527,7 +621,7 @@ func (x *CloseOrder) String() string {
 func (*CloseOrder) ProtoMessage() {}
 
 func (x *CloseOrder) ProtoReflect() protoreflect.Message {
-	mi := &file_certificate_proto_msgTypes[6]
+	mi := &file_certificate_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -540,7 +634,7 @@ func (x *CloseOrder) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseOrder.ProtoReflect.Descriptor instead.
 func (*CloseOrder) Descriptor() ([]byte, []int) {
-	return file_certificate_proto_rawDescGZIP(), []int{6}
+	return file_certificate_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CloseOrder) GetOrderId() []byte {
@@ -579,7 +673,7 @@ type Checkpoint struct {
 
 func (x *Checkpoint) Reset() {
 	*x = Checkpoint{}
-	mi := &file_certificate_proto_msgTypes[7]
+	mi := &file_certificate_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -591,7 +685,7 @@ func (x *Checkpoint) String() string {
 func (*Checkpoint) ProtoMessage() {}
 
 func (x *Checkpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_certificate_proto_msgTypes[7]
+	mi := &file_certificate_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -604,7 +698,7 @@ func (x *Checkpoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Checkpoint.ProtoReflect.Descriptor instead.
 func (*Checkpoint) Descriptor() ([]byte, []int) {
-	return file_certificate_proto_rawDescGZIP(), []int{7}
+	return file_certificate_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Checkpoint) GetHeight() uint64 {
@@ -638,7 +732,7 @@ type PaymentPercents struct {
 
 func (x *PaymentPercents) Reset() {
 	*x = PaymentPercents{}
-	mi := &file_certificate_proto_msgTypes[8]
+	mi := &file_certificate_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -650,7 +744,7 @@ func (x *PaymentPercents) String() string {
 func (*PaymentPercents) ProtoMessage() {}
 
 func (x *PaymentPercents) ProtoReflect() protoreflect.Message {
-	mi := &file_certificate_proto_msgTypes[8]
+	mi := &file_certificate_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -663,7 +757,7 @@ func (x *PaymentPercents) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PaymentPercents.ProtoReflect.Descriptor instead.
 func (*PaymentPercents) Descriptor() ([]byte, []int) {
-	return file_certificate_proto_rawDescGZIP(), []int{8}
+	return file_certificate_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PaymentPercents) GetAddress() []byte {
@@ -703,7 +797,7 @@ type DoubleSigner struct {
 
 func (x *DoubleSigner) Reset() {
 	*x = DoubleSigner{}
-	mi := &file_certificate_proto_msgTypes[9]
+	mi := &file_certificate_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -715,7 +809,7 @@ func (x *DoubleSigner) String() string {
 func (*DoubleSigner) ProtoMessage() {}
 
 func (x *DoubleSigner) ProtoReflect() protoreflect.Message {
-	mi := &file_certificate_proto_msgTypes[9]
+	mi := &file_certificate_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,7 +822,7 @@ func (x *DoubleSigner) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DoubleSigner.ProtoReflect.Descriptor instead.
 func (*DoubleSigner) Descriptor() ([]byte, []int) {
-	return file_certificate_proto_rawDescGZIP(), []int{9}
+	return file_certificate_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DoubleSigner) GetId() []byte {
@@ -755,7 +849,7 @@ type CommitteesData struct {
 
 func (x *CommitteesData) Reset() {
 	*x = CommitteesData{}
-	mi := &file_certificate_proto_msgTypes[10]
+	mi := &file_certificate_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -767,7 +861,7 @@ func (x *CommitteesData) String() string {
 func (*CommitteesData) ProtoMessage() {}
 
 func (x *CommitteesData) ProtoReflect() protoreflect.Message {
-	mi := &file_certificate_proto_msgTypes[10]
+	mi := &file_certificate_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -780,7 +874,7 @@ func (x *CommitteesData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommitteesData.ProtoReflect.Descriptor instead.
 func (*CommitteesData) Descriptor() ([]byte, []int) {
-	return file_certificate_proto_rawDescGZIP(), []int{10}
+	return file_certificate_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CommitteesData) GetList() []*CommitteeData {
@@ -813,7 +907,7 @@ type CommitteeData struct {
 
 func (x *CommitteeData) Reset() {
 	*x = CommitteeData{}
-	mi := &file_certificate_proto_msgTypes[11]
+	mi := &file_certificate_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -825,7 +919,7 @@ func (x *CommitteeData) String() string {
 func (*CommitteeData) ProtoMessage() {}
 
 func (x *CommitteeData) ProtoReflect() protoreflect.Message {
-	mi := &file_certificate_proto_msgTypes[11]
+	mi := &file_certificate_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -838,7 +932,7 @@ func (x *CommitteeData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommitteeData.ProtoReflect.Descriptor instead.
 func (*CommitteeData) Descriptor() ([]byte, []int) {
-	return file_certificate_proto_rawDescGZIP(), []int{11}
+	return file_certificate_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CommitteeData) GetChainId() uint64 {
@@ -904,18 +998,27 @@ const file_certificate_proto_rawDesc = "" +
 	"\x10payment_percents\x18\x01 \x03(\v2\x16.types.PaymentPercentsR\x0fpaymentPercents\x12*\n" +
 	"\x11number_of_samples\x18\x02 \x01(\x04R\x0fnumberOfSamples\"M\n" +
 	"\x0fSlashRecipients\x12:\n" +
-	"\x0edouble_signers\x18\x01 \x03(\v2\x13.types.DoubleSignerR\rdoubleSigners\"\x81\x01\n" +
+	"\x0edouble_signers\x18\x01 \x03(\v2\x13.types.DoubleSignerR\rdoubleSigners\"\x98\x02\n" +
 	"\x06Orders\x121\n" +
 	"\vlock_orders\x18\x01 \x03(\v2\x10.types.LockOrderR\n" +
 	"lockOrders\x12!\n" +
 	"\freset_orders\x18\x02 \x03(\fR\vresetOrders\x12!\n" +
-	"\fclose_orders\x18\x03 \x03(\fR\vcloseOrders\"\xd5\x01\n" +
+	"\fclose_orders\x18\x03 \x03(\fR\vcloseOrders\x123\n" +
+	"\vreset_locks\x18\x04 \x03(\v2\x12.types.OrderLockIdR\n" +
+	"resetLocks\x123\n" +
+	"\vclose_locks\x18\x05 \x03(\v2\x12.types.OrderLockIdR\n" +
+	"closeLocks\x12+\n" +
+	"\x11external_payments\x18\x06 \x03(\fR\x10externalPayments\"V\n" +
+	"\vOrderLockId\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\fR\aorderId\x12,\n" +
+	"\x12buyer_send_address\x18\x02 \x01(\fR\x10buyerSendAddress\"\xed\x01\n" +
 	"\tLockOrder\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\fR\aorderId\x12\x19\n" +
 	"\bchain_id\x18\x02 \x01(\x04R\achainId\x122\n" +
 	"\x15buyer_receive_address\x18\x03 \x01(\fR\x13buyerReceiveAddress\x12,\n" +
 	"\x12buyer_send_address\x18\x04 \x01(\fR\x10buyerSendAddress\x120\n" +
-	"\x14buyer_chain_deadline\x18\x05 \x01(\x04R\x12buyerChainDeadline\"c\n" +
+	"\x14buyer_chain_deadline\x18\x05 \x01(\x04R\x12buyerChainDeadline\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x04R\x06amount\"c\n" +
 	"\n" +
 	"CloseOrder\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\fR\aorderId\x12\x19\n" +
@@ -955,44 +1058,47 @@ func file_certificate_proto_rawDescGZIP() []byte {
 	return file_certificate_proto_rawDescData
 }
 
-var file_certificate_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_certificate_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_certificate_proto_goTypes = []any{
 	(*QuorumCertificate)(nil),  // 0: types.QuorumCertificate
 	(*CertificateResult)(nil),  // 1: types.CertificateResult
 	(*RewardRecipients)(nil),   // 2: types.RewardRecipients
 	(*SlashRecipients)(nil),    // 3: types.SlashRecipients
 	(*Orders)(nil),             // 4: types.Orders
-	(*LockOrder)(nil),          // 5: types.LockOrder
-	(*CloseOrder)(nil),         // 6: types.CloseOrder
-	(*Checkpoint)(nil),         // 7: types.Checkpoint
-	(*PaymentPercents)(nil),    // 8: types.PaymentPercents
-	(*DoubleSigner)(nil),       // 9: types.DoubleSigner
-	(*CommitteesData)(nil),     // 10: types.CommitteesData
-	(*CommitteeData)(nil),      // 11: types.CommitteeData
-	(*View)(nil),               // 12: types.View
-	(*AggregateSignature)(nil), // 13: types.AggregateSignature
-	(*DexBatch)(nil),           // 14: types.DexBatch
+	(*OrderLockId)(nil),        // 5: types.OrderLockId
+	(*LockOrder)(nil),          // 6: types.LockOrder
+	(*CloseOrder)(nil),         // 7: types.CloseOrder
+	(*Checkpoint)(nil),         // 8: types.Checkpoint
+	(*PaymentPercents)(nil),    // 9: types.PaymentPercents
+	(*DoubleSigner)(nil),       // 10: types.DoubleSigner
+	(*CommitteesData)(nil),     // 11: types.CommitteesData
+	(*CommitteeData)(nil),      // 12: types.CommitteeData
+	(*View)(nil),               // 13: types.View
+	(*AggregateSignature)(nil), // 14: types.AggregateSignature
+	(*DexBatch)(nil),           // 15: types.DexBatch
 }
 var file_certificate_proto_depIdxs = []int32{
-	12, // 0: types.QuorumCertificate.header:type_name -> types.View
+	13, // 0: types.QuorumCertificate.header:type_name -> types.View
 	1,  // 1: types.QuorumCertificate.results:type_name -> types.CertificateResult
-	13, // 2: types.QuorumCertificate.signature:type_name -> types.AggregateSignature
+	14, // 2: types.QuorumCertificate.signature:type_name -> types.AggregateSignature
 	2,  // 3: types.CertificateResult.reward_recipients:type_name -> types.RewardRecipients
 	3,  // 4: types.CertificateResult.slash_recipients:type_name -> types.SlashRecipients
 	4,  // 5: types.CertificateResult.orders:type_name -> types.Orders
-	7,  // 6: types.CertificateResult.checkpoint:type_name -> types.Checkpoint
-	14, // 7: types.CertificateResult.dex_batch:type_name -> types.DexBatch
-	14, // 8: types.CertificateResult.root_dex_batch:type_name -> types.DexBatch
-	8,  // 9: types.RewardRecipients.payment_percents:type_name -> types.PaymentPercents
-	9,  // 10: types.SlashRecipients.double_signers:type_name -> types.DoubleSigner
-	5,  // 11: types.Orders.lock_orders:type_name -> types.LockOrder
-	11, // 12: types.CommitteesData.list:type_name -> types.CommitteeData
-	8,  // 13: types.CommitteeData.payment_percents:type_name -> types.PaymentPercents
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	8,  // 6: types.CertificateResult.checkpoint:type_name -> types.Checkpoint
+	15, // 7: types.CertificateResult.dex_batch:type_name -> types.DexBatch
+	15, // 8: types.CertificateResult.root_dex_batch:type_name -> types.DexBatch
+	9,  // 9: types.RewardRecipients.payment_percents:type_name -> types.PaymentPercents
+	10, // 10: types.SlashRecipients.double_signers:type_name -> types.DoubleSigner
+	6,  // 11: types.Orders.lock_orders:type_name -> types.LockOrder
+	5,  // 12: types.Orders.reset_locks:type_name -> types.OrderLockId
+	5,  // 13: types.Orders.close_locks:type_name -> types.OrderLockId
+	12, // 14: types.CommitteesData.list:type_name -> types.CommitteeData
+	9,  // 15: types.CommitteeData.payment_percents:type_name -> types.PaymentPercents
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_certificate_proto_init() }
@@ -1008,7 +1114,7 @@ func file_certificate_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_certificate_proto_rawDesc), len(file_certificate_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -582,6 +582,18 @@ func TestOrdersCheckBasicRejectsOversizedLists(t *testing.T) {
 				CloseOrders: make([][]byte, MaxOrdersPerDexBatch+1),
 			},
 		},
+		{
+			name: "reset locks",
+			orders: &Orders{
+				ResetLocks: make([]*OrderLockId, MaxLocksPerCertificate+1),
+			},
+		},
+		{
+			name: "close locks",
+			orders: &Orders{
+				CloseLocks: make([]*OrderLockId, MaxLocksPerCertificate+1),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -593,6 +605,15 @@ func TestOrdersCheckBasicRejectsOversizedLists(t *testing.T) {
 	}
 }
 
+func TestOrdersCheckBasicExternalPayments(t *testing.T) {
+	// valid payments
+	require.NoError(t, (&Orders{ExternalPayments: [][]byte{{1}, {2}}}).CheckBasic())
+	// an empty, oversized or duplicate payment
+	for _, payments := range [][][]byte{{{}}, {make([]byte, MaxExternalPaymentIdSize+1)}, {{1}, {1}}} {
+		require.ErrorContains(t, (&Orders{ExternalPayments: payments}).CheckBasic(), ErrInvalidExternalPayment().Error())
+	}
+}
+
 func newTestOrderId(_ *testing.T, variant int) []byte {
 	return []byte(fmt.Sprintf("%d", variant))
 }
@@ -19,6 +19,7 @@ const (
 	UnknownChainId         = uint64(0)            // the default 'unknown' chain id
 	CanopyChainId          = uint64(1)            // NOTE: to not break nested-chain recursion, this should not be used except for 'default config/genesis' developer setups
 	DAOPoolID              = 2*math.MaxUint16 + 1 // must be above the MaxUint16 * 2 to ensure no 'overlap' with 'chainId + EscrowAddend'
+	GovDepositPoolID       = DAOPoolID + 1        // escrows the deposits of on-chain governance proposals until they're tallied
 	CanopyMainnetNetworkId = 1                    // the identifier of the 'mainnet' of Canopy
 )
 
@@ -31,6 +32,9 @@ const (
 	PollsFilePath     = "polls.json"         // the file path for governance 'straw' polling voting and tracking
 )
 
+// RemoteSignerClientKeyPath is the file path for the key the node authenticates to its remote signer with
+const RemoteSignerClientKeyPath = "remote_signer_client_key.json"
+
 // Config is the structure of the user configuration options for a Canopy node
 type Config struct {
 	MainConfig         // main options spanning over all modules
@@ -79,6 +83,42 @@ type MainConfig struct {
 	Plugin              string                 `json:"plugin"`              // the configured plugin to use
 	PluginTimeoutMS     int                    `json:"pluginTimeoutMS"`     // plugin request timeout in milliseconds
 	PluginAutoUpdate    PluginAutoUpdateConfig `json:"pluginAutoUpdate"`    // plugin auto-update configuration
+	SwapWatcher         SwapWatcherConfig      `json:"swapWatcher"`         // external chain watcher for the 'counter asset' leg of order book swaps
+	RemoteSigner        RemoteSignerConfig     `json:"remoteSigner"`        // sign with a validator key held by a separate signer process
+}
+
+// RemoteSignerConfig configures a remote signer: a separate process that holds the validator key and signs on request
+// NOTE: the node authenticates with the 'remote_signer_client_key.json' key, which must be allowed by the signer
+type RemoteSignerConfig struct {
+	Address   string `json:"address"`   // the tcp address of the remote signer (empty = sign with 'validator_key.json')
+	PublicKey string `json:"publicKey"` // the hex BLS public key of the validator; the signer must prove it holds its private key
+	TimeoutMS int    `json:"timeoutMS"` // the signing request timeout in milliseconds
+}
+
+// DefaultRemoteSignerConfig() returns the remote signer defaults
+func DefaultRemoteSignerConfig() RemoteSignerConfig {
+	return RemoteSignerConfig{
+		TimeoutMS: 2000, // 2 seconds
+	}
+}
+
+// SwapWatcherConfig configures the external chain watcher a swap committee runs to witness payments on an EVM chain
+// NOTE: every validator of the committee must watch the same chain and tokens, otherwise the certificate results won't match
+type SwapWatcherConfig struct {
+	EVMRPCUrl        string `json:"evmRPCUrl"`        // the EVM JSON-RPC endpoint to watch (empty = off)
+	TokenContract    string `json:"tokenContract"`    // the default ERC-20 contract for orders that don't name one in their 'data'
+	ConfirmationLagS int    `json:"confirmationLagS"` // only transfers at least this old (relative to the block time) are witnessed
+	WindowS          int    `json:"windowS"`          // how far back (before the lag) transfers are witnessed
+	TimeoutMS        int    `json:"timeoutMS"`        // the JSON-RPC request timeout in milliseconds
+}
+
+// DefaultSwapWatcherConfig() returns the external chain watcher defaults
+func DefaultSwapWatcherConfig() SwapWatcherConfig {
+	return SwapWatcherConfig{
+		ConfirmationLagS: 900,  // 15 minutes: past Ethereum finality, so every validator sees the same blocks
+		WindowS:          3600, // 1 hour
+		TimeoutMS:        5000, // 5 seconds
+	}
 }
 
 // PluginAutoUpdateConfig holds configuration for plugin auto-updates
@@ -103,6 +143,8 @@ func DefaultMainConfig() MainConfig {
 		Headless:        false,         // serve the web wallet and block explorer by default
 		AutoUpdate:      true,          // set it as default while in inmature state
 		PluginTimeoutMS: 1000,          // 1 second default plugin timeout
+		SwapWatcher:     DefaultSwapWatcherConfig(),
+		RemoteSigner:    DefaultRemoteSignerConfig(),
 	}
 }
 
@@ -140,6 +182,8 @@ type RPCConfig struct {
 	RCSubscriberWriteTimeoutMS int    `json:"rcSubscriberWriteTimeoutMS"` // ws write timeout for publishing root-chain info
 	RCSubscriberPongWaitS      int    `json:"rcSubscriberPongWaitS"`      // time to wait for pong responses
 	RCSubscriberPingPeriodS    int    `json:"rcSubscriberPingPeriodS"`    // how often to ping subscribers
+	MaxStreamSubscribers       int    `json:"maxStreamSubscribers"`       // max '/v1/subscribe' event stream subscribers
+	StreamMempoolBufferSize    int    `json:"streamMempoolBufferSize"`    // number of recent mempool admissions kept for resuming subscribers
 }
 
 // RootChain defines a rpc url to a possible 'root chain' which is used if the governance parameter RootChainId == ChainId
@@ -166,6 +210,8 @@ func DefaultRPCConfig() RPCConfig {
 		RCSubscriberWriteTimeoutMS: 10000,                      // 10s write deadline for publishes
 		RCSubscriberPongWaitS:      60,                         // 60s pong wait
 		RCSubscriberPingPeriodS:    50,                         // 50s ping interval
+		MaxStreamSubscribers:       512,                        // limit event stream subscribers
+		StreamMempoolBufferSize:    10_000,                     // replay up to 10K recent mempool admissions
 	}
 }
 
@@ -212,8 +258,19 @@ type ConsensusConfig struct {
 	PrecommitVoteTimeoutMS  int `json:"precommitVoteTimeoutMS"`  // minus QC validation + vote time, is how long (in milliseconds) the replica sleeps before moving to COMMIT phase
 	CommitTimeoutMS         int `json:"commitTimeoutMS"`         // minus Precommit-QC aggregation time (if Leader), how long (in milliseconds) the replica sleeps before moving to the COMMIT-PROCESS phase
 	RoundInterruptTimeoutMS int `json:"roundInterruptTimeoutMS"` // minus gossiping current Round time, how long (in milliseconds) the replica sleeps before moving to PACEMAKER phase
+
+	// AdaptiveTimeouts derives the leader phase timeouts above from the observed time to +2/3 over recent heights,
+	// bounded by the governance 'minPhaseTimeoutMS' and 'maxPhaseTimeoutMS' (the values above are the starting point)
+	// NOTE: the vote phase timeouts stay as configured, so slow replicas still make it into the certificate
+	AdaptiveTimeouts bool `json:"adaptiveTimeouts"`
 }
 
+// the protocol default bounds of adaptive phase timeouts, if not set by governance
+const (
+	DefaultMinPhaseTimeoutMS = 500   // 0.5 seconds
+	DefaultMaxPhaseTimeoutMS = 10000 // 10 seconds
+)
+
 // DefaultConsensusConfig() configures the block time
 func DefaultConsensusConfig() ConsensusConfig {
 	return ConsensusConfig{
@@ -287,6 +344,11 @@ type StoreConfig struct {
 	BackupDirectory       string `json:"backupDirectory"`       // directory where backups of the database are stored
 	BackupInterval        uint64 `json:"backupInterval"`        // interval in blocks for creating backups of the database (0 to disable automatic backups)
 	CompressionProfile    string `json:"compressionProfile"`    // the pebbledb compression profile to use.
+	StateSync             bool   `json:"stateSync"`             // bootstrap a fresh node from a verified state snapshot instead of replaying every block
+	StateSyncTrustHeight  uint64 `json:"stateSyncTrustHeight"`  // the trusted block height to state-sync to (0 uses the highest checkpoint)
+	StateSyncTrustHash    string `json:"stateSyncTrustHash"`    // the trusted hex block hash at the trust height
+	// the bucket lengths (seconds) the indexer aggregates the dex pool history into; empty disables the dex history
+	DexHistoryResolutions []uint64 `json:"dexHistoryResolutions"`
 }
 
 // DefaultDataDirPath() is $USERHOME/.canopy
@@ -316,6 +378,8 @@ func DefaultStoreConfig() StoreConfig {
 		BackupDirectory:           path.Join(DefaultDataDirPath(), "backup"), // backup directory name
 		BackupInterval:            0,                                         // backups disabled by default
 		CompressionProfile:        "zstd",
+		StateSync:                 false, // state-sync disabled by default
+		DexHistoryResolutions:     []uint64{60, 3600, 86400},
 	}
 }
 
@@ -326,8 +390,12 @@ type MempoolConfig struct {
 	MaxTotalBytes              uint64 `json:"maxTotalBytes"`              // maximum collective bytes in the pool
 	MaxTransactionCount        uint32 `json:"maxTransactionCount"`        // max number of Transactions
 	IndividualMaxTxSize        uint32 `json:"individualMaxTxSize"`        // max bytes of a single Transaction
-	DropPercentage             int    `json:"dropPercentage"`             // percentage that is dropped from the bottom of the queue if limits are reached
+	DropPercentage             int    `json:"dropPercentage"`             // minimum percentage of the pool that is evicted if limits are reached
 	LazyMempoolCheckFrequencyS int    `json:"lazyMempoolCheckFrequencyS"` // how often the mempool is checked for new transactions besides the mandatory (after Commit) (0) for none
+	MaxTxsPerSender            uint32 `json:"maxTxsPerSender"`            // max number of pending Transactions of a single sender (0) for no limit; certificate results are exempt
+	ReplaceFeeBumpPercent      uint64 `json:"replaceFeeBumpPercent"`      // minimum fee increase (percent) for a transaction to replace a pending one with the same sender and nonce
+	TxTTLS                     int    `json:"txTTLS"`                     // how long a Transaction may remain pending before it expires (0) for no expiry
+	EvictionPolicy             string `json:"evictionPolicy"`             // which Transactions are evicted first if limits are reached: lowest-fee, largest-sender or oldest
 }
 
 // DefaultMempoolConfig() returns the developer created Mempool options
@@ -338,6 +406,10 @@ func DefaultMempoolConfig() MempoolConfig {
 		IndividualMaxTxSize:        uint32(4 * units.Kilobyte), // 4 KB max individual tx size
 		DropPercentage:             35,                         // drop 35% if limits are reached
 		LazyMempoolCheckFrequencyS: 2,                          // check every 2 seconds
+		MaxTxsPerSender:            100,                        // 100 max pending transactions per sender
+		ReplaceFeeBumpPercent:      10,                         // replacements must pay a 10% higher fee
+		TxTTLS:                     3 * 60 * 60,                // expire after 3 hours
+		EvictionPolicy:             EvictLargestSender,         // evict from the largest sender first
 	}
 }
 
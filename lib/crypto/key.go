@@ -39,6 +39,27 @@ type PrivateKeyI interface {
 	json.Unmarshaler
 }
 
+// SignPurpose identifies a payload whose bytes don't say what they are (a hash), for keys that check what they sign
+type SignPurpose int32
+
+const (
+	SignHandshakeChallenge SignPurpose = iota + 1 // the challenge of a peer handshake
+	SignVRFSeed                                   // the seed of a leader election vrf
+)
+
+// PurposeSignerI is a private key that only signs an undecodable payload when told its purpose (ex. a remote signer)
+type PurposeSignerI interface {
+	SignFor(purpose SignPurpose, msg []byte) []byte
+}
+
+// SignFor() signs the payload with the key, passing its purpose to keys that check what they sign
+func SignFor(key PrivateKeyI, purpose SignPurpose, msg []byte) []byte {
+	if signer, ok := key.(PurposeSignerI); ok {
+		return signer.SignFor(purpose, msg)
+	}
+	return key.Sign(msg)
+}
+
 // AddressI is an interface model for the short version of the Public Key
 type AddressI interface {
 	// Marshal() models the protobuf.Marshaller interface
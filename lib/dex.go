@@ -4,6 +4,11 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"slices"
 	"strings"
 
 	"github.com/canopy-network/canopy/lib/crypto"
@@ -44,6 +49,8 @@ func (x *DexBatch) Copy() *DexBatch {
 		Receipts:         x.Receipts,
 		LockedHeight:     x.LockedHeight,
 		LivenessFallback: x.LivenessFallback,
+		PoolConfig:       x.PoolConfig,
+		Fills:            x.Fills,
 	}
 }
 
@@ -57,6 +64,32 @@ func (x *DexLimitOrder) Copy() *DexLimitOrder {
 		AmountForSale:   x.AmountForSale,
 		RequestedAmount: x.RequestedAmount,
 		Address:         bytes.Clone(x.Address),
+		ExpiryHeight:    x.ExpiryHeight,
+		PartialFill:     x.PartialFill,
+		FilledAmount:    x.FilledAmount,
+		Route:           slices.Clone(x.Route),
+		OriginChainId:   x.OriginChainId,
+	}
+}
+
+// Remainder() returns the resting order for the unsold part of the order, keeping its limit price
+// the requested amount is rounded up so the remainder never accepts a worse price than the original order
+func (x *DexLimitOrder) Remainder(sold uint64) *DexLimitOrder {
+	remaining := x.AmountForSale - sold
+	// requested * remaining / amountForSale always fits as remaining <= amountForSale
+	hi, lo := bits.Mul64(x.RequestedAmount, remaining)
+	requested, rem := bits.Div64(hi, lo, x.AmountForSale)
+	if rem != 0 {
+		requested++
+	}
+	return &DexLimitOrder{
+		AmountForSale:   remaining,
+		RequestedAmount: requested,
+		Address:         x.Address,
+		OrderId:         x.OrderId,
+		ExpiryHeight:    x.ExpiryHeight,
+		PartialFill:     x.PartialFill,
+		FilledAmount:    x.FilledAmount + sold,
 	}
 }
 
@@ -95,11 +128,14 @@ func (x *DexBatch) CopyOrders(blockHash []byte) (cpy1 []*DexLimitOrderWithKey, c
 		// copy 1
 		cpy1[i] = &DexLimitOrderWithKey{DexLimitOrder: order.Copy()}
 		cpy1[i].HashKey(i, blockHash)
+		// the order id isn't part of the hash key, but identifies the order in events (and its forwarded leg)
+		cpy1[i].OrderId = order.OrderId
 		// copy 2
 		cpy2[i] = &DexLimitOrderWithKey{
 			DexLimitOrder: order.Copy(),
 			Key:           cpy1[i].Key,
 		}
+		cpy2[i].OrderId = order.OrderId
 	}
 	return
 }
@@ -132,6 +168,11 @@ type dexLimitOrder struct {
 	RequestedAmount uint64   `json:"requestedAmount"`
 	Address         HexBytes `json:"address"`
 	OrderId         HexBytes `json:"orderId"`
+	ExpiryHeight    uint64   `json:"expiryHeight,omitempty"`
+	PartialFill     bool     `json:"partialFill,omitempty"`
+	FilledAmount    uint64   `json:"filledAmount,omitempty"`
+	Route           []uint64 `json:"route,omitempty"`
+	OriginChainId   uint64   `json:"originChainId,omitempty"`
 }
 
 // MarshalJSON() implements the json.Marshal interface for DexLimitOrder
@@ -141,6 +182,11 @@ func (x DexLimitOrder) MarshalJSON() ([]byte, error) {
 		RequestedAmount: x.RequestedAmount,
 		Address:         x.Address,
 		OrderId:         x.OrderId,
+		ExpiryHeight:    x.ExpiryHeight,
+		PartialFill:     x.PartialFill,
+		FilledAmount:    x.FilledAmount,
+		Route:           x.Route,
+		OriginChainId:   x.OriginChainId,
 	})
 }
 
@@ -155,22 +201,29 @@ func (x *DexLimitOrder) UnmarshalJSON(b []byte) (err error) {
 		RequestedAmount: d.RequestedAmount,
 		Address:         d.Address,
 		OrderId:         d.OrderId,
+		ExpiryHeight:    d.ExpiryHeight,
+		PartialFill:     d.PartialFill,
+		FilledAmount:    d.FilledAmount,
+		Route:           d.Route,
+		OriginChainId:   d.OriginChainId,
 	}
 	return
 }
 
 type dexLiquidityDeposit struct {
-	Amount  uint64   `json:"amount"`
-	Address HexBytes `json:"address"`
-	OrderId HexBytes `json:"orderId"`
+	Amount    uint64   `json:"amount"`
+	Address   HexBytes `json:"address"`
+	OrderId   HexBytes `json:"orderId"`
+	MinPoints uint64   `json:"minPoints"`
 }
 
 // MarshalJSON() implements the json.Marshal interface for DexLiquidityDeposit
 func (x DexLiquidityDeposit) MarshalJSON() ([]byte, error) {
 	return json.Marshal(dexLiquidityDeposit{
-		Amount:  x.Amount,
-		Address: x.Address,
-		OrderId: x.OrderId,
+		Amount:    x.Amount,
+		Address:   x.Address,
+		OrderId:   x.OrderId,
+		MinPoints: x.MinPoints,
 	})
 }
 
@@ -181,25 +234,30 @@ func (x *DexLiquidityDeposit) UnmarshalJSON(b []byte) (err error) {
 		return err
 	}
 	*x = DexLiquidityDeposit{
-		Amount:  d.Amount,
-		Address: d.Address,
-		OrderId: d.OrderId,
+		Amount:    d.Amount,
+		Address:   d.Address,
+		OrderId:   d.OrderId,
+		MinPoints: d.MinPoints,
 	}
 	return
 }
 
 type dexLiquidityWithdraw struct {
-	Percent uint64   `json:"percent"`
-	Address HexBytes `json:"address"`
-	OrderId HexBytes `json:"orderId"`
+	Percent          uint64   `json:"percent"`
+	Address          HexBytes `json:"address"`
+	OrderId          HexBytes `json:"orderId"`
+	MinAmount        uint64   `json:"minAmount"`
+	MinCounterAmount uint64   `json:"minCounterAmount"`
 }
 
 // MarshalJSON() implements the json.Marshal interface for dexLiquidityWithdraw
 func (x DexLiquidityWithdraw) MarshalJSON() ([]byte, error) {
 	return json.Marshal(dexLiquidityWithdraw{
-		Percent: x.Percent,
-		Address: x.Address,
-		OrderId: x.OrderId,
+		Percent:          x.Percent,
+		Address:          x.Address,
+		OrderId:          x.OrderId,
+		MinAmount:        x.MinAmount,
+		MinCounterAmount: x.MinCounterAmount,
 	})
 }
 
@@ -210,9 +268,11 @@ func (x *DexLiquidityWithdraw) UnmarshalJSON(b []byte) (err error) {
 		return err
 	}
 	*x = DexLiquidityWithdraw{
-		Percent: d.Percent,
-		Address: d.Address,
-		OrderId: d.OrderId,
+		Percent:          d.Percent,
+		Address:          d.Address,
+		OrderId:          d.OrderId,
+		MinAmount:        d.MinAmount,
+		MinCounterAmount: d.MinCounterAmount,
 	}
 	return
 }
@@ -230,6 +290,8 @@ type dexBatch struct {
 	Receipts         []uint64                `json:"receipts"`
 	LockedHeight     uint64                  `json:"lockedHeight"`
 	LivenessFallback bool                    `json:"livenessFallback"`
+	PoolConfig       *DexPoolConfig          `json:"poolConfig,omitempty"`
+	Fills            []uint64                `json:"fills,omitempty"`
 }
 
 // MarshalJSON() implements the json.Marshal interface for dex batch
@@ -248,6 +310,8 @@ func (x DexBatch) MarshalJSON() ([]byte, error) {
 		Receipts:         x.Receipts,
 		LockedHeight:     x.LockedHeight,
 		LivenessFallback: x.LivenessFallback,
+		PoolConfig:       x.PoolConfig,
+		Fills:            x.Fills,
 	})
 }
 
@@ -270,6 +334,8 @@ func (x *DexBatch) UnmarshalJSON(b []byte) (err error) {
 		Receipts:         d.Receipts,
 		LockedHeight:     d.LockedHeight,
 		LivenessFallback: d.LivenessFallback,
+		PoolConfig:       d.PoolConfig,
+		Fills:            d.Fills,
 	}
 	x.EnsureNonNil()
 	return
@@ -300,3 +366,605 @@ func (x *PoolPoints) UnmarshalJSON(bz []byte) (err error) {
 	}
 	return
 }
+
+// DEX POOL CONFIG CODE BELOW
+
+const (
+	DexFeeDenominator        = uint64(10_000)    // fee tiers are in basis points (1/10000ths)
+	DefaultDexFeeBasisPoints = uint64(100)       // the taker fee of a pool without a governance chosen config (1%)
+	MaxDexAmplification      = uint64(1_000_000) // the upper bound of the stable swap amplification coefficient
+	stableSwapMaxIterations  = 255               // the newton's method iteration bound of the stable swap math
+	partialFillBoundSteps    = 64                // the steps a partial fill walks its bound down before searching
+)
+
+// DexFeeTiers are the taker fees (in basis points) governance may choose for a pool
+var DexFeeTiers = []uint64{1, 5, 30, 100}
+
+// DefaultDexPoolConfig() returns the config of a pool without a governance chosen config: constant product with a 1% fee
+func DefaultDexPoolConfig(chainId uint64) *DexPoolConfig {
+	return &DexPoolConfig{ChainId: chainId, Curve: DexCurve_CONSTANT_PRODUCT, FeeBasisPoints: DefaultDexFeeBasisPoints}
+}
+
+// Check() validates the curve, amplification and fee tier of the pool config
+func (x *DexPoolConfig) Check() ErrorI {
+	if x == nil || !slices.Contains(DexFeeTiers, x.FeeBasisPoints) {
+		return ErrInvalidDexPoolConfig()
+	}
+	switch x.Curve {
+	case DexCurve_CONSTANT_PRODUCT:
+		if x.Amplification != 0 {
+			return ErrInvalidDexPoolConfig()
+		}
+	case DexCurve_STABLE_SWAP:
+		if x.Amplification == 0 || x.Amplification > MaxDexAmplification {
+			return ErrInvalidDexPoolConfig()
+		}
+	default:
+		return ErrInvalidDexPoolConfig()
+	}
+	return nil
+}
+
+// Equals() compares two pool configs
+func (x *DexPoolConfig) Equals(y *DexPoolConfig) bool {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	return x.ChainId == y.ChainId && x.Curve == y.Curve && x.Amplification == y.Amplification && x.FeeBasisPoints == y.FeeBasisPoints
+}
+
+// ComputeDY() returns the amount of y distributed for selling dX to the pool (x, y), net of the fee tier
+// NOTE: a nil config is the default constant product pool with a 1% fee
+func (x *DexPoolConfig) ComputeDY(poolX, poolY, dX uint64) uint64 {
+	if x == nil {
+		return constantProductDY(poolX, poolY, dX, DefaultDexFeeBasisPoints)
+	}
+	if x.Curve == DexCurve_STABLE_SWAP {
+		return stableSwapDY(poolX, poolY, dX, x.Amplification, x.FeeBasisPoints)
+	}
+	return constantProductDY(poolX, poolY, dX, x.FeeBasisPoints)
+}
+
+// ComputePartialFill() returns the largest part dX of an order that fills at no worse than its limit price
+// (requestedAmount / amountForSale) and the dY it receives; (0, 0) if no part of the order fills
+// NOTE: rounding dy down (and the stable swap's extra -1) costs small swaps relatively more, so whether a size fills isn't
+// monotonic in the size and a plain bisection over it may settle below the largest fill (or on no fill at all)
+func (x *DexPoolConfig) ComputePartialFill(poolX, poolY, amountForSale, requestedAmount uint64) (dX, dY uint64) {
+	// bound() returns dy and the largest dx' that dy would fill at the limit price: dy·amountForSale / requestedAmount
+	bound := func(dx uint64) (dy, b uint64) {
+		dy = x.ComputeDY(poolX, poolY, dx)
+		hi, lo := bits.Mul64(dy, amountForSale)
+		if hi >= requestedAmount {
+			return dy, math.MaxUint64
+		}
+		b, _ = bits.Div64(hi, lo, requestedAmount)
+		return
+	}
+	// fills() checks dy / dx >= requestedAmount / amountForSale without overflow
+	fills := func(dx uint64) (uint64, bool) {
+		dy, b := bound(dx)
+		return dy, dy != 0 && b >= dx
+	}
+	if amountForSale == 0 || requestedAmount == 0 {
+		if dY = x.ComputeDY(poolX, poolY, amountForSale); dY == 0 {
+			return 0, 0
+		}
+		return amountForSale, dY
+	}
+	// dy never grows as dx shrinks, so every fill at or below an unfilled size is at or below its bound; walking the bound
+	// down from the whole order lands exactly on the largest fill or on a known fill (floor) that nothing above beats
+	upper := amountForSale
+	walk := func(floor uint64) (uint64, bool) {
+		for range partialFillBoundSteps {
+			dy, b := bound(upper)
+			if upper <= floor || dy == 0 {
+				return floor, true
+			}
+			if b >= upper {
+				return upper, true
+			}
+			upper = b
+		}
+		return floor, false
+	}
+	dX, ok := walk(0)
+	if !ok {
+		// the walk is slow where the pool's marginal price is near the limit price; there the margin dy·amountForSale -
+		// dx·requestedAmount is concave but for rounding, so the fills are monotonic past its peak: find the peak, bisect
+		// above it for a fill and walk the rest of the way down to that fill
+		dX, _ = walk(partialFillSearch(x, poolX, poolY, amountForSale, requestedAmount, upper, fills))
+	}
+	if dX == 0 {
+		return 0, 0
+	}
+	dY, _ = fills(dX)
+	return dX, dY
+}
+
+// partialFillSearch() returns a fillable dx below the unfilled upper bound; 0 if the best sized part doesn't fill
+func partialFillSearch(config *DexPoolConfig, poolX, poolY, amountForSale, requestedAmount, upper uint64,
+	fills func(uint64) (uint64, bool)) uint64 {
+	// margin() returns dy·amountForSale - dx·requestedAmount
+	margin := func(dx uint64) *big.Int {
+		m := new(big.Int).Mul(new(big.Int).SetUint64(config.ComputeDY(poolX, poolY, dx)), new(big.Int).SetUint64(amountForSale))
+		return m.Sub(m, new(big.Int).Mul(new(big.Int).SetUint64(dx), new(big.Int).SetUint64(requestedAmount)))
+	}
+	// ternary search the peak of the margin in [1, upper]
+	lo, hi := uint64(1), upper
+	for hi-lo > 2 {
+		if m1, m2 := lo+(hi-lo)/3, hi-(hi-lo)/3; margin(m1).Cmp(margin(m2)) < 0 {
+			lo = m1 + 1
+		} else {
+			hi = m2
+		}
+	}
+	peak := lo
+	for dx := lo + 1; dx <= hi; dx++ {
+		if margin(dx).Cmp(margin(peak)) > 0 {
+			peak = dx
+		}
+	}
+	if _, ok := fills(peak); !ok {
+		return 0
+	}
+	// bisect the largest fillable dx in [peak, upper)
+	lo, hi = peak, upper-1
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		if _, ok := fills(mid); ok {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
+
+// Liquidity() returns the invariant measure of the pool (x, y) that liquidity points are minted against
+// Constant product: √(x * y); stable swap: D / 2 (both equal x for a balanced x = y pool)
+func (x *DexPoolConfig) Liquidity(poolX, poolY uint64) uint64 {
+	if x == nil || x.Curve != DexCurve_STABLE_SWAP {
+		return SqrtProductUint64(poolX, poolY)
+	}
+	d := stableSwapD(new(big.Int).SetUint64(poolX), new(big.Int).SetUint64(poolY), new(big.Int).SetUint64(x.Amplification))
+	if d.Rsh(d, 1); !d.IsUint64() {
+		return math.MaxUint64
+	}
+	return d.Uint64()
+}
+
+// constantProductDY() executes overflow protected uniswap V2 formula: dY = (dX' * y) / (x + dX') where dX' is dX net of the fee
+func constantProductDY(x, y, dX, feeBasisPoints uint64) uint64 {
+	// amountInWithFee = dX * (10000 - fee)
+	amountInWithFee := new(big.Int).Mul(new(big.Int).SetUint64(dX), new(big.Int).SetUint64(DexFeeDenominator-feeBasisPoints))
+	// numerator = amountInWithFee * y
+	numerator := new(big.Int).Mul(amountInWithFee, new(big.Int).SetUint64(y))
+	// denominator = x * 10000 + amountInWithFee
+	denominator := new(big.Int).Mul(new(big.Int).SetUint64(x), new(big.Int).SetUint64(DexFeeDenominator))
+	denominator.Add(denominator, amountInWithFee)
+	if denominator.Sign() == 0 {
+		return 0
+	}
+	// integer flooring
+	return numerator.Div(numerator, denominator).Uint64()
+}
+
+// stableSwapDY() executes the curve stable swap formula for 2 coins: solves the invariant for y after adding dX' (dX net of
+// the fee) to x; rounds down by 1 so precision loss always favors the pool
+func stableSwapDY(x, y, dX, amplification, feeBasisPoints uint64) uint64 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	bx, by, amp := new(big.Int).SetUint64(x), new(big.Int).SetUint64(y), new(big.Int).SetUint64(amplification)
+	// dX' = dX * (10000 - fee) / 10000
+	dXFee := new(big.Int).Mul(new(big.Int).SetUint64(dX), new(big.Int).SetUint64(DexFeeDenominator-feeBasisPoints))
+	dXFee.Div(dXFee, new(big.Int).SetUint64(DexFeeDenominator))
+	if dXFee.Sign() == 0 {
+		return 0
+	}
+	d := stableSwapD(bx, by, amp)
+	yAfter := stableSwapY(new(big.Int).Add(bx, dXFee), d, amp)
+	// dY = y - y' - 1
+	dY := new(big.Int).Sub(by, yAfter)
+	dY.Sub(dY, big.NewInt(1))
+	if dY.Sign() <= 0 || dY.Cmp(by) >= 0 {
+		return 0
+	}
+	return dY.Uint64()
+}
+
+// stableSwapD() solves the 2 coin stable swap invariant A·n^n·(x+y) + D = A·n^n·D + D^(n+1) / (n^n·x·y) for D (n = 2)
+// using newton's method: D' = (Ann·S + 2·Dp)·D / ((Ann - 1)·D + 3·Dp) where Dp = D^3 / (4·x·y)
+func stableSwapD(x, y, amp *big.Int) *big.Int {
+	s := new(big.Int).Add(x, y)
+	if s.Sign() == 0 || x.Sign() == 0 || y.Sign() == 0 {
+		return new(big.Int)
+	}
+	ann := new(big.Int).Mul(amp, big.NewInt(4))
+	d, two, three := new(big.Int).Set(s), big.NewInt(2), big.NewInt(3)
+	for range stableSwapMaxIterations {
+		// dP = D^3 / (4·x·y) computed in 2 steps like the reference implementation
+		dP := new(big.Int).Mul(d, d)
+		dP.Div(dP, new(big.Int).Mul(x, two))
+		dP.Mul(dP, d)
+		dP.Div(dP, new(big.Int).Mul(y, two))
+		prev := new(big.Int).Set(d)
+		// numerator = (Ann·S + 2·dP)·D
+		numerator := new(big.Int).Mul(ann, s)
+		numerator.Add(numerator, new(big.Int).Mul(dP, two))
+		numerator.Mul(numerator, d)
+		// denominator = (Ann - 1)·D + 3·dP
+		denominator := new(big.Int).Sub(ann, big.NewInt(1))
+		denominator.Mul(denominator, d)
+		denominator.Add(denominator, new(big.Int).Mul(dP, three))
+		if denominator.Sign() <= 0 {
+			return prev
+		}
+		d.Div(numerator, denominator)
+		// converged
+		if new(big.Int).Sub(d, prev).CmpAbs(big.NewInt(1)) <= 0 {
+			break
+		}
+	}
+	return d
+}
+
+// stableSwapY() solves the 2 coin stable swap invariant for y given x and D using newton's method:
+// y' = (y² + c) / (2·y + b - D) where c = D^3 / (4·x·Ann) and b = x + D / Ann
+func stableSwapY(x, d, amp *big.Int) *big.Int {
+	ann, two := new(big.Int).Mul(amp, big.NewInt(4)), big.NewInt(2)
+	// c = D^3 / (4·x·Ann) computed in 2 steps like the reference implementation
+	c := new(big.Int).Mul(d, d)
+	c.Div(c, new(big.Int).Mul(x, two))
+	c.Mul(c, d)
+	c.Div(c, new(big.Int).Mul(ann, two))
+	// b = x + D / Ann
+	b := new(big.Int).Div(d, ann)
+	b.Add(b, x)
+	y := new(big.Int).Set(d)
+	for range stableSwapMaxIterations {
+		prev := new(big.Int).Set(y)
+		// numerator = y² + c
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		// denominator = 2·y + b - D
+		denominator := new(big.Int).Mul(y, two)
+		denominator.Add(denominator, b)
+		denominator.Sub(denominator, d)
+		if denominator.Sign() <= 0 {
+			return prev
+		}
+		y.Div(numerator, denominator)
+		// converged
+		if new(big.Int).Sub(y, prev).CmpAbs(big.NewInt(1)) <= 0 {
+			break
+		}
+	}
+	return y
+}
+
+// DEX PRICE ORACLE CODE BELOW
+
+// Cumulative() returns the cumulative e6 scaled price (price x blocks) as of the given height, extrapolating the last price
+// NOTE: the last price stays in effect from last height until the next update, so extrapolation is exact for any height up
+// to the next update
+func (x *DexPriceAccumulator) Cumulative(height uint64) *big.Int {
+	cumulative := new(big.Int).SetBytes(x.CumulativeE6Price)
+	if height <= x.LastHeight {
+		return cumulative
+	}
+	elapsed := new(big.Int).SetUint64(height - x.LastHeight)
+	return cumulative.Add(cumulative, elapsed.Mul(elapsed, new(big.Int).SetUint64(x.LastE6Price)))
+}
+
+// Update() accumulates the last price up to height and sets the new spot price in effect from height onward
+// NOTE: the first observation only sets the price, as nothing was in effect before it
+func (x *DexPriceAccumulator) Update(height, e6ScaledPrice uint64) {
+	if x.LastHeight != 0 {
+		x.CumulativeE6Price = x.Cumulative(height).Bytes()
+	}
+	x.LastE6Price, x.LastHeight = e6ScaledPrice, height
+}
+
+// ComputeDexTWAP() returns the e6 scaled time weighted average price between startHeight and endHeight given the accumulator
+// as of (any height before or at) each of them: (cumulative(end) - cumulative(start)) / (end - start)
+// NOTE: the window must not start before the first price observation
+func ComputeDexTWAP(start, end *DexPriceAccumulator, startHeight, endHeight uint64) (uint64, ErrorI) {
+	if start == nil || end == nil || start.LastHeight == 0 || startHeight < start.LastHeight || endHeight <= startHeight {
+		return 0, ErrInvalidDexTWAPWindow()
+	}
+	diff := new(big.Int).Sub(end.Cumulative(endHeight), start.Cumulative(startHeight))
+	if diff.Sign() < 0 {
+		return 0, ErrInvalidDexTWAPWindow()
+	}
+	twap := diff.Div(diff, new(big.Int).SetUint64(endHeight-startHeight))
+	if !twap.IsUint64() {
+		return 0, ErrInvalidDexTWAPWindow()
+	}
+	return twap.Uint64(), nil
+}
+
+type dexPriceAccumulator struct {
+	ChainId           uint64 `json:"chainId"`
+	CumulativeE6Price string `json:"cumulativeE6Price"`
+	LastE6Price       uint64 `json:"lastE6Price"`
+	LastHeight        uint64 `json:"lastHeight"`
+}
+
+// MarshalJSON() implements the json.Marshal interface for DexPriceAccumulator
+// NOTE: the cumulative price is a base 10 string as it may exceed 64 bits
+func (x *DexPriceAccumulator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dexPriceAccumulator{
+		ChainId:           x.ChainId,
+		CumulativeE6Price: new(big.Int).SetBytes(x.CumulativeE6Price).String(),
+		LastE6Price:       x.LastE6Price,
+		LastHeight:        x.LastHeight,
+	})
+}
+
+// UnmarshalJSON() implements the json.Unmarshaller interface for DexPriceAccumulator
+func (x *DexPriceAccumulator) UnmarshalJSON(b []byte) (err error) {
+	a := new(dexPriceAccumulator)
+	if err = json.Unmarshal(b, a); err != nil {
+		return
+	}
+	cumulative, ok := new(big.Int).SetString(a.CumulativeE6Price, 10)
+	if !ok || cumulative.Sign() < 0 {
+		return ErrJSONUnmarshal(fmt.Errorf("invalid cumulative e6 price %q", a.CumulativeE6Price))
+	}
+	*x = DexPriceAccumulator{
+		ChainId:           a.ChainId,
+		CumulativeE6Price: cumulative.Bytes(),
+		LastE6Price:       a.LastE6Price,
+		LastHeight:        a.LastHeight,
+	}
+	return
+}
+
+// DEX LIQUIDITY POSITION CODE BELOW
+
+// NewDexPosition() values a liquidity provider's points with the local (x) and counter (y) pool sizes, the liquidity of the
+// pool (the curve invariant of x and y) and the cost basis of the position (nil if untracked)
+// - the redeemable amounts are the provider's share of each pool
+// - fees are the share of the redeemable amounts from liquidity grown beyond the basis, as only fees grow the liquidity per point
+// - values are in the local asset at the current price (x / y)
+func NewDexPosition(localChainId, remoteChainId uint64, address []byte, points, totalPoints, x, y, liquidity uint64, basis *DexPositionBasis) *DexPosition {
+	p := &DexPosition{
+		LocalChainId:    localChainId,
+		RemoteChainId:   remoteChainId,
+		Address:         address,
+		Points:          points,
+		TotalPoolPoints: totalPoints,
+		LocalAmount:     SafeMulDiv(x, points, totalPoints),
+		RemoteAmount:    SafeMulDiv(y, points, totalPoints),
+		Basis:           basis,
+	}
+	// value the counter asset in the local asset
+	inLocal := func(remote uint64) *big.Int {
+		return new(big.Int).SetUint64(SafeMulDiv(remote, x, y))
+	}
+	positionValue := new(big.Int).Add(new(big.Int).SetUint64(p.LocalAmount), inLocal(p.RemoteAmount))
+	p.PositionValue = saturateUint64(positionValue)
+	// without a basis there's nothing to compare against
+	if basis == nil {
+		return p
+	}
+	// calculate the fees earned from the growth of the position's liquidity
+	if current := SafeMulDiv(liquidity, points, totalPoints); current > basis.Liquidity {
+		p.LocalFees = SafeMulDiv(p.LocalAmount, current-basis.Liquidity, current)
+		p.RemoteFees = SafeMulDiv(p.RemoteAmount, current-basis.Liquidity, current)
+	}
+	// calculate the value of simply holding the deposits
+	holdValue := new(big.Int).Add(new(big.Int).SetUint64(basis.LocalDeposited), inLocal(basis.RemoteDeposited))
+	p.HoldValue = saturateUint64(holdValue)
+	// impermanent loss = hold value - (position value - fees)
+	feeValue := new(big.Int).Add(new(big.Int).SetUint64(p.LocalFees), inLocal(p.RemoteFees))
+	loss := new(big.Int).Sub(holdValue, positionValue.Sub(positionValue, feeValue))
+	if loss.IsInt64() {
+		p.ImpermanentLoss = loss.Int64()
+	} else if loss.Sign() > 0 {
+		p.ImpermanentLoss = math.MaxInt64
+	} else {
+		p.ImpermanentLoss = math.MinInt64
+	}
+	return p
+}
+
+// saturateUint64() converts a non-negative big integer to uint64, capping at the maximum
+func saturateUint64(i *big.Int) uint64 {
+	if !i.IsUint64() {
+		return math.MaxUint64
+	}
+	return i.Uint64()
+}
+
+// dexPositionJSON is the json.Marshaller and json.Unmarshaler implementation for the DexPosition object
+type dexPositionJSON struct {
+	LocalChainId    uint64            `json:"chainId"`
+	RemoteChainId   uint64            `json:"remoteChainId"`
+	Address         HexBytes          `json:"address"`
+	Points          uint64            `json:"points"`
+	TotalPoolPoints uint64            `json:"totalPoolPoints"`
+	LocalAmount     uint64            `json:"localAmount"`
+	RemoteAmount    uint64            `json:"remoteAmount"`
+	Basis           *DexPositionBasis `json:"basis"`
+	LocalFees       uint64            `json:"localFees"`
+	RemoteFees      uint64            `json:"remoteFees"`
+	HoldValue       uint64            `json:"holdValue"`
+	PositionValue   uint64            `json:"positionValue"`
+	ImpermanentLoss int64             `json:"impermanentLoss"`
+}
+
+// MarshalJSON() implements the json.Marshaller interface for DexPosition
+func (x *DexPosition) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dexPositionJSON{
+		LocalChainId:    x.LocalChainId,
+		RemoteChainId:   x.RemoteChainId,
+		Address:         x.Address,
+		Points:          x.Points,
+		TotalPoolPoints: x.TotalPoolPoints,
+		LocalAmount:     x.LocalAmount,
+		RemoteAmount:    x.RemoteAmount,
+		Basis:           x.Basis,
+		LocalFees:       x.LocalFees,
+		RemoteFees:      x.RemoteFees,
+		HoldValue:       x.HoldValue,
+		PositionValue:   x.PositionValue,
+		ImpermanentLoss: x.ImpermanentLoss,
+	})
+}
+
+// UnmarshalJSON() implements the json.Unmarshaler interface for DexPosition
+func (x *DexPosition) UnmarshalJSON(b []byte) (err error) {
+	j := new(dexPositionJSON)
+	if err = json.Unmarshal(b, j); err != nil {
+		return
+	}
+	*x = DexPosition{
+		LocalChainId:    j.LocalChainId,
+		RemoteChainId:   j.RemoteChainId,
+		Address:         j.Address,
+		Points:          j.Points,
+		TotalPoolPoints: j.TotalPoolPoints,
+		LocalAmount:     j.LocalAmount,
+		RemoteAmount:    j.RemoteAmount,
+		Basis:           j.Basis,
+		LocalFees:       j.LocalFees,
+		RemoteFees:      j.RemoteFees,
+		HoldValue:       j.HoldValue,
+		PositionValue:   j.PositionValue,
+		ImpermanentLoss: j.ImpermanentLoss,
+	}
+	return
+}
+
+// the side of a chain pair holding up the batch pipeline (see DexBatchStatus)
+const (
+	DexStuckNone    = "none"    // no stall recorded
+	DexStuckLocal   = "local"   // this chain can't read the counter chain's batch
+	DexStuckCounter = "counter" // the counter chain hasn't (correctly) receipted our locked batch
+)
+
+// StuckSide() returns the side of the pair a stall reason points to
+func (x DexStallReason) StuckSide() string {
+	switch x {
+	case DexStallReason_DEX_STALL_RECEIPT_HASH_MISMATCH, DexStallReason_DEX_STALL_RECEIPT_COUNT_MISMATCH:
+		return DexStuckCounter
+	case DexStallReason_DEX_STALL_MISSING_COUNTER_BATCH:
+		return DexStuckLocal
+	default:
+		return DexStuckNone
+	}
+}
+
+// dexBatchStallJSON is the json.Marshaller and json.Unmarshaler implementation for the DexBatchStall object
+type dexBatchStallJSON struct {
+	ChainId             uint64   `json:"chainId"`
+	Reason              string   `json:"reason"`
+	SinceHeight         uint64   `json:"sinceHeight"`
+	LastHeight          uint64   `json:"lastHeight"`
+	Count               uint64   `json:"count"`
+	ExpectedReceiptHash HexBytes `json:"expectedReceiptHash"`
+	ReceivedReceiptHash HexBytes `json:"receivedReceiptHash"`
+	ExpectedReceipts    uint64   `json:"expectedReceipts"`
+	ReceivedReceipts    uint64   `json:"receivedReceipts"`
+}
+
+// MarshalJSON() implements the json.Marshaller interface for DexBatchStall
+func (x *DexBatchStall) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dexBatchStallJSON{
+		ChainId:             x.ChainId,
+		Reason:              x.Reason.String(),
+		SinceHeight:         x.SinceHeight,
+		LastHeight:          x.LastHeight,
+		Count:               x.Count,
+		ExpectedReceiptHash: x.ExpectedReceiptHash,
+		ReceivedReceiptHash: x.ReceivedReceiptHash,
+		ExpectedReceipts:    x.ExpectedReceipts,
+		ReceivedReceipts:    x.ReceivedReceipts,
+	})
+}
+
+// UnmarshalJSON() implements the json.Unmarshaler interface for DexBatchStall
+func (x *DexBatchStall) UnmarshalJSON(b []byte) (err error) {
+	j := new(dexBatchStallJSON)
+	if err = json.Unmarshal(b, j); err != nil {
+		return
+	}
+	reason, ok := DexStallReason_value[j.Reason]
+	if !ok {
+		return ErrJSONUnmarshal(fmt.Errorf("invalid dex stall reason %q", j.Reason))
+	}
+	*x = DexBatchStall{
+		ChainId:             j.ChainId,
+		Reason:              DexStallReason(reason),
+		SinceHeight:         j.SinceHeight,
+		LastHeight:          j.LastHeight,
+		Count:               j.Count,
+		ExpectedReceiptHash: j.ExpectedReceiptHash,
+		ReceivedReceiptHash: j.ReceivedReceiptHash,
+		ExpectedReceipts:    j.ExpectedReceipts,
+		ReceivedReceipts:    j.ReceivedReceipts,
+	}
+	return
+}
+
+// dexBatchStatusJSON is the json.Marshaller and json.Unmarshaler implementation for the DexBatchStatus object
+type dexBatchStatusJSON struct {
+	LocalChainId           uint64         `json:"chainId"`
+	RemoteChainId          uint64         `json:"remoteChainId"`
+	Height                 uint64         `json:"height"`
+	LockedHeight           uint64         `json:"lockedHeight"`
+	LockedHash             HexBytes       `json:"lockedHash"`
+	LockedOrders           uint64         `json:"lockedOrders"`
+	LockedDeposits         uint64         `json:"lockedDeposits"`
+	LockedWithdrawals      uint64         `json:"lockedWithdrawals"`
+	BlocksLocked           uint64         `json:"blocksLocked"`
+	LivenessFallbackBlocks uint64         `json:"livenessFallbackBlocks"`
+	LivenessFallbackHeight uint64         `json:"livenessFallbackHeight"`
+	Stuck                  string         `json:"stuck"`
+	Stall                  *DexBatchStall `json:"stall"`
+}
+
+// MarshalJSON() implements the json.Marshaller interface for DexBatchStatus
+func (x *DexBatchStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dexBatchStatusJSON{
+		LocalChainId:           x.LocalChainId,
+		RemoteChainId:          x.RemoteChainId,
+		Height:                 x.Height,
+		LockedHeight:           x.LockedHeight,
+		LockedHash:             x.LockedHash,
+		LockedOrders:           x.LockedOrders,
+		LockedDeposits:         x.LockedDeposits,
+		LockedWithdrawals:      x.LockedWithdrawals,
+		BlocksLocked:           x.BlocksLocked,
+		LivenessFallbackBlocks: x.LivenessFallbackBlocks,
+		LivenessFallbackHeight: x.LivenessFallbackHeight,
+		Stuck:                  x.Stuck,
+		Stall:                  x.Stall,
+	})
+}
+
+// UnmarshalJSON() implements the json.Unmarshaler interface for DexBatchStatus
+func (x *DexBatchStatus) UnmarshalJSON(b []byte) (err error) {
+	j := new(dexBatchStatusJSON)
+	if err = json.Unmarshal(b, j); err != nil {
+		return
+	}
+	*x = DexBatchStatus{
+		LocalChainId:           j.LocalChainId,
+		RemoteChainId:          j.RemoteChainId,
+		Height:                 j.Height,
+		LockedHeight:           j.LockedHeight,
+		LockedHash:             j.LockedHash,
+		LockedOrders:           j.LockedOrders,
+		LockedDeposits:         j.LockedDeposits,
+		LockedWithdrawals:      j.LockedWithdrawals,
+		BlocksLocked:           j.BlocksLocked,
+		LivenessFallbackBlocks: j.LivenessFallbackBlocks,
+		LivenessFallbackHeight: j.LivenessFallbackHeight,
+		Stuck:                  j.Stuck,
+		Stall:                  j.Stall,
+	}
+	return
+}
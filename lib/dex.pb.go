@@ -21,6 +21,112 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// DexStallReason is why processing the receipts for a chain's locked batch was skipped
+type DexStallReason int32
+
+const (
+	// DEX_STALL_NONE: the receipts were processed
+	DexStallReason_DEX_STALL_NONE DexStallReason = 0
+	// DEX_STALL_RECEIPT_HASH_MISMATCH: the counter chain's batch doesn't receipt our locked batch (yet)
+	DexStallReason_DEX_STALL_RECEIPT_HASH_MISMATCH DexStallReason = 1
+	// DEX_STALL_RECEIPT_COUNT_MISMATCH: the counter chain's batch receipts our locked batch with the wrong number of receipts
+	DexStallReason_DEX_STALL_RECEIPT_COUNT_MISMATCH DexStallReason = 2
+	// DEX_STALL_MISSING_COUNTER_BATCH: the certificate didn't carry the root chain's batch (nested chains only)
+	DexStallReason_DEX_STALL_MISSING_COUNTER_BATCH DexStallReason = 3
+)
+
+// Enum value maps for DexStallReason.
+var (
+	DexStallReason_name = map[int32]string{
+		0: "DEX_STALL_NONE",
+		1: "DEX_STALL_RECEIPT_HASH_MISMATCH",
+		2: "DEX_STALL_RECEIPT_COUNT_MISMATCH",
+		3: "DEX_STALL_MISSING_COUNTER_BATCH",
+	}
+	DexStallReason_value = map[string]int32{
+		"DEX_STALL_NONE":                   0,
+		"DEX_STALL_RECEIPT_HASH_MISMATCH":  1,
+		"DEX_STALL_RECEIPT_COUNT_MISMATCH": 2,
+		"DEX_STALL_MISSING_COUNTER_BATCH":  3,
+	}
+)
+
+func (x DexStallReason) Enum() *DexStallReason {
+	p := new(DexStallReason)
+	*p = x
+	return p
+}
+
+func (x DexStallReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DexStallReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_dex_proto_enumTypes[0].Descriptor()
+}
+
+func (DexStallReason) Type() protoreflect.EnumType {
+	return &file_dex_proto_enumTypes[0]
+}
+
+func (x DexStallReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DexStallReason.Descriptor instead.
+func (DexStallReason) EnumDescriptor() ([]byte, []int) {
+	return file_dex_proto_rawDescGZIP(), []int{0}
+}
+
+// DexCurve is the invariant a liquidity pool prices swaps against
+type DexCurve int32
+
+const (
+	// CONSTANT_PRODUCT: the uniswap V2 style x * y = k invariant for pairs without a price relationship
+	DexCurve_CONSTANT_PRODUCT DexCurve = 0
+	// STABLE_SWAP: the curve style stable swap invariant for pairs that trade near 1:1
+	DexCurve_STABLE_SWAP DexCurve = 1
+)
+
+// Enum value maps for DexCurve.
+var (
+	DexCurve_name = map[int32]string{
+		0: "CONSTANT_PRODUCT",
+		1: "STABLE_SWAP",
+	}
+	DexCurve_value = map[string]int32{
+		"CONSTANT_PRODUCT": 0,
+		"STABLE_SWAP":      1,
+	}
+)
+
+func (x DexCurve) Enum() *DexCurve {
+	p := new(DexCurve)
+	*p = x
+	return p
+}
+
+func (x DexCurve) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DexCurve) Descriptor() protoreflect.EnumDescriptor {
+	return file_dex_proto_enumTypes[1].Descriptor()
+}
+
+func (DexCurve) Type() protoreflect.EnumType {
+	return &file_dex_proto_enumTypes[1]
+}
+
+func (x DexCurve) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DexCurve.Descriptor instead.
+func (DexCurve) EnumDescriptor() ([]byte, []int) {
+	return file_dex_proto_rawDescGZIP(), []int{1}
+}
+
 // DexLimitOrder is the core structure holding AMM token swap data - created when user submits intent, processed
 // through cross-chain validation, and resolved when both chains confirm the atomic swap completion.
 type DexLimitOrder struct {
@@ -32,7 +138,21 @@ type DexLimitOrder struct {
 	// address: the address where the funds are transferred from and to
 	Address []byte `protobuf:"bytes,3,opt,name=address,proto3" json:"address"` // @gotags: json:"address"
 	// OrderId: auto-populated by the state machine to assign the unique bytes to the order
-	OrderId       []byte `protobuf:"bytes,4,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	OrderId []byte `protobuf:"bytes,4,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	// expiry_height: the origin chain height the order stops resting at; an unfilled order (or the unfilled part of a
+	// partially fillable order) is requeued into the next batch until then (0 = single batch, refunded if unfilled)
+	ExpiryHeight uint64 `protobuf:"varint,5,opt,name=expiryHeight,proto3" json:"expiryHeight"` // @gotags: json:"expiryHeight"
+	// partial_fill: allows the order to be filled in part at the limit price of amount_for_sale / requested_amount
+	PartialFill bool `protobuf:"varint,6,opt,name=partialFill,proto3" json:"partialFill"` // @gotags: json:"partialFill"
+	// filled_amount: the amount of the order already sold in earlier batches (a resting order's amount for sale and
+	// requested amount are the unfilled remainder)
+	FilledAmount uint64 `protobuf:"varint,7,opt,name=filledAmount,proto3" json:"filledAmount"` // @gotags: json:"filledAmount"
+	// route: the chain the proceeds are routed to by the root chain (A -> root -> B); a routed order's requested_amount is
+	// the minimum final output of the route
+	Route []uint64 `protobuf:"varint,8,rep,packed,name=route,proto3" json:"route"` // @gotags: json:"route"
+	// origin_chain_id: the chain a forwarded order of a route was first sold on (0 = not forwarded); if the forwarded leg
+	// fails, its proceeds are swapped back to the origin chain's asset at the current price
+	OriginChainId uint64 `protobuf:"varint,9,opt,name=originChainId,proto3" json:"originChainId"` // @gotags: json:"originChainId"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -95,6 +215,41 @@ func (x *DexLimitOrder) GetOrderId() []byte {
 	return nil
 }
 
+func (x *DexLimitOrder) GetExpiryHeight() uint64 {
+	if x != nil {
+		return x.ExpiryHeight
+	}
+	return 0
+}
+
+func (x *DexLimitOrder) GetPartialFill() bool {
+	if x != nil {
+		return x.PartialFill
+	}
+	return false
+}
+
+func (x *DexLimitOrder) GetFilledAmount() uint64 {
+	if x != nil {
+		return x.FilledAmount
+	}
+	return 0
+}
+
+func (x *DexLimitOrder) GetRoute() []uint64 {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+func (x *DexLimitOrder) GetOriginChainId() uint64 {
+	if x != nil {
+		return x.OriginChainId
+	}
+	return 0
+}
+
 // DexLiquidityDeposit a liquidity deposit command
 type DexLiquidityDeposit struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -103,7 +258,9 @@ type DexLiquidityDeposit struct {
 	// amount: the amount of the deposit
 	Amount uint64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
 	// OrderId: auto-populated by the state machine to assign the unique bytes to the order
-	OrderId       []byte `protobuf:"bytes,4,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	OrderId []byte `protobuf:"bytes,4,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	// min_points: the minimum liquidity points the deposit must mint, otherwise it's refunded (0 = no minimum)
+	MinPoints     uint64 `protobuf:"varint,5,opt,name=min_points,json=minPoints,proto3" json:"minPoints"` // @gotags: json:"minPoints"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -159,6 +316,13 @@ func (x *DexLiquidityDeposit) GetOrderId() []byte {
 	return nil
 }
 
+func (x *DexLiquidityDeposit) GetMinPoints() uint64 {
+	if x != nil {
+		return x.MinPoints
+	}
+	return 0
+}
+
 // DexLiquidityWithdraw a liquidity withdraw command
 type DexLiquidityWithdraw struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -167,9 +331,13 @@ type DexLiquidityWithdraw struct {
 	// percent: the percent of points being withdrawn
 	Percent uint64 `protobuf:"varint,2,opt,name=percent,proto3" json:"percent,omitempty"`
 	// OrderId: auto-populated by the state machine to assign the unique bytes to the order
-	OrderId       []byte `protobuf:"bytes,4,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	OrderId []byte `protobuf:"bytes,4,opt,name=OrderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	// min_amount: the minimum amount of the batch's (origin chain) asset to receive, otherwise no points are burned (0 = no minimum)
+	MinAmount uint64 `protobuf:"varint,5,opt,name=min_amount,json=minAmount,proto3" json:"minAmount"` // @gotags: json:"minAmount"
+	// min_counter_amount: the minimum amount of the counter asset to receive, otherwise no points are burned (0 = no minimum)
+	MinCounterAmount uint64 `protobuf:"varint,6,opt,name=min_counter_amount,json=minCounterAmount,proto3" json:"minCounterAmount"` // @gotags: json:"minCounterAmount"
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *DexLiquidityWithdraw) Reset() {
@@ -223,6 +391,20 @@ func (x *DexLiquidityWithdraw) GetOrderId() []byte {
 	return nil
 }
 
+func (x *DexLiquidityWithdraw) GetMinAmount() uint64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *DexLiquidityWithdraw) GetMinCounterAmount() uint64 {
+	if x != nil {
+		return x.MinCounterAmount
+	}
+	return 0
+}
+
 // DexBatch is a group of limit orders that must be processed atomically
 type DexBatch struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -250,8 +432,14 @@ type DexBatch struct {
 	LockedHeight uint64 `protobuf:"varint,11,opt,name=locked_height,json=lockedHeight,proto3" json:"locked_height,omitempty"`
 	// liveness_fallback: a boolean directing if the action is a 'liveness fallback'
 	LivenessFallback bool `protobuf:"varint,12,opt,name=liveness_fallback,json=livenessFallback,proto3" json:"livenessFallback"` // @gotags: json:"livenessFallback"
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// pool_config: the root chain's governance chosen curve and fee tier both chains price this batch with
+	// nested chains price with the config of the root chain's batch; empty is the default constant product pool with a 1% fee
+	PoolConfig *DexPoolConfig `protobuf:"bytes,13,opt,name=pool_config,json=poolConfig,proto3" json:"poolConfig"` // @gotags: json:"poolConfig"
+	// fills: the amount sold of each order of the receipted batch (parallel to receipts), only set when the receipted
+	// batch contains partially fillable orders; without fills a non-zero receipt means the whole order was sold
+	Fills         []uint64 `protobuf:"varint,14,rep,packed,name=fills,proto3" json:"fills"` // @gotags: json:"fills"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DexBatch) Reset() {
@@ -368,6 +556,20 @@ func (x *DexBatch) GetLivenessFallback() bool {
 	return false
 }
 
+func (x *DexBatch) GetPoolConfig() *DexPoolConfig {
+	if x != nil {
+		return x.PoolConfig
+	}
+	return nil
+}
+
+func (x *DexBatch) GetFills() []uint64 {
+	if x != nil {
+		return x.Fills
+	}
+	return nil
+}
+
 // DexPrice represents the computed swap price between two chains.
 type DexPrice struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -450,31 +652,35 @@ func (x *DexPrice) GetE6ScaledPrice() uint64 {
 	return 0
 }
 
-// PoolPoints represents an ownership 'share' of the pool
-type PoolPoints struct {
+// DexQuote is the expected output of a (multi-hop) dex swap along a path of chains, priced with the current pool sizes
+type DexQuote struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// address: the recipient address of the points
-	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address"` // @gotags: json:"address"
-	// points: the amount of points owned
-	Points        uint64 `protobuf:"varint,2,opt,name=points,proto3" json:"points"` // @gotags: json:"points"
+	// path: the chains swapped through, starting with the chain of the sold asset
+	Path []uint64 `protobuf:"varint,1,rep,packed,name=path,proto3" json:"path"` // @gotags: json:"path"
+	// amount_in: the amount of the first chain's asset sold
+	AmountIn uint64 `protobuf:"varint,2,opt,name=amount_in,json=amountIn,proto3" json:"amountIn"` // @gotags: json:"amountIn"
+	// leg_outputs: the expected output of each leg (path[i] -> path[i+1])
+	LegOutputs []uint64 `protobuf:"varint,3,rep,packed,name=leg_outputs,json=legOutputs,proto3" json:"legOutputs"` // @gotags: json:"legOutputs"
+	// amount_out: the expected amount of the last chain's asset received
+	AmountOut     uint64 `protobuf:"varint,4,opt,name=amount_out,json=amountOut,proto3" json:"amountOut"` // @gotags: json:"amountOut"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PoolPoints) Reset() {
-	*x = PoolPoints{}
+func (x *DexQuote) Reset() {
+	*x = DexQuote{}
 	mi := &file_dex_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PoolPoints) String() string {
+func (x *DexQuote) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PoolPoints) ProtoMessage() {}
+func (*DexQuote) ProtoMessage() {}
 
-func (x *PoolPoints) ProtoReflect() protoreflect.Message {
+func (x *DexQuote) ProtoReflect() protoreflect.Message {
 	mi := &file_dex_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -486,70 +692,1192 @@ func (x *PoolPoints) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PoolPoints.ProtoReflect.Descriptor instead.
-func (*PoolPoints) Descriptor() ([]byte, []int) {
+// Deprecated: Use DexQuote.ProtoReflect.Descriptor instead.
+func (*DexQuote) Descriptor() ([]byte, []int) {
 	return file_dex_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *PoolPoints) GetAddress() []byte {
+func (x *DexQuote) GetPath() []uint64 {
 	if x != nil {
-		return x.Address
+		return x.Path
 	}
 	return nil
 }
 
-func (x *PoolPoints) GetPoints() uint64 {
+func (x *DexQuote) GetAmountIn() uint64 {
 	if x != nil {
-		return x.Points
+		return x.AmountIn
 	}
 	return 0
 }
 
-var File_dex_proto protoreflect.FileDescriptor
+func (x *DexQuote) GetLegOutputs() []uint64 {
+	if x != nil {
+		return x.LegOutputs
+	}
+	return nil
+}
 
-const file_dex_proto_rawDesc = "" +
-	"\n" +
-	"\tdex.proto\x12\x05types\"\x93\x01\n" +
-	"\rDexLimitOrder\x12$\n" +
-	"\ramountForSale\x18\x01 \x01(\x04R\ramountForSale\x12(\n" +
-	"\x0frequestedAmount\x18\x02 \x01(\x04R\x0frequestedAmount\x12\x18\n" +
-	"\aaddress\x18\x03 \x01(\fR\aaddress\x12\x18\n" +
-	"\aOrderId\x18\x04 \x01(\fR\aOrderId\"a\n" +
-	"\x13DexLiquidityDeposit\x12\x18\n" +
-	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x04R\x06amount\x12\x18\n" +
-	"\aOrderId\x18\x04 \x01(\fR\aOrderId\"d\n" +
-	"\x14DexLiquidityWithdraw\x12\x18\n" +
-	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x18\n" +
-	"\apercent\x18\x02 \x01(\x04R\apercent\x12\x18\n" +
-	"\aOrderId\x18\x04 \x01(\fR\aOrderId\"\x87\x04\n" +
-	"\bDexBatch\x12\x1c\n" +
-	"\tCommittee\x18\x01 \x01(\x04R\tCommittee\x12!\n" +
-	"\freceipt_hash\x18\x02 \x01(\fR\vreceiptHash\x12,\n" +
-	"\x06orders\x18\x03 \x03(\v2\x14.types.DexLimitOrderR\x06orders\x126\n" +
-	"\bdeposits\x18\x04 \x03(\v2\x1a.types.DexLiquidityDepositR\bdeposits\x12=\n" +
-	"\vwithdrawals\x18\x05 \x03(\v2\x1b.types.DexLiquidityWithdrawR\vwithdrawals\x12\x1b\n" +
-	"\tpool_size\x18\x06 \x01(\x04R\bpoolSize\x12*\n" +
-	"\x11counter_pool_size\x18\a \x01(\x04R\x0fcounterPoolSize\x122\n" +
-	"\vpool_points\x18\b \x03(\v2\x11.types.PoolPointsR\n" +
-	"poolPoints\x12*\n" +
-	"\x11total_pool_points\x18\t \x01(\x04R\x0ftotalPoolPoints\x12\x1a\n" +
-	"\breceipts\x18\n" +
-	" \x03(\x04R\breceipts\x12#\n" +
-	"\rlocked_height\x18\v \x01(\x04R\flockedHeight\x12+\n" +
-	"\x11liveness_fallback\x18\f \x01(\bR\x10livenessFallback\"\xc0\x01\n" +
-	"\bDexPrice\x12$\n" +
-	"\x0elocal_chain_id\x18\x01 \x01(\x04R\flocalChainId\x12&\n" +
-	"\x0fremote_chain_id\x18\x02 \x01(\x04R\rremoteChainId\x12\x1d\n" +
-	"\n" +
-	"local_pool\x18\x03 \x01(\x04R\tlocalPool\x12\x1f\n" +
-	"\vremote_pool\x18\x04 \x01(\x04R\n" +
-	"remotePool\x12&\n" +
-	"\x0fe6_scaled_price\x18\x05 \x01(\x04R\re6ScaledPrice\">\n" +
+func (x *DexQuote) GetAmountOut() uint64 {
+	if x != nil {
+		return x.AmountOut
+	}
+	return 0
+}
+
+// DexPriceAccumulator is the per chain pair cumulative price oracle updated each time a dex batch is locked
+// The time weighted average price between two heights is the difference of the cumulative prices divided by
+// the number of blocks between them (see DexTWAP)
+type DexPriceAccumulator struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// chain_id: the counter chain of the pair
+	ChainId uint64 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chainId"` // @gotags: json:"chainId"
+	// cumulative_e6_price: the big endian sum of e6 scaled price x blocks the price was in effect, as of last_height
+	CumulativeE6Price []byte `protobuf:"bytes,2,opt,name=cumulative_e6_price,json=cumulativeE6Price,proto3" json:"cumulativeE6Price"` // @gotags: json:"cumulativeE6Price"
+	// last_e6_price: the e6 scaled spot price set at last_height (in effect until the next update)
+	LastE6Price uint64 `protobuf:"varint,3,opt,name=last_e6_price,json=lastE6Price,proto3" json:"lastE6Price"` // @gotags: json:"lastE6Price"
+	// last_height: the height the accumulator was last updated
+	LastHeight    uint64 `protobuf:"varint,4,opt,name=last_height,json=lastHeight,proto3" json:"lastHeight"` // @gotags: json:"lastHeight"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DexPriceAccumulator) Reset() {
+	*x = DexPriceAccumulator{}
+	mi := &file_dex_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DexPriceAccumulator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DexPriceAccumulator) ProtoMessage() {}
+
+func (x *DexPriceAccumulator) ProtoReflect() protoreflect.Message {
+	mi := &file_dex_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DexPriceAccumulator.ProtoReflect.Descriptor instead.
+func (*DexPriceAccumulator) Descriptor() ([]byte, []int) {
+	return file_dex_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DexPriceAccumulator) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *DexPriceAccumulator) GetCumulativeE6Price() []byte {
+	if x != nil {
+		return x.CumulativeE6Price
+	}
+	return nil
+}
+
+func (x *DexPriceAccumulator) GetLastE6Price() uint64 {
+	if x != nil {
+		return x.LastE6Price
+	}
+	return 0
+}
+
+func (x *DexPriceAccumulator) GetLastHeight() uint64 {
+	if x != nil {
+		return x.LastHeight
+	}
+	return 0
+}
+
+// DexTWAP is the time weighted average price of a chain pair over a height window
+type DexTWAP struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// local_chain_id: the local chain id
+	LocalChainId uint64 `protobuf:"varint,1,opt,name=local_chain_id,json=localChainId,proto3" json:"chainId"` // @gotags: json:"chainId"
+	// remote_chain_id: the remote chain id
+	RemoteChainId uint64 `protobuf:"varint,2,opt,name=remote_chain_id,json=remoteChainId,proto3" json:"remoteChainId"` // @gotags: json:"remoteChainId"
+	// start_height: the (inclusive) start of the window
+	StartHeight uint64 `protobuf:"varint,3,opt,name=start_height,json=startHeight,proto3" json:"startHeight"` // @gotags: json:"startHeight"
+	// end_height: the (exclusive) end of the window
+	EndHeight uint64 `protobuf:"varint,4,opt,name=end_height,json=endHeight,proto3" json:"endHeight"` // @gotags: json:"endHeight"
+	// e6_scaled_twap: the time weighted average price over the window scaled by 1e6
+	E6ScaledTwap uint64 `protobuf:"varint,5,opt,name=e6_scaled_twap,json=e6ScaledTwap,proto3" json:"e6ScaledTwap"` // @gotags: json:"e6ScaledTwap"
+	// e6_scaled_spot_price: the spot price in effect at end_height scaled by 1e6
+	E6ScaledSpotPrice uint64 `protobuf:"varint,6,opt,name=e6_scaled_spot_price,json=e6ScaledSpotPrice,proto3" json:"e6ScaledSpotPrice"` // @gotags: json:"e6ScaledSpotPrice"
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DexTWAP) Reset() {
+	*x = DexTWAP{}
+	mi := &file_dex_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DexTWAP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DexTWAP) ProtoMessage() {}
+
+func (x *DexTWAP) ProtoReflect() protoreflect.Message {
+	mi := &file_dex_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DexTWAP.ProtoReflect.Descriptor instead.
+func (*DexTWAP) Descriptor() ([]byte, []int) {
+	return file_dex_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DexTWAP) GetLocalChainId() uint64 {
+	if x != nil {
+		return x.LocalChainId
+	}
+	return 0
+}
+
+func (x *DexTWAP) GetRemoteChainId() uint64 {
+	if x != nil {
+		return x.RemoteChainId
+	}
+	return 0
+}
+
+func (x *DexTWAP) GetStartHeight() uint64 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+func (x *DexTWAP) GetEndHeight() uint64 {
+	if x != nil {
+		return x.EndHeight
+	}
+	return 0
+}
+
+func (x *DexTWAP) GetE6ScaledTwap() uint64 {
+	if x != nil {
+		return x.E6ScaledTwap
+	}
+	return 0
+}
+
+func (x *DexTWAP) GetE6ScaledSpotPrice() uint64 {
+	if x != nil {
+		return x.E6ScaledSpotPrice
+	}
+	return 0
+}
+
+// DexPositionBasis is the cost basis of a liquidity provider's position, tracked in state as liquidity is deposited
+// and withdrawn; withdrawals reduce every field pro-rata to the points withdrawn (average cost)
+type DexPositionBasis struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// local_deposited: the amount of the local asset deposited (net of withdrawals)
+	LocalDeposited uint64 `protobuf:"varint,1,opt,name=local_deposited,json=localDeposited,proto3" json:"localDeposited"` // @gotags: json:"localDeposited"
+	// remote_deposited: the amount of the counter asset deposited (net of withdrawals)
+	RemoteDeposited uint64 `protobuf:"varint,2,opt,name=remote_deposited,json=remoteDeposited,proto3" json:"remoteDeposited"` // @gotags: json:"remoteDeposited"
+	// liquidity: the units of pool liquidity (the curve invariant) added by the deposits (net of withdrawals)
+	// the liquidity per point only grows by fees, so the position's current liquidity minus this basis is fee earnings
+	Liquidity     uint64 `protobuf:"varint,3,opt,name=liquidity,proto3" json:"liquidity"` // @gotags: json:"liquidity"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DexPositionBasis) Reset() {
+	*x = DexPositionBasis{}
+	mi := &file_dex_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DexPositionBasis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DexPositionBasis) ProtoMessage() {}
+
+func (x *DexPositionBasis) ProtoReflect() protoreflect.Message {
+	mi := &file_dex_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DexPositionBasis.ProtoReflect.Descriptor instead.
+func (*DexPositionBasis) Descriptor() ([]byte, []int) {
+	return file_dex_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DexPositionBasis) GetLocalDeposited() uint64 {
+	if x != nil {
+		return x.LocalDeposited
+	}
+	return 0
+}
+
+func (x *DexPositionBasis) GetRemoteDeposited() uint64 {
+	if x != nil {
+		return x.RemoteDeposited
+	}
+	return 0
+}
+
+func (x *DexPositionBasis) GetLiquidity() uint64 {
+	if x != nil {
+		return x.Liquidity
+	}
+	return 0
+}
+
+// DexPosition is a liquidity provider's position in the pool of a counter chain, valued with the locked batch pool sizes
+type DexPosition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// local_chain_id: the local chain id
+	LocalChainId uint64 `protobuf:"varint,1,opt,name=local_chain_id,json=localChainId,proto3" json:"chainId"` // @gotags: json:"chainId"
+	// remote_chain_id: the counter chain id of the pool
+	RemoteChainId uint64 `protobuf:"varint,2,opt,name=remote_chain_id,json=remoteChainId,proto3" json:"remoteChainId"` // @gotags: json:"remoteChainId"
+	// address: the liquidity provider
+	Address []byte `protobuf:"bytes,3,opt,name=address,proto3" json:"address"` // @gotags: json:"address"
+	// points: the liquidity points of the provider
+	Points uint64 `protobuf:"varint,4,opt,name=points,proto3" json:"points"` // @gotags: json:"points"
+	// total_pool_points: the total liquidity points of the pool
+	TotalPoolPoints uint64 `protobuf:"varint,5,opt,name=total_pool_points,json=totalPoolPoints,proto3" json:"totalPoolPoints"` // @gotags: json:"totalPoolPoints"
+	// local_amount: the amount of the local asset the points currently redeem
+	LocalAmount uint64 `protobuf:"varint,6,opt,name=local_amount,json=localAmount,proto3" json:"localAmount"` // @gotags: json:"localAmount"
+	// remote_amount: the amount of the counter asset the points currently redeem
+	RemoteAmount uint64 `protobuf:"varint,7,opt,name=remote_amount,json=remoteAmount,proto3" json:"remoteAmount"` // @gotags: json:"remoteAmount"
+	// basis: the cost basis of the position (empty for positions that predate cost basis tracking)
+	Basis *DexPositionBasis `protobuf:"bytes,8,opt,name=basis,proto3" json:"basis"` // @gotags: json:"basis"
+	// local_fees: the part of local_amount earned by fees since depositing
+	LocalFees uint64 `protobuf:"varint,9,opt,name=local_fees,json=localFees,proto3" json:"localFees"` // @gotags: json:"localFees"
+	// remote_fees: the part of remote_amount earned by fees since depositing
+	RemoteFees uint64 `protobuf:"varint,10,opt,name=remote_fees,json=remoteFees,proto3" json:"remoteFees"` // @gotags: json:"remoteFees"
+	// hold_value: the value of the deposited amounts if simply held, in the local asset at the current price
+	HoldValue uint64 `protobuf:"varint,11,opt,name=hold_value,json=holdValue,proto3" json:"holdValue"` // @gotags: json:"holdValue"
+	// position_value: the value of the redeemable amounts, in the local asset at the current price
+	PositionValue uint64 `protobuf:"varint,12,opt,name=position_value,json=positionValue,proto3" json:"positionValue"` // @gotags: json:"positionValue"
+	// impermanent_loss: hold_value minus the position value excluding fees, in the local asset (negative is a gain)
+	ImpermanentLoss int64 `protobuf:"varint,13,opt,name=impermanent_loss,json=impermanentLoss,proto3" json:"impermanentLoss"` // @gotags: json:"impermanentLoss"
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DexPosition) Reset() {
+	*x = DexPosition{}
+	mi := &file_dex_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DexPosition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DexPosition) ProtoMessage() {}
+
+func (x *DexPosition) ProtoReflect() protoreflect.Message {
+	mi := &file_dex_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DexPosition.ProtoReflect.Descriptor instead.
+func (*DexPosition) Descriptor() ([]byte, []int) {
+	return file_dex_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DexPosition) GetLocalChainId() uint64 {
+	if x != nil {
+		return x.LocalChainId
+	}
+	return 0
+}
+
+func (x *DexPosition) GetRemoteChainId() uint64 {
+	if x != nil {
+		return x.RemoteChainId
+	}
+	return 0
+}
+
+func (x *DexPosition) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *DexPosition) GetPoints() uint64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *DexPosition) GetTotalPoolPoints() uint64 {
+	if x != nil {
+		return x.TotalPoolPoints
+	}
+	return 0
+}
+
+func (x *DexPosition) GetLocalAmount() uint64 {
+	if x != nil {
+		return x.LocalAmount
+	}
+	return 0
+}
+
+func (x *DexPosition) GetRemoteAmount() uint64 {
+	if x != nil {
+		return x.RemoteAmount
+	}
+	return 0
+}
+
+func (x *DexPosition) GetBasis() *DexPositionBasis {
+	if x != nil {
+		return x.Basis
+	}
+	return nil
+}
+
+func (x *DexPosition) GetLocalFees() uint64 {
+	if x != nil {
+		return x.LocalFees
+	}
+	return 0
+}
+
+func (x *DexPosition) GetRemoteFees() uint64 {
+	if x != nil {
+		return x.RemoteFees
+	}
+	return 0
+}
+
+func (x *DexPosition) GetHoldValue() uint64 {
+	if x != nil {
+		return x.HoldValue
+	}
+	return 0
+}
+
+func (x *DexPosition) GetPositionValue() uint64 {
+	if x != nil {
+		return x.PositionValue
+	}
+	return 0
+}
+
+func (x *DexPosition) GetImpermanentLoss() int64 {
+	if x != nil {
+		return x.ImpermanentLoss
+	}
+	return 0
+}
+
+// DexCandle is an OHLCV bucket of a liquidity pool's history aggregated by the indexer from the dex swap, deposit and
+// withdraw events of the blocks with a time in [start_time, start_time + resolution)
+// prices are e6 scaled like DexPrice (local asset per counter asset) and 0 when no swap executed in the bucket
+type DexCandle struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// chain_id: the counter chain of the pool
+	ChainId uint64 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chainId"` // @gotags: json:"chainId"
+	// resolution: the length of the bucket in seconds
+	Resolution uint64 `protobuf:"varint,2,opt,name=resolution,proto3" json:"resolution"` // @gotags: json:"resolution"
+	// start_time: the unix time (seconds) the bucket starts
+	StartTime uint64 `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"startTime"` // @gotags: json:"startTime"
+	// start_height: the first block height aggregated into the bucket
+	StartHeight uint64 `protobuf:"varint,4,opt,name=start_height,json=startHeight,proto3" json:"startHeight"` // @gotags: json:"startHeight"
+	// end_height: the last block height aggregated into the bucket
+	EndHeight uint64 `protobuf:"varint,5,opt,name=end_height,json=endHeight,proto3" json:"endHeight"` // @gotags: json:"endHeight"
+	// open: the price of the first swap in the bucket
+	Open uint64 `protobuf:"varint,6,opt,name=open,proto3" json:"open"` // @gotags: json:"open"
+	// high: the highest swap price in the bucket
+	High uint64 `protobuf:"varint,7,opt,name=high,proto3" json:"high"` // @gotags: json:"high"
+	// low: the lowest swap price in the bucket
+	Low uint64 `protobuf:"varint,8,opt,name=low,proto3" json:"low"` // @gotags: json:"low"
+	// close: the price of the last swap in the bucket
+	Close uint64 `protobuf:"varint,9,opt,name=close,proto3" json:"close"` // @gotags: json:"close"
+	// local_volume: the amount of the local asset swapped (sold or bought)
+	LocalVolume uint64 `protobuf:"varint,10,opt,name=local_volume,json=localVolume,proto3" json:"localVolume"` // @gotags: json:"localVolume"
+	// remote_volume: the amount of the counter asset swapped (sold or bought)
+	RemoteVolume uint64 `protobuf:"varint,11,opt,name=remote_volume,json=remoteVolume,proto3" json:"remoteVolume"` // @gotags: json:"remoteVolume"
+	// swaps: the number of successful swaps
+	Swaps uint64 `protobuf:"varint,12,opt,name=swaps,proto3" json:"swaps"` // @gotags: json:"swaps"
+	// local_deposited: the amount of the local asset deposited as liquidity
+	LocalDeposited uint64 `protobuf:"varint,13,opt,name=local_deposited,json=localDeposited,proto3" json:"localDeposited"` // @gotags: json:"localDeposited"
+	// remote_deposited: the amount of the counter asset deposited as liquidity
+	RemoteDeposited uint64 `protobuf:"varint,14,opt,name=remote_deposited,json=remoteDeposited,proto3" json:"remoteDeposited"` // @gotags: json:"remoteDeposited"
+	// local_withdrawn: the amount of the local asset withdrawn from liquidity
+	LocalWithdrawn uint64 `protobuf:"varint,15,opt,name=local_withdrawn,json=localWithdrawn,proto3" json:"localWithdrawn"` // @gotags: json:"localWithdrawn"
+	// remote_withdrawn: the amount of the counter asset withdrawn from liquidity
+	RemoteWithdrawn uint64 `protobuf:"varint,16,opt,name=remote_withdrawn,json=remoteWithdrawn,proto3" json:"remoteWithdrawn"` // @gotags: json:"remoteWithdrawn"
+	// points_added: the liquidity points created by deposits
+	PointsAdded uint64 `protobuf:"varint,17,opt,name=points_added,json=pointsAdded,proto3" json:"pointsAdded"` // @gotags: json:"pointsAdded"
+	// points_burned: the liquidity points burned by withdrawals
+	PointsBurned  uint64 `protobuf:"varint,18,opt,name=points_burned,json=pointsBurned,proto3" json:"pointsBurned"` // @gotags: json:"pointsBurned"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DexCandle) Reset() {
+	*x = DexCandle{}
+	mi := &file_dex_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DexCandle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DexCandle) ProtoMessage() {}
+
+func (x *DexCandle) ProtoReflect() protoreflect.Message {
+	mi := &file_dex_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DexCandle.ProtoReflect.Descriptor instead.
+func (*DexCandle) Descriptor() ([]byte, []int) {
+	return file_dex_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DexCandle) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *DexCandle) GetResolution() uint64 {
+	if x != nil {
+		return x.Resolution
+	}
+	return 0
+}
+
+func (x *DexCandle) GetStartTime() uint64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *DexCandle) GetStartHeight() uint64 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+func (x *DexCandle) GetEndHeight() uint64 {
+	if x != nil {
+		return x.EndHeight
+	}
+	return 0
+}
+
+func (x *DexCandle) GetOpen() uint64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *DexCandle) GetHigh() uint64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *DexCandle) GetLow() uint64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *DexCandle) GetClose() uint64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *DexCandle) GetLocalVolume() uint64 {
+	if x != nil {
+		return x.LocalVolume
+	}
+	return 0
+}
+
+func (x *DexCandle) GetRemoteVolume() uint64 {
+	if x != nil {
+		return x.RemoteVolume
+	}
+	return 0
+}
+
+func (x *DexCandle) GetSwaps() uint64 {
+	if x != nil {
+		return x.Swaps
+	}
+	return 0
+}
+
+func (x *DexCandle) GetLocalDeposited() uint64 {
+	if x != nil {
+		return x.LocalDeposited
+	}
+	return 0
+}
+
+func (x *DexCandle) GetRemoteDeposited() uint64 {
+	if x != nil {
+		return x.RemoteDeposited
+	}
+	return 0
+}
+
+func (x *DexCandle) GetLocalWithdrawn() uint64 {
+	if x != nil {
+		return x.LocalWithdrawn
+	}
+	return 0
+}
+
+func (x *DexCandle) GetRemoteWithdrawn() uint64 {
+	if x != nil {
+		return x.RemoteWithdrawn
+	}
+	return 0
+}
+
+func (x *DexCandle) GetPointsAdded() uint64 {
+	if x != nil {
+		return x.PointsAdded
+	}
+	return 0
+}
+
+func (x *DexCandle) GetPointsBurned() uint64 {
+	if x != nil {
+		return x.PointsBurned
+	}
+	return 0
+}
+
+// DexBatchStall is the diagnostic record of a chain pair whose locked batch is waiting on receipts, saved in state each
+// time receipt processing is skipped and deleted once the receipts are processed
+type DexBatchStall struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// chain_id: the counter chain of the pair
+	ChainId uint64 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chainId"` // @gotags: json:"chainId"
+	// reason: why receipt processing was last skipped
+	Reason DexStallReason `protobuf:"varint,2,opt,name=reason,proto3,enum=types.DexStallReason" json:"reason,omitempty"`
+	// since_height: the first height receipt processing was skipped for the current reason
+	SinceHeight uint64 `protobuf:"varint,3,opt,name=since_height,json=sinceHeight,proto3" json:"sinceHeight"` // @gotags: json:"sinceHeight"
+	// last_height: the last height receipt processing was skipped
+	LastHeight uint64 `protobuf:"varint,4,opt,name=last_height,json=lastHeight,proto3" json:"lastHeight"` // @gotags: json:"lastHeight"
+	// count: the number of times receipt processing was skipped for the current reason
+	Count uint64 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	// expected_receipt_hash: the hash of our locked batch the counter chain's batch must receipt
+	ExpectedReceiptHash []byte `protobuf:"bytes,6,opt,name=expected_receipt_hash,json=expectedReceiptHash,proto3" json:"expectedReceiptHash"` // @gotags: json:"expectedReceiptHash"
+	// received_receipt_hash: the receipt hash of the counter chain's batch
+	ReceivedReceiptHash []byte `protobuf:"bytes,7,opt,name=received_receipt_hash,json=receivedReceiptHash,proto3" json:"receivedReceiptHash"` // @gotags: json:"receivedReceiptHash"
+	// expected_receipts: the number of orders in our locked batch
+	ExpectedReceipts uint64 `protobuf:"varint,8,opt,name=expected_receipts,json=expectedReceipts,proto3" json:"expectedReceipts"` // @gotags: json:"expectedReceipts"
+	// received_receipts: the number of receipts in the counter chain's batch
+	ReceivedReceipts uint64 `protobuf:"varint,9,opt,name=received_receipts,json=receivedReceipts,proto3" json:"receivedReceipts"` // @gotags: json:"receivedReceipts"
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DexBatchStall) Reset() {
+	*x = DexBatchStall{}
+	mi := &file_dex_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DexBatchStall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DexBatchStall) ProtoMessage() {}
+
+func (x *DexBatchStall) ProtoReflect() protoreflect.Message {
+	mi := &file_dex_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DexBatchStall.ProtoReflect.Descriptor instead.
+func (*DexBatchStall) Descriptor() ([]byte, []int) {
+	return file_dex_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DexBatchStall) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *DexBatchStall) GetReason() DexStallReason {
+	if x != nil {
+		return x.Reason
+	}
+	return DexStallReason_DEX_STALL_NONE
+}
+
+func (x *DexBatchStall) GetSinceHeight() uint64 {
+	if x != nil {
+		return x.SinceHeight
+	}
+	return 0
+}
+
+func (x *DexBatchStall) GetLastHeight() uint64 {
+	if x != nil {
+		return x.LastHeight
+	}
+	return 0
+}
+
+func (x *DexBatchStall) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DexBatchStall) GetExpectedReceiptHash() []byte {
+	if x != nil {
+		return x.ExpectedReceiptHash
+	}
+	return nil
+}
+
+func (x *DexBatchStall) GetReceivedReceiptHash() []byte {
+	if x != nil {
+		return x.ReceivedReceiptHash
+	}
+	return nil
+}
+
+func (x *DexBatchStall) GetExpectedReceipts() uint64 {
+	if x != nil {
+		return x.ExpectedReceipts
+	}
+	return 0
+}
+
+func (x *DexBatchStall) GetReceivedReceipts() uint64 {
+	if x != nil {
+		return x.ReceivedReceipts
+	}
+	return 0
+}
+
+// DexBatchStatus is a diagnostic view of a chain pair's batch pipeline for operators
+type DexBatchStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// local_chain_id: the local chain id
+	LocalChainId uint64 `protobuf:"varint,1,opt,name=local_chain_id,json=localChainId,proto3" json:"chainId"` // @gotags: json:"chainId"
+	// remote_chain_id: the counter chain id
+	RemoteChainId uint64 `protobuf:"varint,2,opt,name=remote_chain_id,json=remoteChainId,proto3" json:"remoteChainId"` // @gotags: json:"remoteChainId"
+	// height: the height of the status
+	Height uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// locked_height: the height our locked batch was locked (0 if no batch is locked)
+	LockedHeight uint64 `protobuf:"varint,4,opt,name=locked_height,json=lockedHeight,proto3" json:"lockedHeight"` // @gotags: json:"lockedHeight"
+	// locked_hash: the hash of our locked batch the counter chain's batch must receipt
+	LockedHash []byte `protobuf:"bytes,5,opt,name=locked_hash,json=lockedHash,proto3" json:"lockedHash"` // @gotags: json:"lockedHash"
+	// locked_orders: the number of orders in our locked batch
+	LockedOrders uint64 `protobuf:"varint,6,opt,name=locked_orders,json=lockedOrders,proto3" json:"lockedOrders"` // @gotags: json:"lockedOrders"
+	// locked_deposits: the number of liquidity deposits in our locked batch
+	LockedDeposits uint64 `protobuf:"varint,7,opt,name=locked_deposits,json=lockedDeposits,proto3" json:"lockedDeposits"` // @gotags: json:"lockedDeposits"
+	// locked_withdrawals: the number of liquidity withdrawals in our locked batch
+	LockedWithdrawals uint64 `protobuf:"varint,8,opt,name=locked_withdrawals,json=lockedWithdrawals,proto3" json:"lockedWithdrawals"` // @gotags: json:"lockedWithdrawals"
+	// blocks_locked: the number of blocks our batch has been locked
+	BlocksLocked uint64 `protobuf:"varint,9,opt,name=blocks_locked,json=blocksLocked,proto3" json:"blocksLocked"` // @gotags: json:"blocksLocked"
+	// liveness_fallback_blocks: the governance set number of locked blocks before a nested chain falls back
+	LivenessFallbackBlocks uint64 `protobuf:"varint,10,opt,name=liveness_fallback_blocks,json=livenessFallbackBlocks,proto3" json:"livenessFallbackBlocks"` // @gotags: json:"livenessFallbackBlocks"
+	// liveness_fallback_height: the height the nested chain falls back at (0 if no batch is locked)
+	LivenessFallbackHeight uint64 `protobuf:"varint,11,opt,name=liveness_fallback_height,json=livenessFallbackHeight,proto3" json:"livenessFallbackHeight"` // @gotags: json:"livenessFallbackHeight"
+	// stuck: the side of the pair holding up the pipeline: 'none', 'local' or 'counter'
+	Stuck string `protobuf:"bytes,12,opt,name=stuck,proto3" json:"stuck,omitempty"`
+	// stall: the last recorded reason receipt processing was skipped (nil if not stalled)
+	Stall         *DexBatchStall `protobuf:"bytes,13,opt,name=stall,proto3" json:"stall,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DexBatchStatus) Reset() {
+	*x = DexBatchStatus{}
+	mi := &file_dex_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DexBatchStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DexBatchStatus) ProtoMessage() {}
+
+func (x *DexBatchStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_dex_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DexBatchStatus.ProtoReflect.Descriptor instead.
+func (*DexBatchStatus) Descriptor() ([]byte, []int) {
+	return file_dex_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DexBatchStatus) GetLocalChainId() uint64 {
+	if x != nil {
+		return x.LocalChainId
+	}
+	return 0
+}
+
+func (x *DexBatchStatus) GetRemoteChainId() uint64 {
+	if x != nil {
+		return x.RemoteChainId
+	}
+	return 0
+}
+
+func (x *DexBatchStatus) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *DexBatchStatus) GetLockedHeight() uint64 {
+	if x != nil {
+		return x.LockedHeight
+	}
+	return 0
+}
+
+func (x *DexBatchStatus) GetLockedHash() []byte {
+	if x != nil {
+		return x.LockedHash
+	}
+	return nil
+}
+
+func (x *DexBatchStatus) GetLockedOrders() uint64 {
+	if x != nil {
+		return x.LockedOrders
+	}
+	return 0
+}
+
+func (x *DexBatchStatus) GetLockedDeposits() uint64 {
+	if x != nil {
+		return x.LockedDeposits
+	}
+	return 0
+}
+
+func (x *DexBatchStatus) GetLockedWithdrawals() uint64 {
+	if x != nil {
+		return x.LockedWithdrawals
+	}
+	return 0
+}
+
+func (x *DexBatchStatus) GetBlocksLocked() uint64 {
+	if x != nil {
+		return x.BlocksLocked
+	}
+	return 0
+}
+
+func (x *DexBatchStatus) GetLivenessFallbackBlocks() uint64 {
+	if x != nil {
+		return x.LivenessFallbackBlocks
+	}
+	return 0
+}
+
+func (x *DexBatchStatus) GetLivenessFallbackHeight() uint64 {
+	if x != nil {
+		return x.LivenessFallbackHeight
+	}
+	return 0
+}
+
+func (x *DexBatchStatus) GetStuck() string {
+	if x != nil {
+		return x.Stuck
+	}
+	return ""
+}
+
+func (x *DexBatchStatus) GetStall() *DexBatchStall {
+	if x != nil {
+		return x.Stall
+	}
+	return nil
+}
+
+// PoolPoints represents an ownership 'share' of the pool
+type PoolPoints struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// address: the recipient address of the points
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address"` // @gotags: json:"address"
+	// points: the amount of points owned
+	Points        uint64 `protobuf:"varint,2,opt,name=points,proto3" json:"points"` // @gotags: json:"points"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolPoints) Reset() {
+	*x = PoolPoints{}
+	mi := &file_dex_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolPoints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolPoints) ProtoMessage() {}
+
+func (x *PoolPoints) ProtoReflect() protoreflect.Message {
+	mi := &file_dex_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolPoints.ProtoReflect.Descriptor instead.
+func (*PoolPoints) Descriptor() ([]byte, []int) {
+	return file_dex_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PoolPoints) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *PoolPoints) GetPoints() uint64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+// DexPoolConfig is the governance chosen curve and fee tier of the liquidity pool for a counter chain
+type DexPoolConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// chain_id: the id of the counter chain the liquidity pool trades against
+	ChainId uint64 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chainId"` // @gotags: json:"chainId"
+	// curve: the invariant swaps and liquidity deposits are priced against
+	Curve DexCurve `protobuf:"varint,2,opt,name=curve,proto3,enum=types.DexCurve" json:"curve,omitempty"`
+	// amplification: the stable swap amplification coefficient; higher keeps the price flat over a wider range (stable swap only)
+	Amplification uint64 `protobuf:"varint,3,opt,name=amplification,proto3" json:"amplification,omitempty"`
+	// fee_basis_points: the taker fee tier charged on the amount sold, in 1/10000ths
+	FeeBasisPoints uint64 `protobuf:"varint,4,opt,name=fee_basis_points,json=feeBasisPoints,proto3" json:"feeBasisPoints"` // @gotags: json:"feeBasisPoints"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DexPoolConfig) Reset() {
+	*x = DexPoolConfig{}
+	mi := &file_dex_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DexPoolConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DexPoolConfig) ProtoMessage() {}
+
+func (x *DexPoolConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_dex_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DexPoolConfig.ProtoReflect.Descriptor instead.
+func (*DexPoolConfig) Descriptor() ([]byte, []int) {
+	return file_dex_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DexPoolConfig) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *DexPoolConfig) GetCurve() DexCurve {
+	if x != nil {
+		return x.Curve
+	}
+	return DexCurve_CONSTANT_PRODUCT
+}
+
+func (x *DexPoolConfig) GetAmplification() uint64 {
+	if x != nil {
+		return x.Amplification
+	}
+	return 0
+}
+
+func (x *DexPoolConfig) GetFeeBasisPoints() uint64 {
+	if x != nil {
+		return x.FeeBasisPoints
+	}
+	return 0
+}
+
+var File_dex_proto protoreflect.FileDescriptor
+
+const file_dex_proto_rawDesc = "" +
+	"\n" +
+	"\tdex.proto\x12\x05types\"\xb9\x02\n" +
+	"\rDexLimitOrder\x12$\n" +
+	"\ramountForSale\x18\x01 \x01(\x04R\ramountForSale\x12(\n" +
+	"\x0frequestedAmount\x18\x02 \x01(\x04R\x0frequestedAmount\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\fR\aaddress\x12\x18\n" +
+	"\aOrderId\x18\x04 \x01(\fR\aOrderId\x12\"\n" +
+	"\fexpiryHeight\x18\x05 \x01(\x04R\fexpiryHeight\x12 \n" +
+	"\vpartialFill\x18\x06 \x01(\bR\vpartialFill\x12\"\n" +
+	"\ffilledAmount\x18\a \x01(\x04R\ffilledAmount\x12\x14\n" +
+	"\x05route\x18\b \x03(\x04R\x05route\x12$\n" +
+	"\roriginChainId\x18\t \x01(\x04R\roriginChainId\"\x80\x01\n" +
+	"\x13DexLiquidityDeposit\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x04R\x06amount\x12\x18\n" +
+	"\aOrderId\x18\x04 \x01(\fR\aOrderId\x12\x1d\n" +
+	"\n" +
+	"min_points\x18\x05 \x01(\x04R\tminPoints\"\xb1\x01\n" +
+	"\x14DexLiquidityWithdraw\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x18\n" +
+	"\apercent\x18\x02 \x01(\x04R\apercent\x12\x18\n" +
+	"\aOrderId\x18\x04 \x01(\fR\aOrderId\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\x05 \x01(\x04R\tminAmount\x12,\n" +
+	"\x12min_counter_amount\x18\x06 \x01(\x04R\x10minCounterAmount\"\xd4\x04\n" +
+	"\bDexBatch\x12\x1c\n" +
+	"\tCommittee\x18\x01 \x01(\x04R\tCommittee\x12!\n" +
+	"\freceipt_hash\x18\x02 \x01(\fR\vreceiptHash\x12,\n" +
+	"\x06orders\x18\x03 \x03(\v2\x14.types.DexLimitOrderR\x06orders\x126\n" +
+	"\bdeposits\x18\x04 \x03(\v2\x1a.types.DexLiquidityDepositR\bdeposits\x12=\n" +
+	"\vwithdrawals\x18\x05 \x03(\v2\x1b.types.DexLiquidityWithdrawR\vwithdrawals\x12\x1b\n" +
+	"\tpool_size\x18\x06 \x01(\x04R\bpoolSize\x12*\n" +
+	"\x11counter_pool_size\x18\a \x01(\x04R\x0fcounterPoolSize\x122\n" +
+	"\vpool_points\x18\b \x03(\v2\x11.types.PoolPointsR\n" +
+	"poolPoints\x12*\n" +
+	"\x11total_pool_points\x18\t \x01(\x04R\x0ftotalPoolPoints\x12\x1a\n" +
+	"\breceipts\x18\n" +
+	" \x03(\x04R\breceipts\x12#\n" +
+	"\rlocked_height\x18\v \x01(\x04R\flockedHeight\x12+\n" +
+	"\x11liveness_fallback\x18\f \x01(\bR\x10livenessFallback\x125\n" +
+	"\vpool_config\x18\r \x01(\v2\x14.types.DexPoolConfigR\n" +
+	"poolConfig\x12\x14\n" +
+	"\x05fills\x18\x0e \x03(\x04R\x05fills\"\xc0\x01\n" +
+	"\bDexPrice\x12$\n" +
+	"\x0elocal_chain_id\x18\x01 \x01(\x04R\flocalChainId\x12&\n" +
+	"\x0fremote_chain_id\x18\x02 \x01(\x04R\rremoteChainId\x12\x1d\n" +
+	"\n" +
+	"local_pool\x18\x03 \x01(\x04R\tlocalPool\x12\x1f\n" +
+	"\vremote_pool\x18\x04 \x01(\x04R\n" +
+	"remotePool\x12&\n" +
+	"\x0fe6_scaled_price\x18\x05 \x01(\x04R\re6ScaledPrice\"{\n" +
+	"\bDexQuote\x12\x12\n" +
+	"\x04path\x18\x01 \x03(\x04R\x04path\x12\x1b\n" +
+	"\tamount_in\x18\x02 \x01(\x04R\bamountIn\x12\x1f\n" +
+	"\vleg_outputs\x18\x03 \x03(\x04R\n" +
+	"legOutputs\x12\x1d\n" +
+	"\n" +
+	"amount_out\x18\x04 \x01(\x04R\tamountOut\"\xa5\x01\n" +
+	"\x13DexPriceAccumulator\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12.\n" +
+	"\x13cumulative_e6_price\x18\x02 \x01(\fR\x11cumulativeE6Price\x12\"\n" +
+	"\rlast_e6_price\x18\x03 \x01(\x04R\vlastE6Price\x12\x1f\n" +
+	"\vlast_height\x18\x04 \x01(\x04R\n" +
+	"lastHeight\"\xf0\x01\n" +
+	"\aDexTWAP\x12$\n" +
+	"\x0elocal_chain_id\x18\x01 \x01(\x04R\flocalChainId\x12&\n" +
+	"\x0fremote_chain_id\x18\x02 \x01(\x04R\rremoteChainId\x12!\n" +
+	"\fstart_height\x18\x03 \x01(\x04R\vstartHeight\x12\x1d\n" +
+	"\n" +
+	"end_height\x18\x04 \x01(\x04R\tendHeight\x12$\n" +
+	"\x0ee6_scaled_twap\x18\x05 \x01(\x04R\fe6ScaledTwap\x12/\n" +
+	"\x14e6_scaled_spot_price\x18\x06 \x01(\x04R\x11e6ScaledSpotPrice\"\x84\x01\n" +
+	"\x10DexPositionBasis\x12'\n" +
+	"\x0flocal_deposited\x18\x01 \x01(\x04R\x0elocalDeposited\x12)\n" +
+	"\x10remote_deposited\x18\x02 \x01(\x04R\x0fremoteDeposited\x12\x1c\n" +
+	"\tliquidity\x18\x03 \x01(\x04R\tliquidity\"\xe1\x03\n" +
+	"\vDexPosition\x12$\n" +
+	"\x0elocal_chain_id\x18\x01 \x01(\x04R\flocalChainId\x12&\n" +
+	"\x0fremote_chain_id\x18\x02 \x01(\x04R\rremoteChainId\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\fR\aaddress\x12\x16\n" +
+	"\x06points\x18\x04 \x01(\x04R\x06points\x12*\n" +
+	"\x11total_pool_points\x18\x05 \x01(\x04R\x0ftotalPoolPoints\x12!\n" +
+	"\flocal_amount\x18\x06 \x01(\x04R\vlocalAmount\x12#\n" +
+	"\rremote_amount\x18\a \x01(\x04R\fremoteAmount\x12-\n" +
+	"\x05basis\x18\b \x01(\v2\x17.types.DexPositionBasisR\x05basis\x12\x1d\n" +
+	"\n" +
+	"local_fees\x18\t \x01(\x04R\tlocalFees\x12\x1f\n" +
+	"\vremote_fees\x18\n" +
+	" \x01(\x04R\n" +
+	"remoteFees\x12\x1d\n" +
+	"\n" +
+	"hold_value\x18\v \x01(\x04R\tholdValue\x12%\n" +
+	"\x0eposition_value\x18\f \x01(\x04R\rpositionValue\x12)\n" +
+	"\x10impermanent_loss\x18\r \x01(\x03R\x0fimpermanentLoss\"\xc5\x04\n" +
+	"\tDexCandle\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x02 \x01(\x04R\n" +
+	"resolution\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\x04R\tstartTime\x12!\n" +
+	"\fstart_height\x18\x04 \x01(\x04R\vstartHeight\x12\x1d\n" +
+	"\n" +
+	"end_height\x18\x05 \x01(\x04R\tendHeight\x12\x12\n" +
+	"\x04open\x18\x06 \x01(\x04R\x04open\x12\x12\n" +
+	"\x04high\x18\a \x01(\x04R\x04high\x12\x10\n" +
+	"\x03low\x18\b \x01(\x04R\x03low\x12\x14\n" +
+	"\x05close\x18\t \x01(\x04R\x05close\x12!\n" +
+	"\flocal_volume\x18\n" +
+	" \x01(\x04R\vlocalVolume\x12#\n" +
+	"\rremote_volume\x18\v \x01(\x04R\fremoteVolume\x12\x14\n" +
+	"\x05swaps\x18\f \x01(\x04R\x05swaps\x12'\n" +
+	"\x0flocal_deposited\x18\r \x01(\x04R\x0elocalDeposited\x12)\n" +
+	"\x10remote_deposited\x18\x0e \x01(\x04R\x0fremoteDeposited\x12'\n" +
+	"\x0flocal_withdrawn\x18\x0f \x01(\x04R\x0elocalWithdrawn\x12)\n" +
+	"\x10remote_withdrawn\x18\x10 \x01(\x04R\x0fremoteWithdrawn\x12!\n" +
+	"\fpoints_added\x18\x11 \x01(\x04R\vpointsAdded\x12#\n" +
+	"\rpoints_burned\x18\x12 \x01(\x04R\fpointsBurned\"\xf5\x02\n" +
+	"\rDexBatchStall\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12-\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x15.types.DexStallReasonR\x06reason\x12!\n" +
+	"\fsince_height\x18\x03 \x01(\x04R\vsinceHeight\x12\x1f\n" +
+	"\vlast_height\x18\x04 \x01(\x04R\n" +
+	"lastHeight\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x04R\x05count\x122\n" +
+	"\x15expected_receipt_hash\x18\x06 \x01(\fR\x13expectedReceiptHash\x122\n" +
+	"\x15received_receipt_hash\x18\a \x01(\fR\x13receivedReceiptHash\x12+\n" +
+	"\x11expected_receipts\x18\b \x01(\x04R\x10expectedReceipts\x12+\n" +
+	"\x11received_receipts\x18\t \x01(\x04R\x10receivedReceipts\"\x94\x04\n" +
+	"\x0eDexBatchStatus\x12$\n" +
+	"\x0elocal_chain_id\x18\x01 \x01(\x04R\flocalChainId\x12&\n" +
+	"\x0fremote_chain_id\x18\x02 \x01(\x04R\rremoteChainId\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x04R\x06height\x12#\n" +
+	"\rlocked_height\x18\x04 \x01(\x04R\flockedHeight\x12\x1f\n" +
+	"\vlocked_hash\x18\x05 \x01(\fR\n" +
+	"lockedHash\x12#\n" +
+	"\rlocked_orders\x18\x06 \x01(\x04R\flockedOrders\x12'\n" +
+	"\x0flocked_deposits\x18\a \x01(\x04R\x0elockedDeposits\x12-\n" +
+	"\x12locked_withdrawals\x18\b \x01(\x04R\x11lockedWithdrawals\x12#\n" +
+	"\rblocks_locked\x18\t \x01(\x04R\fblocksLocked\x128\n" +
+	"\x18liveness_fallback_blocks\x18\n" +
+	" \x01(\x04R\x16livenessFallbackBlocks\x128\n" +
+	"\x18liveness_fallback_height\x18\v \x01(\x04R\x16livenessFallbackHeight\x12\x14\n" +
+	"\x05stuck\x18\f \x01(\tR\x05stuck\x12*\n" +
+	"\x05stall\x18\r \x01(\v2\x14.types.DexBatchStallR\x05stall\">\n" +
 	"\n" +
 	"PoolPoints\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x16\n" +
-	"\x06points\x18\x02 \x01(\x04R\x06pointsB&Z$github.com/canopy-network/canopy/libb\x06proto3"
+	"\x06points\x18\x02 \x01(\x04R\x06points\"\xa1\x01\n" +
+	"\rDexPoolConfig\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12%\n" +
+	"\x05curve\x18\x02 \x01(\x0e2\x0f.types.DexCurveR\x05curve\x12$\n" +
+	"\ramplification\x18\x03 \x01(\x04R\ramplification\x12(\n" +
+	"\x10fee_basis_points\x18\x04 \x01(\x04R\x0efeeBasisPoints*\x94\x01\n" +
+	"\x0eDexStallReason\x12\x12\n" +
+	"\x0eDEX_STALL_NONE\x10\x00\x12#\n" +
+	"\x1fDEX_STALL_RECEIPT_HASH_MISMATCH\x10\x01\x12$\n" +
+	" DEX_STALL_RECEIPT_COUNT_MISMATCH\x10\x02\x12#\n" +
+	"\x1fDEX_STALL_MISSING_COUNTER_BATCH\x10\x03*1\n" +
+	"\bDexCurve\x12\x14\n" +
+	"\x10CONSTANT_PRODUCT\x10\x00\x12\x0f\n" +
+	"\vSTABLE_SWAP\x10\x01B&Z$github.com/canopy-network/canopy/libb\x06proto3"
 
 var (
 	file_dex_proto_rawDescOnce sync.Once
@@ -563,25 +1891,42 @@ func file_dex_proto_rawDescGZIP() []byte {
 	return file_dex_proto_rawDescData
 }
 
-var file_dex_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_dex_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_dex_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_dex_proto_goTypes = []any{
-	(*DexLimitOrder)(nil),        // 0: types.DexLimitOrder
-	(*DexLiquidityDeposit)(nil),  // 1: types.DexLiquidityDeposit
-	(*DexLiquidityWithdraw)(nil), // 2: types.DexLiquidityWithdraw
-	(*DexBatch)(nil),             // 3: types.DexBatch
-	(*DexPrice)(nil),             // 4: types.DexPrice
-	(*PoolPoints)(nil),           // 5: types.PoolPoints
+	(DexStallReason)(0),          // 0: types.DexStallReason
+	(DexCurve)(0),                // 1: types.DexCurve
+	(*DexLimitOrder)(nil),        // 2: types.DexLimitOrder
+	(*DexLiquidityDeposit)(nil),  // 3: types.DexLiquidityDeposit
+	(*DexLiquidityWithdraw)(nil), // 4: types.DexLiquidityWithdraw
+	(*DexBatch)(nil),             // 5: types.DexBatch
+	(*DexPrice)(nil),             // 6: types.DexPrice
+	(*DexQuote)(nil),             // 7: types.DexQuote
+	(*DexPriceAccumulator)(nil),  // 8: types.DexPriceAccumulator
+	(*DexTWAP)(nil),              // 9: types.DexTWAP
+	(*DexPositionBasis)(nil),     // 10: types.DexPositionBasis
+	(*DexPosition)(nil),          // 11: types.DexPosition
+	(*DexCandle)(nil),            // 12: types.DexCandle
+	(*DexBatchStall)(nil),        // 13: types.DexBatchStall
+	(*DexBatchStatus)(nil),       // 14: types.DexBatchStatus
+	(*PoolPoints)(nil),           // 15: types.PoolPoints
+	(*DexPoolConfig)(nil),        // 16: types.DexPoolConfig
 }
 var file_dex_proto_depIdxs = []int32{
-	0, // 0: types.DexBatch.orders:type_name -> types.DexLimitOrder
-	1, // 1: types.DexBatch.deposits:type_name -> types.DexLiquidityDeposit
-	2, // 2: types.DexBatch.withdrawals:type_name -> types.DexLiquidityWithdraw
-	5, // 3: types.DexBatch.pool_points:type_name -> types.PoolPoints
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	2,  // 0: types.DexBatch.orders:type_name -> types.DexLimitOrder
+	3,  // 1: types.DexBatch.deposits:type_name -> types.DexLiquidityDeposit
+	4,  // 2: types.DexBatch.withdrawals:type_name -> types.DexLiquidityWithdraw
+	15, // 3: types.DexBatch.pool_points:type_name -> types.PoolPoints
+	16, // 4: types.DexBatch.pool_config:type_name -> types.DexPoolConfig
+	10, // 5: types.DexPosition.basis:type_name -> types.DexPositionBasis
+	0,  // 6: types.DexBatchStall.reason:type_name -> types.DexStallReason
+	13, // 7: types.DexBatchStatus.stall:type_name -> types.DexBatchStall
+	1,  // 8: types.DexPoolConfig.curve:type_name -> types.DexCurve
+	9,  // [9:9] is the sub-list for method output_type
+	9,  // [9:9] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_dex_proto_init() }
@@ -594,13 +1939,14 @@ func file_dex_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dex_proto_rawDesc), len(file_dex_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_dex_proto_goTypes,
 		DependencyIndexes: file_dex_proto_depIdxs,
+		EnumInfos:         file_dex_proto_enumTypes,
 		MessageInfos:      file_dex_proto_msgTypes,
 	}.Build()
 	File_dex_proto = out.File
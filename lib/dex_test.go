@@ -285,6 +285,30 @@ func TestDexBatch_CheckBasic_RejectsOverLimitFallbackPoints(t *testing.T) {
 	}
 }
 
+func TestDexBatch_CheckBasic_Route(t *testing.T) {
+	tests := []struct {
+		name  string
+		order *DexLimitOrder
+		valid bool
+	}{
+		{name: "no route", order: &DexLimitOrder{}, valid: true},
+		{name: "one hop", order: &DexLimitOrder{Route: []uint64{3}}, valid: true},
+		{name: "too many hops", order: &DexLimitOrder{Route: []uint64{3, 4}}},
+		{name: "partially fillable", order: &DexLimitOrder{Route: []uint64{3}, PartialFill: true}},
+		{name: "routed back to the counter chain", order: &DexLimitOrder{Route: []uint64{1}}},
+	}
+	for _, test := range tests {
+		batch := &DexBatch{Committee: 1, Orders: []*DexLimitOrder{test.order}}
+		err := batch.CheckBasic()
+		if test.valid && err != nil {
+			t.Fatalf("%s: unexpected error %v", test.name, err)
+		}
+		if !test.valid && (err == nil || err.Code() != CodeInvalidDexRoute) {
+			t.Fatalf("%s: expected an invalid route error, got %v", test.name, err)
+		}
+	}
+}
+
 func TestPoolPoints_MarshalJSON(t *testing.T) {
 	points := PoolPoints{
 		Address: []byte("test"),
@@ -300,3 +324,195 @@ func TestPoolPoints_MarshalJSON(t *testing.T) {
 		t.Error("expected non-empty JSON data")
 	}
 }
+
+func TestDexPoolConfig_Check(t *testing.T) {
+	tests := []struct {
+		config *DexPoolConfig
+		valid  bool
+	}{
+		{nil, false},
+		{DefaultDexPoolConfig(1), true},
+		{&DexPoolConfig{Curve: DexCurve_CONSTANT_PRODUCT, FeeBasisPoints: 30}, true},
+		{&DexPoolConfig{Curve: DexCurve_CONSTANT_PRODUCT, FeeBasisPoints: 31}, false},
+		{&DexPoolConfig{Curve: DexCurve_CONSTANT_PRODUCT, Amplification: 10, FeeBasisPoints: 30}, false},
+		{&DexPoolConfig{Curve: DexCurve_STABLE_SWAP, Amplification: 100, FeeBasisPoints: 5}, true},
+		{&DexPoolConfig{Curve: DexCurve_STABLE_SWAP, FeeBasisPoints: 5}, false},
+		{&DexPoolConfig{Curve: DexCurve_STABLE_SWAP, Amplification: MaxDexAmplification + 1, FeeBasisPoints: 5}, false},
+		{&DexPoolConfig{Curve: DexCurve(7), FeeBasisPoints: 5}, false},
+	}
+	for i, test := range tests {
+		if err := test.config.Check(); (err == nil) != test.valid {
+			t.Fatalf("case %d: expected valid=%t, got %v", i, test.valid, err)
+		}
+	}
+}
+
+func TestDexPoolConfig_ComputeDY(t *testing.T) {
+	// a nil config and the default config both reproduce the legacy constant product math at a 1% fee
+	x, y, dX := uint64(1_000_000), uint64(1_000_000), uint64(10_000)
+	legacy := (dX * 990 * y) / (x*1000 + dX*990)
+	var nilConfig *DexPoolConfig
+	if got := nilConfig.ComputeDY(x, y, dX); got != legacy {
+		t.Fatalf("expected nil config dy %d, got %d", legacy, got)
+	}
+	if got := DefaultDexPoolConfig(1).ComputeDY(x, y, dX); got != legacy {
+		t.Fatalf("expected default config dy %d, got %d", legacy, got)
+	}
+	// a lower fee tier pays more
+	lowFee := &DexPoolConfig{Curve: DexCurve_CONSTANT_PRODUCT, FeeBasisPoints: 5}
+	if got := lowFee.ComputeDY(x, y, dX); got <= legacy {
+		t.Fatalf("expected 5 bps dy above %d, got %d", legacy, got)
+	}
+	// a stable swap pool of a balanced pair trades near 1:1 (minus the fee) and never pays more than sold
+	stable := &DexPoolConfig{Curve: DexCurve_STABLE_SWAP, Amplification: 100, FeeBasisPoints: 5}
+	got := stable.ComputeDY(x, y, dX)
+	if got > dX || got < dX*9990/10000 {
+		t.Fatalf("expected stable dy close to %d, got %d", dX, got)
+	}
+	if got <= lowFee.ComputeDY(x, y, dX) {
+		t.Fatalf("expected stable swap slippage below constant product")
+	}
+	// deterministic
+	if again := stable.ComputeDY(x, y, dX); again != got {
+		t.Fatalf("expected deterministic dy %d, got %d", got, again)
+	}
+	// empty pools pay nothing
+	if stable.ComputeDY(0, y, dX) != 0 || stable.ComputeDY(x, 0, dX) != 0 || stable.ComputeDY(x, y, 0) != 0 {
+		t.Fatal("expected zero dy for an empty pool or zero input")
+	}
+}
+
+func TestDexPoolConfig_Liquidity(t *testing.T) {
+	stable := &DexPoolConfig{Curve: DexCurve_STABLE_SWAP, Amplification: 100, FeeBasisPoints: 5}
+	if got := stable.Liquidity(1_000, 1_000); got != 1_000 {
+		t.Fatalf("expected balanced stable liquidity 1000, got %d", got)
+	}
+	if got := DefaultDexPoolConfig(1).Liquidity(100, 400); got != 200 {
+		t.Fatalf("expected constant product liquidity 200, got %d", got)
+	}
+}
+
+func TestDexPoolConfig_ComputePartialFill(t *testing.T) {
+	config := DefaultDexPoolConfig(1)
+	x, y := uint64(1_000_000), uint64(1_000_000)
+	// the whole order can't fill at a 1:1 limit price of 100_000 for 95_000
+	amountForSale, requested := uint64(100_000), uint64(95_000)
+	if config.ComputeDY(x, y, amountForSale) >= requested {
+		t.Fatal("expected the whole order to miss the limit price")
+	}
+	dX, dY := config.ComputePartialFill(x, y, amountForSale, requested)
+	if dX == 0 || dX >= amountForSale || dY != config.ComputeDY(x, y, dX) {
+		t.Fatalf("unexpected partial fill dX=%d dY=%d", dX, dY)
+	}
+	// the fill is at no worse than the limit price and is the largest such fill
+	if dY*amountForSale < requested*dX {
+		t.Fatalf("partial fill below the limit price: dX=%d dY=%d", dX, dY)
+	}
+	if next := config.ComputeDY(x, y, dX+1); next*amountForSale >= requested*(dX+1) {
+		t.Fatalf("partial fill isn't the largest: dX=%d", dX)
+	}
+	// a limit price above the spot price doesn't fill at all
+	if dX, dY = config.ComputePartialFill(x, y, amountForSale, amountForSale*2); dX != 0 || dY != 0 {
+		t.Fatalf("expected no fill, got dX=%d dY=%d", dX, dY)
+	}
+}
+
+func TestDexPoolConfig_ComputePartialFillStableSwap(t *testing.T) {
+	stable := &DexPoolConfig{Curve: DexCurve_STABLE_SWAP, Amplification: 100, FeeBasisPoints: 5}
+	// the -1 rounding of a small swap misses a limit price the whole order meets
+	if stable.ComputeDY(1_000, 1_000, 2)*3 >= 1*2 || stable.ComputeDY(1_000, 1_000, 3)*3 < 1*3 {
+		t.Fatal("expected a 2 sized part to miss the limit price and the whole order to meet it")
+	}
+	if dX, dY := stable.ComputePartialFill(1_000, 1_000, 3, 1); dX != 3 || dY != stable.ComputeDY(1_000, 1_000, 3) {
+		t.Fatalf("expected the whole order to fill, got dX=%d dY=%d", dX, dY)
+	}
+	// small orders against small pools fill the largest part that meets the limit price
+	for _, pool := range [][2]uint64{{1_000, 1_000}, {50, 50}, {40, 400}, {400, 40}} {
+		for amountForSale := uint64(1); amountForSale <= 60; amountForSale++ {
+			for requested := amountForSale / 4; requested <= amountForSale+1; requested++ {
+				var expected uint64
+				for dx := uint64(1); dx <= amountForSale; dx++ {
+					if dy := stable.ComputeDY(pool[0], pool[1], dx); dy != 0 && dy*amountForSale >= requested*dx {
+						expected = dx
+					}
+				}
+				if dX, _ := stable.ComputePartialFill(pool[0], pool[1], amountForSale, requested); dX != expected {
+					t.Fatalf("pool %v selling %d for %d: expected dX=%d, got %d", pool, amountForSale, requested, expected, dX)
+				}
+			}
+		}
+	}
+}
+
+func TestDexLimitOrder_Remainder(t *testing.T) {
+	order := &DexLimitOrder{AmountForSale: 1_000, RequestedAmount: 333, Address: []byte("a"), OrderId: []byte("o"), ExpiryHeight: 10, PartialFill: true, FilledAmount: 50}
+	remainder := order.Remainder(400)
+	if remainder.AmountForSale != 600 || remainder.FilledAmount != 450 {
+		t.Fatalf("unexpected remainder %v", remainder)
+	}
+	// 333 * 600 / 1000 = 199.8, rounded up so the price never worsens
+	if remainder.RequestedAmount != 200 {
+		t.Fatalf("expected requested amount 200, got %d", remainder.RequestedAmount)
+	}
+	if !remainder.PartialFill || remainder.ExpiryHeight != 10 || string(remainder.OrderId) != "o" {
+		t.Fatalf("expected the remainder to keep the order terms, got %v", remainder)
+	}
+}
+
+func TestDexPriceAccumulator(t *testing.T) {
+	a := &DexPriceAccumulator{ChainId: 2}
+	// the first observation only sets the price
+	a.Update(10, 1_000_000)
+	if a.Cumulative(10).Sign() != 0 || a.Cumulative(14).Uint64() != 4_000_000 {
+		t.Fatalf("unexpected cumulative %v", a)
+	}
+	start := &DexPriceAccumulator{ChainId: a.ChainId, CumulativeE6Price: a.CumulativeE6Price, LastE6Price: a.LastE6Price, LastHeight: a.LastHeight}
+	a.Update(14, 3_000_000)
+	// updating doesn't change the cumulative price as of the update height
+	if a.Cumulative(14).Uint64() != start.Cumulative(14).Uint64() {
+		t.Fatalf("expected the update to preserve the cumulative price")
+	}
+	// (1 * 4 + 3 * 4) / 8 = 2
+	twap, err := ComputeDexTWAP(start, a, 10, 18)
+	if err != nil || twap != 2_000_000 {
+		t.Fatalf("expected twap 2_000_000, got %d (%v)", twap, err)
+	}
+	// a window entirely after the last update is the last price
+	if twap, err = ComputeDexTWAP(a, a, 15, 20); err != nil || twap != 3_000_000 {
+		t.Fatalf("expected twap 3_000_000, got %d (%v)", twap, err)
+	}
+	// invalid windows
+	for _, w := range [][2]uint64{{18, 18}, {18, 10}, {9, 18}} {
+		if _, err = ComputeDexTWAP(start, a, w[0], w[1]); err == nil {
+			t.Fatalf("expected an invalid window error for %v", w)
+		}
+	}
+	if _, err = ComputeDexTWAP(&DexPriceAccumulator{}, a, 10, 18); err == nil {
+		t.Fatalf("expected an error without a price observation")
+	}
+	// the cumulative price never wraps at 64 bits
+	wide := &DexPriceAccumulator{LastE6Price: 1 << 63, LastHeight: 1}
+	wide.Update(5, 1<<63)
+	if wide.Cumulative(5).BitLen() != 66 {
+		t.Fatalf("expected a 66 bit cumulative price, got %d bits", wide.Cumulative(5).BitLen())
+	}
+}
+
+func TestDexPriceAccumulator_JSON(t *testing.T) {
+	a := &DexPriceAccumulator{ChainId: 2, LastE6Price: 1 << 63, LastHeight: 1}
+	a.Update(5, 7)
+	bz, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := new(DexPriceAccumulator)
+	if err = json.Unmarshal(bz, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Cumulative(5).Cmp(a.Cumulative(5)) != 0 || got.LastE6Price != 7 || got.LastHeight != 5 || got.ChainId != 2 {
+		t.Fatalf("unexpected round trip %s", bz)
+	}
+	if err = json.Unmarshal([]byte(`{"cumulativeE6Price":"-1"}`), got); err == nil {
+		t.Fatalf("expected an error for a negative cumulative price")
+	}
+}
@@ -165,6 +165,18 @@ const (
 	CodeWrongViewHeight                 ErrorCode = 65
 	CodeBadPort                         ErrorCode = 66
 	CodeBadPortLowLimit                 ErrorCode = 67
+	CodeNoStateSyncAnchor               ErrorCode = 68
+	CodeInvalidStateChunk               ErrorCode = 69
+	CodeMismatchStateSyncRoot           ErrorCode = 70
+	CodeStateSyncTimeout                ErrorCode = 71
+	CodeUntrustedValidatorSet           ErrorCode = 72
+	CodeUnverifiedHeight                ErrorCode = 73
+	CodeMismatchLastBlockHash           ErrorCode = 74
+	CodeInvalidStateProof               ErrorCode = 75
+	CodeDoubleSignProtection            ErrorCode = 76
+	CodeHighWaterMark                   ErrorCode = 77
+	CodeWAL                             ErrorCode = 78
+	CodeInvalidConsensusSignBytes       ErrorCode = 79
 
 	// State Machine Module
 	StateMachineModule ErrorModule = "state_machine"
@@ -283,6 +295,25 @@ const (
 	CodeNilPluginQueryRead        ErrorCode = 112
 	CodeNoCommittedState          ErrorCode = 113
 	CodeTooManyLiquidityProviders ErrorCode = 114
+	CodeMultisigAccountExists     ErrorCode = 115
+	CodeMultisigAccountNotFound   ErrorCode = 116
+	CodeInvalidMultisigThreshold  ErrorCode = 117
+	CodeInvalidMultisigMembers    ErrorCode = 118
+	CodeProposalNotFound          ErrorCode = 119
+	CodeProposalNotVoting         ErrorCode = 120
+	CodeInvalidProposal           ErrorCode = 121
+	CodeDepositBelowMinimum       ErrorCode = 122
+	CodeProposalsDisabled         ErrorCode = 123
+	CodeMempoolTxExpired          ErrorCode = 124
+	CodeInvalidDexPoolConfig      ErrorCode = 125
+	CodeInvalidDexOrderExpiry     ErrorCode = 126
+	CodeInvalidDexBatchFills      ErrorCode = 127
+	CodeInvalidDexTWAPWindow      ErrorCode = 128
+	CodeInvalidDexRoute           ErrorCode = 129
+	CodeInvalidLockAmount         ErrorCode = 130
+	CodeInvalidExternalPayment    ErrorCode = 131
+	CodeUnderpricedReplacement    ErrorCode = 132
+	CodeSenderMempoolFull         ErrorCode = 133
 
 	// P2P Module
 	P2PModule ErrorModule = "p2p"
@@ -321,6 +352,8 @@ const (
 	CodeBannedID                ErrorCode = 31
 	CodeIncompatiblePeer        ErrorCode = 32
 	CodeInvalidNetAddress       ErrorCode = 33
+	CodeRemoteSigner            ErrorCode = 34
+	CodeRemoteSignerRefused     ErrorCode = 35
 
 	StorageModule              ErrorModule = "store"
 	CodeOpenDB                 ErrorCode   = 1
@@ -339,17 +372,22 @@ const (
 	CodeReadBytes              ErrorCode   = 14
 	CodeIndexBlock             ErrorCode   = 15
 	CodeCompactDB              ErrorCode   = 16
-
-	RPCModule             ErrorModule = "rpc"
-	CodeMempoolStopSignal ErrorCode   = 1
-	CodeInvalidParams     ErrorCode   = 2
-	CodeNewFSM            ErrorCode   = 3
-	CodeTimeMachine       ErrorCode   = 4
-	CodePostRequest       ErrorCode   = 5
-	CodeGetRequest        ErrorCode   = 6
-	CodeHttpStatus        ErrorCode   = 7
-	CodeReadBody          ErrorCode   = 8
-	CodeStringToCommittee ErrorCode   = 9
+	CodeInvalidDexResolution   ErrorCode   = 17
+
+	RPCModule                  ErrorModule = "rpc"
+	CodeMempoolStopSignal      ErrorCode   = 1
+	CodeInvalidParams          ErrorCode   = 2
+	CodeNewFSM                 ErrorCode   = 3
+	CodeTimeMachine            ErrorCode   = 4
+	CodePostRequest            ErrorCode   = 5
+	CodeGetRequest             ErrorCode   = 6
+	CodeHttpStatus             ErrorCode   = 7
+	CodeReadBody               ErrorCode   = 8
+	CodeStringToCommittee      ErrorCode   = 9
+	CodeInvalidProofQuery      ErrorCode   = 10
+	CodeNotMultisigMember      ErrorCode   = 11
+	CodeMultisigThresholdUnmet ErrorCode   = 12
+	CodeExternalChain          ErrorCode   = 13
 )
 
 // error implementations below for the `types` package
@@ -485,6 +523,38 @@ func ErrNewHeight() ErrorI {
 	return NewError(CodeNewHeight, ConsensusModule, "new height")
 }
 
+func ErrNoStateSyncAnchor() ErrorI {
+	return NewError(CodeNoStateSyncAnchor, ConsensusModule, "no trusted height and hash to state-sync to")
+}
+
+func ErrInvalidStateChunk(reason string) ErrorI {
+	return NewError(CodeInvalidStateChunk, ConsensusModule, fmt.Sprintf("invalid state chunk: %s", reason))
+}
+
+func ErrMismatchStateSyncRoot(got, wanted []byte) ErrorI {
+	return NewError(CodeMismatchStateSyncRoot, ConsensusModule, fmt.Sprintf("state-sync root mismatch, got=%x | wanted=%x", got, wanted))
+}
+
+func ErrStateSyncTimeout() ErrorI {
+	return NewError(CodeStateSyncTimeout, ConsensusModule, "state-sync timed out waiting for a peer response")
+}
+
+func ErrUntrustedValidatorSet(rootHeight uint64) ErrorI {
+	return NewError(CodeUntrustedValidatorSet, ConsensusModule, fmt.Sprintf("validator set at root height %d is not committed by a verified header", rootHeight))
+}
+
+func ErrUnverifiedHeight(height uint64) ErrorI {
+	return NewError(CodeUnverifiedHeight, ConsensusModule, fmt.Sprintf("height %d is not verified", height))
+}
+
+func ErrMismatchLastBlockHash() ErrorI {
+	return NewError(CodeMismatchLastBlockHash, ConsensusModule, "last block hash doesn't match the previous verified block")
+}
+
+func ErrInvalidStateProof() ErrorI {
+	return NewError(CodeInvalidStateProof, ConsensusModule, "state proof failed verification against the verified state root")
+}
+
 func ErrWrongRootHeight() ErrorI {
 	return NewError(CodeRootHeight, ConsensusModule, "wrong root height")
 }
@@ -717,6 +787,47 @@ func ErrMaxTxSize() ErrorI {
 	return NewError(CodeMaxTxSize, StateMachineModule, "max tx size")
 }
 
+func ErrMempoolTxExpired() ErrorI {
+	return NewError(CodeMempoolTxExpired, StateMachineModule, "transaction expired in the mempool")
+}
+
+func ErrUnderpricedReplacement(minFee uint64) ErrorI {
+	return NewError(CodeUnderpricedReplacement, StateMachineModule,
+		fmt.Sprintf("underpriced replacement: a fee of at least %d is required to replace the pending transaction with the same sender and nonce", minFee))
+}
+
+func ErrSenderMempoolFull(limit uint32) ErrorI {
+	return NewError(CodeSenderMempoolFull, StateMachineModule, fmt.Sprintf("the sender already has the maximum of %d pending transactions in the mempool", limit))
+}
+
+func ErrInvalidDexPoolConfig() ErrorI {
+	return NewError(CodeInvalidDexPoolConfig, StateMachineModule, "the dex pool config is invalid")
+}
+
+func ErrInvalidDexOrderExpiry() ErrorI {
+	return NewError(CodeInvalidDexOrderExpiry, StateMachineModule, "the dex order expiry height is invalid")
+}
+
+func ErrInvalidDexBatchFills() ErrorI {
+	return NewError(CodeInvalidDexBatchFills, StateMachineModule, "the dex batch fills don't correspond to the receipts")
+}
+
+func ErrInvalidDexTWAPWindow() ErrorI {
+	return NewError(CodeInvalidDexTWAPWindow, StateMachineModule, "the dex twap window is invalid or has no price observations")
+}
+
+func ErrInvalidDexRoute() ErrorI {
+	return NewError(CodeInvalidDexRoute, StateMachineModule, "the dex route is invalid")
+}
+
+func ErrInvalidExternalPayment() ErrorI {
+	return NewError(CodeInvalidExternalPayment, StateMachineModule, "the external payments are invalid, duplicated or too many")
+}
+
+func ErrInvalidLockAmount() ErrorI {
+	return NewError(CodeInvalidLockAmount, StateMachineModule, "the lock amount exceeds the unlocked amount of the order or is below the minimum order size")
+}
+
 func ErrInvalidArgument() ErrorI {
 	return NewError(CodeInvalidArgument, MainModule, "the argument is invalid")
 }
@@ -826,10 +937,26 @@ func ErrReadBody(err error) ErrorI {
 	return NewError(CodeReadBody, RPCModule, fmt.Sprintf("io.ReadAll(http.ResponseBody) failed with err: %s", err.Error()))
 }
 
+func ErrExternalChain(err error) ErrorI {
+	return NewError(CodeExternalChain, RPCModule, fmt.Sprintf("external chain watcher failed with err: %s", err.Error()))
+}
+
 func ErrStringToCommittee(s string) ErrorI {
 	return NewError(CodeStringToCommittee, RPCModule, fmt.Sprintf("committee arg %s is invalid, requires a comma separated list of <chainId>=<percent> ex. 0=50,21=25,99=25", s))
 }
 
+func ErrInvalidProofQuery() ErrorI {
+	return NewError(CodeInvalidProofQuery, RPCModule, "proof query requires exactly one of: key, account, validator or orderId")
+}
+
+func ErrNotMultisigMember(publicKey string) ErrorI {
+	return NewError(CodeNotMultisigMember, RPCModule, fmt.Sprintf("public key %s is not a member of the multisig account", publicKey))
+}
+
+func ErrMultisigThresholdUnmet(signatures, threshold uint32) ErrorI {
+	return NewError(CodeMultisigThresholdUnmet, RPCModule, fmt.Sprintf("multisig transaction has %d of %d required signatures", signatures, threshold))
+}
+
 func ErrNoSubsidizedCommittees(chainId uint64) ErrorI {
 	return NewError(CodeNoSubsidizedCommittees, StateMachineModule, fmt.Sprintf("Chain ID %d has no subsidized committees", chainId))
 }
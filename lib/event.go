@@ -23,6 +23,8 @@ const (
 	EventTypeOrderBookSwap        EventType = "order-book-swap"
 	EventTypeOrderBookLock        EventType = "order-book-lock"
 	EventTypeOrderBookReset       EventType = "order-book-reset"
+	EventTypeProposalSubmit       EventType = "proposal-submit"
+	EventTypeProposalTally        EventType = "proposal-tally"
 )
 
 type EventsTracker struct {
@@ -55,6 +57,22 @@ func (t *EventsTracker) GetReference() string {
 	return t.Reference
 }
 
+// Len() returns the number of captured events
+func (t *EventsTracker) Len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.Events)
+}
+
+// Truncate() drops any events captured after the first n, used to roll back the events of a discarded operation
+func (t *EventsTracker) Truncate(n int) {
+	if t == nil || n < 0 || n >= len(t.Events) {
+		return
+	}
+	t.Events = t.Events[:n]
+}
+
 // Reset() resets the event tracker and returns the captured events
 func (t *EventsTracker) Reset() (e Events) {
 	if t == nil {
@@ -122,6 +140,10 @@ func (e *Event) MarshalJSON() ([]byte, error) {
 			msgBytes, err = json.Marshal(msg.AutoBeginUnstaking)
 		case *Event_FinishUnstaking:
 			msgBytes, err = json.Marshal(msg.FinishUnstaking)
+		case *Event_ProposalSubmit:
+			msgBytes, err = json.Marshal(msg.ProposalSubmit)
+		case *Event_ProposalTally:
+			msgBytes, err = json.Marshal(msg.ProposalTally)
 		case *Event_Custom:
 			if msg.Custom != nil && msg.Custom.Msg != nil {
 				msgBytes, err = MarshalAnypbJSON(msg.Custom.Msg)
@@ -246,6 +268,18 @@ func (e *Event) UnmarshalJSON(data []byte) error {
 				return err
 			}
 			e.Msg = &Event_OrderBookReset{OrderBookReset: &orderBookReset}
+		case string(EventTypeProposalSubmit):
+			var proposalSubmit EventProposalSubmit
+			if err := json.Unmarshal(temp.Msg, &proposalSubmit); err != nil {
+				return err
+			}
+			e.Msg = &Event_ProposalSubmit{ProposalSubmit: &proposalSubmit}
+		case string(EventTypeProposalTally):
+			var proposalTally EventProposalTally
+			if err := json.Unmarshal(temp.Msg, &proposalTally); err != nil {
+				return err
+			}
+			e.Msg = &Event_ProposalTally{ProposalTally: &proposalTally}
 		}
 	}
 	if e.Msg == nil && len(temp.Msg) > 0 {
@@ -487,3 +521,79 @@ func (e *EventDexLiquidityWithdrawal) UnmarshalJSON(b []byte) error {
 	}
 	return nil
 }
+
+// eventProposalSubmitJSON represents the JSON structure for EventProposalSubmit marshalling/unmarshalling
+type eventProposalSubmitJSON struct {
+	ProposalId      HexBytes `json:"proposalId,omitempty"`
+	Deposit         uint64   `json:"deposit,omitempty"`
+	VotingEndHeight uint64   `json:"votingEndHeight,omitempty"`
+}
+
+// MarshalJSON implements custom JSON marshalling for EventProposalSubmit, converting []byte fields to HexBytes
+func (e *EventProposalSubmit) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return json.Marshal(nil)
+	}
+
+	return json.Marshal(eventProposalSubmitJSON{
+		ProposalId:      e.ProposalId,
+		Deposit:         e.Deposit,
+		VotingEndHeight: e.VotingEndHeight,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshalling for EventProposalSubmit, converting HexBytes to []byte fields
+func (e *EventProposalSubmit) UnmarshalJSON(data []byte) error {
+	var temp eventProposalSubmitJSON
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	e.ProposalId = temp.ProposalId
+	e.Deposit = temp.Deposit
+	e.VotingEndHeight = temp.VotingEndHeight
+
+	return nil
+}
+
+// eventProposalTallyJSON represents the JSON structure for EventProposalTally marshalling/unmarshalling
+type eventProposalTallyJSON struct {
+	ProposalId       HexBytes `json:"proposalId,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	ApproveVotes     uint64   `json:"approveVotes,omitempty"`
+	RejectVotes      uint64   `json:"rejectVotes,omitempty"`
+	TotalVotingPower uint64   `json:"totalVotingPower,omitempty"`
+}
+
+// MarshalJSON implements custom JSON marshalling for EventProposalTally, converting []byte fields to HexBytes
+func (e *EventProposalTally) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return json.Marshal(nil)
+	}
+
+	return json.Marshal(eventProposalTallyJSON{
+		ProposalId:       e.ProposalId,
+		Status:           e.Status,
+		ApproveVotes:     e.ApproveVotes,
+		RejectVotes:      e.RejectVotes,
+		TotalVotingPower: e.TotalVotingPower,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshalling for EventProposalTally, converting HexBytes to []byte fields
+func (e *EventProposalTally) UnmarshalJSON(data []byte) error {
+	var temp eventProposalTallyJSON
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	e.ProposalId = temp.ProposalId
+	e.Status = temp.Status
+	e.ApproveVotes = temp.ApproveVotes
+	e.RejectVotes = temp.RejectVotes
+	e.TotalVotingPower = temp.TotalVotingPower
+
+	return nil
+}
@@ -42,6 +42,8 @@ type Event struct {
 	//	*Event_OrderBookLock
 	//	*Event_OrderBookReset
 	//	*Event_Custom
+	//	*Event_ProposalSubmit
+	//	*Event_ProposalTally
 	Msg isEvent_Msg `protobuf_oneof:"msg"`
 	// height: the block height of the event
 	Height uint64 `protobuf:"varint,91,opt,name=height,proto3" json:"height,omitempty"`
@@ -213,6 +215,24 @@ func (x *Event) GetCustom() *EventCustom {
 	return nil
 }
 
+func (x *Event) GetProposalSubmit() *EventProposalSubmit {
+	if x != nil {
+		if x, ok := x.Msg.(*Event_ProposalSubmit); ok {
+			return x.ProposalSubmit
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetProposalTally() *EventProposalTally {
+	if x != nil {
+		if x, ok := x.Msg.(*Event_ProposalTally); ok {
+			return x.ProposalTally
+		}
+	}
+	return nil
+}
+
 func (x *Event) GetHeight() uint64 {
 	if x != nil {
 		return x.Height
@@ -307,6 +327,14 @@ type Event_Custom struct {
 	Custom *EventCustom `protobuf:"bytes,13,opt,name=custom,proto3,oneof"`
 }
 
+type Event_ProposalSubmit struct {
+	ProposalSubmit *EventProposalSubmit `protobuf:"bytes,14,opt,name=proposal_submit,json=proposalSubmit,proto3,oneof"`
+}
+
+type Event_ProposalTally struct {
+	ProposalTally *EventProposalTally `protobuf:"bytes,15,opt,name=proposal_tally,json=proposalTally,proto3,oneof"`
+}
+
 func (*Event_Reward) isEvent_Msg() {}
 
 func (*Event_Slash) isEvent_Msg() {}
@@ -331,6 +359,10 @@ func (*Event_OrderBookReset) isEvent_Msg() {}
 
 func (*Event_Custom) isEvent_Msg() {}
 
+func (*Event_ProposalSubmit) isEvent_Msg() {}
+
+func (*Event_ProposalTally) isEvent_Msg() {}
+
 type EventReward struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// amount: amount of reward
@@ -585,9 +617,14 @@ type EventDexSwap struct {
 	// success: did the swap succeed
 	Success bool `protobuf:"varint,4,opt,name=success,proto3" json:"success"` // @gotags: json:"success"
 	// order_id: the unique identifier of the order
-	OrderId       []byte `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"orderId"` // @gotags: json:"orderId"
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	OrderId []byte `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"orderId"` // @gotags: json:"orderId"
+	// filled_amount: the amount of the order sold so far, including this swap
+	FilledAmount uint64 `protobuf:"varint,6,opt,name=filled_amount,json=filledAmount,proto3" json:"filledAmount"` // @gotags: json:"filledAmount"
+	// remaining_amount: the amount of the order left unsold after this swap; on the origin chain it's the amount still
+	// resting for the next batch (0 once the order is filled, refunded or expired)
+	RemainingAmount uint64 `protobuf:"varint,7,opt,name=remaining_amount,json=remainingAmount,proto3" json:"remainingAmount"` // @gotags: json:"remainingAmount"
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *EventDexSwap) Reset() {
@@ -655,6 +692,20 @@ func (x *EventDexSwap) GetOrderId() []byte {
 	return nil
 }
 
+func (x *EventDexSwap) GetFilledAmount() uint64 {
+	if x != nil {
+		return x.FilledAmount
+	}
+	return 0
+}
+
+func (x *EventDexSwap) GetRemainingAmount() uint64 {
+	if x != nil {
+		return x.RemainingAmount
+	}
+	return 0
+}
+
 type EventOrderBookSwap struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// sold_amount: amount sold
@@ -979,6 +1030,150 @@ func (x *EventOrderBookReset) GetOrderId() []byte {
 	return nil
 }
 
+type EventProposalSubmit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// proposal_id: the unique identifier of the proposal
+	ProposalId []byte `protobuf:"bytes,1,opt,name=proposal_id,json=proposalId,proto3" json:"proposalId"` // @gotags: json:"proposalId"
+	// deposit: the amount escrowed with the proposal
+	Deposit uint64 `protobuf:"varint,2,opt,name=deposit,proto3" json:"deposit,omitempty"`
+	// voting_end_height: the height the voting period ends and the proposal is tallied
+	VotingEndHeight uint64 `protobuf:"varint,3,opt,name=voting_end_height,json=votingEndHeight,proto3" json:"votingEndHeight"` // @gotags: json:"votingEndHeight"
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EventProposalSubmit) Reset() {
+	*x = EventProposalSubmit{}
+	mi := &file_event_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventProposalSubmit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventProposalSubmit) ProtoMessage() {}
+
+func (x *EventProposalSubmit) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventProposalSubmit.ProtoReflect.Descriptor instead.
+func (*EventProposalSubmit) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EventProposalSubmit) GetProposalId() []byte {
+	if x != nil {
+		return x.ProposalId
+	}
+	return nil
+}
+
+func (x *EventProposalSubmit) GetDeposit() uint64 {
+	if x != nil {
+		return x.Deposit
+	}
+	return 0
+}
+
+func (x *EventProposalSubmit) GetVotingEndHeight() uint64 {
+	if x != nil {
+		return x.VotingEndHeight
+	}
+	return 0
+}
+
+type EventProposalTally struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// proposal_id: the unique identifier of the proposal
+	ProposalId []byte `protobuf:"bytes,1,opt,name=proposal_id,json=proposalId,proto3" json:"proposalId"` // @gotags: json:"proposalId"
+	// status: the result of the tally (passed, rejected, no quorum or failed)
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// approve_votes: the total stake that voted to approve
+	ApproveVotes uint64 `protobuf:"varint,3,opt,name=approve_votes,json=approveVotes,proto3" json:"approveVotes"` // @gotags: json:"approveVotes"
+	// reject_votes: the total stake that voted to reject
+	RejectVotes uint64 `protobuf:"varint,4,opt,name=reject_votes,json=rejectVotes,proto3" json:"rejectVotes"` // @gotags: json:"rejectVotes"
+	// total_voting_power: the total stake eligible to vote
+	TotalVotingPower uint64 `protobuf:"varint,5,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"totalVotingPower"` // @gotags: json:"totalVotingPower"
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *EventProposalTally) Reset() {
+	*x = EventProposalTally{}
+	mi := &file_event_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventProposalTally) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventProposalTally) ProtoMessage() {}
+
+func (x *EventProposalTally) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventProposalTally.ProtoReflect.Descriptor instead.
+func (*EventProposalTally) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EventProposalTally) GetProposalId() []byte {
+	if x != nil {
+		return x.ProposalId
+	}
+	return nil
+}
+
+func (x *EventProposalTally) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EventProposalTally) GetApproveVotes() uint64 {
+	if x != nil {
+		return x.ApproveVotes
+	}
+	return 0
+}
+
+func (x *EventProposalTally) GetRejectVotes() uint64 {
+	if x != nil {
+		return x.RejectVotes
+	}
+	return 0
+}
+
+func (x *EventProposalTally) GetTotalVotingPower() uint64 {
+	if x != nil {
+		return x.TotalVotingPower
+	}
+	return 0
+}
+
 // EventCustom carries a plugin-defined event payload.
 type EventCustom struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -990,7 +1185,7 @@ type EventCustom struct {
 
 func (x *EventCustom) Reset() {
 	*x = EventCustom{}
-	mi := &file_event_proto_msgTypes[12]
+	mi := &file_event_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1002,7 +1197,7 @@ func (x *EventCustom) String() string {
 func (*EventCustom) ProtoMessage() {}
 
 func (x *EventCustom) ProtoReflect() protoreflect.Message {
-	mi := &file_event_proto_msgTypes[12]
+	mi := &file_event_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,7 +1210,7 @@ func (x *EventCustom) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventCustom.ProtoReflect.Descriptor instead.
 func (*EventCustom) Descriptor() ([]byte, []int) {
-	return file_event_proto_rawDescGZIP(), []int{12}
+	return file_event_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *EventCustom) GetMsg() *anypb.Any {
@@ -1029,7 +1224,7 @@ var File_event_proto protoreflect.FileDescriptor
 
 const file_event_proto_rawDesc = "" +
 	"\n" +
-	"\vevent.proto\x12\x05types\x1a\x19google/protobuf/any.proto\"\xf1\a\n" +
+	"\vevent.proto\x12\x05types\x1a\x19google/protobuf/any.proto\"\xfc\b\n" +
 	"\x05Event\x12\x1d\n" +
 	"\n" +
 	"event_type\x18\x01 \x01(\tR\teventType\x12,\n" +
@@ -1046,7 +1241,9 @@ const file_event_proto_rawDesc = "" +
 	" \x01(\v2\x1b.types.EventFinishUnstakingH\x00R\x0ffinishUnstaking\x12C\n" +
 	"\x0forder_book_lock\x18\v \x01(\v2\x19.types.EventOrderBookLockH\x00R\rorderBookLock\x12F\n" +
 	"\x10order_book_reset\x18\f \x01(\v2\x1a.types.EventOrderBookResetH\x00R\x0eorderBookReset\x12,\n" +
-	"\x06custom\x18\r \x01(\v2\x12.types.EventCustomH\x00R\x06custom\x12\x16\n" +
+	"\x06custom\x18\r \x01(\v2\x12.types.EventCustomH\x00R\x06custom\x12E\n" +
+	"\x0fproposal_submit\x18\x0e \x01(\v2\x1a.types.EventProposalSubmitH\x00R\x0eproposalSubmit\x12B\n" +
+	"\x0eproposal_tally\x18\x0f \x01(\v2\x19.types.EventProposalTallyH\x00R\rproposalTally\x12\x16\n" +
 	"\x06height\x18[ \x01(\x04R\x06height\x12\x1c\n" +
 	"\treference\x18\\ \x01(\tR\treference\x12\x18\n" +
 	"\achainId\x18] \x01(\x04R\achainId\x12!\n" +
@@ -1070,14 +1267,16 @@ const file_event_proto_rawDesc = "" +
 	"\rremote_amount\x18\x02 \x01(\x04R\fremoteAmount\x12\x19\n" +
 	"\border_id\x18\x03 \x01(\fR\aorderId\x12#\n" +
 	"\rpoints_burned\x18\x04 \x01(\x04R\fpointsBurned\x12\x18\n" +
-	"\apercent\x18\x05 \x01(\x04R\apercent\"\xac\x01\n" +
+	"\apercent\x18\x05 \x01(\x04R\apercent\"\xfc\x01\n" +
 	"\fEventDexSwap\x12\x1f\n" +
 	"\vsold_amount\x18\x01 \x01(\x04R\n" +
 	"soldAmount\x12#\n" +
 	"\rbought_amount\x18\x02 \x01(\x04R\fboughtAmount\x12!\n" +
 	"\flocal_origin\x18\x03 \x01(\bR\vlocalOrigin\x12\x18\n" +
 	"\asuccess\x18\x04 \x01(\bR\asuccess\x12\x19\n" +
-	"\border_id\x18\x05 \x01(\fR\aorderId\"\x98\x02\n" +
+	"\border_id\x18\x05 \x01(\fR\aorderId\x12#\n" +
+	"\rfilled_amount\x18\x06 \x01(\x04R\ffilledAmount\x12)\n" +
+	"\x10remaining_amount\x18\a \x01(\x04R\x0fremainingAmount\"\x98\x02\n" +
 	"\x12EventOrderBookSwap\x12\x1f\n" +
 	"\vsold_amount\x18\x01 \x01(\x04R\n" +
 	"soldAmount\x12#\n" +
@@ -1096,7 +1295,19 @@ const file_event_proto_rawDesc = "" +
 	"\x12buyer_send_address\x18\x03 \x01(\fR\x10buyerSendAddress\x120\n" +
 	"\x14buyer_chain_deadline\x18\x04 \x01(\x04R\x12buyerChainDeadline\"0\n" +
 	"\x13EventOrderBookReset\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\fR\aorderId\"5\n" +
+	"\border_id\x18\x01 \x01(\fR\aorderId\"|\n" +
+	"\x13EventProposalSubmit\x12\x1f\n" +
+	"\vproposal_id\x18\x01 \x01(\fR\n" +
+	"proposalId\x12\x18\n" +
+	"\adeposit\x18\x02 \x01(\x04R\adeposit\x12*\n" +
+	"\x11voting_end_height\x18\x03 \x01(\x04R\x0fvotingEndHeight\"\xc3\x01\n" +
+	"\x12EventProposalTally\x12\x1f\n" +
+	"\vproposal_id\x18\x01 \x01(\fR\n" +
+	"proposalId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12#\n" +
+	"\rapprove_votes\x18\x03 \x01(\x04R\fapproveVotes\x12!\n" +
+	"\freject_votes\x18\x04 \x01(\x04R\vrejectVotes\x12,\n" +
+	"\x12total_voting_power\x18\x05 \x01(\x04R\x10totalVotingPower\"5\n" +
 	"\vEventCustom\x12&\n" +
 	"\x03msg\x18\x01 \x01(\v2\x14.google.protobuf.AnyR\x03msgB&Z$github.com/canopy-network/canopy/libb\x06proto3"
 
@@ -1112,7 +1323,7 @@ func file_event_proto_rawDescGZIP() []byte {
 	return file_event_proto_rawDescData
 }
 
-var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_event_proto_goTypes = []any{
 	(*Event)(nil),                       // 0: types.Event
 	(*EventReward)(nil),                 // 1: types.EventReward
@@ -1126,8 +1337,10 @@ var file_event_proto_goTypes = []any{
 	(*EventFinishUnstaking)(nil),        // 9: types.EventFinishUnstaking
 	(*EventOrderBookLock)(nil),          // 10: types.EventOrderBookLock
 	(*EventOrderBookReset)(nil),         // 11: types.EventOrderBookReset
-	(*EventCustom)(nil),                 // 12: types.EventCustom
-	(*anypb.Any)(nil),                   // 13: google.protobuf.Any
+	(*EventProposalSubmit)(nil),         // 12: types.EventProposalSubmit
+	(*EventProposalTally)(nil),          // 13: types.EventProposalTally
+	(*EventCustom)(nil),                 // 14: types.EventCustom
+	(*anypb.Any)(nil),                   // 15: google.protobuf.Any
 }
 var file_event_proto_depIdxs = []int32{
 	1,  // 0: types.Event.reward:type_name -> types.EventReward
@@ -1141,13 +1354,15 @@ var file_event_proto_depIdxs = []int32{
 	9,  // 8: types.Event.finish_unstaking:type_name -> types.EventFinishUnstaking
 	10, // 9: types.Event.order_book_lock:type_name -> types.EventOrderBookLock
 	11, // 10: types.Event.order_book_reset:type_name -> types.EventOrderBookReset
-	12, // 11: types.Event.custom:type_name -> types.EventCustom
-	13, // 12: types.EventCustom.msg:type_name -> google.protobuf.Any
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	14, // 11: types.Event.custom:type_name -> types.EventCustom
+	12, // 12: types.Event.proposal_submit:type_name -> types.EventProposalSubmit
+	13, // 13: types.Event.proposal_tally:type_name -> types.EventProposalTally
+	15, // 14: types.EventCustom.msg:type_name -> google.protobuf.Any
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_event_proto_init() }
@@ -1168,6 +1383,8 @@ func file_event_proto_init() {
 		(*Event_OrderBookLock)(nil),
 		(*Event_OrderBookReset)(nil),
 		(*Event_Custom)(nil),
+		(*Event_ProposalSubmit)(nil),
+		(*Event_ProposalTally)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1175,7 +1392,7 @@ func file_event_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -67,6 +67,30 @@ func TestEventsTracker_Reset(t *testing.T) {
 	}
 }
 
+func TestEventsTracker_Truncate(t *testing.T) {
+	tracker := &EventsTracker{Events: Events{&Event{EventType: "a"}, &Event{EventType: "b"}, &Event{EventType: "c"}}}
+
+	if tracker.Len() != 3 {
+		t.Errorf("expected 3 events, got %d", tracker.Len())
+	}
+
+	tracker.Truncate(5)
+	if tracker.Len() != 3 {
+		t.Errorf("expected 3 events after out of range truncate, got %d", tracker.Len())
+	}
+
+	tracker.Truncate(1)
+	if tracker.Len() != 1 || tracker.Events[0].EventType != "a" {
+		t.Errorf("expected only the first event to remain, got %d", tracker.Len())
+	}
+
+	var nilTracker *EventsTracker
+	nilTracker.Truncate(0)
+	if nilTracker.Len() != 0 {
+		t.Error("expected 0 events for nil tracker")
+	}
+}
+
 func TestEvents_Len(t *testing.T) {
 	events := Events{&Event{}, &Event{}}
 
@@ -0,0 +1,311 @@
+// Package lightclient follows a Canopy chain using only QuorumCertificates.
+//
+// Starting from a trusted checkpoint (height + block hash) and a trusted validator set, the light client
+// downloads each certificate over RPC, verifies the +2/3 aggregate signature against a validator set it
+// already trusts, and links every block to its predecessor. Validator set changes are only accepted when
+// the merkle root of the new set is committed by a verified block header. State reads are verified with
+// SMT proofs against the state root of a verified block header, so the RPC node is never trusted.
+package lightclient
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/canopy-network/canopy/fsm"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/canopy-network/canopy/store"
+)
+
+// DefaultMaxHeaders is the default number of verified headers retained for historical state reads
+const DefaultMaxHeaders = 1000
+
+// RPCI is the subset of the Canopy RPC client the light client depends on; *rpc.Client satisfies it
+type RPCI interface {
+	Height() (*lib.HeightResult, lib.ErrorI)                                  // the next height of the chain
+	CertByHeight(height uint64) (*lib.QuorumCertificate, lib.ErrorI)          // the quorum certificate (with block) for a height
+	ValidatorSet(height uint64, id uint64) (lib.ValidatorSet, lib.ErrorI)     // the validator set for a chain at a root height
+	StateProof(height uint64, key lib.HexBytes) (*lib.StateEntry, lib.ErrorI) // a proof of a state key against a block's state root
+}
+
+// Config is the trust anchor and chain identity of a light client
+type Config struct {
+	NetworkId         uint64                   // the network the chain belongs to
+	ChainId           uint64                   // the chain being followed
+	TrustedHeight     uint64                   // the height of the trusted checkpoint
+	TrustedHash       lib.HexBytes             // the block hash of the trusted checkpoint
+	TrustedValidators *lib.ConsensusValidators // the validator set trusted to sign the blocks after the checkpoint
+	MaxHeaders        int                      // the number of verified headers retained; defaults to DefaultMaxHeaders
+}
+
+// LightClient tracks verified block headers and the trusted validator set of a chain
+type LightClient struct {
+	config     Config
+	rpc        RPCI                        // the rpc for certificates and state proofs
+	rootRPC    RPCI                        // the rpc for validator sets; differs from rpc for nested chains
+	vs         lib.ValidatorSet            // the currently trusted validator set
+	vsRoot     []byte                      // the merkle root of the currently trusted validator set
+	rootHeight uint64                      // the root height the trusted validator set was fetched at
+	latest     *lib.BlockHeader            // the highest verified block header
+	headers    map[uint64]*lib.BlockHeader // the retained verified block headers by height
+	mu         sync.RWMutex
+}
+
+// New() creates a light client from a trusted checkpoint
+// rootRPC serves the validator sets; if nil, rpc is used (the chain is its own root)
+func New(config Config, rpc, rootRPC RPCI) (*LightClient, lib.ErrorI) {
+	if config.MaxHeaders <= 0 {
+		config.MaxHeaders = DefaultMaxHeaders
+	}
+	if rootRPC == nil {
+		rootRPC = rpc
+	}
+	// convert the trusted validators into a validator set
+	vs, err := lib.NewValidatorSet(config.TrustedValidators)
+	if err != nil {
+		return nil, err
+	}
+	vsRoot, err := config.TrustedValidators.Root()
+	if err != nil {
+		return nil, err
+	}
+	c := &LightClient{
+		config:  config,
+		rpc:     rpc,
+		rootRPC: rootRPC,
+		vs:      vs,
+		vsRoot:  vsRoot,
+		headers: make(map[uint64]*lib.BlockHeader),
+	}
+	// load the checkpoint block; it's trusted by hash so the signatures aren't checked
+	qc, err := rpc.CertByHeight(config.TrustedHeight)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(qc.BlockHash, config.TrustedHash) {
+		return nil, lib.ErrMismatchQCBlockHash()
+	}
+	header, err := c.blockHeader(qc, config.TrustedHeight)
+	if err != nil {
+		return nil, err
+	}
+	c.addHeader(header)
+	return c, nil
+}
+
+// Update() verifies every block between the latest verified height and the latest height of the rpc
+func (c *LightClient) Update() (height uint64, err lib.ErrorI) {
+	// the rpc reports the next height, the latest committed block is one below it
+	h, err := c.rpc.Height()
+	if err != nil {
+		return 0, err
+	}
+	for height = c.Latest().Height; height+1 < h.Height; height++ {
+		if _, err = c.VerifyNext(); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// VerifyNext() downloads and verifies the certificate of the block after the latest verified header
+func (c *LightClient) VerifyNext() (*lib.BlockHeader, lib.ErrorI) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	height := c.latest.Height + 1
+	// download the certificate with the block
+	qc, err := c.rpc.CertByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	if qc.GetHeader() == nil || qc.Header.Height != height {
+		return nil, lib.ErrWrongBlockHeight(qc.GetHeader().GetHeight(), height)
+	}
+	// load a trusted validator set for the root height of the certificate
+	vs, err := c.validatorSet(qc.Header.RootHeight)
+	if err != nil {
+		return nil, err
+	}
+	// verify the aggregate signature; this also checks the block hash against the block bytes
+	isPartialQC, err := qc.Check(vs, lib.GlobalMaxBlockSize, &lib.View{NetworkId: c.config.NetworkId, ChainId: c.config.ChainId}, false)
+	if err != nil {
+		return nil, err
+	}
+	if isPartialQC {
+		return nil, lib.ErrNoMaj23()
+	}
+	header, err := c.blockHeader(qc, height)
+	if err != nil {
+		return nil, err
+	}
+	// ensure the block extends the latest verified block
+	if !bytes.Equal(header.LastBlockHash, c.latest.Hash) {
+		return nil, lib.ErrMismatchLastBlockHash()
+	}
+	c.addHeader(header)
+	return header, nil
+}
+
+// Get() reads a raw state key as of the end of a verified block; height 0 is the latest verified block
+// a nil value with no error is a verified proof of non-membership
+func (c *LightClient) Get(height uint64, key []byte) ([]byte, lib.ErrorI) {
+	header, err := c.Header(height)
+	if err != nil {
+		return nil, err
+	}
+	// download the proof
+	entry, err := c.rpc.StateProof(header.Height, key)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || !bytes.Equal(entry.Key, key) {
+		return nil, lib.ErrInvalidStateProof()
+	}
+	// verify the proof against the state root committed in the verified header
+	valid, err := store.VerifyStateProof(key, entry.Value, entry.Value != nil, header.StateRoot, entry.Proof)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, lib.ErrInvalidStateProof()
+	}
+	return entry.Value, nil
+}
+
+// Account() reads a verified account; a non-existent account is returned empty
+func (c *LightClient) Account(height uint64, address crypto.AddressI) (*fsm.Account, lib.ErrorI) {
+	bz, err := c.Get(height, fsm.KeyForAccount(address))
+	if err != nil {
+		return nil, err
+	}
+	acc := new(fsm.Account)
+	if err = lib.Unmarshal(bz, acc); err != nil {
+		return nil, err
+	}
+	acc.Address = address.Bytes()
+	return acc, nil
+}
+
+// Validator() reads a verified validator
+func (c *LightClient) Validator(height uint64, address crypto.AddressI) (*fsm.Validator, lib.ErrorI) {
+	bz, err := c.Get(height, fsm.KeyForValidator(address))
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, fsm.ErrValidatorNotExists()
+	}
+	val := new(fsm.Validator)
+	if err = lib.Unmarshal(bz, val); err != nil {
+		return nil, err
+	}
+	val.Address = address.Bytes()
+	return val, nil
+}
+
+// Order() reads a verified sell order
+func (c *LightClient) Order(height, chainId uint64, orderId []byte) (*lib.SellOrder, lib.ErrorI) {
+	bz, err := c.Get(height, fsm.KeyForOrder(chainId, orderId))
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, lib.ErrOrderNotFound()
+	}
+	order := new(lib.SellOrder)
+	if err = lib.Unmarshal(bz, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Header() returns a retained verified block header; height 0 is the latest verified block
+func (c *LightClient) Header(height uint64) (*lib.BlockHeader, lib.ErrorI) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if height == 0 {
+		return c.latest, nil
+	}
+	header, found := c.headers[height]
+	if !found {
+		return nil, lib.ErrUnverifiedHeight(height)
+	}
+	return header, nil
+}
+
+// Latest() returns the highest verified block header
+func (c *LightClient) Latest() *lib.BlockHeader {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.latest
+}
+
+// ValidatorSet() returns the currently trusted validator set
+func (c *LightClient) ValidatorSet() lib.ValidatorSet {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.vs
+}
+
+// validatorSet() returns the trusted validator set for a root height, rotating to a new set if the
+// merkle root of the new set is committed by the latest verified header
+// CONTRACT: the caller must hold the lock
+func (c *LightClient) validatorSet(rootHeight uint64) (lib.ValidatorSet, lib.ErrorI) {
+	// the trusted set is already loaded for this root height
+	if c.rootHeight != 0 && c.rootHeight == rootHeight {
+		return c.vs, nil
+	}
+	// download the untrusted validator set
+	vs, err := c.rootRPC.ValidatorSet(rootHeight, c.config.ChainId)
+	if err != nil {
+		return lib.ValidatorSet{}, err
+	}
+	root, err := vs.ValidatorSet.Root()
+	if err != nil {
+		return lib.ValidatorSet{}, err
+	}
+	// the set must be the trusted set or one committed to by the latest verified header
+	switch {
+	case bytes.Equal(root, c.vsRoot):
+	case bytes.Equal(root, c.latest.ValidatorRoot), bytes.Equal(root, c.latest.NextValidatorRoot):
+		c.vs, c.vsRoot = vs, root
+	default:
+		return lib.ValidatorSet{}, lib.ErrUntrustedValidatorSet(rootHeight)
+	}
+	c.rootHeight = rootHeight
+	return c.vs, nil
+}
+
+// blockHeader() extracts and validates the block header from a certificate
+func (c *LightClient) blockHeader(qc *lib.QuorumCertificate, height uint64) (*lib.BlockHeader, lib.ErrorI) {
+	// ensure the block hash matches the block bytes
+	if err := qc.CheckBasic(); err != nil {
+		return nil, err
+	}
+	// convert the block bytes into a block
+	block := new(lib.Block)
+	if err := lib.Unmarshal(qc.Block, block); err != nil {
+		return nil, err
+	}
+	// ensure the block is well-formed and the header hash isn't forged
+	if err := block.Check(c.config.NetworkId, c.config.ChainId); err != nil {
+		return nil, err
+	}
+	if block.BlockHeader.Height != height {
+		return nil, lib.ErrWrongBlockHeight(block.BlockHeader.Height, height)
+	}
+	// ensure the certificate justifies the block
+	if !bytes.Equal(block.BlockHeader.Hash, qc.BlockHash) {
+		return nil, lib.ErrMismatchQCBlockHash()
+	}
+	return block.BlockHeader, nil
+}
+
+// addHeader() sets the latest verified header and prunes headers outside the retention window
+// CONTRACT: the caller must hold the lock (or have exclusive access)
+func (c *LightClient) addHeader(header *lib.BlockHeader) {
+	c.latest, c.headers[header.Height] = header, header
+	if header.Height > uint64(c.config.MaxHeaders) {
+		delete(c.headers, header.Height-uint64(c.config.MaxHeaders))
+	}
+}
@@ -0,0 +1,219 @@
+package lightclient
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/canopy-network/canopy/fsm"
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/canopy-network/canopy/store"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testNetworkId = 1
+	testChainId   = 1
+)
+
+func TestLightClient(t *testing.T) {
+	// create two validator sets to rotate between
+	keysA, setA := newTestValidators(t, 3)
+	keysB, setB := newTestValidators(t, 3)
+	keysC, setC := newTestValidators(t, 3)
+	rootA, rootB := validatorRoot(t, setA), validatorRoot(t, setB)
+	// create a state with an account to read through the light client
+	db, err := store.NewStoreInMemory(lib.NewDefaultLogger())
+	require.NoError(t, err)
+	address := crypto.NewAddress(bytes.Repeat([]byte{0x11}, crypto.AddressSize))
+	accountBz, err := lib.Marshal(&fsm.Account{Amount: 42})
+	require.NoError(t, err)
+	require.NoError(t, db.Set(fsm.KeyForAccount(address), accountBz))
+	stateRoot, err := db.Root()
+	require.NoError(t, err)
+	_, err = db.Commit()
+	require.NoError(t, err)
+	// build the chain: set A signs block 2 which commits to set B as the next validator set; set B signs block 3
+	rpc := &testRPC{certs: make(map[uint64]*lib.QuorumCertificate), sets: map[uint64]*lib.ConsensusValidators{2: setA, 3: setB, 4: setC}, db: db}
+	qc1 := newTestQC(t, 1, 1, nil, crypto.Hash([]byte("genesis")), crypto.Hash([]byte("state-1")), rootA, rootA, keysA, setA, 3)
+	qc2 := newTestQC(t, 2, 2, qc1, qc1.BlockHash, crypto.Hash([]byte("state-2")), rootA, rootB, keysA, setA, 3)
+	qc3 := newTestQC(t, 3, 3, qc2, qc2.BlockHash, stateRoot, rootA, rootB, keysB, setB, 3)
+	rpc.certs[1], rpc.certs[2], rpc.certs[3], rpc.height = qc1, qc2, qc3, 3
+
+	// the checkpoint must match the trusted hash
+	_, err = New(Config{NetworkId: testNetworkId, ChainId: testChainId, TrustedHeight: 1, TrustedHash: qc2.BlockHash, TrustedValidators: setA}, rpc, nil)
+	require.Error(t, err)
+	lc, err := New(Config{NetworkId: testNetworkId, ChainId: testChainId, TrustedHeight: 1, TrustedHash: qc1.BlockHash, TrustedValidators: setA}, rpc, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), lc.Latest().Height)
+
+	// follow the chain across the validator set change
+	height, err := lc.Update()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), height)
+	require.Equal(t, []byte(qc3.BlockHash), lc.Latest().Hash)
+	require.Equal(t, setB.ValidatorSet[0].PublicKey, lc.ValidatorSet().ValidatorSet.ValidatorSet[0].PublicKey)
+
+	// invalid certificates don't advance the light client
+	tests := []struct {
+		name string
+		qc   *lib.QuorumCertificate
+		code lib.ErrorCode
+	}{
+		{
+			name: "no +2/3 majority",
+			qc:   newTestQC(t, 4, 3, qc3, qc3.BlockHash, stateRoot, rootB, rootB, keysB, setB, 1),
+			code: lib.CodeNoMaj23,
+		},
+		{
+			name: "validator set not committed by a verified header",
+			qc:   newTestQC(t, 4, 4, qc3, qc3.BlockHash, stateRoot, rootB, rootB, keysC, setC, 3),
+			code: lib.CodeUntrustedValidatorSet,
+		},
+		{
+			name: "block doesn't extend the latest verified block",
+			qc:   newTestQC(t, 4, 3, qc3, qc2.BlockHash, stateRoot, rootB, rootB, keysB, setB, 3),
+			code: lib.CodeMismatchLastBlockHash,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rpc.certs[4] = test.qc
+			_, e := lc.VerifyNext()
+			require.Error(t, e)
+			require.Equal(t, test.code, e.Code())
+			require.Equal(t, uint64(3), lc.Latest().Height)
+		})
+	}
+
+	// verified state reads against the latest header
+	acc, err := lc.Account(0, address)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), acc.Amount)
+	// absent keys are proven by non-membership
+	missing := crypto.NewAddress(bytes.Repeat([]byte{0x22}, crypto.AddressSize))
+	acc, err = lc.Account(3, missing)
+	require.NoError(t, err)
+	require.Zero(t, acc.Amount)
+	_, err = lc.Validator(3, missing)
+	require.Error(t, err)
+	// a lying rpc is detected
+	rpc.tamper = true
+	_, err = lc.Account(3, address)
+	require.Error(t, err)
+	require.Equal(t, lib.CodeInvalidStateProof, err.Code())
+	// proofs against a state root of a different block fail
+	rpc.tamper = false
+	_, err = lc.Account(2, address)
+	require.Error(t, err)
+	// unverified heights can't be read
+	_, err = lc.Get(4, fsm.KeyForAccount(address))
+	require.Equal(t, lib.CodeUnverifiedHeight, err.Code())
+}
+
+// testRPC is an in-memory stand-in for the rpc client
+type testRPC struct {
+	certs  map[uint64]*lib.QuorumCertificate
+	sets   map[uint64]*lib.ConsensusValidators
+	height uint64
+	db     lib.StoreI
+	tamper bool
+}
+
+func (r *testRPC) Height() (*lib.HeightResult, lib.ErrorI) {
+	return &lib.HeightResult{Height: r.height + 1}, nil
+}
+
+func (r *testRPC) CertByHeight(height uint64) (*lib.QuorumCertificate, lib.ErrorI) {
+	qc, found := r.certs[height]
+	if !found {
+		return nil, lib.ErrWrongBlockHeight(height, r.height)
+	}
+	return qc, nil
+}
+
+func (r *testRPC) ValidatorSet(height uint64, _ uint64) (lib.ValidatorSet, lib.ErrorI) {
+	return lib.NewValidatorSet(r.sets[height])
+}
+
+func (r *testRPC) StateProof(_ uint64, key lib.HexBytes) (*lib.StateEntry, lib.ErrorI) {
+	ro, err := r.db.NewReadOnly(r.db.Version())
+	if err != nil {
+		return nil, err
+	}
+	defer ro.Discard()
+	value, err := ro.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := ro.GetProof(key)
+	if err != nil {
+		return nil, err
+	}
+	if r.tamper && value != nil {
+		value = append(bytes.Clone(value), 0)
+	}
+	return &lib.StateEntry{Key: key, Value: value, Proof: proof}, nil
+}
+
+// newTestValidators() generates a set of BLS validators with equal voting power
+func newTestValidators(t *testing.T, n int) (keys []crypto.PrivateKeyI, set *lib.ConsensusValidators) {
+	set = new(lib.ConsensusValidators)
+	for range n {
+		pk, err := crypto.NewBLS12381PrivateKey()
+		require.NoError(t, err)
+		keys = append(keys, pk)
+		set.ValidatorSet = append(set.ValidatorSet, &lib.ConsensusValidator{PublicKey: pk.PublicKey().Bytes(), VotingPower: 1})
+	}
+	return
+}
+
+// validatorRoot() returns the merkle root of a validator set
+func validatorRoot(t *testing.T, set *lib.ConsensusValidators) []byte {
+	root, err := set.Root()
+	require.NoError(t, err)
+	return root
+}
+
+// newTestQC() creates a block and a certificate for it signed by the first numSigners keys
+func newTestQC(t *testing.T, height, rootHeight uint64, lastQC *lib.QuorumCertificate, lastBlockHash, stateRoot, valRoot,
+	nextValRoot []byte, keys []crypto.PrivateKeyI, set *lib.ConsensusValidators, numSigners int) *lib.QuorumCertificate {
+	// link the last certificate without its block
+	var last *lib.QuorumCertificate
+	if lastQC != nil {
+		last = &lib.QuorumCertificate{Header: lastQC.Header, ResultsHash: lastQC.ResultsHash, BlockHash: lastQC.BlockHash, Signature: lastQC.Signature}
+	}
+	header := &lib.BlockHeader{
+		Height:                height,
+		NetworkId:             testNetworkId,
+		Time:                  height,
+		LastBlockHash:         lastBlockHash,
+		StateRoot:             stateRoot,
+		TransactionRoot:       crypto.Hash(nil),
+		ValidatorRoot:         valRoot,
+		NextValidatorRoot:     nextValRoot,
+		ProposerAddress:       bytes.Repeat([]byte{0x01}, crypto.AddressSize),
+		LastQuorumCertificate: last,
+	}
+	hash, err := header.SetHash()
+	require.NoError(t, err)
+	blockBz, err := lib.Marshal(&lib.Block{BlockHeader: header})
+	require.NoError(t, err)
+	qc := &lib.QuorumCertificate{
+		Header:      &lib.View{Height: height, RootHeight: rootHeight, NetworkId: testNetworkId, ChainId: testChainId, Phase: lib.Phase_PRECOMMIT_VOTE},
+		ResultsHash: crypto.Hash([]byte("results")),
+		Block:       blockBz,
+		BlockHash:   hash,
+	}
+	// aggregate the signatures
+	vs, err := lib.NewValidatorSet(set)
+	require.NoError(t, err)
+	multiKey := vs.MultiKey.Copy()
+	for i := range numSigners {
+		require.NoError(t, multiKey.AddSigner(keys[i].Sign(qc.SignBytes()), i))
+	}
+	signature, e := multiKey.AggregateSignatures()
+	require.NoError(t, e)
+	qc.Signature = &lib.AggregateSignature{Signature: signature, Bitmap: multiKey.Bitmap()}
+	return qc
+}
@@ -1,10 +1,13 @@
 package lib
 
 import (
+	"bytes"
 	"github.com/canopy-network/canopy/lib/crypto"
 	"maps"
 	"math"
+	"slices"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -18,7 +21,8 @@ type Mempool interface {
 	Contains(txHash string) bool                             // whether the mempool has this transaction already (de-duplicated by hash)
 	AddTransactions(tx ...[]byte) (recheck bool, err ErrorI) // insert new unconfirmed transaction
 	DeleteTransaction(tx ...[]byte)                          // delete unconfirmed transaction
-	GetTransactions(maxBytes uint64) [][]byte                // retrieve transactions from the highest fee to lowest
+	GetTransactions(maxBytes uint64) [][]byte                // retrieve transactions from the highest priority to lowest
+	Expire() (expired [][]byte)                              // remove and return the transactions pending longer than the ttl
 
 	Clear()              // reset the entire store
 	TxCount() int        // number of Transactions in the pool
@@ -26,7 +30,7 @@ type Mempool interface {
 	Iterator() IteratorI // loop through each transaction in the pool
 }
 
-// FeeMempool is a Mempool implementation that prioritizes transactions with the highest fees
+// FeeMempool is a Mempool implementation that prioritizes transactions by lane and then by the highest fees
 type FeeMempool struct {
 	pool     MempoolTxs    // the actual pool of transactions
 	txsBytes int           // collective number of bytes in the pool
@@ -35,8 +39,33 @@ type FeeMempool struct {
 
 // MempoolTx is a wrapper over Transaction bytes that maintains the fee associated with the bytes
 type MempoolTx struct {
-	Tx  []byte // transaction bytes
-	Fee uint64 // fee associated with the transaction
+	Tx        []byte    // transaction bytes
+	Fee       uint64    // fee associated with the transaction
+	Lane      int       // the priority lane of the transaction; higher lanes are ordered first and evicted last
+	Sender    string    // the signer of the transaction
+	Nonce     uint64    // the account nonce of a nonce protected transaction
+	Sequenced bool      // whether the transaction is nonce protected and occupies a (sender, nonce) slot
+	Added     time.Time // when the transaction entered the pool
+}
+
+// the priority lanes of the mempool
+const (
+	MempoolLaneDefault     = iota // regular transactions
+	MempoolLaneValidator          // validator operations: stake, edit-stake, unstake, pause and unpause
+	MempoolLaneCertificate        // certificate results that finalize committee work
+)
+
+// MempoolLaneI is optionally implemented by a message type to prioritize its transactions in the mempool
+type MempoolLaneI interface {
+	MempoolLane() int // the priority lane of the transactions of this message type
+}
+
+// MempoolLane() returns the priority lane of a registered message type (the default lane if it doesn't choose one)
+func MempoolLane(messageType string) int {
+	if m, ok := RegisteredMessages[messageType].(MempoolLaneI); ok {
+		return m.MempoolLane()
+	}
+	return MempoolLaneDefault
 }
 
 // NewMempool() creates a new FeeMempool instance of a Mempool
@@ -55,11 +84,20 @@ func NewMempool(config MempoolConfig) Mempool {
 
 // AddTransaction() inserts a new unconfirmed Transaction to the Pool and returns if this addition
 // requires a recheck of the Mempool due to dropping or re-ordering of the Transactions
+// Transactions already in the pool are skipped (gossip delivers them again); a transaction the pool refuses by policy
+// (an underpriced replacement or a full sender queue) doesn't stop the rest of the batch, which is added before the
+// first refusal is returned
 func (f *FeeMempool) AddTransactions(txs ...[]byte) (recheck bool, err ErrorI) {
 	// create a list of MempoolTxs
 	mempoolTxs := make([]MempoolTx, 0, len(txs))
 	batchTxs := make(map[string]struct{}, len(txs))
-	txsBytes := 0
+	// index the (sender, nonce) lanes and the transaction count of each sender in the pool
+	lanes, senders := f.pool.lanes(), f.pool.senders()
+	// the pool transactions replaced by a fee bump
+	var replaced [][]byte
+	// the first transaction refused by the pool's policy
+	var refused ErrorI
+	txsBytes, now := 0, time.Now()
 	for _, tx := range txs {
 		txBytes := len(tx)
 		// check if the mempool already contains the transaction
@@ -85,40 +123,139 @@ func (f *FeeMempool) AddTransactions(txs ...[]byte) (recheck bool, err ErrorI) {
 		if uint32(txBytes) > f.config.IndividualMaxTxSize && transaction.MessageType != "certificateResults" {
 			return false, ErrMaxTxSize()
 		}
-		// extract the fee from the transaction result
-		fee := transaction.Fee
-		// if the transaction is a special type: 'certificate result'
-		if transaction.MessageType == "certificateResults" {
-			// prioritize certificate result transactions by artificially raising the fee 'stored fee'
-			fee = math.MaxUint32
+		mempoolTx := MempoolTx{Tx: tx, Fee: transaction.Fee, Lane: MempoolLane(transaction.MessageType),
+			Sender: mempoolSender(transaction), Added: now}
+		// nonce protected transactions occupy a (sender, nonce) slot that may only be replaced by a fee bump
+		var existing *MempoolTx
+		if transaction.IsNonceProtected() {
+			mempoolTx.Sequenced, mempoolTx.Nonce = true, transaction.Nonce
+			if e, found := lanes[mempoolTx.lane()]; found {
+				if minFee := f.minReplacementFee(e.Fee); mempoolTx.Fee < minFee {
+					refused = firstRefusal(refused, ErrUnderpricedReplacement(minFee))
+					continue
+				}
+				existing = &e
+			}
+		}
+		// a replacement doesn't add to the sender's count; certificate results are exempt from the cap
+		if existing == nil {
+			if limit := f.config.MaxTxsPerSender; limit != 0 && mempoolTx.Lane != MempoolLaneCertificate &&
+				senders[mempoolTx.Sender] >= int(limit) {
+				refused = firstRefusal(refused, ErrSenderMempoolFull(limit))
+				continue
+			}
+			senders[mempoolTx.Sender]++
+		} else {
+			// evict the replaced transaction from the pool (once the batch is accepted) or the pending batch
+			if _, found := f.pool.m[crypto.HashString(existing.Tx)]; found {
+				replaced = append(replaced, existing.Tx)
+			} else {
+				mempoolTxs, txsBytes = removeMempoolTx(mempoolTxs, existing.Tx), txsBytes-len(existing.Tx)
+			}
+		}
+		if mempoolTx.Sequenced {
+			lanes[mempoolTx.lane()] = mempoolTx
 		}
 		// add to the list
-		mempoolTxs = append(mempoolTxs, MempoolTx{Tx: tx, Fee: fee})
+		mempoolTxs = append(mempoolTxs, mempoolTx)
 		// update the number of bytes
 		txsBytes += txBytes
 	}
+	f.DeleteTransaction(replaced...)
 	f.txsBytes += txsBytes
 	recheck = len(mempoolTxs) != 0
 	// insert the transactions into the pool
 	f.pool.insert(mempoolTxs...)
-	// assess if limits are exceeded - if so, drop from the bottom
-	var dropped []MempoolTx
+	// assess if limits are exceeded - if so, evict using the configured policy
+	f.evict()
+	// if any are dropped or re-order happened
+	return recheck, refused
+}
+
+// firstRefusal() keeps the first refusal of a batch
+func firstRefusal(refused, err ErrorI) ErrorI {
+	if refused != nil {
+		return refused
+	}
+	return err
+}
+
+// IsMempoolRefusal() returns true if the error is a transaction the mempool refused by policy rather than an invalid one
+func IsMempoolRefusal(err ErrorI) bool {
+	return err != nil && err.Module() == StateMachineModule &&
+		(err.Code() == CodeUnderpricedReplacement || err.Code() == CodeSenderMempoolFull)
+}
+
+// minReplacementFee() returns the minimum fee a transaction needs to replace a pending one in the same (sender, nonce) slot
+func (f *FeeMempool) minReplacementFee(fee uint64) uint64 {
+	// the bump is always at least 1 so a replacement strictly increases the fee
+	bump := max(SafeMulDiv(fee, f.config.ReplaceFeeBumpPercent, 100), 1)
+	if fee > math.MaxUint64-bump {
+		return math.MaxUint64
+	}
+	return fee + bump
+}
+
+// evict() removes transactions until the pool is within its limits
+// The lowest priority lane is evicted first, the transactions of a lane in the order of the configured eviction policy;
+// once limits are exceeded at least 'drop percentage' of the pool is evicted to avoid evicting on each addition
+func (f *FeeMempool) evict() {
 	// handle bad config
 	if f.config.MaxTransactionCount == 0 {
 		f.config.MaxTransactionCount = 1
 	}
-	// loop until the conditions are satisfied
-	for uint32(len(f.pool.s)) >= f.config.MaxTransactionCount || uint64(f.txsBytes) > f.config.MaxTotalBytes {
-		// drop percentage is configurable
-		dropped = f.pool.drop(f.config.DropPercentage)
-		// for each dropped transaction
-		for _, d := range dropped {
-			// subtract the txsBytes
-			f.txsBytes -= len(d.Tx)
+	count, txsBytes := len(f.pool.s), f.txsBytes
+	exceeded := func() bool {
+		return uint32(count) >= f.config.MaxTransactionCount || uint64(txsBytes) > f.config.MaxTotalBytes
+	}
+	if !exceeded() {
+		return
+	}
+	policy, minEvict := EvictionPolicyFor(f.config.EvictionPolicy), len(f.pool.s)*f.config.DropPercentage/100+1
+	// collect the lanes present from the lowest priority to the highest
+	var lanes []int
+	for _, tx := range f.pool.s {
+		if !slices.Contains(lanes, tx.Lane) {
+			lanes = append(lanes, tx.Lane)
 		}
 	}
-	// if any are dropped or re-order happened
-	return recheck, nil
+	slices.Sort(lanes)
+	var evicted [][]byte
+	for _, lane := range lanes {
+		// the minimum eviction never spills over into a higher priority lane
+		if !exceeded() && len(evicted) != 0 {
+			break
+		}
+		var candidates []MempoolTx
+		for _, tx := range f.pool.s {
+			if tx.Lane == lane {
+				candidates = append(candidates, tx)
+			}
+		}
+		for _, victim := range policy(candidates) {
+			if !exceeded() && len(evicted) >= minEvict {
+				break
+			}
+			evicted, count, txsBytes = append(evicted, victim.Tx), count-1, txsBytes-len(victim.Tx)
+		}
+	}
+	f.DeleteTransaction(evicted...)
+}
+
+// Expire() removes and returns the transactions that have been pending longer than the configured ttl
+func (f *FeeMempool) Expire() (expired [][]byte) {
+	// a ttl of 0 disables expiry
+	if f.config.TxTTLS <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-time.Duration(f.config.TxTTLS) * time.Second)
+	for _, tx := range f.pool.s {
+		if tx.Added.Before(cutoff) {
+			expired = append(expired, tx.Tx)
+		}
+	}
+	f.DeleteTransaction(expired...)
+	return
 }
 
 // GetTransactions() returns a list of the Transactions from the pool up to 'max collective Transaction bytes'
@@ -222,12 +359,15 @@ type MempoolTxs struct {
 	s []MempoolTx
 }
 
-// insert() batch inserts a number of txs into the list sorted by the highest fee to the lowest fee
+// insert() batch inserts a number of txs into the list sorted by the highest lane and fee to the lowest
 func (t *MempoolTxs) insert(txs ...MempoolTx) {
 	// combine existing and incoming txs
 	combined := append(t.s, txs...)
-	// sort by fee descending while preserving arrival order among equal-fee transactions
+	// sort by lane then fee descending while preserving arrival order among equal transactions
 	sort.SliceStable(combined, func(i, j int) bool {
+		if combined[i].Lane != combined[j].Lane {
+			return combined[i].Lane > combined[j].Lane
+		}
 		return combined[i].Fee > combined[j].Fee
 	})
 	// prepare new map and slice
@@ -244,11 +384,33 @@ func (t *MempoolTxs) insert(txs ...MempoolTx) {
 		newList = append(newList, tx)
 		newMap[hash] = struct{}{}
 	}
+	// keep each sender's nonce protected transactions in nonce order
+	orderLanes(newList)
 	// update
 	t.s = newList
 	t.m = newMap
 }
 
+// lanes() indexes the nonce protected transactions in the pool by their (sender, nonce) slot
+func (t *MempoolTxs) lanes() map[string]MempoolTx {
+	lanes := make(map[string]MempoolTx)
+	for _, tx := range t.s {
+		if tx.Sequenced {
+			lanes[tx.lane()] = tx
+		}
+	}
+	return lanes
+}
+
+// senders() counts the transactions in the pool by sender
+func (t *MempoolTxs) senders() map[string]int {
+	senders := make(map[string]int)
+	for _, tx := range t.s {
+		senders[tx.Sender]++
+	}
+	return senders
+}
+
 // delete() batch deletes a number of transactions
 func (t *MempoolTxs) delete(txs [][]byte) (deleted []MempoolTx, deletedBz int) {
 	if len(txs) == 0 || len(t.s) == 0 {
@@ -286,27 +448,6 @@ func (t *MempoolTxs) delete(txs [][]byte) (deleted []MempoolTx, deletedBz int) {
 	return
 }
 
-// drop() removes the bottom (the lowest fee) X percent of Transactions
-func (t *MempoolTxs) drop(percent int) (dropped []MempoolTx) {
-	// calculate the percent using integer division
-	numDrop := (len(t.s)*percent)/100 + 1
-	// avoid slicing out of bounds
-	if numDrop >= len(t.s) {
-		numDrop = len(t.s)
-	}
-	// save the evicted list
-	dropped = t.s[len(t.s)-numDrop:]
-	// update the list with what's not evicted
-	t.s = t.s[:len(t.s)-numDrop]
-	// rebuild map
-	t.m = make(map[string]struct{}, len(t.s))
-	for _, tx := range t.s {
-		t.m[crypto.HashString(tx.Tx)] = struct{}{}
-	}
-	// exit
-	return
-}
-
 // copy() returns a shallow copy of the MempoolTxs
 func (t *MempoolTxs) copy() *MempoolTxs {
 	// allocate a destination
@@ -322,6 +463,132 @@ func (t *MempoolTxs) copy() *MempoolTxs {
 	}
 }
 
+// lane() returns the (sender, nonce) slot of a nonce protected transaction
+func (m *MempoolTx) lane() string { return m.Sender + "/" + strconv.FormatUint(m.Nonce, 10) }
+
+// orderLanes() reorders each sender's nonce protected transactions in place so lower nonces come first
+// The fee ordering decides which positions a sender occupies, the nonce decides which of its transactions fills them;
+// otherwise a higher fee, higher nonce transaction would execute first and advance the nonce floor past its siblings
+func orderLanes(list []MempoolTx) {
+	positions := make(map[string][]int)
+	for i, tx := range list {
+		if tx.Sequenced {
+			positions[tx.Sender] = append(positions[tx.Sender], i)
+		}
+	}
+	for _, p := range positions {
+		if len(p) < 2 {
+			continue
+		}
+		lane := make([]MempoolTx, len(p))
+		for i, position := range p {
+			lane[i] = list[position]
+		}
+		sort.SliceStable(lane, func(i, j int) bool { return lane[i].Nonce < lane[j].Nonce })
+		for i, position := range p {
+			list[position] = lane[i]
+		}
+	}
+}
+
+// mempoolSender() returns the signer address of a transaction, falling back to the public key bytes if it can't be parsed
+func mempoolSender(tx *Transaction) string {
+	publicKey, err := crypto.NewPublicKeyFromBytes(tx.Signature.PublicKey)
+	if err != nil {
+		return BytesToString(tx.Signature.PublicKey)
+	}
+	return publicKey.Address().String()
+}
+
+// removeMempoolTx() removes the transaction bytes from the list
+func removeMempoolTx(list []MempoolTx, tx []byte) []MempoolTx {
+	for i := range list {
+		if bytes.Equal(list[i].Tx, tx) {
+			return append(list[:i], list[i+1:]...)
+		}
+	}
+	return list
+}
+
+// EVICTION POLICIES BELOW
+
+// EvictionPolicy orders the candidate transactions of a single lane (given in pool order) from the first to evict to the last
+type EvictionPolicy func(candidates []MempoolTx) (order []MempoolTx)
+
+// the eviction policies selectable with 'MempoolConfig.EvictionPolicy'
+const (
+	EvictLowestFee     = "lowest-fee"     // evict the lowest fee transactions first
+	EvictLargestSender = "largest-sender" // evict the cheapest transactions of the senders holding the most of the pool first
+	EvictOldest        = "oldest"         // evict the longest pending transactions first
+)
+
+// evictionPolicies is the registry of the available eviction policies by name
+var evictionPolicies = map[string]EvictionPolicy{
+	EvictLowestFee:     evictLowestFee,
+	EvictLargestSender: evictLargestSender,
+	EvictOldest:        evictOldest,
+}
+
+// RegisterEvictionPolicy() makes a custom eviction policy selectable by name; not safe to call concurrently with the mempool
+func RegisterEvictionPolicy(name string, policy EvictionPolicy) { evictionPolicies[name] = policy }
+
+// EvictionPolicyFor() returns the eviction policy for a name, falling back to the default policy if unknown
+func EvictionPolicyFor(name string) EvictionPolicy {
+	if policy, ok := evictionPolicies[name]; ok {
+		return policy
+	}
+	return evictionPolicies[DefaultMempoolConfig().EvictionPolicy]
+}
+
+// evictLowestFee() evicts from the bottom of the pool (the lowest fee) up
+func evictLowestFee(candidates []MempoolTx) []MempoolTx {
+	order := slices.Clone(candidates)
+	slices.Reverse(order)
+	return order
+}
+
+// evictLargestSender() repeatedly evicts the cheapest remaining transaction of the sender with the most transactions
+// A spammer filling the pool only evicts its own transactions, no matter the fees it pays
+func evictLargestSender(candidates []MempoolTx) []MempoolTx {
+	type victim struct {
+		tx    MempoolTx
+		score int // the size of the sender's queue at the time this transaction would be evicted
+	}
+	// group by sender from the bottom of the pool up, so each sender's cheapest transactions go first
+	var (
+		queues  = make(map[string]int)
+		victims = make([]victim, 0, len(candidates))
+	)
+	for _, tx := range candidates {
+		queues[tx.Sender]++
+	}
+	seen := make(map[string]int)
+	for i := len(candidates) - 1; i >= 0; i-- {
+		tx := candidates[i]
+		victims = append(victims, victim{tx: tx, score: queues[tx.Sender] - seen[tx.Sender]})
+		seen[tx.Sender]++
+	}
+	// the largest queue goes first, ties broken by the lowest fee (the bottom of the pool)
+	sort.SliceStable(victims, func(i, j int) bool {
+		if victims[i].score != victims[j].score {
+			return victims[i].score > victims[j].score
+		}
+		return victims[i].tx.Fee < victims[j].tx.Fee
+	})
+	order := make([]MempoolTx, len(victims))
+	for i, v := range victims {
+		order[i] = v.tx
+	}
+	return order
+}
+
+// evictOldest() evicts the longest pending transactions first
+func evictOldest(candidates []MempoolTx) []MempoolTx {
+	order := evictLowestFee(candidates)
+	sort.SliceStable(order, func(i, j int) bool { return order[i].Added.Before(order[j].Added) })
+	return order
+}
+
 // FAILED TX CACHE CODE BELOW
 
 // FailedTxCache is a cache of failed transactions that is used to inform the user of the failure
@@ -109,6 +109,186 @@ func TestRejectedBatchDoesNotChangeMempoolBytes(t *testing.T) {
 	require.Equal(t, len(valid), mempool.TxsBytes())
 }
 
+func TestAddTransactionNonceLanes(t *testing.T) {
+	sigA := &Signature{PublicKey: newTestPublicKeyBytes(t), Signature: newTestPublicKeyBytes(t)}
+	sigB := &Signature{PublicKey: newTestPublicKeyBytes(t, 1), Signature: newTestPublicKeyBytes(t, 1)}
+	a, e := NewAny(sigA)
+	require.NoError(t, e)
+	newTx := func(sig *Signature, nonce, fee, time uint64) []byte {
+		bz, err := Marshal(&Transaction{MessageType: testMessageName, Msg: a, Signature: sig, CreatedHeight: 1,
+			Time: time, Fee: fee, NetworkId: 1, ChainId: 2, Nonce: nonce})
+		require.NoError(t, err)
+		return bz
+	}
+	a1, a2, b1, legacy := newTx(sigA, 1, 10, 1), newTx(sigA, 2, 100, 1), newTx(sigB, 1, 50, 1), newTx(sigA, 0, 70, 1)
+	mempool := NewMempool(DefaultMempoolConfig())
+	_, err := mempool.AddTransactions(a2, legacy, b1, a1)
+	require.NoError(t, err)
+	// the fee ordering assigns the positions, a sender's nonces fill its positions in ascending order
+	require.Equal(t, [][]byte{a1, legacy, b1, a2}, mempool.GetTransactions(math.MaxUint64))
+	// a replacement of the same (sender, nonce) must pay a higher fee
+	underpriced := newTx(sigA, 1, 10, 2)
+	recheck, err := mempool.AddTransactions(underpriced)
+	require.EqualError(t, err, ErrUnderpricedReplacement(11).Error())
+	require.False(t, recheck)
+	require.False(t, mempool.Contains(crypto.HashString(underpriced)))
+	replacement := newTx(sigA, 1, 200, 2)
+	recheck, err = mempool.AddTransactions(replacement)
+	require.NoError(t, err)
+	require.True(t, recheck)
+	require.Equal(t, [][]byte{replacement, a2, legacy, b1}, mempool.GetTransactions(math.MaxUint64))
+	require.Equal(t, len(replacement)+len(a2)+len(legacy)+len(b1), mempool.TxsBytes())
+}
+
+func TestAddTransactionSenderCapAndFeeBump(t *testing.T) {
+	sigA := &Signature{PublicKey: newTestPublicKeyBytes(t), Signature: newTestPublicKeyBytes(t)}
+	sigB := &Signature{PublicKey: newTestPublicKeyBytes(t, 1), Signature: newTestPublicKeyBytes(t, 1)}
+	a, e := NewAny(sigA)
+	require.NoError(t, e)
+	newTx := func(sig *Signature, messageType string, nonce, fee uint64) []byte {
+		bz, err := Marshal(&Transaction{MessageType: messageType, Msg: a, Signature: sig, CreatedHeight: 1,
+			Time: 1, Fee: fee, NetworkId: 1, ChainId: 2, Nonce: nonce})
+		require.NoError(t, err)
+		return bz
+	}
+	config := DefaultMempoolConfig()
+	config.MaxTxsPerSender, config.ReplaceFeeBumpPercent = 2, 10
+	mempool := NewMempool(config)
+	a1, a2, a3, b1 := newTx(sigA, testMessageName, 1, 100), newTx(sigA, testMessageName, 2, 100),
+		newTx(sigA, testMessageName, 3, 100), newTx(sigB, testMessageName, 1, 1)
+	// the third transaction of sender A exceeds the cap: it's refused, but the rest of the batch is added
+	recheck, err := mempool.AddTransactions(a1, a2, a3, b1)
+	require.True(t, recheck)
+	require.EqualError(t, err, ErrSenderMempoolFull(2).Error())
+	require.True(t, IsMempoolRefusal(err))
+	require.Equal(t, [][]byte{a1, a2, b1}, mempool.GetTransactions(math.MaxUint64))
+	// a transaction already in the pool is skipped silently
+	recheck, err = mempool.AddTransactions(a1)
+	require.False(t, recheck)
+	require.NoError(t, err)
+	// a replacement doesn't count against the cap but must bump the fee by the minimum percentage
+	underpriced, replacement := newTx(sigA, testMessageName, 2, 109), newTx(sigA, testMessageName, 2, 110)
+	_, err = mempool.AddTransactions(underpriced)
+	require.EqualError(t, err, ErrUnderpricedReplacement(110).Error())
+	require.True(t, IsMempoolRefusal(err))
+	require.False(t, mempool.Contains(crypto.HashString(underpriced)))
+	_, err = mempool.AddTransactions(replacement)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{a1, replacement, b1}, mempool.GetTransactions(math.MaxUint64))
+	// certificate results are exempt from the cap and ordered before validator operations and regular transactions
+	certificate, stake := newTx(sigA, "certificateResults", 0, 0), newTx(sigB, "stake", 0, 0)
+	_, err = mempool.AddTransactions(stake, certificate)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{certificate, stake, a1, replacement, b1}, mempool.GetTransactions(math.MaxUint64))
+	require.Equal(t, MempoolLaneCertificate, MempoolLane("certificateResults"))
+	require.Equal(t, MempoolLaneValidator, MempoolLane("stake"))
+	require.Equal(t, MempoolLaneDefault, MempoolLane(testMessageName))
+	require.Equal(t, MempoolLaneDefault, MempoolLane("unregistered"))
+}
+
+// testLaneMessage is a test message type that chooses its mempool lane
+type testLaneMessage struct {
+	*Signature
+	lane int
+}
+
+func (x testLaneMessage) MempoolLane() int { return x.lane }
+
+func init() {
+	RegisteredMessages["certificateResults"] = testLaneMessage{&Signature{}, MempoolLaneCertificate}
+	RegisteredMessages["stake"] = testLaneMessage{&Signature{}, MempoolLaneValidator}
+}
+
+func TestMempoolEvictionPolicies(t *testing.T) {
+	sigs := []*Signature{
+		{PublicKey: newTestPublicKeyBytes(t), Signature: newTestPublicKeyBytes(t)},
+		{PublicKey: newTestPublicKeyBytes(t, 1), Signature: newTestPublicKeyBytes(t, 1)},
+	}
+	a, e := NewAny(sigs[0])
+	require.NoError(t, e)
+	newTx := func(sender int, messageType string, fee uint64) []byte {
+		bz, err := Marshal(&Transaction{MessageType: messageType, Msg: a, Signature: sigs[sender], CreatedHeight: 1,
+			Time: fee + 1, Fee: fee, NetworkId: 1, ChainId: 2})
+		require.NoError(t, err)
+		return bz
+	}
+	// the spammer (sender 0) fills most of the pool with high fees, the honest user (sender 1) pays less
+	spam1, spam2, spam3, honest := newTx(0, testMessageName, 100), newTx(0, testMessageName, 90),
+		newTx(0, testMessageName, 80), newTx(1, testMessageName, 10)
+	stake := newTx(1, "stake", 0)
+	tests := []struct {
+		name     string
+		detail   string
+		policy   string
+		expected [][]byte
+	}{
+		{
+			name:     "lowest fee",
+			detail:   "the lowest fee transaction of the lowest lane is evicted",
+			policy:   EvictLowestFee,
+			expected: [][]byte{stake, spam1, spam2, spam3},
+		},
+		{
+			name:     "largest sender",
+			detail:   "the cheapest transaction of the sender holding the most of the pool is evicted",
+			policy:   EvictLargestSender,
+			expected: [][]byte{stake, spam1, spam2, honest},
+		},
+		{
+			name:     "oldest",
+			detail:   "the first transaction added is evicted",
+			policy:   EvictOldest,
+			expected: [][]byte{stake, spam2, spam3, honest},
+		},
+		{
+			name:     "unknown",
+			detail:   "an unknown policy falls back to the default",
+			policy:   "unknown",
+			expected: [][]byte{stake, spam1, spam2, honest},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mempool := NewMempool(MempoolConfig{MaxTotalBytes: math.MaxUint64, MaxTransactionCount: 5,
+				IndividualMaxTxSize: math.MaxUint32, DropPercentage: 1, EvictionPolicy: test.policy})
+			for _, tx := range [][]byte{spam1, spam2, spam3, honest} {
+				_, err := mempool.AddTransactions(tx)
+				require.NoError(t, err)
+			}
+			_, err := mempool.AddTransactions(stake)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, mempool.GetTransactions(math.MaxUint64))
+		})
+	}
+}
+
+func TestMempoolExpire(t *testing.T) {
+	sig := &Signature{PublicKey: newTestPublicKeyBytes(t), Signature: newTestPublicKeyBytes(t)}
+	a, e := NewAny(sig)
+	require.NoError(t, e)
+	newTx := func(fee uint64) []byte {
+		bz, err := Marshal(&Transaction{MessageType: testMessageName, Msg: a, Signature: sig, CreatedHeight: 1,
+			Time: 1, Fee: fee, NetworkId: 1, ChainId: 2})
+		require.NoError(t, err)
+		return bz
+	}
+	config := DefaultMempoolConfig()
+	config.TxTTLS = 60
+	mempool := NewMempool(config).(*FeeMempool)
+	old, fresh := newTx(2), newTx(1)
+	_, err := mempool.AddTransactions(old, fresh)
+	require.NoError(t, err)
+	// age the first transaction past the ttl
+	mempool.pool.s[0].Added = time.Now().Add(-time.Minute - time.Second)
+	require.Equal(t, [][]byte{old}, mempool.Expire())
+	require.Equal(t, [][]byte{fresh}, mempool.GetTransactions(math.MaxUint64))
+	require.Equal(t, len(fresh), mempool.TxsBytes())
+	// a ttl of 0 disables expiry
+	mempool.config.TxTTLS, mempool.pool.s[0].Added = 0, time.Time{}
+	require.Empty(t, mempool.Expire())
+	require.Equal(t, 1, mempool.TxCount())
+}
+
 func TestAddTransaction(t *testing.T) {
 	// pre-define a test message
 	sig := &Signature{
@@ -152,6 +152,8 @@ type BFTMetrics struct {
 	CommitProcessTime prometheus.Histogram // how long did the commit process phase take?
 	RootHeight        prometheus.Gauge     // what's the height of the root-chain?
 	RootChainId       prometheus.Gauge     // what's the chain id of the root-chain?
+	PhaseTimeout      *prometheus.GaugeVec // what's the timeout of each phase?
+	PhaseLatency      *prometheus.GaugeVec // what's the slowest recent time to +2/3 of each phase?
 }
 
 // FSMMetrics represents the telemetry of the FSM module for the node's address
@@ -472,6 +474,14 @@ func NewMetricsServer(nodeAddress crypto.AddressI, chainID float64, softwareVers
 				Name: "canopy_root_chain_id",
 				Help: "The chain ID of the root chain this node is operating on",
 			}),
+			PhaseTimeout: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "canopy_bft_phase_timeout",
+				Help: "Timeout (in seconds) of each bft phase, adapted to the observed latency if adaptive timeouts are enabled",
+			}, []string{"phase"}),
+			PhaseLatency: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "canopy_bft_phase_latency",
+				Help: "Slowest recent time (in seconds) for each bft phase to reach +2/3",
+			}, []string{"phase"}),
 		},
 		// FSM
 		FSMMetrics: FSMMetrics{
@@ -794,6 +804,16 @@ func (m *Metrics) UpdateBFTMetrics(height, rootHeight, rootChainId, round uint64
 	}
 }
 
+// UpdatePhaseTimeout() is a setter for the timeout and the slowest recent +2/3 latency of a BFT phase
+func (m *Metrics) UpdatePhaseTimeout(phase Phase, timeout, latency time.Duration) {
+	// exit if empty
+	if m == nil {
+		return
+	}
+	m.PhaseTimeout.WithLabelValues(phase.String()).Set(timeout.Seconds())
+	m.PhaseLatency.WithLabelValues(phase.String()).Set(latency.Seconds())
+}
+
 // UpdateValidator() updates the validator metrics for prometheus
 func (m *Metrics) UpdateValidator(address string, stakeAmount uint64, unstaking, paused, delegate, compounding, isProducer bool,
 	nonSigners map[string]uint64, doubleSigners []crypto.AddressI) {
@@ -57,6 +57,10 @@ const (
 	Topic_PEERS_REQUEST Topic = 5
 	// HEARTBEAT: reserved for transport heartbeat
 	Topic_HEARTBEAT Topic = 6
+	// STATE_REQUEST: topic for a peer requesting a chunk of a state snapshot
+	Topic_STATE_REQUEST Topic = 7
+	// STATE: topic for an inbound chunk of a state snapshot and its merkle proofs
+	Topic_STATE Topic = 8
 	// INVALID: topic to mark the exclusive end of valid topics
 	Topic_INVALID Topic = 99
 )
@@ -71,6 +75,8 @@ var (
 		4:  "PEERS_RESPONSE",
 		5:  "PEERS_REQUEST",
 		6:  "HEARTBEAT",
+		7:  "STATE_REQUEST",
+		8:  "STATE",
 		99: "INVALID",
 	}
 	Topic_value = map[string]int32{
@@ -81,6 +87,8 @@ var (
 		"PEERS_RESPONSE": 4,
 		"PEERS_REQUEST":  5,
 		"HEARTBEAT":      6,
+		"STATE_REQUEST":  7,
+		"STATE":          8,
 		"INVALID":        99,
 	}
 )
@@ -528,12 +536,223 @@ func (x *TxMessage) GetTxs() [][]byte {
 	return nil
 }
 
+// StateChunkRequestMessage is a p2p message payload that is requesting a chunk of the state snapshot at a certain
+// height from a peer
+type StateChunkRequestMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// chain_id: is the unique identifier of the committee associated with this message
+	ChainId uint64 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chainID"` // @gotags: json:"chainID"
+	// height: the version of the state store the snapshot is taken from
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// start_key: the exclusive lower bound of the requested chunk; empty means the start of the state
+	StartKey      []byte `protobuf:"bytes,3,opt,name=start_key,json=startKey,proto3" json:"startKey"` // @gotags: json:"startKey"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateChunkRequestMessage) Reset() {
+	*x = StateChunkRequestMessage{}
+	mi := &file_peer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateChunkRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateChunkRequestMessage) ProtoMessage() {}
+
+func (x *StateChunkRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateChunkRequestMessage.ProtoReflect.Descriptor instead.
+func (*StateChunkRequestMessage) Descriptor() ([]byte, []int) {
+	return file_peer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StateChunkRequestMessage) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *StateChunkRequestMessage) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *StateChunkRequestMessage) GetStartKey() []byte {
+	if x != nil {
+		return x.StartKey
+	}
+	return nil
+}
+
+// StateChunkMessage is a p2p message payload that is responding to a state chunk request message
+type StateChunkMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// chain_id: is the unique identifier of the committee associated with this message
+	ChainId uint64 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chainID"` // @gotags: json:"chainID"
+	// height: the version of the state store the snapshot is taken from
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// start_key: the exclusive lower bound of the chunk; echoed from the request
+	StartKey []byte `protobuf:"bytes,3,opt,name=start_key,json=startKey,proto3" json:"startKey"` // @gotags: json:"startKey"
+	// entries: the ordered key value pairs of the chunk along with their merkle proofs
+	Entries []*StateEntry `protobuf:"bytes,4,rep,name=entries,proto3" json:"entries,omitempty"`
+	// done: signals the chunk contains the last key of the state
+	Done          bool `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateChunkMessage) Reset() {
+	*x = StateChunkMessage{}
+	mi := &file_peer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateChunkMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateChunkMessage) ProtoMessage() {}
+
+func (x *StateChunkMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateChunkMessage.ProtoReflect.Descriptor instead.
+func (*StateChunkMessage) Descriptor() ([]byte, []int) {
+	return file_peer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StateChunkMessage) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *StateChunkMessage) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *StateChunkMessage) GetStartKey() []byte {
+	if x != nil {
+		return x.StartKey
+	}
+	return nil
+}
+
+func (x *StateChunkMessage) GetEntries() []*StateEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *StateChunkMessage) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+// StateEntry is a single key value pair of a state snapshot that is provable against the state root
+type StateEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// key: the key of the state entry
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// value: the value of the state entry
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// proof: the merkle proof of membership of the entry against the state root of the snapshot
+	Proof         []*Node `protobuf:"bytes,3,rep,name=proof,proto3" json:"proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateEntry) Reset() {
+	*x = StateEntry{}
+	mi := &file_peer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateEntry) ProtoMessage() {}
+
+func (x *StateEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateEntry.ProtoReflect.Descriptor instead.
+func (*StateEntry) Descriptor() ([]byte, []int) {
+	return file_peer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StateEntry) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *StateEntry) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *StateEntry) GetProof() []*Node {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
 var File_peer_proto protoreflect.FileDescriptor
 
 const file_peer_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"peer.proto\x12\x05types\x1a\x11certificate.proto\"\xc0\x01\n" +
+	"peer.proto\x12\x05types\x1a\x11certificate.proto\x1a\vstore.proto\"\xc0\x01\n" +
 	"\bPeerInfo\x12,\n" +
 	"\aAddress\x18\x01 \x01(\v2\x12.types.PeerAddressR\aAddress\x12\x1f\n" +
 	"\vis_outbound\x18\x02 \x01(\bR\n" +
@@ -569,7 +788,22 @@ const file_peer_proto_rawDesc = "" +
 	"\x04time\x18\x05 \x01(\x04R\x04time\"8\n" +
 	"\tTxMessage\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12\x10\n" +
-	"\x03txs\x18\x02 \x03(\fR\x03txs*\x7f\n" +
+	"\x03txs\x18\x02 \x03(\fR\x03txs\"j\n" +
+	"\x18StateChunkRequestMessage\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\x12\x1b\n" +
+	"\tstart_key\x18\x03 \x01(\fR\bstartKey\"\xa4\x01\n" +
+	"\x11StateChunkMessage\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\x12\x1b\n" +
+	"\tstart_key\x18\x03 \x01(\fR\bstartKey\x12+\n" +
+	"\aentries\x18\x04 \x03(\v2\x11.types.StateEntryR\aentries\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\bR\x04done\"W\n" +
+	"\n" +
+	"StateEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\fR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\x12!\n" +
+	"\x05proof\x18\x03 \x03(\v2\v.types.NodeR\x05proof*\x9d\x01\n" +
 	"\x05Topic\x12\r\n" +
 	"\tCONSENSUS\x10\x00\x12\t\n" +
 	"\x05BLOCK\x10\x01\x12\x11\n" +
@@ -577,7 +811,9 @@ const file_peer_proto_rawDesc = "" +
 	"\x02TX\x10\x03\x12\x12\n" +
 	"\x0ePEERS_RESPONSE\x10\x04\x12\x11\n" +
 	"\rPEERS_REQUEST\x10\x05\x12\r\n" +
-	"\tHEARTBEAT\x10\x06\x12\v\n" +
+	"\tHEARTBEAT\x10\x06\x12\x11\n" +
+	"\rSTATE_REQUEST\x10\a\x12\t\n" +
+	"\x05STATE\x10\b\x12\v\n" +
 	"\aINVALID\x10cB&Z$github.com/canopy-network/canopy/libb\x06proto3"
 
 var (
@@ -593,26 +829,32 @@ func file_peer_proto_rawDescGZIP() []byte {
 }
 
 var file_peer_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_peer_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_peer_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_peer_proto_goTypes = []any{
-	(Topic)(0),                  // 0: types.Topic
-	(*PeerInfo)(nil),            // 1: types.PeerInfo
-	(*PeerAddress)(nil),         // 2: types.PeerAddress
-	(*PeerMeta)(nil),            // 3: types.PeerMeta
-	(*BlockRequestMessage)(nil), // 4: types.BlockRequestMessage
-	(*BlockMessage)(nil),        // 5: types.BlockMessage
-	(*TxMessage)(nil),           // 6: types.TxMessage
-	(*QuorumCertificate)(nil),   // 7: types.QuorumCertificate
+	(Topic)(0),                       // 0: types.Topic
+	(*PeerInfo)(nil),                 // 1: types.PeerInfo
+	(*PeerAddress)(nil),              // 2: types.PeerAddress
+	(*PeerMeta)(nil),                 // 3: types.PeerMeta
+	(*BlockRequestMessage)(nil),      // 4: types.BlockRequestMessage
+	(*BlockMessage)(nil),             // 5: types.BlockMessage
+	(*TxMessage)(nil),                // 6: types.TxMessage
+	(*StateChunkRequestMessage)(nil), // 7: types.StateChunkRequestMessage
+	(*StateChunkMessage)(nil),        // 8: types.StateChunkMessage
+	(*StateEntry)(nil),               // 9: types.StateEntry
+	(*QuorumCertificate)(nil),        // 10: types.QuorumCertificate
+	(*Node)(nil),                     // 11: types.Node
 }
 var file_peer_proto_depIdxs = []int32{
-	2, // 0: types.PeerInfo.Address:type_name -> types.PeerAddress
-	3, // 1: types.PeerAddress.peer_meta:type_name -> types.PeerMeta
-	7, // 2: types.BlockMessage.BlockAndCertificate:type_name -> types.QuorumCertificate
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2,  // 0: types.PeerInfo.Address:type_name -> types.PeerAddress
+	3,  // 1: types.PeerAddress.peer_meta:type_name -> types.PeerMeta
+	10, // 2: types.BlockMessage.BlockAndCertificate:type_name -> types.QuorumCertificate
+	9,  // 3: types.StateChunkMessage.entries:type_name -> types.StateEntry
+	11, // 4: types.StateEntry.proof:type_name -> types.Node
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_peer_proto_init() }
@@ -621,13 +863,14 @@ func file_peer_proto_init() {
 		return
 	}
 	file_certificate_proto_init()
+	file_store_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_peer_proto_rawDesc), len(file_peer_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
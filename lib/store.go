@@ -79,6 +79,7 @@ type RIndexerI interface {
 	GetCheckpoint(chainId, height uint64) (blockHash HexBytes, err ErrorI)                         // get the checkpoint block hash for a certain committee and height combination
 	GetMostRecentCheckpoint(chainId uint64) (checkpoint *Checkpoint, err ErrorI)                   // get the most recent checkpoint for a committee
 	GetAllCheckpoints(chainId uint64) (checkpoints []*Checkpoint, err ErrorI)                      // export all checkpoints for a committee
+	GetDexCandles(chainId, resolution, start, end uint64, limit int) ([]*DexCandle, ErrorI)        // get the OHLCV history of a dex pool
 }
 
 // WStoreI defines an interface for basic write operations
@@ -3,11 +3,16 @@ package lib
 import (
 	"bytes"
 	"encoding/json"
+	"math/bits"
+	"slices"
 )
 
 /* This file implements 'sell order book' logic for token swaps that is used throughout the app */
 
-const OrdersPageName = "orders" // the name of a page of orders
+const (
+	OrdersPageName       = "orders" // the name of a page of orders
+	MaxLocksPerSellOrder = 16       // the maximum number of partial locks a single sell order may hold at once
+)
 
 func init() {
 	RegisteredPageables[OrdersPageName] = new(SellOrders) // preregister the page type for unmarshalling
@@ -35,18 +40,109 @@ func (x *SellOrder) Empty() bool {
 	return x == nil || x.SellersSendAddress == nil
 }
 
+// Locked() indicates whether the whole sell order is locked by a single buyer
+func (x *SellOrder) Locked() bool {
+	return len(x.BuyerReceiveAddress) != 0
+}
+
+// LockedAmount() returns the amount of the sell order reserved by buyers
+func (x *SellOrder) LockedAmount() (amount uint64) {
+	// a whole order lock reserves the entire amount for sale
+	if x.Locked() {
+		return x.AmountForSale
+	}
+	// sum the partial locks
+	for _, lock := range x.Locks {
+		amount += lock.Amount
+	}
+	return
+}
+
+// AvailableAmount() returns the amount of the sell order that may still be locked
+func (x *SellOrder) AvailableAmount() uint64 {
+	locked := x.LockedAmount()
+	// defensive: locks never exceed the amount for sale
+	if locked >= x.AmountForSale {
+		return 0
+	}
+	return x.AmountForSale - locked
+}
+
+// IsWholeLock() indicates whether a lock of amount reserves the whole sell order rather than a part of it
+func (x *SellOrder) IsWholeLock(amount uint64) bool {
+	return amount == 0 || amount == x.AmountForSale
+}
+
+// GetLock() retrieves the partial lock held by a buyer send address
+func (x *SellOrder) GetLock(buyerSendAddress []byte) *SellOrderLock {
+	for _, lock := range x.Locks {
+		if bytes.Equal(lock.BuyerSendAddress, buyerSendAddress) {
+			return lock
+		}
+	}
+	return nil
+}
+
+// RemoveLock() removes the partial lock held by a buyer send address
+func (x *SellOrder) RemoveLock(buyerSendAddress []byte) {
+	x.Locks = slices.DeleteFunc(x.Locks, func(lock *SellOrderLock) bool {
+		return bytes.Equal(lock.BuyerSendAddress, buyerSendAddress)
+	})
+}
+
+// LockRequestedAmount() calculates the 'counter-asset' owed for locking an amount of the order at the order's price
+// NOTE: the result is rounded up, so the seller's price never worsens
+func (x *SellOrder) LockRequestedAmount(amount uint64) uint64 {
+	// defensive: an empty order has no price
+	if x.AmountForSale == 0 {
+		return 0
+	}
+	// requested * amount / amountForSale in 128 bit precision
+	hi, lo := bits.Mul64(x.RequestedAmount, amount)
+	// a lock never exceeds the order, so the quotient never exceeds the requested amount
+	if hi >= x.AmountForSale {
+		return x.RequestedAmount
+	}
+	quotient, remainder := bits.Div64(hi, lo, x.AmountForSale)
+	if remainder != 0 {
+		quotient++
+	}
+	return quotient
+}
+
+// ForLock() returns a view of the sell order limited to a partial lock (used for lock, reset and swap events)
+func (x *SellOrder) ForLock(lock *SellOrderLock) *SellOrder {
+	return &SellOrder{
+		Id:                   x.Id,
+		Committee:            x.Committee,
+		Data:                 x.Data,
+		AmountForSale:        lock.Amount,
+		RequestedAmount:      lock.RequestedAmount,
+		SellerReceiveAddress: x.SellerReceiveAddress,
+		BuyerSendAddress:     lock.BuyerSendAddress,
+		BuyerReceiveAddress:  lock.BuyerReceiveAddress,
+		BuyerChainDeadline:   lock.BuyerChainDeadline,
+		SellersSendAddress:   x.SellersSendAddress,
+		LockTime:             lock.LockTime,
+	}
+}
+
 // jsonSellOrder is the json.Marshaller and json.Unmarshaler implementation for the SellOrder object
 type jsonSellOrder struct {
-	Id                   HexBytes `json:"id,omitempty"`                   // the unique identifier of the order
-	Committee            uint64   `json:"committee,omitempty"`            // the id of the committee that is in-charge of escrow for the swap
-	Data                 HexBytes `json:"data,omitempty"`                 // generic data for the swap to allow additional functionality
-	AmountForSale        uint64   `json:"amountForSale,omitempty"`        // amount of CNPY for sale
-	RequestedAmount      uint64   `json:"requestedAmount,omitempty"`      // amount of 'token' to receive
-	SellerReceiveAddress HexBytes `json:"sellerReceiveAddress,omitempty"` // the external chain address to receive the 'token'
-	BuyerSendAddress     HexBytes `json:"buyerSendAddress,omitempty"`     // the send address from the buyer
-	BuyerReceiveAddress  HexBytes `json:"buyerReceiveAddress,omitempty"`  // the buyers address to receive the 'coin'
-	BuyerChainDeadline   uint64   `json:"buyerChainDeadline,omitempty"`   // the external chain height deadline to send the 'tokens' to SellerReceiveAddress
-	SellersSellAddress   HexBytes `json:"sellersSendAddress,omitempty"`   // the address of seller who is selling the 'coin'
+	Id                   HexBytes         `json:"id,omitempty"`                   // the unique identifier of the order
+	Committee            uint64           `json:"committee,omitempty"`            // the id of the committee that is in-charge of escrow for the swap
+	Data                 HexBytes         `json:"data,omitempty"`                 // generic data for the swap to allow additional functionality
+	AmountForSale        uint64           `json:"amountForSale,omitempty"`        // amount of CNPY for sale
+	RequestedAmount      uint64           `json:"requestedAmount,omitempty"`      // amount of 'token' to receive
+	SellerReceiveAddress HexBytes         `json:"sellerReceiveAddress,omitempty"` // the external chain address to receive the 'token'
+	BuyerSendAddress     HexBytes         `json:"buyerSendAddress,omitempty"`     // the send address from the buyer
+	BuyerReceiveAddress  HexBytes         `json:"buyerReceiveAddress,omitempty"`  // the buyers address to receive the 'coin'
+	BuyerChainDeadline   uint64           `json:"buyerChainDeadline,omitempty"`   // the external chain height deadline to send the 'tokens' to SellerReceiveAddress
+	SellersSellAddress   HexBytes         `json:"sellersSendAddress,omitempty"`   // the address of seller who is selling the 'coin'
+	Locks                []*SellOrderLock `json:"locks,omitempty"`                // the partial locks of the order
+	FilledAmount         uint64           `json:"filledAmount,omitempty"`         // the amount already sold through closed partial locks
+	LockTime             uint64           `json:"lockTime,omitempty"`             // the time (unix micro) before which an external payment can't pay the lock
+	AvailableAmount      uint64           `json:"availableAmount"`                // the amount that may still be locked (read only)
 }
 
 // MarshalJSON() is the json.Marshaller implementation for the SellOrder object
@@ -62,6 +158,10 @@ func (x SellOrder) MarshalJSON() ([]byte, error) {
 		BuyerReceiveAddress:  x.BuyerReceiveAddress,
 		BuyerChainDeadline:   x.BuyerChainDeadline,
 		SellersSellAddress:   x.SellersSendAddress,
+		Locks:                x.Locks,
+		FilledAmount:         x.FilledAmount,
+		LockTime:             x.LockTime,
+		AvailableAmount:      x.AvailableAmount(),
 	})
 }
 
@@ -86,6 +186,53 @@ func (x *SellOrder) UnmarshalJSON(jsonBytes []byte) (err error) {
 		BuyerReceiveAddress:  j.BuyerReceiveAddress,
 		BuyerChainDeadline:   j.BuyerChainDeadline,
 		SellersSendAddress:   j.SellersSellAddress,
+		Locks:                j.Locks,
+		FilledAmount:         j.FilledAmount,
+		LockTime:             j.LockTime,
+	}
+	// exit
+	return
+}
+
+// jsonSellOrderLock is the json.Marshaller and json.Unmarshaler implementation for the SellOrderLock object
+type jsonSellOrderLock struct {
+	BuyerSendAddress    HexBytes `json:"buyerSendAddress,omitempty"`    // the send address from the buyer
+	BuyerReceiveAddress HexBytes `json:"buyerReceiveAddress,omitempty"` // the buyers address to receive the 'coin'
+	BuyerChainDeadline  uint64   `json:"buyerChainDeadline,omitempty"`  // the external chain height deadline to send the 'tokens'
+	Amount              uint64   `json:"amount,omitempty"`              // the amount of 'coin' locked
+	RequestedAmount     uint64   `json:"requestedAmount,omitempty"`     // the amount of 'token' owed for the locked amount
+	LockTime            uint64   `json:"lockTime,omitempty"`            // the time (unix micro) before which an external payment can't pay the lock
+}
+
+// MarshalJSON() is the json.Marshaller implementation for the SellOrderLock object
+func (x *SellOrderLock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonSellOrderLock{
+		BuyerSendAddress:    x.BuyerSendAddress,
+		BuyerReceiveAddress: x.BuyerReceiveAddress,
+		BuyerChainDeadline:  x.BuyerChainDeadline,
+		Amount:              x.Amount,
+		RequestedAmount:     x.RequestedAmount,
+		LockTime:            x.LockTime,
+	})
+}
+
+// UnmarshalJSON() is the json.Unmarshaler implementation for the SellOrderLock object
+func (x *SellOrderLock) UnmarshalJSON(jsonBytes []byte) (err error) {
+	// create a new json object reference to ensure a non nil result
+	j := new(jsonSellOrderLock)
+	// populate the json object using the json bytes
+	if err = json.Unmarshal(jsonBytes, j); err != nil {
+		// exit with error
+		return
+	}
+	// populate the underlying lock using the json object
+	*x = SellOrderLock{
+		BuyerSendAddress:    j.BuyerSendAddress,
+		BuyerReceiveAddress: j.BuyerReceiveAddress,
+		BuyerChainDeadline:  j.BuyerChainDeadline,
+		Amount:              j.Amount,
+		RequestedAmount:     j.RequestedAmount,
+		LockTime:            j.LockTime,
 	}
 	// exit
 	return
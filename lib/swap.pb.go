@@ -80,8 +80,15 @@ type SellOrder struct {
 	BuyerChainDeadline uint64 `protobuf:"varint,9,opt,name=BuyerChainDeadline,proto3" json:"buyerChainDeadline"` // @gotags: json:"buyerChainDeadline"
 	// sellers_send_address: the signing address of seller who is selling the CNPY
 	SellersSendAddress []byte `protobuf:"bytes,10,opt,name=SellersSendAddress,proto3" json:"sellersSendAddress"` // @gotags: json:"sellersSendAddress"
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// locks: the partial locks of the order, each reserving a part of the amount for sale for a different buyer
+	Locks []*SellOrderLock `protobuf:"bytes,11,rep,name=Locks,proto3" json:"locks"` // @gotags: json:"locks"
+	// filled_amount: the amount of CNPY already sold to the buyers of closed partial locks
+	FilledAmount uint64 `protobuf:"varint,12,opt,name=FilledAmount,proto3" json:"filledAmount"` // @gotags: json:"filledAmount"
+	// lock_time: the time (unix micro) of the last root chain block before the whole order was locked; an external
+	// payment made before it can't pay the lock
+	LockTime      uint64 `protobuf:"varint,13,opt,name=LockTime,proto3" json:"lockTime"` // @gotags: json:"lockTime"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SellOrder) Reset() {
@@ -184,6 +191,119 @@ func (x *SellOrder) GetSellersSendAddress() []byte {
 	return nil
 }
 
+func (x *SellOrder) GetLocks() []*SellOrderLock {
+	if x != nil {
+		return x.Locks
+	}
+	return nil
+}
+
+func (x *SellOrder) GetFilledAmount() uint64 {
+	if x != nil {
+		return x.FilledAmount
+	}
+	return 0
+}
+
+func (x *SellOrder) GetLockTime() uint64 {
+	if x != nil {
+		return x.LockTime
+	}
+	return 0
+}
+
+// SellOrderLock is a buyer 'claiming / reserving' a part of a sell order at the order's price
+type SellOrderLock struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// buyer_send_address: the address the buyer will be transferring the funds from (identifies the lock)
+	BuyerSendAddress []byte `protobuf:"bytes,1,opt,name=BuyerSendAddress,proto3" json:"buyerSendAddress"` // @gotags: json:"buyerSendAddress"
+	// buyer_receive_address: the buyer Canopy address to receive the CNPY
+	BuyerReceiveAddress []byte `protobuf:"bytes,2,opt,name=BuyerReceiveAddress,proto3" json:"buyerReceiveAddress"` // @gotags: json:"buyerReceiveAddress"
+	// buyer_chain_deadline: the external chain height deadline to send the 'tokens' to SellerReceiveAddress
+	BuyerChainDeadline uint64 `protobuf:"varint,3,opt,name=BuyerChainDeadline,proto3" json:"buyerChainDeadline"` // @gotags: json:"buyerChainDeadline"
+	// amount: the amount of CNPY locked
+	Amount uint64 `protobuf:"varint,4,opt,name=Amount,proto3" json:"amount"` // @gotags: json:"amount"
+	// requested_amount: the amount of 'counter-asset' the buyer must send for the locked amount
+	RequestedAmount uint64 `protobuf:"varint,5,opt,name=RequestedAmount,proto3" json:"requestedAmount"` // @gotags: json:"requestedAmount"
+	// lock_time: the time (unix micro) of the last root chain block before the part was locked; an external
+	// payment made before it can't pay the lock
+	LockTime      uint64 `protobuf:"varint,6,opt,name=LockTime,proto3" json:"lockTime"` // @gotags: json:"lockTime"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SellOrderLock) Reset() {
+	*x = SellOrderLock{}
+	mi := &file_swap_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SellOrderLock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SellOrderLock) ProtoMessage() {}
+
+func (x *SellOrderLock) ProtoReflect() protoreflect.Message {
+	mi := &file_swap_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SellOrderLock.ProtoReflect.Descriptor instead.
+func (*SellOrderLock) Descriptor() ([]byte, []int) {
+	return file_swap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SellOrderLock) GetBuyerSendAddress() []byte {
+	if x != nil {
+		return x.BuyerSendAddress
+	}
+	return nil
+}
+
+func (x *SellOrderLock) GetBuyerReceiveAddress() []byte {
+	if x != nil {
+		return x.BuyerReceiveAddress
+	}
+	return nil
+}
+
+func (x *SellOrderLock) GetBuyerChainDeadline() uint64 {
+	if x != nil {
+		return x.BuyerChainDeadline
+	}
+	return 0
+}
+
+func (x *SellOrderLock) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *SellOrderLock) GetRequestedAmount() uint64 {
+	if x != nil {
+		return x.RequestedAmount
+	}
+	return 0
+}
+
+func (x *SellOrderLock) GetLockTime() uint64 {
+	if x != nil {
+		return x.LockTime
+	}
+	return 0
+}
+
 // OrderBooks: is a list of order book objects held in the blockchain state
 type OrderBooks struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -195,7 +315,7 @@ type OrderBooks struct {
 
 func (x *OrderBooks) Reset() {
 	*x = OrderBooks{}
-	mi := &file_swap_proto_msgTypes[1]
+	mi := &file_swap_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -207,7 +327,7 @@ func (x *OrderBooks) String() string {
 func (*OrderBooks) ProtoMessage() {}
 
 func (x *OrderBooks) ProtoReflect() protoreflect.Message {
-	mi := &file_swap_proto_msgTypes[1]
+	mi := &file_swap_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -220,7 +340,7 @@ func (x *OrderBooks) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderBooks.ProtoReflect.Descriptor instead.
 func (*OrderBooks) Descriptor() ([]byte, []int) {
-	return file_swap_proto_rawDescGZIP(), []int{1}
+	return file_swap_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *OrderBooks) GetOrderBooks() []*OrderBook {
@@ -243,7 +363,7 @@ type OrderBook struct {
 
 func (x *OrderBook) Reset() {
 	*x = OrderBook{}
-	mi := &file_swap_proto_msgTypes[2]
+	mi := &file_swap_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -255,7 +375,7 @@ func (x *OrderBook) String() string {
 func (*OrderBook) ProtoMessage() {}
 
 func (x *OrderBook) ProtoReflect() protoreflect.Message {
-	mi := &file_swap_proto_msgTypes[2]
+	mi := &file_swap_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -268,7 +388,7 @@ func (x *OrderBook) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderBook.ProtoReflect.Descriptor instead.
 func (*OrderBook) Descriptor() ([]byte, []int) {
-	return file_swap_proto_rawDescGZIP(), []int{2}
+	return file_swap_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *OrderBook) GetChainId() uint64 {
@@ -290,7 +410,7 @@ var File_swap_proto protoreflect.FileDescriptor
 const file_swap_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"swap.proto\x12\x05types\"\x8f\x03\n" +
+	"swap.proto\x12\x05types\"\xfb\x03\n" +
 	"\tSellOrder\x12\x0e\n" +
 	"\x02Id\x18\x01 \x01(\fR\x02Id\x12\x1c\n" +
 	"\tCommittee\x18\x02 \x01(\x04R\tCommittee\x12\x12\n" +
@@ -302,7 +422,17 @@ const file_swap_proto_rawDesc = "" +
 	"\x13BuyerReceiveAddress\x18\b \x01(\fR\x13BuyerReceiveAddress\x12.\n" +
 	"\x12BuyerChainDeadline\x18\t \x01(\x04R\x12BuyerChainDeadline\x12.\n" +
 	"\x12SellersSendAddress\x18\n" +
-	" \x01(\fR\x12SellersSendAddress\">\n" +
+	" \x01(\fR\x12SellersSendAddress\x12*\n" +
+	"\x05Locks\x18\v \x03(\v2\x14.types.SellOrderLockR\x05Locks\x12\"\n" +
+	"\fFilledAmount\x18\f \x01(\x04R\fFilledAmount\x12\x1a\n" +
+	"\bLockTime\x18\r \x01(\x04R\bLockTime\"\xfb\x01\n" +
+	"\rSellOrderLock\x12*\n" +
+	"\x10BuyerSendAddress\x18\x01 \x01(\fR\x10BuyerSendAddress\x120\n" +
+	"\x13BuyerReceiveAddress\x18\x02 \x01(\fR\x13BuyerReceiveAddress\x12.\n" +
+	"\x12BuyerChainDeadline\x18\x03 \x01(\x04R\x12BuyerChainDeadline\x12\x16\n" +
+	"\x06Amount\x18\x04 \x01(\x04R\x06Amount\x12(\n" +
+	"\x0fRequestedAmount\x18\x05 \x01(\x04R\x0fRequestedAmount\x12\x1a\n" +
+	"\bLockTime\x18\x06 \x01(\x04R\bLockTime\">\n" +
 	"\n" +
 	"OrderBooks\x120\n" +
 	"\n" +
@@ -324,20 +454,22 @@ func file_swap_proto_rawDescGZIP() []byte {
 	return file_swap_proto_rawDescData
 }
 
-var file_swap_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_swap_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_swap_proto_goTypes = []any{
-	(*SellOrder)(nil),  // 0: types.SellOrder
-	(*OrderBooks)(nil), // 1: types.OrderBooks
-	(*OrderBook)(nil),  // 2: types.OrderBook
+	(*SellOrder)(nil),     // 0: types.SellOrder
+	(*SellOrderLock)(nil), // 1: types.SellOrderLock
+	(*OrderBooks)(nil),    // 2: types.OrderBooks
+	(*OrderBook)(nil),     // 3: types.OrderBook
 }
 var file_swap_proto_depIdxs = []int32{
-	2, // 0: types.OrderBooks.OrderBooks:type_name -> types.OrderBook
-	0, // 1: types.OrderBook.orders:type_name -> types.SellOrder
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	1, // 0: types.SellOrder.Locks:type_name -> types.SellOrderLock
+	3, // 1: types.OrderBooks.OrderBooks:type_name -> types.OrderBook
+	0, // 2: types.OrderBook.orders:type_name -> types.SellOrder
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_swap_proto_init() }
@@ -351,7 +483,7 @@ func file_swap_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_swap_proto_rawDesc), len(file_swap_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
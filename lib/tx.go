@@ -130,6 +130,17 @@ func (x *Transaction) CheckBasic() ErrorI {
 	return nil
 }
 
+// IsNonceProtected() returns true if the transaction is replay protected by the sender's account nonce
+// rather than the tx indexer and the created height acceptance range
+// - RLP.V2 transactions always carry the signed Ethereum nonce
+// - native transactions opt in by signing a non-zero nonce (so the first sequenced nonce of an account is 1)
+func (x *Transaction) IsNonceProtected() bool {
+	if x == nil {
+		return false
+	}
+	return x.Memo == RLPV2Indicator || (x.Nonce != 0 && !IsRLPMemo(x.Memo))
+}
+
 // GetHash() returns the cryptographic hash of the Transaction
 func (x *Transaction) GetHash() ([]byte, ErrorI) {
 	// convert the transaction into proto bytes
@@ -790,6 +790,43 @@ func StopTimer(t *time.Timer) {
 	}
 }
 
+// Clock is the source of time of a process: the operating system clock, unless simulated (i.e. in a virtual-time test)
+type Clock interface {
+	Now() time.Time                      // the current time
+	AfterFunc(d time.Duration, f func()) // executes the function (on its own thread) once the duration elapsed
+}
+
+// Timer is a resettable timer that fires on a channel
+type Timer interface {
+	C() <-chan time.Time   // the channel the timer fires on
+	Reset(d time.Duration) // stops the timer and restarts it with the duration
+	Stop()                 // stops the timer
+}
+
+// WallClock is the Clock of the operating system
+type WallClock struct{}
+
+// Now() returns the current time of the operating system
+func (WallClock) Now() time.Time { return time.Now() }
+
+// AfterFunc() executes the function in its own goroutine once the duration elapsed
+func (WallClock) AfterFunc(d time.Duration, f func()) { time.AfterFunc(d, f) }
+
+// WallTimer is a Timer on the clock of the operating system
+type WallTimer struct{ timer *time.Timer }
+
+// NewWallTimer() creates a stopped timer
+func NewWallTimer() *WallTimer { return &WallTimer{timer: NewTimer()} }
+
+// C() returns the channel the timer fires on
+func (t *WallTimer) C() <-chan time.Time { return t.timer.C }
+
+// Reset() stops the timer and restarts it with the duration
+func (t *WallTimer) Reset(d time.Duration) { ResetTimer(t.timer, d) }
+
+// Stop() stops the timer, discarding a trigger that wasn't read
+func (t *WallTimer) Stop() { StopTimer(t.timer) }
+
 // CatchPanic() catches any panic in the function call or child function calls
 func CatchPanic(l LoggerI) {
 	if r := recover(); r != nil {
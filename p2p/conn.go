@@ -40,6 +40,9 @@ const (
 	SyncTimeoutS            = 5   // wait time to receive an individual block (certificate) from a peer during syncing
 	MaxBlockReqPerWindow    = 20  // maximum block (certificate) requests per window per requester
 	BlockReqWindowS         = 2   // the 'window of time' before resetting limits for block (certificate) requests
+	StateSyncTimeoutS       = 10  // wait time to receive an individual state chunk from a peer during state-sync
+	MaxStateReqPerWindow    = 5   // maximum state chunk requests per window per requester
+	StateReqWindowS         = 2   // the 'window of time' before resetting limits for state chunk requests
 	GoodPeerBookRespRep     = 3   // reputation points for a good peer book response
 	GoodBlockRep            = 3   // rep boost for sending us a valid block (certificate)
 	UnexpectedBlockRep      = -1  // rep slash for sending us a block we weren't expecting
@@ -50,6 +53,9 @@ const (
 	InvalidTxRep            = -3  // rep slash for sending us an invalid transaction
 	InvalidBlockRep         = -3  // rep slash for sending an invalid block (certificate) message
 	BlockReqExceededRep     = -3  // rep slash for over-requesting blocks (certificates)
+	GoodStateChunkRep       = 1   // rep boost for sending us a valid state chunk
+	InvalidStateChunkRep    = -3  // rep slash for sending an invalid state chunk
+	StateReqExceededRep     = -3  // rep slash for over-requesting state chunks
 	MaxMessageExceededSlash = -10 // slash for sending a 'Message (sum of Packets)' above the allowed maximum size
 )
 
@@ -212,6 +218,10 @@ func (c *MultiConn) startSendService() {
 			c.sendPacketWithTiming(pwt, m)
 		case pwt = <-c.streams[lib.Topic_PEERS_REQUEST].sendQueue:
 			c.sendPacketWithTiming(pwt, m)
+		case pwt = <-c.streams[lib.Topic_STATE_REQUEST].sendQueue:
+			c.sendPacketWithTiming(pwt, m)
+		case pwt = <-c.streams[lib.Topic_STATE].sendQueue:
+			c.sendPacketWithTiming(pwt, m)
 		case <-c.quitSending: // fires when Stop() is called
 			return
 		}
@@ -84,7 +84,7 @@ func NewHandshake(conn net.Conn, meta *lib.PeerMeta, privateKey crypto.PrivateKe
 	// swap signatures with the peer to establish the true public key identity
 	peerSig, err := signatureSwap(encryptedConn, &lib.Signature{
 		PublicKey: privateKey.PublicKey().Bytes(),
-		Signature: privateKey.Sign(challenge[:]),
+		Signature: crypto.SignFor(privateKey, crypto.SignHandshakeChallenge, challenge[:]),
 	}, handshakeTimeout)
 	if err != nil {
 		return nil, ErrFailedSignatureSwap(err)
@@ -148,3 +148,11 @@ func ErrMaxOutbound() lib.ErrorI {
 func ErrMaxInbound() lib.ErrorI {
 	return lib.NewError(lib.CodeMaxInbound, lib.P2PModule, "max inbound peers")
 }
+
+func ErrRemoteSigner(err error) lib.ErrorI {
+	return lib.NewError(lib.CodeRemoteSigner, lib.P2PModule, fmt.Sprintf("remote signer failed with err: %s", err.Error()))
+}
+
+func ErrRemoteSignerRefused() lib.ErrorI {
+	return lib.NewError(lib.CodeRemoteSignerRefused, lib.P2PModule, "remote signer refused to sign: not a consensus, handshake or certificate results payload")
+}
@@ -68,7 +68,7 @@ func New(p crypto.PrivateKeyI, maxMembersPerCommittee uint64, m *lib.Metrics, c
 	peerBook := NewPeerBook(p.PublicKey().Bytes(), c, l)
 	// make inbound multiplexed channels
 	channels := make(lib.Channels)
-	for i := lib.Topic(0); i <= lib.Topic_HEARTBEAT; i++ {
+	for i := lib.Topic(0); i <= lib.Topic_STATE; i++ {
 		channels[i] = make(chan *lib.MessageAndMetadata, maxInboxQueueSize)
 	}
 	// load banned IPs
@@ -628,6 +628,11 @@ func (p *P2P) ListenForMustConnects() {
 	}
 }
 
+// SetMustConnects() passes the 'must connect peers' from the controller to the ListenForMustConnects() listener
+func (p *P2P) SetMustConnects(mustConnects []*lib.PeerAddress) {
+	p.MustConnectsReceiver <- mustConnects
+}
+
 // ID() returns the self peer address
 func (p *P2P) ID() *lib.PeerAddress {
 	return &lib.PeerAddress{
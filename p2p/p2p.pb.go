@@ -23,6 +23,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// RemoteSignType tells the remote signer what a payload it can't decode is
+type RemoteSignType int32
+
+const (
+	// REMOTE_SIGN_MESSAGE: an encoded message the signer decodes (consensus messages, peer meta, certificate results)
+	RemoteSignType_REMOTE_SIGN_MESSAGE RemoteSignType = 0
+	// REMOTE_SIGN_HANDSHAKE_CHALLENGE: the 32 byte challenge of a handshake
+	RemoteSignType_REMOTE_SIGN_HANDSHAKE_CHALLENGE RemoteSignType = 1
+	// REMOTE_SIGN_VRF_SEED: the 32 byte seed of a leader election vrf
+	RemoteSignType_REMOTE_SIGN_VRF_SEED RemoteSignType = 2
+)
+
+// Enum value maps for RemoteSignType.
+var (
+	RemoteSignType_name = map[int32]string{
+		0: "REMOTE_SIGN_MESSAGE",
+		1: "REMOTE_SIGN_HANDSHAKE_CHALLENGE",
+		2: "REMOTE_SIGN_VRF_SEED",
+	}
+	RemoteSignType_value = map[string]int32{
+		"REMOTE_SIGN_MESSAGE":             0,
+		"REMOTE_SIGN_HANDSHAKE_CHALLENGE": 1,
+		"REMOTE_SIGN_VRF_SEED":            2,
+	}
+)
+
+func (x RemoteSignType) Enum() *RemoteSignType {
+	p := new(RemoteSignType)
+	*p = x
+	return p
+}
+
+func (x RemoteSignType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RemoteSignType) Descriptor() protoreflect.EnumDescriptor {
+	return file_p2p_proto_enumTypes[0].Descriptor()
+}
+
+func (RemoteSignType) Type() protoreflect.EnumType {
+	return &file_p2p_proto_enumTypes[0]
+}
+
+func (x RemoteSignType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RemoteSignType.Descriptor instead.
+func (RemoteSignType) EnumDescriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{0}
+}
+
 // *****************************************************************************************************
 // This file is auto-generated from source files in `/lib/.proto/*` using Protocol Buffers (protobuf)
 //
@@ -292,6 +345,117 @@ func (x *BookPeer) GetConsecutiveFailedDial() int32 {
 	return 0
 }
 
+// RemoteSignRequest is sent by a node to its remote signer to request a signature from the validator key
+// NOTE: the request is sent over the encrypted connection the node and the signer authenticated with the handshake
+type RemoteSignRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sign_bytes: the canonical sign bytes of the lib.Signable payload (vote, proposal, vrf, etc.)
+	SignBytes []byte `protobuf:"bytes,1,opt,name=sign_bytes,json=signBytes,proto3" json:"signBytes"` // @gotags: json:"signBytes"
+	// type: what the sign bytes are, for the payloads the signer can't decode (the handshake challenge and vrf seed)
+	Type          RemoteSignType `protobuf:"varint,2,opt,name=type,proto3,enum=types.RemoteSignType" json:"type"` // @gotags: json:"type"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoteSignRequest) Reset() {
+	*x = RemoteSignRequest{}
+	mi := &file_p2p_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteSignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteSignRequest) ProtoMessage() {}
+
+func (x *RemoteSignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteSignRequest.ProtoReflect.Descriptor instead.
+func (*RemoteSignRequest) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemoteSignRequest) GetSignBytes() []byte {
+	if x != nil {
+		return x.SignBytes
+	}
+	return nil
+}
+
+func (x *RemoteSignRequest) GetType() RemoteSignType {
+	if x != nil {
+		return x.Type
+	}
+	return RemoteSignType_REMOTE_SIGN_MESSAGE
+}
+
+// RemoteSignResponse is sent by a remote signer in reply to a RemoteSignRequest
+type RemoteSignResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// signature: the validator key's signature of the sign bytes (empty if refused)
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	// error: the reason the signer refused to sign (empty if signed)
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoteSignResponse) Reset() {
+	*x = RemoteSignResponse{}
+	mi := &file_p2p_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteSignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteSignResponse) ProtoMessage() {}
+
+func (x *RemoteSignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteSignResponse.ProtoReflect.Descriptor instead.
+func (*RemoteSignResponse) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RemoteSignResponse) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *RemoteSignResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_p2p_proto protoreflect.FileDescriptor
 
 const file_p2p_proto_rawDesc = "" +
@@ -309,7 +473,18 @@ const file_p2p_proto_rawDesc = "" +
 	"\x04book\x18\x01 \x03(\v2\x0f.types.BookPeerR\x04book\"p\n" +
 	"\bBookPeer\x12,\n" +
 	"\aAddress\x18\x01 \x01(\v2\x12.types.PeerAddressR\aAddress\x126\n" +
-	"\x17consecutive_failed_dial\x18\x02 \x01(\x05R\x15consecutiveFailedDialB&Z$github.com/canopy-network/canopy/p2pb\x06proto3"
+	"\x17consecutive_failed_dial\x18\x02 \x01(\x05R\x15consecutiveFailedDial\"]\n" +
+	"\x11RemoteSignRequest\x12\x1d\n" +
+	"\n" +
+	"sign_bytes\x18\x01 \x01(\fR\tsignBytes\x12)\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x15.types.RemoteSignTypeR\x04type\"H\n" +
+	"\x12RemoteSignResponse\x12\x1c\n" +
+	"\tsignature\x18\x01 \x01(\fR\tsignature\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error*h\n" +
+	"\x0eRemoteSignType\x12\x17\n" +
+	"\x13REMOTE_SIGN_MESSAGE\x10\x00\x12#\n" +
+	"\x1fREMOTE_SIGN_HANDSHAKE_CHALLENGE\x10\x01\x12\x18\n" +
+	"\x14REMOTE_SIGN_VRF_SEED\x10\x02B&Z$github.com/canopy-network/canopy/p2pb\x06proto3"
 
 var (
 	file_p2p_proto_rawDescOnce sync.Once
@@ -323,27 +498,32 @@ func file_p2p_proto_rawDescGZIP() []byte {
 	return file_p2p_proto_rawDescData
 }
 
-var file_p2p_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_p2p_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_p2p_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_p2p_proto_goTypes = []any{
-	(*Envelope)(nil),                // 0: types.Envelope
-	(*Packet)(nil),                  // 1: types.Packet
-	(*PeerBookRequestMessage)(nil),  // 2: types.PeerBookRequestMessage
-	(*PeerBookResponseMessage)(nil), // 3: types.PeerBookResponseMessage
-	(*BookPeer)(nil),                // 4: types.BookPeer
-	(*anypb.Any)(nil),               // 5: google.protobuf.Any
-	(lib.Topic)(0),                  // 6: types.Topic
-	(*lib.PeerAddress)(nil),         // 7: types.PeerAddress
+	(RemoteSignType)(0),             // 0: types.RemoteSignType
+	(*Envelope)(nil),                // 1: types.Envelope
+	(*Packet)(nil),                  // 2: types.Packet
+	(*PeerBookRequestMessage)(nil),  // 3: types.PeerBookRequestMessage
+	(*PeerBookResponseMessage)(nil), // 4: types.PeerBookResponseMessage
+	(*BookPeer)(nil),                // 5: types.BookPeer
+	(*RemoteSignRequest)(nil),       // 6: types.RemoteSignRequest
+	(*RemoteSignResponse)(nil),      // 7: types.RemoteSignResponse
+	(*anypb.Any)(nil),               // 8: google.protobuf.Any
+	(lib.Topic)(0),                  // 9: types.Topic
+	(*lib.PeerAddress)(nil),         // 10: types.PeerAddress
 }
 var file_p2p_proto_depIdxs = []int32{
-	5, // 0: types.Envelope.payload:type_name -> google.protobuf.Any
-	6, // 1: types.Packet.stream_id:type_name -> types.Topic
-	4, // 2: types.PeerBookResponseMessage.book:type_name -> types.BookPeer
-	7, // 3: types.BookPeer.Address:type_name -> types.PeerAddress
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	8,  // 0: types.Envelope.payload:type_name -> google.protobuf.Any
+	9,  // 1: types.Packet.stream_id:type_name -> types.Topic
+	5,  // 2: types.PeerBookResponseMessage.book:type_name -> types.BookPeer
+	10, // 3: types.BookPeer.Address:type_name -> types.PeerAddress
+	0,  // 4: types.RemoteSignRequest.type:type_name -> types.RemoteSignType
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_p2p_proto_init() }
@@ -356,13 +536,14 @@ func file_p2p_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_p2p_proto_rawDesc), len(file_p2p_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      1,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_p2p_proto_goTypes,
 		DependencyIndexes: file_p2p_proto_depIdxs,
+		EnumInfos:         file_p2p_proto_enumTypes,
 		MessageInfos:      file_p2p_proto_msgTypes,
 	}.Build()
 	File_p2p_proto = out.File
@@ -666,6 +666,8 @@ func TestGetInboxStats(t *testing.T) {
 				lib.Topic_PEERS_RESPONSE: 0,
 				lib.Topic_PEERS_REQUEST:  0,
 				lib.Topic_HEARTBEAT:      0,
+				lib.Topic_STATE_REQUEST:  0,
+				lib.Topic_STATE:          0,
 			},
 		},
 		{
@@ -696,6 +698,8 @@ func TestGetInboxStats(t *testing.T) {
 				lib.Topic_PEERS_RESPONSE: 0,
 				lib.Topic_PEERS_REQUEST:  0,
 				lib.Topic_HEARTBEAT:      0,
+				lib.Topic_STATE_REQUEST:  0,
+				lib.Topic_STATE:          0,
 			},
 		},
 		{
@@ -746,6 +750,8 @@ func TestGetInboxStats(t *testing.T) {
 				lib.Topic_PEERS_RESPONSE: 1,
 				lib.Topic_PEERS_REQUEST:  0,
 				lib.Topic_HEARTBEAT:      0,
+				lib.Topic_STATE_REQUEST:  0,
+				lib.Topic_STATE:          0,
 			},
 		},
 	}
@@ -972,7 +978,7 @@ func TestGetInboxStatsPerformance(t *testing.T) {
 
 	// Add messages to each channel without blocking
 	fillAmount := maxInboxQueueSize
-	for topic := lib.Topic_CONSENSUS; topic <= lib.Topic_HEARTBEAT; topic++ {
+	for topic := lib.Topic_CONSENSUS; topic <= lib.Topic_STATE; topic++ {
 		for i := 0; i < fillAmount; i++ {
 			txMsg := &lib.TxMessage{ChainId: 1, Txs: [][]byte{[]byte("test")}}
 			msgBytes, _ := lib.Marshal(txMsg)
@@ -0,0 +1,332 @@
+package p2p
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+)
+
+/*
+	Remote signer: the validator key is held by a separate signer process and the node requests signatures over a socket
+
+	- the connection is authenticated and encrypted with the same handshake as peers (see encrypt.go)
+	- the signer proves it holds the validator key by signing the handshake challenge with it
+	- the node proves its identity with a 'client key', which the signer must explicitly allow
+	- both sides must agree on the network and chain id, so a signer can't be used by a node of another chain
+	- the signer isn't a signing oracle: it decodes every request and only signs what a validator node signs,
+	  what the consensus policy allows, its peer meta and certificate results; the handshake challenge and vrf seed
+	  are hashes it can't decode, so they're only signed when the request says that's what they are
+*/
+
+var _ crypto.PrivateKeyI = new(RemoteSigner)    // ensure the remote signer is interchangeable with an in-memory key
+var _ crypto.PurposeSignerI = new(RemoteSigner) // ensure the handshake and vrf tell the signer what they sign
+
+// remoteSignerIdleTimeout is how long the signer keeps an idle client connection; the node reconnects on demand
+const remoteSignerIdleTimeout = time.Hour
+
+// RemoteSigner is a crypto.PrivateKeyI whose signatures are produced by a remote signer process
+type RemoteSigner struct {
+	config    lib.RemoteSignerConfig // the remote signer configuration
+	meta      *lib.PeerMeta          // the network and chain the node signs for
+	clientKey crypto.PrivateKeyI     // the key the node authenticates to the signer with
+	publicKey crypto.PublicKeyI      // the validator public key the signer must prove
+	conn      *EncryptedConn         // the connection to the signer (nil if not connected)
+	mux       sync.Mutex             // one signing request at a time
+	log       lib.LoggerI            // logging
+}
+
+// NewRemoteSigner() creates a remote signer from the node configuration; the signer is dialed on the first signature
+func NewRemoteSigner(c lib.Config, clientKey crypto.PrivateKeyI, log lib.LoggerI) (*RemoteSigner, lib.ErrorI) {
+	publicKey, err := crypto.NewPublicKeyFromString(c.RemoteSigner.PublicKey)
+	if err != nil {
+		return nil, ErrInvalidPublicKey(err)
+	}
+	return &RemoteSigner{
+		config:    c.RemoteSigner,
+		meta:      &lib.PeerMeta{NetworkId: c.NetworkID, ChainId: c.ChainId},
+		clientKey: clientKey,
+		publicKey: publicKey,
+		log:       log,
+	}, nil
+}
+
+// Sign() requests the signature of the message from the remote signer
+// NOTE: the interface has no error, so a failed request returns a nil (invalid) signature and logs the error
+func (r *RemoteSigner) Sign(msg []byte) []byte {
+	return r.request(&RemoteSignRequest{SignBytes: msg})
+}
+
+// SignFor() requests the signature of a payload the signer can't decode, telling it what the payload is
+func (r *RemoteSigner) SignFor(purpose crypto.SignPurpose, msg []byte) []byte {
+	request := &RemoteSignRequest{SignBytes: msg}
+	switch purpose {
+	case crypto.SignHandshakeChallenge:
+		request.Type = RemoteSignType_REMOTE_SIGN_HANDSHAKE_CHALLENGE
+	case crypto.SignVRFSeed:
+		request.Type = RemoteSignType_REMOTE_SIGN_VRF_SEED
+	}
+	return r.request(request)
+}
+
+// request() executes the signing request, reconnecting once if the connection is stale
+func (r *RemoteSigner) request(request *RemoteSignRequest) []byte {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	signature, err := r.sign(request)
+	if err != nil && r.conn != nil {
+		// the connection may be stale: reconnect and retry once
+		r.disconnect()
+		signature, err = r.sign(request)
+	}
+	if err != nil {
+		r.disconnect()
+		r.log.Errorf("Remote signer %s failed to sign: %s", r.config.Address, err.Error())
+		return nil
+	}
+	return signature
+}
+
+// sign() executes a single signing request
+func (r *RemoteSigner) sign(request *RemoteSignRequest) ([]byte, lib.ErrorI) {
+	if err := r.connect(); err != nil {
+		return nil, err
+	}
+	timeout := time.Duration(r.config.TimeoutMS) * time.Millisecond
+	if _, err := sendProtoMsg(r.conn, request, timeout); err != nil {
+		return nil, err
+	}
+	response := new(RemoteSignResponse)
+	if _, err := receiveProtoMsg(r.conn, response, timeout); err != nil {
+		return nil, err
+	}
+	if response.Error != "" {
+		return nil, ErrRemoteSigner(errors.New(response.Error))
+	}
+	// defensive: never hand out a signature that doesn't verify
+	if !r.publicKey.VerifyBytes(request.SignBytes, response.Signature) {
+		return nil, ErrRemoteSigner(errors.New("invalid signature"))
+	}
+	return response.Signature, nil
+}
+
+// connect() dials and authenticates the remote signer if not already connected
+func (r *RemoteSigner) connect() lib.ErrorI {
+	if r.conn != nil {
+		return nil
+	}
+	conn, er := net.DialTimeout(transport, r.config.Address, time.Duration(r.config.TimeoutMS)*time.Millisecond)
+	if er != nil {
+		return ErrFailedDial(er)
+	}
+	encryptedConn, err := NewHandshake(conn, r.meta, r.clientKey)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	// ensure the signer holds the validator key
+	if !bytes.Equal(encryptedConn.Address.PublicKey, r.publicKey.Bytes()) {
+		_ = encryptedConn.Close()
+		return ErrMismatchPeerPublicKey(r.publicKey.Bytes(), encryptedConn.Address.PublicKey)
+	}
+	r.conn = encryptedConn
+	return nil
+}
+
+// disconnect() closes the connection to the remote signer (if any)
+func (r *RemoteSigner) disconnect() {
+	if r.conn != nil {
+		_ = r.conn.Close()
+		r.conn = nil
+	}
+}
+
+// PublicKey() returns the validator public key
+func (r *RemoteSigner) PublicKey() crypto.PublicKeyI { return r.publicKey }
+
+// Equals() compares the public keys of the two private keys
+func (r *RemoteSigner) Equals(key crypto.PrivateKeyI) bool {
+	return key != nil && r.publicKey.Equals(key.PublicKey())
+}
+
+// Bytes() returns nil as the private key never leaves the remote signer
+func (r *RemoteSigner) Bytes() []byte { return nil }
+
+// String() returns an empty string as the private key never leaves the remote signer
+func (r *RemoteSigner) String() string { return "" }
+
+// MarshalJSON() refuses to encode as the private key never leaves the remote signer
+func (r *RemoteSigner) MarshalJSON() ([]byte, error) {
+	return nil, ErrRemoteSigner(errors.New("the private key of a remote signer can't be exported"))
+}
+
+// UnmarshalJSON() refuses to decode as the private key never leaves the remote signer
+func (r *RemoteSigner) UnmarshalJSON([]byte) error {
+	return ErrRemoteSigner(errors.New("the private key of a remote signer can't be imported"))
+}
+
+// the message type and payload type of the certificate results transaction a leader sends to its root chain
+const (
+	certificateResultsMessageType = "certificateResults"
+	certificateResultsTypeUrl     = "type.googleapis.com/types.MessageCertificateResults"
+)
+
+// RemoteSignPolicy decides if the signer signs consensus sign bytes, returning the reason if it refuses (see bft.NewRemoteSignPolicy)
+// consensus is false if the sign bytes aren't a consensus message, leaving them to the signer's other checks
+type RemoteSignPolicy func(signBytes []byte) (consensus bool, err lib.ErrorI)
+
+// RemoteSignerServer is the signer side: it holds the validator key and signs for the allowed nodes
+type RemoteSignerServer struct {
+	privateKey crypto.PrivateKeyI // the validator key
+	meta       *lib.PeerMeta      // the network and chain the signer signs for
+	allowed    [][]byte           // the client public keys allowed to request signatures
+	policy     RemoteSignPolicy   // decides on consensus messages (nil refuses them all)
+	listener   net.Listener       // the tcp listener
+	log        lib.LoggerI        // logging
+}
+
+// NewRemoteSignerServer() creates a new signer for the validator key that serves the allowed client public keys
+func NewRemoteSignerServer(privateKey crypto.PrivateKeyI, networkId, chainId uint64, allowed [][]byte, policy RemoteSignPolicy, log lib.LoggerI) *RemoteSignerServer {
+	return &RemoteSignerServer{
+		privateKey: privateKey,
+		meta:       &lib.PeerMeta{NetworkId: networkId, ChainId: chainId},
+		allowed:    allowed,
+		policy:     policy,
+		log:        log,
+	}
+}
+
+// Listen() starts serving signing requests on the tcp address
+func (s *RemoteSignerServer) Listen(address string) lib.ErrorI {
+	ln, er := net.Listen(transport, address)
+	if er != nil {
+		return ErrFailedListen(er)
+	}
+	s.listener = ln
+	s.log.Infof("Remote signer for %s listening on tcp://%s", s.privateKey.PublicKey().String(), ln.Addr().String())
+	go s.serve()
+	return nil
+}
+
+// Addr() returns the address the signer is listening on
+func (s *RemoteSignerServer) Addr() net.Addr { return s.listener.Addr() }
+
+// Stop() stops accepting and closes the listener
+func (s *RemoteSignerServer) Stop() {
+	if s.listener != nil {
+		_ = s.listener.Close()
+	}
+}
+
+// serve() accepts connections until the listener is closed
+func (s *RemoteSignerServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			s.log.Errorf("Remote signer accept error: %s", err.Error())
+			<-time.After(time.Second)
+			continue
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle() authenticates the node and serves its signing requests until the connection closes
+func (s *RemoteSignerServer) handle(conn net.Conn) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Errorf("panic recovered, err: %s, stack: %s", r, string(debug.Stack()))
+		}
+		_ = conn.Close()
+	}()
+	encryptedConn, err := NewHandshake(conn, s.meta, s.privateKey)
+	if err != nil {
+		s.log.Warnf("Remote signer handshake with %s failed: %s", conn.RemoteAddr().String(), err.Error())
+		return
+	}
+	// only the allowed nodes may request signatures
+	if !s.isAllowed(encryptedConn.Address.PublicKey) {
+		s.log.Warnf("Remote signer refused client %s@%s", lib.BytesToString(encryptedConn.Address.PublicKey), conn.RemoteAddr().String())
+		return
+	}
+	s.log.Infof("Remote signer connected to client %s@%s", lib.BytesToString(encryptedConn.Address.PublicKey), conn.RemoteAddr().String())
+	for {
+		// wait for the next request
+		request := new(RemoteSignRequest)
+		if _, err = receiveProtoMsg(encryptedConn, request, remoteSignerIdleTimeout); err != nil {
+			s.log.Debugf("Remote signer client %s disconnected: %s", conn.RemoteAddr().String(), err.Error())
+			return
+		}
+		response := &RemoteSignResponse{}
+		if e := s.checkSignBytes(request); e != nil {
+			s.log.Warnf("Remote signer refused a request from %s: %s", conn.RemoteAddr().String(), e.Error())
+			response.Error = e.Error()
+		} else {
+			response.Signature = s.privateKey.Sign(request.SignBytes)
+		}
+		if _, err = sendProtoMsg(encryptedConn, response); err != nil {
+			s.log.Warnf("Remote signer failed to respond to %s: %s", conn.RemoteAddr().String(), err.Error())
+			return
+		}
+	}
+}
+
+// checkSignBytes() returns an error unless the sign bytes are a payload a validator node signs with its key
+func (s *RemoteSignerServer) checkSignBytes(request *RemoteSignRequest) lib.ErrorI {
+	signBytes := request.SignBytes
+	if len(signBytes) == 0 {
+		return ErrRemoteSigner(errors.New("empty sign bytes"))
+	}
+	// the consensus policy decides first, so a consensus message never bypasses the high-water mark whatever the request says
+	if s.policy != nil {
+		if consensus, err := s.policy(signBytes); consensus {
+			return err
+		}
+	}
+	switch request.Type {
+	// the handshake challenge and the vrf seed are 32 byte hashes the signer can't decode, only signed when asked for explicitly
+	case RemoteSignType_REMOTE_SIGN_HANDSHAKE_CHALLENGE, RemoteSignType_REMOTE_SIGN_VRF_SEED:
+		if len(signBytes) == crypto.ChallengeSize {
+			return nil
+		}
+	case RemoteSignType_REMOTE_SIGN_MESSAGE:
+		// the handshake peer meta of the signer's network and chain
+		if bytes.Equal(signBytes, s.meta.SignBytes()) || s.isCertificateResultsTx(signBytes) {
+			return nil
+		}
+	}
+	return ErrRemoteSignerRefused()
+}
+
+// isCertificateResultsTx() returns true if the sign bytes are exactly those of a fee-less certificate results transaction of the network
+// NOTE: the root chain only accepts the results with the +2/3 aggregate signature of the committee, and without a fee nothing is spent
+func (s *RemoteSignerServer) isCertificateResultsTx(signBytes []byte) bool {
+	tx := new(lib.Transaction)
+	if err := lib.Unmarshal(signBytes, tx); err != nil {
+		return false
+	}
+	if tx.MessageType != certificateResultsMessageType || tx.Msg.GetTypeUrl() != certificateResultsTypeUrl || tx.Fee != 0 || tx.NetworkId != s.meta.NetworkId {
+		return false
+	}
+	// re-encoding must reproduce the bytes, so nothing but the transaction fields can be smuggled in
+	txSignBytes, err := tx.GetSignBytes()
+	return err == nil && bytes.Equal(txSignBytes, signBytes)
+}
+
+// isAllowed() returns true if the client public key may request signatures
+func (s *RemoteSignerServer) isAllowed(publicKey []byte) bool {
+	for _, allowed := range s.allowed {
+		if bytes.Equal(allowed, publicKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/canopy-network/canopy/lib"
+	"github.com/canopy-network/canopy/lib/crypto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	msg, other := []byte("consensus sign bytes"), []byte("other consensus sign bytes")
+	// a 32 byte consensus message the high-water mark refuses
+	refused := bytes.Repeat([]byte{1}, crypto.ChallengeSize)
+	validatorKey, err := crypto.NewBLS12381PrivateKey()
+	require.NoError(t, err)
+	clientKey, err := crypto.NewEd25519PrivateKey()
+	require.NoError(t, err)
+	otherClientKey, err := crypto.NewEd25519PrivateKey()
+	require.NoError(t, err)
+	// the consensus policy allows the two messages
+	policy := func(signBytes []byte) (bool, lib.ErrorI) {
+		switch {
+		case bytes.Equal(signBytes, msg), bytes.Equal(signBytes, other):
+			return true, nil
+		case bytes.Equal(signBytes, refused):
+			return true, ErrRemoteSignerRefused()
+		}
+		return false, ErrRemoteSignerRefused()
+	}
+	// start the signer for the client
+	server := NewRemoteSignerServer(validatorKey, 1, 1, [][]byte{clientKey.PublicKey().Bytes()}, policy, lib.NewDefaultLogger())
+	require.NoError(t, server.Listen("127.0.0.1:0"))
+	defer server.Stop()
+	newRemoteSigner := func(clientKey crypto.PrivateKeyI, publicKey crypto.PublicKeyI, chainId uint64) *RemoteSigner {
+		c := lib.DefaultConfig()
+		c.NetworkID, c.ChainId = 1, chainId
+		c.RemoteSigner.Address, c.RemoteSigner.PublicKey = server.Addr().String(), publicKey.String()
+		signer, e := NewRemoteSigner(c, clientKey, lib.NewDefaultLogger())
+		require.NoError(t, e)
+		return signer
+	}
+	// the remote signer is interchangeable with the in-memory key
+	signer := newRemoteSigner(clientKey, validatorKey.PublicKey(), 1)
+	require.True(t, signer.PublicKey().Equals(validatorKey.PublicKey()))
+	require.True(t, signer.Equals(validatorKey))
+	require.Equal(t, validatorKey.Sign(msg), signer.Sign(msg))
+	// the connection is reused
+	conn := signer.conn
+	require.NotNil(t, conn)
+	require.True(t, validatorKey.PublicKey().VerifyBytes(other, signer.Sign(other)))
+	require.Same(t, conn, signer.conn)
+	// a broken connection is re-established
+	require.NoError(t, signer.conn.Close())
+	require.Equal(t, validatorKey.Sign(msg), signer.Sign(msg))
+	// the signer isn't a signing oracle: a payload the node doesn't sign is refused
+	require.Nil(t, signer.Sign([]byte("arbitrary")))
+	// the handshake challenge and vrf seed are only signed when the request says that's what they are
+	seed := lib.FormatInputIntoSeed(nil, 1, 1, 0)
+	require.Nil(t, signer.Sign(seed))
+	require.Equal(t, validatorKey.Sign(seed), crypto.SignFor(signer, crypto.SignVRFSeed, seed))
+	require.Equal(t, validatorKey.Sign(seed), crypto.SignFor(signer, crypto.SignHandshakeChallenge, seed))
+	require.Nil(t, crypto.SignFor(signer, crypto.SignVRFSeed, []byte("arbitrary")))
+	// but a consensus message is always decided by the policy, whatever the request says
+	require.Nil(t, crypto.SignFor(signer, crypto.SignVRFSeed, refused))
+	require.Nil(t, crypto.SignFor(signer, crypto.SignHandshakeChallenge, refused))
+	// the peer meta of the network and chain is signed
+	meta := &lib.PeerMeta{NetworkId: 1, ChainId: 1}
+	require.Equal(t, validatorKey.Sign(meta.SignBytes()), signer.Sign(meta.SignBytes()))
+	require.Nil(t, signer.Sign((&lib.PeerMeta{NetworkId: 1, ChainId: 2}).SignBytes()))
+	// a fee-less certificate results transaction is signed, but not one that pays a fee or carries another message
+	newTx := func(messageType, typeUrl string, fee uint64) []byte {
+		tx := &lib.Transaction{MessageType: messageType, Msg: &anypb.Any{TypeUrl: typeUrl, Value: []byte("results")}, Fee: fee, NetworkId: 1, ChainId: 2}
+		signBytes, e := tx.GetSignBytes()
+		require.NoError(t, e)
+		return signBytes
+	}
+	require.NotNil(t, signer.Sign(newTx(certificateResultsMessageType, certificateResultsTypeUrl, 0)))
+	require.Nil(t, signer.Sign(newTx(certificateResultsMessageType, certificateResultsTypeUrl, 10)))
+	require.Nil(t, signer.Sign(newTx(certificateResultsMessageType, "type.googleapis.com/types.MessageSend", 0)))
+	require.Nil(t, signer.Sign(newTx("send", "type.googleapis.com/types.MessageSend", 0)))
+	// the private key can't be exported
+	require.Nil(t, signer.Bytes())
+	_, err = signer.MarshalJSON()
+	require.Error(t, err)
+	// a client that isn't allowed isn't served
+	require.Nil(t, newRemoteSigner(otherClientKey, validatorKey.PublicKey(), 1).Sign(msg))
+	// a client of another chain isn't served
+	require.Nil(t, newRemoteSigner(clientKey, validatorKey.PublicKey(), 2).Sign(msg))
+	// a signer that doesn't hold the expected validator key isn't used
+	require.Nil(t, newRemoteSigner(clientKey, otherClientKey.PublicKey(), 1).Sign(msg))
+	// the signer is unreachable
+	server.Stop()
+	unreachable := newRemoteSigner(clientKey, validatorKey.PublicKey(), 1)
+	require.Nil(t, unreachable.Sign(msg))
+}
@@ -144,6 +144,81 @@ func (x *Pool) GetAmount() uint64 {
 	return 0
 }
 
+// DexPriceAccumulator is Canopy's read only, manipulation resistant DEX price oracle for a chain pair, found at
+// KeyForDexPriceAccumulator(counterChainId); it's updated each time a dex batch is locked
+// The time weighted average price between two heights is (cumulative(end) - cumulative(start)) / (end - start)
+type DexPriceAccumulator struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// chain_id: the counter chain of the pair
+	ChainId uint64 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// cumulative_e6_price: the big endian sum of e6 scaled price x blocks the price was in effect, as of last_height
+	CumulativeE6Price []byte `protobuf:"bytes,2,opt,name=cumulative_e6_price,json=cumulativeE6Price,proto3" json:"cumulative_e6_price,omitempty"`
+	// last_e6_price: the e6 scaled spot price set at last_height (in effect until the next update)
+	LastE6Price uint64 `protobuf:"varint,3,opt,name=last_e6_price,json=lastE6Price,proto3" json:"last_e6_price,omitempty"`
+	// last_height: the height the accumulator was last updated
+	LastHeight    uint64 `protobuf:"varint,4,opt,name=last_height,json=lastHeight,proto3" json:"last_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DexPriceAccumulator) Reset() {
+	*x = DexPriceAccumulator{}
+	mi := &file_account_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DexPriceAccumulator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DexPriceAccumulator) ProtoMessage() {}
+
+func (x *DexPriceAccumulator) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DexPriceAccumulator.ProtoReflect.Descriptor instead.
+func (*DexPriceAccumulator) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DexPriceAccumulator) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *DexPriceAccumulator) GetCumulativeE6Price() []byte {
+	if x != nil {
+		return x.CumulativeE6Price
+	}
+	return nil
+}
+
+func (x *DexPriceAccumulator) GetLastE6Price() uint64 {
+	if x != nil {
+		return x.LastE6Price
+	}
+	return 0
+}
+
+func (x *DexPriceAccumulator) GetLastHeight() uint64 {
+	if x != nil {
+		return x.LastHeight
+	}
+	return 0
+}
+
 var File_account_proto protoreflect.FileDescriptor
 
 const file_account_proto_rawDesc = "" +
@@ -155,7 +230,13 @@ const file_account_proto_rawDesc = "" +
 	"\x05nonce\x18\a \x01(\x04R\x05nonce\".\n" +
 	"\x04Pool\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x04R\x06amountB.Z,github.com/canopy-network/go-plugin/contractb\x06proto3"
+	"\x06amount\x18\x02 \x01(\x04R\x06amount\"\xa5\x01\n" +
+	"\x13DexPriceAccumulator\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12.\n" +
+	"\x13cumulative_e6_price\x18\x02 \x01(\fR\x11cumulativeE6Price\x12\"\n" +
+	"\rlast_e6_price\x18\x03 \x01(\x04R\vlastE6Price\x12\x1f\n" +
+	"\vlast_height\x18\x04 \x01(\x04R\n" +
+	"lastHeightB.Z,github.com/canopy-network/go-plugin/contractb\x06proto3"
 
 var (
 	file_account_proto_rawDescOnce sync.Once
@@ -169,10 +250,11 @@ func file_account_proto_rawDescGZIP() []byte {
 	return file_account_proto_rawDescData
 }
 
-var file_account_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_account_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_account_proto_goTypes = []any{
-	(*Account)(nil), // 0: types.Account
-	(*Pool)(nil),    // 1: types.Pool
+	(*Account)(nil),             // 0: types.Account
+	(*Pool)(nil),                // 1: types.Pool
+	(*DexPriceAccumulator)(nil), // 2: types.DexPriceAccumulator
 }
 var file_account_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -193,7 +275,7 @@ func file_account_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_account_proto_rawDesc), len(file_account_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"log"
 	"math"
+	"math/big"
 	"math/rand"
 
 	"google.golang.org/protobuf/proto"
@@ -262,9 +263,10 @@ func (c *Contract) DeliverMessageSend(msg *MessageSend, fee uint64, memo string)
 }
 
 var (
-	accountPrefix = []byte{1} // store key prefix for accounts
-	poolPrefix    = []byte{2} // store key prefix for pools
-	paramsPrefix  = []byte{7} // store key prefix for governance parameters
+	accountPrefix = []byte{1}  // store key prefix for accounts
+	poolPrefix    = []byte{2}  // store key prefix for pools
+	paramsPrefix  = []byte{7}  // store key prefix for governance parameters
+	dexPrefix     = []byte{15} // store key prefix for dex functionality (read only for plugins)
 )
 
 // KeyForAccount() returns the state database key for an account
@@ -282,6 +284,23 @@ func KeyForFeePool(chainId uint64) []byte {
 	return JoinLenPrefix(poolPrefix, formatUint64(chainId))
 }
 
+// KeyForDexPriceAccumulator() returns the (read only) state database key for the DEX price oracle of a counter chain
+func KeyForDexPriceAccumulator(chainId uint64) []byte {
+	return JoinLenPrefix(dexPrefix, []byte{4}, formatUint64(chainId))
+}
+
+// Cumulative() returns the cumulative e6 scaled price (price x blocks) as of the given height, extrapolating the last price
+// NOTE: checkpoint Cumulative(startHeight) in plugin state and later compute the time weighted average price as
+// (Cumulative(endHeight) - checkpoint) / (endHeight - startHeight)
+func (x *DexPriceAccumulator) Cumulative(height uint64) *big.Int {
+	cumulative := new(big.Int).SetBytes(x.CumulativeE6Price)
+	if height <= x.LastHeight {
+		return cumulative
+	}
+	elapsed := new(big.Int).SetUint64(height - x.LastHeight)
+	return cumulative.Add(cumulative, elapsed.Mul(elapsed, new(big.Int).SetUint64(x.LastE6Price)))
+}
+
 func formatUint64(u uint64) []byte {
 	b := make([]byte, 8)
 	binary.BigEndian.PutUint64(b, u)
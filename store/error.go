@@ -69,3 +69,7 @@ func ErrReadBytes(err error) lib.ErrorI {
 func ErrIndexBlock(err error) lib.ErrorI {
 	return lib.NewError(lib.CodeIndexBlock, lib.StorageModule, fmt.Sprintf("index block failed with err: %s", err.Error()))
 }
+
+func ErrInvalidDexResolution(resolution uint64, resolutions []uint64) lib.ErrorI {
+	return lib.NewError(lib.CodeInvalidDexResolution, lib.StorageModule, fmt.Sprintf("dex history resolution %d isn't indexed, indexed resolutions: %v", resolution, resolutions))
+}
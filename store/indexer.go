@@ -3,6 +3,8 @@ package store
 import (
 	"bytes"
 	"encoding/binary"
+	"math"
+	"slices"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -30,8 +32,7 @@ var (
 	eventChainIdPrefix = []byte{12} // store key prefix for events by chainId
 	eventHashPrefix    = []byte{13} // store key prefix for events by event hash (concept just used for indexing)
 	stateChangePrefix  = []byte{14} // state keys written at a particular committed version
-	// create indexer cache
-	blockCache, _ = lru.New[uint64, *lib.BlockResult](64)
+	dexCandlePrefix    = []byte{15} // store key prefix for dex history buckets by chainId, resolution and start time
 	//qcCache, _ = lru.New[uint64, *lib.QuorumCertificate](4) TODO add back
 )
 
@@ -43,8 +44,15 @@ var stateChangeMarker = []byte{1}
 
 // Indexer: the part of the DB that stores transactions, blocks, and quorum certificates
 type Indexer struct {
-	db     *Txn
-	config lib.Config
+	db         *Txn
+	config     lib.Config
+	blockCache *lru.Cache[uint64, *lib.BlockResult] // recent block results, shared by the copies of a store (not process wide)
+}
+
+// newBlockCache() creates the block result cache of a store
+func newBlockCache() *lru.Cache[uint64, *lib.BlockResult] {
+	cache, _ := lru.New[uint64, *lib.BlockResult](64)
+	return cache
 }
 
 // StateChangeKeys() returns state keys written while committing version, optionally
@@ -110,7 +118,7 @@ func (t *Indexer) IndexBlock(b *lib.BlockResult) lib.ErrorI {
 	}
 	// set meta stats for the block
 	b.Meta = &lib.BlockResultMeta{Size: uint64(len(resultBz))}
-	blockCache.Add(b.BlockHeader.Height, b)
+	t.blockCache.Add(b.BlockHeader.Height, b)
 	// get bytes of block header
 	bz, err := lib.Marshal(b.BlockHeader)
 	if err != nil {
@@ -139,6 +147,10 @@ func (t *Indexer) IndexBlock(b *lib.BlockResult) lib.ErrorI {
 			return t.IndexEvent(e, idx)
 		})
 	}
+	// aggregate the dex events into the history buckets in their own goroutine (order matters within a bucket)
+	eg.Go(func() error {
+		return t.IndexDexHistory(b)
+	})
 	// wait for all goroutines to finish
 	if err := eg.Wait(); err != nil {
 		return ErrIndexBlock(err)
@@ -146,10 +158,18 @@ func (t *Indexer) IndexBlock(b *lib.BlockResult) lib.ErrorI {
 	return nil
 }
 
-// DeleteBlockForHeight() deletes the block & transaction data for a certain height
+// DeleteBlockForHeight() deletes the block, transaction & event data for a certain height and reverts its dex history
 func (t *Indexer) DeleteBlockForHeight(height uint64) lib.ErrorI {
+	// remove the events of the height from the dex history buckets (before the events are deleted)
+	if err := t.DeleteDexHistoryForHeight(height); err != nil {
+		return err
+	}
+	// delete the events for the height
+	if err := t.DeleteEventsForHeight(height); err != nil {
+		return err
+	}
 	// remove from cache
-	blockCache.Remove(height)
+	t.blockCache.Remove(height)
 	// get the height key
 	heightKey := t.blockHeightKey(height)
 	// get the hash key (was indexed by height key)
@@ -177,7 +197,7 @@ func (t *Indexer) GetBlockByHash(hash []byte) (*lib.BlockResult, lib.ErrorI) {
 // GetBlockByHeight() returns the block result by height key
 func (t *Indexer) GetBlockByHeight(height uint64) (*lib.BlockResult, lib.ErrorI) {
 	// check cache
-	if got, found := blockCache.Get(height); found {
+	if got, found := t.blockCache.Get(height); found {
 		return got, nil
 	}
 	// height key points to hash key
@@ -191,14 +211,14 @@ func (t *Indexer) GetBlockByHeight(height uint64) (*lib.BlockResult, lib.ErrorI)
 		return nil, err
 	}
 	// populate cache on read so historical blocks are warm after a restart
-	blockCache.Add(height, block)
+	t.blockCache.Add(height, block)
 	return block, nil
 }
 
 // GetBlockHeaderByHeight() returns the block result without transactions
 func (t *Indexer) GetBlockHeaderByHeight(height uint64) (*lib.BlockResult, lib.ErrorI) {
 	// check cache (full block result may be cached from GetBlockByHeight or IndexBlock)
-	if got, found := blockCache.Get(height); found {
+	if got, found := t.blockCache.Get(height); found {
 		return got, nil
 	}
 	// height key points to hash key
@@ -212,7 +232,7 @@ func (t *Indexer) GetBlockHeaderByHeight(height uint64) (*lib.BlockResult, lib.E
 		return nil, err
 	}
 	// populate cache on read so historical blocks are warm after a restart
-	blockCache.Add(height, block)
+	t.blockCache.Add(height, block)
 	return block, nil
 }
 
@@ -344,7 +364,7 @@ func blockResultSize(block *lib.BlockResult) (uint64, lib.ErrorI) {
 // getBlockForPage() returns the block at the height
 func (t *Indexer) getBlockForPage(height uint64, transactions bool) (*lib.BlockResult, lib.ErrorI) {
 	// use the cached block result if it's already loaded
-	if got, found := blockCache.Get(height); found {
+	if got, found := t.blockCache.Get(height); found {
 		return got, nil
 	}
 	// height key points to hash key
@@ -651,6 +671,29 @@ func (t *Indexer) IndexEvent(e *lib.Event, index int) lib.ErrorI {
 	return nil
 }
 
+// DeleteEventsForHeight() deletes the events (and their chain id and address indexes) for a certain height
+func (t *Indexer) DeleteEventsForHeight(height uint64) lib.ErrorI {
+	events, err := t.GetEventsNonPaginated(height, false)
+	if err != nil {
+		return err
+	}
+	// events are indexed by their position in the block
+	for i, e := range events {
+		heightAndIndexKey := t.eventHeightAndIndexKey(height, uint64(i))
+		if e.ChainId != 0 {
+			if err = t.db.Delete(t.eventChainIdKey(e.ChainId, heightAndIndexKey)); err != nil {
+				return err
+			}
+		}
+		if t.config.IndexByAccount && e.Address != nil {
+			if err = t.db.Delete(t.eventAddressKey(e.Address, heightAndIndexKey)); err != nil {
+				return err
+			}
+		}
+	}
+	return t.deleteAll(t.eventHeightKey(height))
+}
+
 // GetEventsByAddress() returns a slice of events ordered by height and index for an address
 func (t *Indexer) GetEventsByAddress(address crypto.AddressI, newestToOldest bool, p lib.PageParams) (*lib.Page, lib.ErrorI) {
 	return t.getEvents(t.eventAddressKey(address.Bytes(), nil), newestToOldest, p)
@@ -755,6 +798,254 @@ func (t *Indexer) eventAddressKey(address, heightAndIndexKey []byte) []byte {
 	return t.key(eventAddressPrefix, address, heightAndIndexKey)
 }
 
+// DEX HISTORY CODE BELOW
+
+// maxDexCandles is the maximum number of dex history buckets returned by a single query
+const maxDexCandles = 1000
+
+// IndexDexHistory() aggregates the dex swap, deposit and withdraw events of a block into the OHLCV buckets of every
+// configured resolution; a bucket that already aggregated the height is rebuilt with the block's events in place of
+// the indexed ones, so re-indexing a block never counts it twice and picks up its new events
+func (t *Indexer) IndexDexHistory(b *lib.BlockResult) lib.ErrorI {
+	if len(t.config.DexHistoryResolutions) == 0 || b.BlockHeader == nil {
+		return nil
+	}
+	height, blockTime := b.BlockHeader.Height, b.BlockHeader.Time/uint64(time.Second/time.Microsecond)
+	// the buckets touched by this block in the order they were touched, and those rebuilt with all of its events
+	candles, keys, rebuilt := make(map[string]*lib.DexCandle), make([]string, 0), make(map[string]struct{})
+	for _, e := range b.Events {
+		if e.ChainId == 0 || !isDexHistoryEvent(e) {
+			continue
+		}
+		for _, resolution := range t.config.DexHistoryResolutions {
+			if resolution == 0 {
+				continue
+			}
+			startTime := blockTime - blockTime%resolution
+			key := t.dexCandleKey(e.ChainId, resolution, startTime)
+			candle, found := candles[string(key)]
+			if !found {
+				// load the bucket from the indexer (or start a new one)
+				existing, err := t.getDexCandle(key)
+				if err != nil {
+					return err
+				}
+				switch {
+				case existing == nil:
+					candle = &lib.DexCandle{ChainId: e.ChainId, Resolution: resolution, StartTime: startTime, StartHeight: height}
+				case existing.EndHeight < height:
+					candle = existing
+				default:
+					// the height is being re-indexed: rebuild the bucket with the events of this block for the height
+					if candle, err = t.rebuildDexCandle(existing, height, b.Events); err != nil {
+						return err
+					}
+					rebuilt[string(key)] = struct{}{}
+				}
+				candles[string(key)], keys = candle, append(keys, string(key))
+			}
+			if _, found = rebuilt[string(key)]; !found {
+				addDexHistoryEvent(candle, e, height)
+			}
+		}
+	}
+	return t.setDexCandles(candles, keys)
+}
+
+// DeleteDexHistoryForHeight() removes the events of a height from the dex history buckets that aggregated them
+// by rebuilding each bucket from the indexed events of its other heights; a bucket left without events is deleted
+// NOTE: a rollback doesn't need this, as the buckets are versioned with the rest of the indexer
+func (t *Indexer) DeleteDexHistoryForHeight(height uint64) lib.ErrorI {
+	if len(t.config.DexHistoryResolutions) == 0 {
+		return nil
+	}
+	// get the time and the events of the height
+	block, err := t.GetBlockHeaderByHeight(height)
+	if err != nil || block == nil || block.BlockHeader == nil {
+		return err
+	}
+	events, err := t.GetEventsNonPaginated(height, false)
+	if err != nil {
+		return err
+	}
+	blockTime := block.BlockHeader.Time / uint64(time.Second/time.Microsecond)
+	// rebuild each bucket the events of the height were aggregated into
+	candles, keys := make(map[string]*lib.DexCandle), make([]string, 0)
+	for _, e := range events {
+		if e.ChainId == 0 || !isDexHistoryEvent(e) {
+			continue
+		}
+		for _, resolution := range t.config.DexHistoryResolutions {
+			if resolution == 0 {
+				continue
+			}
+			key := t.dexCandleKey(e.ChainId, resolution, blockTime-blockTime%resolution)
+			if _, found := candles[string(key)]; found {
+				continue
+			}
+			existing, e := t.getDexCandle(key)
+			if e != nil {
+				return e
+			}
+			// skip the buckets that don't hold the height
+			if existing == nil || height < existing.StartHeight || height > existing.EndHeight {
+				continue
+			}
+			candle, e := t.rebuildDexCandle(existing, height, nil)
+			if e != nil {
+				return e
+			}
+			candles[string(key)], keys = candle, append(keys, string(key))
+		}
+	}
+	return t.setDexCandles(candles, keys)
+}
+
+// rebuildDexCandle() re-aggregates a dex history bucket from the indexed events of its heights, using the events given
+// for the height instead of the indexed ones (nil leaves the height out); returns nil if no event is left in the bucket
+func (t *Indexer) rebuildDexCandle(existing *lib.DexCandle, height uint64, events []*lib.Event) (candle *lib.DexCandle, err lib.ErrorI) {
+	// the blocks are in time order, so every height between the first and the last of the bucket falls into it
+	for h := min(existing.StartHeight, height); h <= max(existing.EndHeight, height); h++ {
+		heightEvents := events
+		if h != height {
+			if heightEvents, err = t.GetEventsNonPaginated(h, false); err != nil {
+				return nil, err
+			}
+		}
+		for _, e := range heightEvents {
+			if e.ChainId != existing.ChainId || !isDexHistoryEvent(e) {
+				continue
+			}
+			if candle == nil {
+				candle = &lib.DexCandle{ChainId: existing.ChainId, Resolution: existing.Resolution, StartTime: existing.StartTime, StartHeight: h}
+			}
+			addDexHistoryEvent(candle, e, h)
+		}
+	}
+	return
+}
+
+// setDexCandles() saves the dex history buckets under their keys in order, deleting the nil (emptied) ones
+func (t *Indexer) setDexCandles(candles map[string]*lib.DexCandle, keys []string) lib.ErrorI {
+	for _, key := range keys {
+		candle := candles[key]
+		if candle == nil {
+			if err := t.db.Delete([]byte(key)); err != nil {
+				return err
+			}
+			continue
+		}
+		bz, err := lib.Marshal(candle)
+		if err != nil {
+			return err
+		}
+		if err = t.db.Set([]byte(key), bz); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDexCandles() returns up to limit dex history buckets of a pool and resolution with a start time in [startTime, endTime]
+// in chronological order; if more buckets are in the range, the newest are returned (0 is no end time or the max limit)
+func (t *Indexer) GetDexCandles(chainId, resolution, startTime, endTime uint64, limit int) (candles []*lib.DexCandle, err lib.ErrorI) {
+	if !slices.Contains(t.config.DexHistoryResolutions, resolution) || resolution == 0 {
+		return nil, ErrInvalidDexResolution(resolution, t.config.DexHistoryResolutions)
+	}
+	if limit <= 0 || limit > maxDexCandles {
+		limit = maxDexCandles
+	}
+	if endTime == 0 {
+		endTime = math.MaxUint64
+	}
+	// iterate the buckets newest to oldest
+	it, err := t.db.RevIterator(t.key(dexCandlePrefix, t.encodeBigEndian(chainId), t.encodeBigEndian(resolution)))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	candles = make([]*lib.DexCandle, 0)
+	for ; it.Valid() && len(candles) < limit; it.Next() {
+		candle := new(lib.DexCandle)
+		if err = lib.Unmarshal(it.Value(), candle); err != nil {
+			return nil, err
+		}
+		if candle.StartTime > endTime {
+			continue
+		}
+		if candle.StartTime < startTime {
+			break
+		}
+		candles = append(candles, candle)
+	}
+	// return in chronological order
+	slices.Reverse(candles)
+	return
+}
+
+// getDexCandle() returns the dex history bucket under the key or nil if not found
+func (t *Indexer) getDexCandle(key []byte) (*lib.DexCandle, lib.ErrorI) {
+	bz, err := t.db.Get(key)
+	if err != nil || bz == nil {
+		return nil, err
+	}
+	candle := new(lib.DexCandle)
+	if err = lib.Unmarshal(bz, candle); err != nil {
+		return nil, err
+	}
+	return candle, nil
+}
+
+// isDexHistoryEvent() returns true if the event changes the price, volume or liquidity of a pool
+// refunded deposits, cancelled withdrawals and failed swaps don't
+func isDexHistoryEvent(e *lib.Event) bool {
+	switch msg := e.Msg.(type) {
+	case *lib.Event_DexSwap:
+		return msg.DexSwap.Success && msg.DexSwap.SoldAmount != 0 && msg.DexSwap.BoughtAmount != 0
+	case *lib.Event_DexLiquidityDeposit:
+		return msg.DexLiquidityDeposit.Points != 0
+	case *lib.Event_DexLiquidityWithdrawal:
+		return msg.DexLiquidityWithdrawal.PointsBurned != 0
+	}
+	return false
+}
+
+// addDexHistoryEvent() aggregates a dex history event at a height into the bucket
+func addDexHistoryEvent(c *lib.DexCandle, e *lib.Event, height uint64) {
+	c.EndHeight = height
+	switch msg := e.Msg.(type) {
+	case *lib.Event_DexSwap:
+		// orient the amounts to the local and counter asset
+		local, remote := msg.DexSwap.SoldAmount, msg.DexSwap.BoughtAmount
+		if !msg.DexSwap.LocalOrigin {
+			local, remote = remote, local
+		}
+		// the e6 scaled execution price (local asset per counter asset like the dex price)
+		price := lib.SafeMulDiv(local, 1_000_000, remote)
+		if c.Swaps == 0 {
+			c.Open, c.High, c.Low = price, price, price
+		}
+		c.High, c.Low, c.Close = max(c.High, price), min(c.Low, price), price
+		c.LocalVolume, c.RemoteVolume, c.Swaps = c.LocalVolume+local, c.RemoteVolume+remote, c.Swaps+1
+	case *lib.Event_DexLiquidityDeposit:
+		if msg.DexLiquidityDeposit.LocalOrigin {
+			c.LocalDeposited += msg.DexLiquidityDeposit.Amount
+		} else {
+			c.RemoteDeposited += msg.DexLiquidityDeposit.Amount
+		}
+		c.PointsAdded += msg.DexLiquidityDeposit.Points
+	case *lib.Event_DexLiquidityWithdrawal:
+		c.LocalWithdrawn += msg.DexLiquidityWithdrawal.LocalAmount
+		c.RemoteWithdrawn += msg.DexLiquidityWithdrawal.RemoteAmount
+		c.PointsBurned += msg.DexLiquidityWithdrawal.PointsBurned
+	}
+}
+
+// dexCandleKey() returns the key of a dex history bucket
+func (t *Indexer) dexCandleKey(chainId, resolution, startTime uint64) []byte {
+	return lib.JoinLenPrefix(dexCandlePrefix, t.encodeBigEndian(chainId), t.encodeBigEndian(resolution), t.encodeBigEndian(startTime))
+}
+
 // CHECKPOINT CODE BELOW
 
 // IndexCheckpoint() indexes a 'checkpoint block hash' for a committee chain at a certain height
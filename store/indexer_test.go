@@ -144,3 +144,135 @@ func newTestTxResult(t *testing.T) (r *lib.TxResult, tx *lib.Transaction, hash [
 	}
 	return
 }
+
+func TestIndexDexHistory(t *testing.T) {
+	store, _, cleanup := testStore(t)
+	defer cleanup()
+	const chainId, minute = uint64(2), uint64(36_000) // 10 hours after the unix epoch
+	swap := func(sold, bought uint64, localOrigin, success bool) *lib.Event {
+		return &lib.Event{ChainId: chainId, Msg: &lib.Event_DexSwap{DexSwap: &lib.EventDexSwap{
+			SoldAmount: sold, BoughtAmount: bought, LocalOrigin: localOrigin, Success: success}}}
+	}
+	blocks := []*lib.BlockResult{
+		newTestDexBlock(1, minute+5,
+			swap(100, 50, true, true),  // sold 100 local for 50 counter: 2.0
+			swap(100, 40, false, true), // sold 100 counter for 40 local: 0.4
+			swap(100, 40, true, false), // failed
+			&lib.Event{ChainId: chainId, Msg: &lib.Event_DexLiquidityDeposit{DexLiquidityDeposit: &lib.EventDexLiquidityDeposit{
+				Amount: 500, LocalOrigin: true, Points: 10}}},
+			&lib.Event{ChainId: chainId, Msg: &lib.Event_DexLiquidityDeposit{DexLiquidityDeposit: &lib.EventDexLiquidityDeposit{
+				Amount: 700}}}, // refunded
+			&lib.Event{Msg: &lib.Event_Reward{Reward: &lib.EventReward{Amount: 1}}},
+		),
+		newTestDexBlock(2, minute+35,
+			swap(300, 100, true, true), // 3.0
+			&lib.Event{ChainId: chainId, Msg: &lib.Event_DexLiquidityWithdrawal{DexLiquidityWithdrawal: &lib.EventDexLiquidityWithdrawal{
+				LocalAmount: 20, RemoteAmount: 10, PointsBurned: 5}}},
+		),
+		newTestDexBlock(3, minute+65, swap(10, 10, false, true)), // 1.0 in the next minute
+	}
+	for _, b := range blocks {
+		require.NoError(t, store.IndexBlock(b))
+	}
+	_, err := store.Commit()
+	require.NoError(t, err)
+	// re-indexing a block is a noop
+	require.NoError(t, store.IndexBlock(blocks[1]))
+	_, err = store.Commit()
+	require.NoError(t, err)
+	// minute buckets
+	candles, err := store.GetDexCandles(chainId, 60, 0, 0, 0)
+	require.NoError(t, err)
+	expected, e := lib.MarshalJSON([]*lib.DexCandle{{
+		ChainId: chainId, Resolution: 60, StartTime: minute, StartHeight: 1, EndHeight: 2,
+		Open: 2_000_000, High: 3_000_000, Low: 400_000, Close: 3_000_000, LocalVolume: 440, RemoteVolume: 250, Swaps: 3,
+		LocalDeposited: 500, PointsAdded: 10, LocalWithdrawn: 20, RemoteWithdrawn: 10, PointsBurned: 5,
+	}, {
+		ChainId: chainId, Resolution: 60, StartTime: minute + 60, StartHeight: 3, EndHeight: 3,
+		Open: 1_000_000, High: 1_000_000, Low: 1_000_000, Close: 1_000_000, LocalVolume: 10, RemoteVolume: 10, Swaps: 1,
+	}})
+	require.NoError(t, e)
+	got, e := lib.MarshalJSON(candles)
+	require.NoError(t, e)
+	require.JSONEq(t, string(expected), string(got))
+	// hour bucket
+	candles, err = store.GetDexCandles(chainId, 3600, 0, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	require.Equal(t, uint64(4), candles[0].Swaps)
+	require.Equal(t, uint64(1_000_000), candles[0].Close)
+	// time range and limit
+	candles, err = store.GetDexCandles(chainId, 60, minute+1, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	require.Equal(t, minute+60, candles[0].StartTime)
+	candles, err = store.GetDexCandles(chainId, 60, 0, minute+59, 0)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	require.Equal(t, minute, candles[0].StartTime)
+	candles, err = store.GetDexCandles(chainId, 60, 0, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	require.Equal(t, minute+60, candles[0].StartTime)
+	// other pools and resolutions
+	candles, err = store.GetDexCandles(chainId+1, 60, 0, 0, 0)
+	require.NoError(t, err)
+	require.Empty(t, candles)
+	_, err = store.GetDexCandles(chainId, 61, 0, 0, 0)
+	require.Error(t, err)
+}
+
+func TestDexHistoryRevert(t *testing.T) {
+	store, _, cleanup := testStore(t)
+	defer cleanup()
+	const chainId, minute = uint64(2), uint64(36_000)
+	swap := func(sold, bought uint64) *lib.Event {
+		return &lib.Event{ChainId: chainId, Msg: &lib.Event_DexSwap{DexSwap: &lib.EventDexSwap{
+			SoldAmount: sold, BoughtAmount: bought, LocalOrigin: true, Success: true}}}
+	}
+	// closes commits and returns the swaps and close price of the minute buckets
+	closes := func() (got [][2]uint64) {
+		_, err := store.Commit()
+		require.NoError(t, err)
+		candles, err := store.GetDexCandles(chainId, 60, 0, 0, 0)
+		require.NoError(t, err)
+		for _, c := range candles {
+			got = append(got, [2]uint64{c.Swaps, c.Close})
+		}
+		return
+	}
+	// one block per commit: heights 1 and 2 in the first minute, 3 in the next
+	for _, b := range []*lib.BlockResult{
+		newTestDexBlock(1, minute+5, swap(100, 50)),
+		newTestDexBlock(2, minute+35, swap(300, 100)),
+		newTestDexBlock(3, minute+65, swap(10, 10)),
+	} {
+		require.NoError(t, store.IndexBlock(b))
+		_, err := store.Commit()
+		require.NoError(t, err)
+	}
+	require.Equal(t, [][2]uint64{{2, 3_000_000}, {1, 1_000_000}}, closes())
+	// deleting a block removes its events from the buckets, and an emptied bucket is removed
+	require.NoError(t, store.DeleteBlockForHeight(3))
+	require.NoError(t, store.DeleteBlockForHeight(2))
+	require.Equal(t, [][2]uint64{{1, 2_000_000}}, closes())
+	// a deleted height is aggregated again when re-indexed
+	require.NoError(t, store.IndexBlock(newTestDexBlock(2, minute+35, swap(400, 100))))
+	require.Equal(t, [][2]uint64{{2, 4_000_000}}, closes())
+	// re-indexing a height replaces its events instead of skipping or adding them twice
+	require.NoError(t, store.IndexBlock(newTestDexBlock(2, minute+35, swap(500, 100))))
+	require.Equal(t, [][2]uint64{{2, 5_000_000}}, closes())
+	// a rollback reverts the buckets with the rest of the indexer
+	require.NoError(t, store.Rollback(1))
+	require.Equal(t, [][2]uint64{{1, 2_000_000}}, closes())
+	require.NoError(t, store.IndexBlock(newTestDexBlock(2, minute+35, swap(600, 100))))
+	require.Equal(t, [][2]uint64{{2, 6_000_000}}, closes())
+}
+
+// newTestDexBlock() returns a block result at a height and unix time with the events of the block
+func newTestDexBlock(height, unixSeconds uint64, events ...*lib.Event) *lib.BlockResult {
+	for _, e := range events {
+		e.Height = height
+	}
+	return &lib.BlockResult{BlockHeader: &lib.BlockHeader{Height: height, Hash: []byte{byte(height)}, Time: unixSeconds * 1_000_000}, Events: events}
+}
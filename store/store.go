@@ -184,7 +184,7 @@ func NewStoreWithDB(config lib.Config, db *pebble.DB, metrics *lib.Metrics, log
 		db:         db,
 		writer:     writer,
 		ss:         NewTxn(lssStore, lssStore, latestStatePrefix, true, true, true, nextVersion),
-		Indexer:    &Indexer{NewTxn(hssStore, hssStore, indexerPrefix, false, false, false, nextVersion), config},
+		Indexer:    &Indexer{NewTxn(hssStore, hssStore, indexerPrefix, false, false, false, nextVersion), config, newBlockCache()},
 		metrics:    metrics,
 		config:     config,
 		mu:         &sync.Mutex{},
@@ -212,8 +212,8 @@ func (s *Store) NewReadOnly(queryVersion uint64) (lib.StoreI, lib.ErrorI) {
 		log:        s.log,
 		db:         s.db,
 		ss:         stateReader,
-		sc:         NewDefaultSMT(NewTxn(hssReader, nil, stateCommitmentPrefix, false, false, true)),
-		Indexer:    &Indexer{NewTxn(hssReader, nil, indexerPrefix, false, false, false), s.config},
+		sc:         NewDefaultSMT(NewTxn(hssReader, nil, stateCommitIDPrefix, false, false, true)),
+		Indexer:    &Indexer{NewTxn(hssReader, nil, indexerPrefix, false, false, false), s.config, s.blockCache},
 		metrics:    s.metrics,
 		mu:         &sync.Mutex{},
 		compaction: atomic.Bool{},
@@ -236,7 +236,7 @@ func (s *Store) Copy() (lib.StoreI, lib.ErrorI) {
 		db:         s.db,
 		writer:     writer,
 		ss:         s.ss.Copy(lssReader, lssReader),
-		Indexer:    &Indexer{s.Indexer.db.Copy(reader, reader), s.config},
+		Indexer:    &Indexer{s.Indexer.db.Copy(reader, reader), s.config, s.blockCache},
 		metrics:    s.metrics,
 		mu:         &sync.Mutex{},
 		compaction: atomic.Bool{},
@@ -410,7 +410,7 @@ func (s *Store) Rollback(targetVersion uint64) lib.ErrorI {
 	// Rebuild writer/snapshots to the rolled-back height.
 	s.version = targetVersion
 	s.Reset()
-	blockCache.Purge()
+	s.blockCache.Purge()
 	s.log.Infof("Rolled back store from height %d to %d", currentVersion, targetVersion)
 	return nil
 }
@@ -501,6 +501,25 @@ func (s *Store) VerifyProof(key, value []byte, validateMembership bool, root []b
 	return s.sc.VerifyProof(key, value, validateMembership, root, proof)
 }
 
+// VerifyStateProof() checks the validity of a member or non-member proof from the StateCommitStore without a store instance
+// this allows verifying state received from peers or untrusted RPC nodes against a trusted root
+func VerifyStateProof(key, value []byte, validateMembership bool, root []byte, proof []*lib.Node) (bool, lib.ErrorI) {
+	return (&SMT{keyBitLength: MaxKeyBitLength}).VerifyProof(key, value, validateMembership, root, proof)
+}
+
+// SetVersion() moves the store to a new version without committing the versions in between
+// CONTRACT: only used by state-sync to restore a verified snapshot; history below the version is unavailable
+func (s *Store) SetVersion(version uint64) lib.ErrorI {
+	// nested transactions share the parent's version
+	if s.isTxn {
+		return ErrCommitDB(fmt.Errorf("set version is not supported for nested transactions"))
+	}
+	// update the version and re-create the writers for the next version
+	s.version = version
+	s.Reset()
+	return nil
+}
+
 // IncreaseVersion increases the version number of the store without committing any data
 func (s *Store) IncreaseVersion() { func() { s.version++; s.sc = nil }() }
 
@@ -521,7 +540,7 @@ func (s *Store) NewTxn() lib.StoreI {
 		db:      s.db,
 		writer:  s.writer,
 		ss:      NewTxn(s.ss, s.ss, nil, false, true, true, nextVersion),
-		Indexer: &Indexer{NewTxn(s.Indexer.db, s.Indexer.db, nil, false, true, false, nextVersion), s.config},
+		Indexer: &Indexer{NewTxn(s.Indexer.db, s.Indexer.db, nil, false, true, false, nextVersion), s.config, s.blockCache},
 		metrics: s.metrics,
 		mu:      s.mu,
 		isTxn:   true,
@@ -380,6 +380,53 @@ func TestMaybeBackup(t *testing.T) {
 	require.Equal(t, []byte("v3"), restoredVal)
 }
 
+func TestSetVersionRestoresSnapshot(t *testing.T) {
+	source, _, cleanupSource := testStore(t)
+	defer cleanupSource()
+	// commit a few versions of state in the source store
+	roots := make(map[uint64][]byte)
+	for i := range 5 {
+		require.NoError(t, source.Set(lib.JoinLenPrefix([]byte{1}, []byte{byte(i)}), []byte{byte(i)}))
+		r, err := source.Commit()
+		require.NoError(t, err)
+		roots[source.Version()] = r
+	}
+	root := roots[source.Version()]
+	// historical proofs must verify against the root committed at that version
+	historical, err := source.NewReadOnly(3)
+	require.NoError(t, err)
+	key := lib.JoinLenPrefix([]byte{1}, []byte{0})
+	proof, err := historical.GetProof(key)
+	require.NoError(t, err)
+	valid, err := VerifyStateProof(key, []byte{0}, true, roots[3], proof)
+	require.NoError(t, err)
+	require.True(t, valid)
+	// restore the latest snapshot into a fresh store using proven entries only
+	restored, _, cleanupRestored := testStore(t)
+	defer cleanupRestored()
+	require.NoError(t, restored.SetVersion(source.Version()-1))
+	ro, err := source.NewReadOnly(source.Version())
+	require.NoError(t, err)
+	it, err := ro.Iterator(nil)
+	require.NoError(t, err)
+	for ; it.Valid(); it.Next() {
+		proof, e := ro.GetProof(it.Key())
+		require.NoError(t, e)
+		valid, e := VerifyStateProof(it.Key(), it.Value(), true, root, proof)
+		require.NoError(t, e)
+		require.True(t, valid)
+		// a tampered value must not verify
+		valid, _ = VerifyStateProof(it.Key(), []byte("bad"), true, root, proof)
+		require.False(t, valid)
+		require.NoError(t, restored.Set(it.Key(), it.Value()))
+	}
+	it.Close()
+	restoredRoot, err := restored.Commit()
+	require.NoError(t, err)
+	require.Equal(t, root, restoredRoot)
+	require.Equal(t, source.Version(), restored.Version())
+}
+
 func testStore(t *testing.T) (*Store, *pebble.DB, func()) {
 	fs := vfs.NewMem()
 	db, err := pebble.Open("", &pebble.Options{